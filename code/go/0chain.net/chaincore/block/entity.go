@@ -1051,10 +1051,13 @@ func transactionNodeToEventTransaction(tr *transaction.Transaction, blockHash st
 		CreationDate:      int64(tr.CreationDate.Duration()),
 		Fee:               tr.Fee,
 		Nonce:             tr.Nonce,
+		MaxCost:           tr.MaxCost,
 		TransactionType:   tr.TransactionType,
 		TransactionOutput: tr.TransactionOutput,
 		OutputHash:        tr.OutputHash,
 		Status:            tr.Status,
+		Cost:              tr.Cost,
+		PriorityFee:       tr.PriorityFee,
 	}
 }
 
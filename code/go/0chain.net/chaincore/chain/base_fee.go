@@ -0,0 +1,154 @@
+package chain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"0chain.net/chaincore/block"
+	bcstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/smartcontract"
+	sci "0chain.net/chaincore/smartcontractinterface"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/smartcontract/minersc"
+	"github.com/0chain/common/core/currency"
+)
+
+// ErrFeeBelowBaseFee is returned for a transaction whose fee doesn't cover
+// the base fee of its cost and its priority fee.
+var ErrFeeBelowBaseFee = errors.New("transaction fee is below the base fee")
+
+// builtInTxns are the functions of the smart contract transactions the
+// generators add to their blocks, the pay fees one moves the base fee.
+var builtInTxns = map[string]struct{}{
+	"payFees":                 {},
+	"generate_challenge":      {},
+	"blobber_block_rewards":   {},
+	"renew_allocations":       {},
+	"commit_settings_changes": {},
+}
+
+// GetBaseFee returns the base fee of the block following the given one, set
+// by the pay fees transaction of the block, nil if the base fee market is
+// disabled.
+func (c *Chain) GetBaseFee(b *block.Block) (*minersc.BaseFeeNode, error) {
+	if !c.ChainConfig.IsFeeEnabled() || b.ClientState == nil {
+		return nil, nil
+	}
+
+	sctx := c.NewStateContext(b, CreateTxnMPT(b.ClientState), &transaction.Transaction{}, nil)
+	return minersc.GetBaseFee(sctx, b.Round+1)
+}
+
+// isBuiltInTxn returns true for the zero fee transactions the generator of
+// the block adds to it, they don't pay the base fee.
+func isBuiltInTxn(b *block.Block, txn *transaction.Transaction) bool {
+	if txn.ClientID != b.MinerID || txn.Fee != 0 || txn.SmartContractData == nil {
+		return false
+	}
+	_, ok := builtInTxns[txn.FunctionName]
+	return ok
+}
+
+func isTxnExempt(c *Chain, txn *transaction.Transaction) bool {
+	if txn.SmartContractData == nil {
+		return false
+	}
+	_, ok := c.ChainConfig.TxnExempt()[txn.FunctionName]
+	return ok
+}
+
+// declaredCost is the cost of the transaction known before its execution,
+// the max cost of the metered transactions.
+func (c *Chain) declaredCost(txn *transaction.Transaction, sctx bcstate.StateContextI) (int, error) {
+	switch txn.TransactionType {
+	case transaction.TxnTypeSmartContract:
+		if txn.IsMetered() {
+			return txn.MaxCost, nil
+		}
+		var scData sci.SmartContractTransactionData
+		if err := json.Unmarshal([]byte(txn.TransactionData), &scData); err != nil {
+			return 0, err
+		}
+		return smartcontract.EstimateTransactionCost(txn, scData, sctx)
+	case transaction.TxnTypeBatch:
+		if txn.IsMetered() {
+			return txn.MaxCost, nil
+		}
+		return smartcontract.EstimateBatchCost(txn, sctx)
+	case transaction.TxnTypeSend:
+		return c.ChainConfig.TxnTransferCost(), nil
+	default:
+		return 0, nil
+	}
+}
+
+// baseFeeMaxFee is the fee of the transaction cost with the base fee, plus
+// its priority fee.
+func baseFeeMaxFee(bf *minersc.BaseFeeNode, txn *transaction.Transaction, cost int) (currency.Coin, error) {
+	fee, err := bf.Fee(cost)
+	if err != nil {
+		return 0, err
+	}
+	return currency.AddCoin(fee, txn.PriorityFee)
+}
+
+// validateBaseFee checks the max fee of the transaction covers the base fee
+// of its declared cost and its priority fee.
+func (c *Chain) validateBaseFee(bf *minersc.BaseFeeNode, txn *transaction.Transaction, declaredCost int) error {
+	if isTxnExempt(c, txn) {
+		return nil
+	}
+	fee, err := baseFeeMaxFee(bf, txn, declaredCost)
+	if err != nil {
+		return err
+	}
+	if txn.Fee < fee {
+		return fmt.Errorf("%w: %v < %v", ErrFeeBelowBaseFee, txn.Fee, fee)
+	}
+	return nil
+}
+
+// chargeBaseFee adds the cost used by the transaction to the block and
+// charges the base fee of it to the base fee pool, and the priority fee to
+// the miner SC. The base fee transferred to the miner SC is burned. The
+// exempt transactions pay their priority fee only.
+func (c *Chain) chargeBaseFee(sctx bcstate.StateContextI, bf *minersc.BaseFeeNode,
+	txn *transaction.Transaction, declaredCost int) error {
+
+	usedCost := declaredCost
+	if txn.IsMetered() && txn.Cost < txn.MaxCost {
+		usedCost = txn.Cost
+	}
+	bf.UsedCost += usedCost
+	if err := bf.Save(sctx); err != nil {
+		return err
+	}
+
+	// the miner SC pays the priority fee to the generator and sharders
+	txn.ChargedFee = txn.PriorityFee
+	if isTxnExempt(c, txn) {
+		if txn.PriorityFee == 0 {
+			return nil
+		}
+		return sctx.AddTransfer(state.NewTransfer(txn.ClientID, minersc.ADDRESS, txn.PriorityFee))
+	}
+
+	baseFee, err := bf.Fee(usedCost)
+	if err != nil {
+		return err
+	}
+	if bf.Pool == "" || bf.Pool == minersc.ADDRESS {
+		fee, err := currency.AddCoin(baseFee, txn.PriorityFee)
+		if err != nil {
+			return err
+		}
+		return sctx.AddTransfer(state.NewTransfer(txn.ClientID, minersc.ADDRESS, fee))
+	}
+
+	if err := sctx.AddTransfer(state.NewTransfer(txn.ClientID, bf.Pool, baseFee)); err != nil {
+		return err
+	}
+	return sctx.AddTransfer(state.NewTransfer(txn.ClientID, minersc.ADDRESS, txn.PriorityFee))
+}
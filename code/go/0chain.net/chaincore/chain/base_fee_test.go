@@ -0,0 +1,121 @@
+package chain
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"0chain.net/chaincore/block"
+	bcstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/smartcontract"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/encryption"
+	"0chain.net/smartcontract/minersc"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var baseFeeTestAddress = encryption.Hash("base_fee_test_sc")
+
+// baseFeeTestSC succeeds on every call.
+type baseFeeTestSC struct{}
+
+func (sc *baseFeeTestSC) Execute(*transaction.Transaction, string, []byte,
+	bcstate.StateContextI) (string, error) {
+	return "ok", nil
+}
+
+func (sc *baseFeeTestSC) GetHandlerStats(context.Context, url.Values) (interface{}, error) {
+	return nil, nil
+}
+
+func (sc *baseFeeTestSC) GetExecutionStats() map[string]interface{} { return nil }
+
+func (sc *baseFeeTestSC) GetName() string { return "base_fee_test" }
+
+func (sc *baseFeeTestSC) GetAddress() string { return baseFeeTestAddress }
+
+func (sc *baseFeeTestSC) GetCostTable(bcstate.StateContextI) (map[string]int, error) {
+	return map[string]int{"write": 100, "generate_challenge": 100}, nil
+}
+
+func init() {
+	smartcontract.ContractMap[baseFeeTestAddress] = &baseFeeTestSC{}
+}
+
+func TestUpdateState_baseFee(t *testing.T) {
+	var (
+		ch          = NewChainFromConfig()
+		clientID    = encryption.Hash("base_fee_client")
+		generatorID = encryption.Hash("base_fee_generator")
+		bfPath      = util.Path(encryption.Hash(minersc.BaseFeeKey))
+	)
+	ch.ChainConfig = NewConfigImpl(&ConfigData{
+		IsFeeEnabled:         true,
+		SmartContractTimeout: DefaultSmartContractTimeout,
+	})
+
+	bState := util.NewMerklePatriciaTrie(util.NewMemoryNodeDB(), 1, nil)
+	s := &state.State{Balance: 1000}
+	require.NoError(t, s.SetTxnHash(encryption.Hash("genesis")))
+	_, err := bState.Insert(util.Path(clientID), s)
+	require.NoError(t, err)
+	// set by the pay fees transaction of the previous block
+	_, err = bState.Insert(bfPath, &minersc.BaseFeeNode{Round: 2, BaseFee: 2})
+	require.NoError(t, err)
+
+	b := block.NewBlock("", 2)
+	b.PrevBlock = block.NewBlock("", 1)
+	b.MinerID = generatorID
+	b.ClientState = bState
+
+	newTxn := func(clientID, funcName string, nonce int64, fee, priorityFee currency.Coin) *transaction.Transaction {
+		txn := &transaction.Transaction{
+			ClientID:        clientID,
+			ToClientID:      baseFeeTestAddress,
+			TransactionType: transaction.TxnTypeSmartContract,
+			TransactionData: `{"name":"` + funcName + `","input":{}}`,
+			Nonce:           nonce,
+			Fee:             fee,
+			PriorityFee:     priorityFee,
+			SmartContractData: &transaction.SmartContractData{
+				FunctionName: funcName,
+			},
+		}
+		txn.Hash = encryption.Hash(txn.TransactionData + clientID + strconv.FormatInt(nonce, 10))
+		return txn
+	}
+
+	// the base fee of the cost is 200
+	_, err = ch.updateState(context.Background(), b, bState, newTxn(clientID, "write", 1, 199, 0))
+	require.ErrorIs(t, err, ErrFeeBelowBaseFee)
+
+	txn := newTxn(clientID, "write", 1, 250, 50)
+	_, err = ch.updateState(context.Background(), b, bState, txn)
+	require.NoError(t, err)
+	assert.Equal(t, transaction.TxnSuccess, txn.Status)
+	assert.Equal(t, currency.Coin(50), txn.ChargedFee)
+
+	var bf minersc.BaseFeeNode
+	require.NoError(t, bState.GetNodeValue(bfPath, &bf))
+	assert.Equal(t, 100, bf.UsedCost)
+	require.NoError(t, bState.GetNodeValue(util.Path(clientID), s))
+	assert.Equal(t, currency.Coin(750), s.Balance)
+
+	// the zero fee built-in transactions of the generator don't pay the base
+	// fee and don't use the cost of the block
+	builtIn := newTxn(generatorID, "generate_challenge", 1, 0, 0)
+	_, err = ch.updateState(context.Background(), b, bState, builtIn)
+	require.NoError(t, err)
+	assert.Equal(t, transaction.TxnSuccess, builtIn.Status)
+	require.NoError(t, bState.GetNodeValue(bfPath, &bf))
+	assert.Equal(t, 100, bf.UsedCost)
+
+	// the same function called by a client pays the base fee
+	_, err = ch.updateState(context.Background(), b, bState, newTxn(clientID, "generate_challenge", 2, 0, 0))
+	require.ErrorIs(t, err, ErrFeeBelowBaseFee)
+}
@@ -0,0 +1,178 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"testing"
+
+	"0chain.net/chaincore/block"
+	bcstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/smartcontract"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/encryption"
+	"0chain.net/smartcontract/dbs/event"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	batchStateTestAddress = encryption.Hash("batch_state_test_sc")
+	batchStateTestKey     = encryption.Hash("batch_state_test_key")
+)
+
+// batchStateTestSC saves a node, transfers the call value to itself and
+// emits an event on the "write" calls, it fails the other ones.
+type batchStateTestSC struct{}
+
+func (sc *batchStateTestSC) Execute(txn *transaction.Transaction, funcName string, _ []byte,
+	balances bcstate.StateContextI) (string, error) {
+	if funcName != "write" {
+		return "", errors.New("call failed")
+	}
+	if _, err := balances.InsertTrieNode(batchStateTestKey,
+		&util.SecureSerializableValue{Buffer: []byte(txn.Hash)}); err != nil {
+		return "", err
+	}
+	if err := balances.AddTransfer(state.NewTransfer(txn.ClientID, batchStateTestAddress, txn.Value)); err != nil {
+		return "", err
+	}
+	balances.EmitEvent(event.TypeStats, event.TagAddOrOverwriteUser, txn.Hash, nil)
+	return "written", nil
+}
+
+func (sc *batchStateTestSC) GetHandlerStats(context.Context, url.Values) (interface{}, error) {
+	return nil, nil
+}
+
+func (sc *batchStateTestSC) GetExecutionStats() map[string]interface{} { return nil }
+
+func (sc *batchStateTestSC) GetName() string { return "batch_state_test" }
+
+func (sc *batchStateTestSC) GetAddress() string { return batchStateTestAddress }
+
+func (sc *batchStateTestSC) GetCostTable(bcstate.StateContextI) (map[string]int, error) {
+	return map[string]int{"write": 10}, nil
+}
+
+func init() {
+	smartcontract.ContractMap[batchStateTestAddress] = &batchStateTestSC{}
+}
+
+func newBatchStateTest(t *testing.T, funcNames ...string) (
+	*block.Block, util.MerklePatriciaTrieI, *transaction.Transaction,
+) {
+	clientID := encryption.Hash("batch_client")
+	s := &state.State{Balance: 100}
+	require.NoError(t, s.SetTxnHash(encryption.Hash("genesis")))
+	bState := util.NewMerklePatriciaTrie(util.NewMemoryNodeDB(), 1, nil)
+	_, err := bState.Insert(util.Path(clientID), s)
+	require.NoError(t, err)
+
+	b := block.NewBlock("", 1)
+	b.PrevBlock = block.NewBlock("", 0)
+	b.ClientState = bState
+
+	calls := make([]transaction.BatchCall, 0, len(funcNames))
+	for _, name := range funcNames {
+		calls = append(calls, transaction.BatchCall{
+			ToClientID:        batchStateTestAddress,
+			Value:             10,
+			SmartContractData: transaction.SmartContractData{FunctionName: name},
+		})
+	}
+	data, err := json.Marshal(calls)
+	require.NoError(t, err)
+
+	txn := &transaction.Transaction{
+		ClientID:        clientID,
+		TransactionType: transaction.TxnTypeBatch,
+		TransactionData: string(data),
+		Value:           currency.Coin(10 * len(calls)),
+		Nonce:           1,
+		// set by ComputeProperties, empty for the batch transactions
+		SmartContractData: &transaction.SmartContractData{},
+	}
+	txn.Hash = encryption.Hash("batch")
+	return b, bState, txn
+}
+
+func TestUpdateState_batch(t *testing.T) {
+	ch := NewChainFromConfig()
+
+	getBalance := func(bState util.MerklePatriciaTrieI, clientID string) state.State {
+		var s state.State
+		require.NoError(t, bState.GetNodeValue(util.Path(clientID), &s))
+		return s
+	}
+
+	t.Run("all the calls succeed", func(t *testing.T) {
+		b, bState, txn := newBatchStateTest(t, "write", "write")
+		events, err := ch.updateState(context.Background(), b, bState, txn)
+		require.NoError(t, err)
+		assert.Equal(t, transaction.TxnSuccess, txn.Status)
+		assert.Equal(t, `["written","written"]`, txn.TransactionOutput)
+
+		// the second call overwrites the node of the first one
+		var v util.SecureSerializableValue
+		require.NoError(t, bState.GetNodeValue(util.Path(encryption.Hash(batchStateTestKey)), &v))
+		assert.Equal(t, txn.BatchCallHash(1), string(v.Buffer))
+		assert.EqualValues(t, 80, getBalance(bState, txn.ClientID).Balance)
+		assert.EqualValues(t, 20, getBalance(bState, batchStateTestAddress).Balance)
+
+		var hashes []string
+		for _, e := range events {
+			if e.Tag == event.TagAddOrOverwriteUser {
+				hashes = append(hashes, e.TxHash)
+			}
+		}
+		assert.Equal(t, []string{txn.BatchCallHash(0), txn.BatchCallHash(1)}, hashes)
+	})
+
+	t.Run("a failing call rolls back the previous ones", func(t *testing.T) {
+		b, bState, txn := newBatchStateTest(t, "write", "fail")
+		events, err := ch.updateState(context.Background(), b, bState, txn)
+		require.NoError(t, err)
+		assert.Equal(t, transaction.TxnError, txn.Status)
+		assert.Equal(t, "batch call 1: call failed", txn.TransactionOutput)
+
+		var v util.SecureSerializableValue
+		assert.Equal(t, util.ErrValueNotPresent,
+			bState.GetNodeValue(util.Path(encryption.Hash(batchStateTestKey)), &v))
+		s := getBalance(bState, txn.ClientID)
+		assert.EqualValues(t, 100, s.Balance)
+		assert.EqualValues(t, 1, s.Nonce)
+		assert.Equal(t, util.ErrValueNotPresent,
+			bState.GetNodeValue(util.Path(batchStateTestAddress), &state.State{}))
+
+		for _, e := range events {
+			assert.NotEqual(t, event.TagAddOrOverwriteUser, e.Tag)
+		}
+	})
+}
+
+func TestSimulateTransaction_batch(t *testing.T) {
+	ch := NewChainFromConfig()
+
+	b, bState, txn := newBatchStateTest(t, "write", "write")
+	root := bState.GetRoot()
+
+	result, err := ch.SimulateTransaction(context.Background(), b, txn)
+	require.NoError(t, err)
+	assert.Empty(t, result.Error)
+	assert.Equal(t, `["written","written"]`, result.Output)
+	assert.Len(t, result.Transfers, 2)
+	// the keys changed by the calls are recorded as well
+	assert.ElementsMatch(t, []string{batchStateTestKey, txn.ClientID, batchStateTestAddress},
+		result.TouchedKeys)
+	assert.Equal(t, root, bState.GetRoot())
+
+	b, _, txn = newBatchStateTest(t, "write", "fail")
+	result, err = ch.SimulateTransaction(context.Background(), b, txn)
+	require.NoError(t, err)
+	assert.Equal(t, "batch call 1: call failed", result.Error)
+}
@@ -120,6 +120,11 @@ func handlersMap(c Chainer) map[string]func(http.ResponseWriter, *http.Request)
 				SuggestedFeeHandler,
 			),
 		)),
+		"/v1/transaction/simulate": common.WithCORS(common.UserRateLimit(
+			common.ToJSONResponse(
+				SimulateTransactionHandler,
+			),
+		)),
 		"/v1/fees_table": common.WithCORS(common.UserRateLimit(
 			common.ToJSONResponse(
 				FeesTableHandler,
@@ -767,6 +772,7 @@ func DiagnosticsHomepageHandler(w http.ResponseWriter, r *http.Request) {
 
 	if node.NodeType(selfNodeType) == node.NodeTypeSharder {
 		fmt.Fprintf(w, "<li><a href='_healthcheck'>/_healthcheck</a></li>")
+		fmt.Fprintf(w, "<li><a href='_diagnostics/block_scrub'>/_diagnostics/block_scrub</a></li>")
 	}
 
 	fmt.Fprintf(w, "<li><a href='_diagnostics/miner_stats'>/_diagnostics/miner_stats</a>")
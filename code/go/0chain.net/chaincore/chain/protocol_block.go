@@ -10,6 +10,7 @@ import (
 	"0chain.net/chaincore/block"
 	"0chain.net/chaincore/config"
 	"0chain.net/chaincore/node"
+	"0chain.net/chaincore/transaction"
 	"0chain.net/core/common"
 	"0chain.net/core/datastore"
 	"0chain.net/core/encryption"
@@ -827,6 +828,16 @@ func (c *Chain) updateFeeStats(fb *block.Block) error {
 		totalFees currency.Coin
 		err       error
 	)
+	bf, err := c.GetBaseFee(fb)
+	if err != nil {
+		return err
+	}
+	c.FeeStats.BaseFee = 0
+	if bf != nil {
+		c.FeeStats.BaseFee = bf.BaseFee
+	}
+	transaction.SetBaseFeeEnabled(bf != nil)
+
 	if len(fb.Txns) == 0 {
 		return nil
 	}
@@ -0,0 +1,245 @@
+package chain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"0chain.net/chaincore/block"
+	bcstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/datastore"
+	"0chain.net/smartcontract/dbs/event"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/util"
+)
+
+// simulationTimeout bounds the smart contract execution of a simulation, it
+// doesn't depend on the node type as the block execution timeout does.
+const simulationTimeout = 5 * time.Second
+
+// SimulationResult is what a transaction would do executed on top of the
+// state of a block, nothing of it is persisted.
+type SimulationResult struct {
+	Round     int64  `json:"round"`
+	BlockHash string `json:"block_hash"`
+	TxnHash   string `json:"txn_hash"`
+	Output    string `json:"output"`
+	Error     string `json:"error,omitempty"`
+	// Cost is the metered execution cost of the smart contract, it can be
+	// used as the max cost of the transaction.
+	Cost            int                     `json:"cost"`
+	Transfers       []*state.Transfer       `json:"transfers"`
+	SignedTransfers []*state.SignedTransfer `json:"signed_transfers"`
+	Mints           []*state.Mint           `json:"mints"`
+	Events          []event.Event           `json:"events"`
+	// TouchedKeys are the MPT keys the transaction read, inserted or
+	// deleted, the balances keys are the client IDs.
+	TouchedKeys []string `json:"touched_keys"`
+	// ChangedKeys are the TouchedKeys the transaction inserted or deleted.
+	ChangedKeys []string `json:"changed_keys"`
+}
+
+// touchedKeysStateContext records the MPT keys read and changed by a smart
+// contract.
+type touchedKeysStateContext struct {
+	bcstate.StateContextI
+	read    map[datastore.Key]struct{}
+	changed map[datastore.Key]struct{}
+}
+
+func newTouchedKeysStateContext(sctx bcstate.StateContextI) *touchedKeysStateContext {
+	return &touchedKeysStateContext{
+		StateContextI: sctx,
+		read:          make(map[datastore.Key]struct{}),
+		changed:       make(map[datastore.Key]struct{}),
+	}
+}
+
+func (tc *touchedKeysStateContext) GetTrieNode(key datastore.Key, v util.MPTSerializable) error {
+	tc.read[key] = struct{}{}
+	return tc.StateContextI.GetTrieNode(key, v)
+}
+
+func (tc *touchedKeysStateContext) InsertTrieNode(key datastore.Key, node util.MPTSerializable) (datastore.Key, error) {
+	tc.changed[key] = struct{}{}
+	return tc.StateContextI.InsertTrieNode(key, node)
+}
+
+func (tc *touchedKeysStateContext) DeleteTrieNode(key datastore.Key) (datastore.Key, error) {
+	tc.changed[key] = struct{}{}
+	return tc.StateContextI.DeleteTrieNode(key)
+}
+
+func (tc *touchedKeysStateContext) GetClientState(clientID datastore.Key) (*state.State, error) {
+	tc.read[clientID] = struct{}{}
+	return tc.StateContextI.GetClientState(clientID)
+}
+
+func (tc *touchedKeysStateContext) SetClientState(clientID datastore.Key, s *state.State) (util.Key, error) {
+	tc.changed[clientID] = struct{}{}
+	return tc.StateContextI.SetClientState(clientID, s)
+}
+
+func (tc *touchedKeysStateContext) GetClientBalance(clientID datastore.Key) (currency.Coin, error) {
+	tc.read[clientID] = struct{}{}
+	return tc.StateContextI.GetClientBalance(clientID)
+}
+
+// ExecuteOnBehalf records the keys touched by the calls of the batch
+// transactions as well.
+func (tc *touchedKeysStateContext) ExecuteOnBehalf(txn *transaction.Transaction,
+	execute func(bcstate.StateContextI) (string, error)) (string, error) {
+	return tc.StateContextI.ExecuteOnBehalf(txn, func(sctx bcstate.StateContextI) (string, error) {
+		return execute(&touchedKeysStateContext{StateContextI: sctx, read: tc.read, changed: tc.changed})
+	})
+}
+
+// touch marks the keys as changed.
+func (tc *touchedKeysStateContext) touch(keys ...datastore.Key) {
+	for _, k := range keys {
+		tc.changed[k] = struct{}{}
+	}
+}
+
+func (tc *touchedKeysStateContext) touchedKeys() []string {
+	all := make(map[datastore.Key]struct{}, len(tc.read)+len(tc.changed))
+	for k := range tc.read {
+		all[k] = struct{}{}
+	}
+	for k := range tc.changed {
+		all[k] = struct{}{}
+	}
+	return sortedKeys(all)
+}
+
+func (tc *touchedKeysStateContext) changedKeys() []string {
+	return sortedKeys(tc.changed)
+}
+
+func sortedKeys(m map[datastore.Key]struct{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// SimulateTransaction executes the smart contract or batch transaction
+// against a throwaway copy of the block state. The chargeable errors of the smart
+// contract are returned in the result, the internal ones as the error.
+func (c *Chain) SimulateTransaction(ctx context.Context, b *block.Block,
+	txn *transaction.Transaction) (*SimulationResult, error) {
+
+	if !txn.IsSmartContract() {
+		return nil, fmt.Errorf("invalid transaction type: %v, only smart contract and batch "+
+			"transactions can be simulated", txn.TransactionType)
+	}
+	if b.ClientState == nil {
+		return nil, errors.New("block state is not computed")
+	}
+
+	var (
+		clientState = CreateTxnMPT(b.ClientState) // never merged back
+		baseSctx    = c.NewStateContext(b, clientState, txn, nil)
+		sctx        = newTouchedKeysStateContext(baseSctx)
+		result      = &SimulationResult{
+			Round:     b.Round,
+			BlockHash: b.Hash,
+			TxnHash:   txn.Hash,
+		}
+	)
+
+	tctx, cancel := context.WithTimeout(ctx, simulationTimeout)
+	defer cancel()
+
+	output, err := c.ExecuteSmartContract(tctx, txn, sctx)
+	result.Cost = baseSctx.GetMeter().Cost()
+	switch {
+	case err == nil:
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded),
+		errors.Is(err, transaction.ErrSmartContractContext), errors.Is(err, util.ErrNodeNotFound),
+		bcstate.ErrInvalidState(err):
+		return nil, err
+	default:
+		// the changes of a failing transaction are dropped, only its reads
+		// are reported
+		result.Error = err.Error()
+		result.TouchedKeys = sortedKeys(sctx.read)
+		return result, nil
+	}
+	result.Output = output
+
+	result.Transfers = sctx.GetTransfers()
+	result.SignedTransfers = sctx.GetSignedTransfers()
+	result.Mints = sctx.GetMints()
+
+	// the balances are moved in the throwaway state to catch the transfers
+	// the clients can't afford
+	for _, t := range result.Transfers {
+		sctx.touch(t.ClientID, t.ToClientID)
+		if _, err := c.transferAmount(sctx, t.ClientID, t.ToClientID, t.Amount); err != nil {
+			result.Error = fmt.Sprintf("transfer from %s to %s: %v", t.ClientID, t.ToClientID, err)
+			break
+		}
+	}
+	for _, t := range result.SignedTransfers {
+		if result.Error != "" {
+			break
+		}
+		sctx.touch(t.ClientID, t.ToClientID)
+		if _, err := c.transferAmount(sctx, t.ClientID, t.ToClientID, t.Amount); err != nil {
+			result.Error = fmt.Sprintf("signed transfer from %s to %s: %v", t.ClientID, t.ToClientID, err)
+		}
+	}
+	for _, m := range result.Mints {
+		if result.Error != "" {
+			break
+		}
+		sctx.touch(m.ToClientID)
+		if _, err := c.mintAmount(sctx, m.ToClientID, m.Amount); err != nil {
+			result.Error = fmt.Sprintf("mint to %s: %v", m.ToClientID, err)
+		}
+	}
+
+	result.Events = sctx.GetEvents()
+	result.TouchedKeys = sctx.touchedKeys()
+	result.ChangedKeys = sctx.changedKeys()
+	return result, nil
+}
+
+// SimulateTransactionHandler executes the signed or unsigned smart contract
+// or batch transaction of the request body against the latest finalized block state.
+func SimulateTransactionHandler(ctx context.Context, r *http.Request) (interface{}, error) {
+	txData, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+
+	var txn transaction.Transaction
+	if err := json.Unmarshal(txData, &txn); err != nil {
+		return nil, err
+	}
+	if err := txn.ComputeProperties(); err != nil {
+		return nil, err
+	}
+	if txn.Hash == "" {
+		txn.Hash = txn.ComputeHash()
+	}
+
+	c := GetServerChain()
+	lfb := c.GetLatestFinalizedBlock()
+	if lfb == nil {
+		return nil, errors.New("LFB not ready yet")
+	}
+
+	return c.SimulateTransaction(ctx, lfb.Clone(), &txn)
+}
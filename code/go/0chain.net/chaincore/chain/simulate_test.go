@@ -0,0 +1,145 @@
+package chain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/chaincore/smartcontract"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/encryption"
+	"0chain.net/smartcontract/faucetsc"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulateTransaction_invalidType(t *testing.T) {
+	t.Parallel()
+
+	ch := NewChainFromConfig()
+	b := block.NewBlock("", 1)
+	b.ClientState = util.NewMerklePatriciaTrie(util.NewMemoryNodeDB(), 1, nil)
+
+	_, err := ch.SimulateTransaction(context.Background(), b,
+		&transaction.Transaction{TransactionType: transaction.TxnTypeSend})
+	require.EqualError(t, err,
+		"invalid transaction type: 0, only smart contract and batch transactions can be simulated")
+}
+
+func TestTouchedKeysStateContext(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ch     = NewChainFromConfig()
+		b      = block.NewBlock("", 1)
+		bState = util.NewMerklePatriciaTrie(util.NewMemoryNodeDB(), 1, nil)
+		mpt    = CreateTxnMPT(bState)
+		sctx   = newTouchedKeysStateContext(ch.NewStateContext(b, mpt, &transaction.Transaction{}, nil))
+	)
+
+	_, err := sctx.InsertTrieNode("b_key", &util.SecureSerializableValue{Buffer: []byte("b")})
+	require.NoError(t, err)
+	_, err = sctx.InsertTrieNode("a_key", &util.SecureSerializableValue{Buffer: []byte("a")})
+	require.NoError(t, err)
+	_, err = sctx.DeleteTrieNode("b_key")
+	require.NoError(t, err)
+	sctx.touch("client")
+
+	var v util.SecureSerializableValue
+	require.NoError(t, sctx.GetTrieNode("a_key", &v))
+	assert.Equal(t, []byte("a"), v.Buffer)
+	require.Error(t, sctx.GetTrieNode("c_key", &v))
+
+	assert.Equal(t, []string{"a_key", "b_key", "c_key", "client"}, sctx.touchedKeys())
+	assert.Equal(t, []string{"a_key", "b_key", "client"}, sctx.changedKeys())
+
+	// the block state is never changed
+	assert.Nil(t, bState.GetRoot())
+}
+
+func TestSimulateTransaction_faucet(t *testing.T) {
+	if _, ok := smartcontract.ContractMap[faucetsc.ADDRESS]; !ok {
+		smartcontract.ContractMap[faucetsc.ADDRESS] = faucetsc.NewFaucetSmartContract()
+	}
+
+	var (
+		ch       = NewChainFromConfig()
+		clientID = encryption.Hash("simulate_client")
+		gnKey    = faucetsc.ADDRESS + encryption.Hash("faucetsc_config")
+		userKey  = faucetsc.ADDRESS + clientID
+		bState   = util.NewMerklePatriciaTrie(util.NewMemoryNodeDB(), 1, nil)
+	)
+
+	for id, balance := range map[string]currency.Coin{faucetsc.ADDRESS: 1000, clientID: 5} {
+		s := &state.State{Balance: balance}
+		require.NoError(t, s.SetTxnHash(encryption.Hash("genesis")))
+		_, err := bState.Insert(util.Path(id), s)
+		require.NoError(t, err)
+	}
+	_, err := bState.Insert(util.Path(encryption.Hash(gnKey)), &faucetsc.GlobalNode{
+		ID: faucetsc.ADDRESS,
+		FaucetConfig: &faucetsc.FaucetConfig{
+			PourAmount:      10,
+			MaxPourAmount:   100,
+			PeriodicLimit:   100,
+			GlobalLimit:     1000,
+			IndividualReset: time.Hour,
+			GlobalReset:     time.Hour,
+		},
+	})
+	require.NoError(t, err)
+	root := bState.GetRoot()
+
+	b := block.NewBlock("", 1)
+	b.PrevBlock = block.NewBlock("", 0)
+	b.ClientState = bState
+
+	newTxn := func(funcName string, value currency.Coin) *transaction.Transaction {
+		txn := &transaction.Transaction{
+			ClientID:        clientID,
+			ToClientID:      faucetsc.ADDRESS,
+			TransactionType: transaction.TxnTypeSmartContract,
+			CreationDate:    common.Now(),
+			Value:           value,
+			SmartContractData: &transaction.SmartContractData{
+				FunctionName: funcName,
+			},
+		}
+		txn.Hash = encryption.Hash(funcName)
+		return txn
+	}
+
+	t.Run("pour", func(t *testing.T) {
+		result, err := ch.SimulateTransaction(context.Background(), b, newTxn("pour", 20))
+		require.NoError(t, err)
+		assert.Empty(t, result.Error)
+		assert.Equal(t, []*state.Transfer{state.NewTransfer(faucetsc.ADDRESS, clientID, 20)}, result.Transfers)
+		assert.ElementsMatch(t, []string{faucetsc.ADDRESS, clientID, gnKey, userKey}, result.TouchedKeys)
+		assert.ElementsMatch(t, []string{faucetsc.ADDRESS, clientID, gnKey, userKey}, result.ChangedKeys)
+	})
+
+	t.Run("refill more than the balance", func(t *testing.T) {
+		result, err := ch.SimulateTransaction(context.Background(), b, newTxn("refill", 50))
+		require.NoError(t, err)
+		assert.Contains(t, result.Error, "it seems you're broke")
+		assert.Empty(t, result.Transfers)
+		// the keys read by the failing refill are still reported
+		assert.ElementsMatch(t, []string{clientID, gnKey}, result.TouchedKeys)
+		assert.Empty(t, result.ChangedKeys)
+	})
+
+	// the block state is never changed
+	assert.Equal(t, root, bState.GetRoot())
+	var s state.State
+	require.NoError(t, bState.GetNodeValue(util.Path(faucetsc.ADDRESS), &s))
+	assert.Equal(t, currency.Coin(1000), s.Balance)
+	require.NoError(t, bState.GetNodeValue(util.Path(clientID), &s))
+	assert.Equal(t, currency.Coin(5), s.Balance)
+	require.ErrorIs(t, bState.GetNodeValue(util.Path(encryption.Hash(userKey)), &faucetsc.UserNode{}),
+		util.ErrValueNotPresent)
+}
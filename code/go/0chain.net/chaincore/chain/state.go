@@ -29,6 +29,10 @@ import (
 	"github.com/0chain/common/core/util"
 )
 
+// ErrMaxCostExceeded is the chargeable error of a metered transaction whose
+// execution costs more than its declared max cost.
+var ErrMaxCostExceeded = bcstate.ErrMaxCostExceeded
+
 // SmartContractExecutionTimer - a metric that tracks the time it takes to execute a smart contract txn
 var SmartContractExecutionTimer metrics.Timer
 var StateComputationTimer metrics.Histogram
@@ -139,8 +143,13 @@ func (c *Chain) ExecuteSmartContract(
 	}
 
 	go func() {
-		output, err := smartcontract.ExecuteSmartContract(txn, balances)
-		resultC <- result{output: output, err: err}
+		var r result
+		if txn.TransactionType == transaction.TxnTypeBatch {
+			r.output, r.err = smartcontract.ExecuteBatch(txn, balances)
+		} else {
+			r.output, r.err = smartcontract.ExecuteSmartContract(txn, balances)
+		}
+		resultC <- r
 	}()
 	select {
 	case <-ctx.Done():
@@ -207,17 +216,30 @@ func (c *Chain) EstimateTransactionCost(ctx context.Context,
 
 	switch txn.TransactionType {
 
-	case transaction.TxnTypeSmartContract:
-		var scData sci.SmartContractTransactionData
-		dataBytes := []byte(txn.TransactionData)
-		err := json.Unmarshal(dataBytes, &scData)
-		if err != nil {
-			logging.Logger.Error("Error while decoding the JSON from transaction",
-				zap.String("input", txn.TransactionData), zap.Error(err))
-			return math.MaxInt32, err
+	case transaction.TxnTypeSmartContract, transaction.TxnTypeBatch:
+		if txn.IsMetered() {
+			// the client pays for the declared upper bound of the execution
+			return txn.MaxCost, nil
 		}
 
-		cost, err := smartcontract.EstimateTransactionCost(txn, scData, sctx)
+		var (
+			cost int
+			err  error
+		)
+		if txn.TransactionType == transaction.TxnTypeBatch {
+			cost, err = smartcontract.EstimateBatchCost(txn, sctx)
+		} else {
+			var scData sci.SmartContractTransactionData
+			dataBytes := []byte(txn.TransactionData)
+			err = json.Unmarshal(dataBytes, &scData)
+			if err != nil {
+				logging.Logger.Error("Error while decoding the JSON from transaction",
+					zap.String("input", txn.TransactionData), zap.Error(err))
+				return math.MaxInt32, err
+			}
+
+			cost, err = smartcontract.EstimateTransactionCost(txn, scData, sctx)
+		}
 		if missingKeys := sctx.GetMissingNodeKeys(); len(missingKeys) > 0 {
 			syncOpts := &SyncReplyC{}
 			for _, opt := range opts {
@@ -245,6 +267,9 @@ func (c *Chain) EstimateTransactionCost(ctx context.Context,
 	case transaction.TxnTypeData:
 		return 0, nil
 
+	case transaction.TxnTypeCancel:
+		return 0, nil
+
 	case transaction.TxnTypeStorageWrite:
 		return 0, nil
 
@@ -295,19 +320,53 @@ func (c *Chain) EstimateTransactionCostFee(ctx context.Context,
 		zap.String("txn hash", txn.Hash),
 		zap.String("txn", txn.TransactionData))
 
+	bf, err := c.GetBaseFee(b)
+	if err != nil {
+		return 0, 0, err
+	}
+	if bf != nil {
+		fee, err := baseFeeMaxFee(bf, txn, cost)
+		if err != nil {
+			return 0, 0, err
+		}
+		return cost, fee, nil
+	}
+
+	return cost, c.costToFee(cost), nil
+}
+
+// costToFee converts the transaction cost to fee, limited by the max fee.
+func (c *Chain) costToFee(cost int) currency.Coin {
 	maxFee := c.ChainConfig.MaxTxnFee()
 
 	zcn := float64(cost) / float64(c.ChainConfig.TxnCostFeeCoeff())
 	parseZCN, err := currency.ParseZCN(zcn)
 	if err != nil {
-		return cost, maxFee, nil
+		return maxFee
 	}
 
 	if maxFee > 0 && parseZCN > maxFee {
-		return cost, maxFee, nil
+		return maxFee
 	}
 
-	return cost, parseZCN, nil
+	return parseZCN
+}
+
+// meteredFee is the fee charged for the consumed cost of a metered
+// transaction, it's never above the fee of the transaction.
+func (c *Chain) meteredFee(txn *transaction.Transaction) currency.Coin {
+	if _, ok := c.ChainConfig.TxnExempt()[txn.FunctionName]; ok {
+		return 0
+	}
+
+	fee := c.costToFee(txn.Cost)
+	if minFee := c.ChainConfig.MinTxnFee(); fee < minFee {
+		fee = minFee
+	}
+	if fee > txn.Fee {
+		fee = txn.Fee
+	}
+	return fee
 }
 
 func (c *Chain) GetTransactionCostFeeTable(ctx context.Context,
@@ -404,10 +463,41 @@ func (c *Chain) updateState(ctx context.Context, b *block.Block, bState util.Mer
 		return nil, err
 	}
 
+	// the base fee of the block is set by the pay fees transaction of the
+	// previous block, the built-in transactions don't pay it
+	var (
+		baseFee      *minersc.BaseFeeNode
+		declaredCost int
+	)
+	if c.ChainConfig.IsFeeEnabled() && !isBuiltInTxn(b, txn) {
+		if baseFee, err = minersc.GetBaseFee(sctx, b.Round); err != nil {
+			return nil, err
+		}
+	}
+	if baseFee != nil {
+		if declaredCost, err = c.declaredCost(txn, sctx); err != nil {
+			return nil, err
+		}
+		if err = c.validateBaseFee(baseFee, txn, declaredCost); err != nil {
+			return nil, err
+		}
+	}
+
 	switch txn.TransactionType {
-	case transaction.TxnTypeSmartContract:
+	case transaction.TxnTypeSmartContract, transaction.TxnTypeBatch:
 		t := time.Now()
+		// meter the smart contract only, a metered one is aborted as soon
+		// as it exceeds its max cost
+		meter := sctx.GetMeter()
+		meter.Reset()
+		if txn.IsMetered() {
+			meter.SetLimit(txn.MaxCost)
+		}
 		output, err := c.ExecuteSmartContract(ctx, txn, sctx)
+		txn.Cost = meter.Cost()
+		exceeded := meter.Exceeded()
+		// the transfers and the fee of the transaction aren't limited
+		meter.SetLimit(0)
 		switch err {
 		//internal errors
 		case context.DeadlineExceeded, transaction.ErrSmartContractContext, util.ErrNodeNotFound:
@@ -433,6 +523,12 @@ func (c *Chain) updateState(ctx context.Context, b *block.Block, bState util.Mer
 			//return original error, to handle upwards
 			return nil, err
 		default:
+			if exceeded && !bcstate.ErrInvalidState(err) {
+				// where the execution is aborted depends on the order of
+				// the concurrent reads, the max cost is charged instead
+				txn.Cost = txn.MaxCost
+				err = fmt.Errorf("%w: %d", ErrMaxCostExceeded, txn.MaxCost)
+			}
 			if err != nil {
 				if bcstate.ErrInvalidState(err) {
 					logging.Logger.Error("Error executing the SC, internal error",
@@ -475,10 +571,13 @@ func (c *Chain) updateState(ctx context.Context, b *block.Block, bState util.Mer
 			zap.Int64("txn_nonce", txn.Nonce),
 			zap.String("txn_func", txn.FunctionName),
 			zap.Int("txn_status", txn.Status),
+			zap.Int("txn_cost", txn.Cost),
 			zap.Duration("txn_exec_time", time.Since(t)),
 			zap.String("begin client state", util.ToHex(startRoot)),
 			zap.String("current_root", util.ToHex(sctx.GetState().GetRoot())))
 	case transaction.TxnTypeData:
+	case transaction.TxnTypeCancel:
+		// the cancel transaction only uses its nonce and pays its fee
 	case transaction.TxnTypeSend:
 		// check src balance
 		balance, err := sctx.GetClientBalance(txn.ClientID)
@@ -505,16 +604,32 @@ func (c *Chain) updateState(ctx context.Context, b *block.Block, bState util.Mer
 		return nil, fmt.Errorf("invalid transaction type: %v", txn.TransactionType)
 	}
 
-	if c.ChainConfig.IsFeeEnabled() {
-		err = sctx.AddTransfer(state.NewTransfer(txn.ClientID, minersc.ADDRESS, txn.Fee))
-		if err != nil {
-			logging.Logger.Error("Failed to add transfer",
+	if baseFee != nil {
+		if err = c.chargeBaseFee(sctx, baseFee, txn, declaredCost); err != nil {
+			logging.Logger.Error("Failed to charge base fee",
 				zap.Int("txn type", txn.TransactionType),
 				zap.String("transaction_ClientID", txn.ClientID),
-				zap.String("minersc_address", minersc.ADDRESS),
-				zap.Any("state_balance", txn.Fee))
+				zap.Any("base_fee", baseFee.BaseFee),
+				zap.Error(err))
 			return nil, err
 		}
+	} else {
+		// the miner SC pays the charged fee to the generator and sharders
+		txn.ChargedFee = txn.Fee
+		if txn.IsMetered() {
+			txn.ChargedFee = c.meteredFee(txn)
+		}
+		if c.ChainConfig.IsFeeEnabled() {
+			err = sctx.AddTransfer(state.NewTransfer(txn.ClientID, minersc.ADDRESS, txn.ChargedFee))
+			if err != nil {
+				logging.Logger.Error("Failed to add transfer",
+					zap.Int("txn type", txn.TransactionType),
+					zap.String("transaction_ClientID", txn.ClientID),
+					zap.String("minersc_address", minersc.ADDRESS),
+					zap.Any("state_balance", txn.ChargedFee))
+				return nil, err
+			}
+		}
 	}
 
 	ue := make(map[string]*event.User)
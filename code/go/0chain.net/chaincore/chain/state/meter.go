@@ -0,0 +1,133 @@
+package state
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// The weights of the metered operations, in the units of the smart contracts
+// cost tables.
+const (
+	MeterReadCost      = 10
+	MeterWriteCost     = 50
+	MeterDeleteCost    = 30
+	MeterEventCost     = 5
+	MeterReadKBCost    = 2
+	MeterWrittenKBCost = 20
+)
+
+// ErrMaxCostExceeded is returned by the metered operations once the metered
+// work costs more than the limit of the meter.
+var ErrMaxCostExceeded = errors.New("max transaction cost exceeded")
+
+// Meter counts the work done by a smart contract on the state context. The
+// smart contracts may read the MPT concurrently, so the counters are atomic.
+type Meter struct {
+	limit        atomic.Int64
+	reads        atomic.Int64
+	writes       atomic.Int64
+	deletes      atomic.Int64
+	events       atomic.Int64
+	readBytes    atomic.Int64
+	writtenBytes atomic.Int64
+}
+
+// MeterUsage is a snapshot of the meter counters.
+type MeterUsage struct {
+	Reads        int64 `json:"reads"`
+	Writes       int64 `json:"writes"`
+	Deletes      int64 `json:"deletes"`
+	Events       int64 `json:"events"`
+	ReadBytes    int64 `json:"read_bytes"`
+	WrittenBytes int64 `json:"written_bytes"`
+}
+
+func (m *Meter) read(size int) {
+	m.reads.Add(1)
+	m.readBytes.Add(int64(size))
+}
+
+func (m *Meter) write(size int) {
+	m.writes.Add(1)
+	m.writtenBytes.Add(int64(size))
+}
+
+func (m *Meter) delete() {
+	m.deletes.Add(1)
+}
+
+func (m *Meter) event() {
+	m.events.Add(1)
+}
+
+// check returns ErrMaxCostExceeded if the metered work exceeds the limit.
+func (m *Meter) check() error {
+	if m.Exceeded() {
+		return ErrMaxCostExceeded
+	}
+	return nil
+}
+
+// SetLimit sets the max cost of the metered work, zero means no limit.
+func (m *Meter) SetLimit(cost int) {
+	m.limit.Store(int64(cost))
+}
+
+// Exceeded returns true if the metered work costs more than the limit.
+func (m *Meter) Exceeded() bool {
+	limit := m.limit.Load()
+	return limit > 0 && int64(m.Cost()) > limit
+}
+
+// Reset the counters and the limit of the meter.
+func (m *Meter) Reset() {
+	m.limit.Store(0)
+	m.reads.Store(0)
+	m.writes.Store(0)
+	m.deletes.Store(0)
+	m.events.Store(0)
+	m.readBytes.Store(0)
+	m.writtenBytes.Store(0)
+}
+
+// Usage returns the current counters of the meter.
+func (m *Meter) Usage() MeterUsage {
+	return MeterUsage{
+		Reads:        m.reads.Load(),
+		Writes:       m.writes.Load(),
+		Deletes:      m.deletes.Load(),
+		Events:       m.events.Load(),
+		ReadBytes:    m.readBytes.Load(),
+		WrittenBytes: m.writtenBytes.Load(),
+	}
+}
+
+// Cost of the metered work, a started KB is charged as a whole one.
+func (u MeterUsage) Cost() int {
+	return int(u.Reads*MeterReadCost +
+		u.Writes*MeterWriteCost +
+		u.Deletes*MeterDeleteCost +
+		u.Events*MeterEventCost +
+		startedKB(u.ReadBytes)*MeterReadKBCost +
+		startedKB(u.WrittenBytes)*MeterWrittenKBCost)
+}
+
+func startedKB(size int64) int64 {
+	return (size + 1023) / 1024
+}
+
+// Cost of the work metered so far.
+func (m *Meter) Cost() int {
+	return m.Usage().Cost()
+}
+
+// encodedNode is an already encoded MPT node value, it's only inserted.
+type encodedNode []byte
+
+func (n encodedNode) MarshalMsg(b []byte) ([]byte, error) {
+	return append(b, n...), nil
+}
+
+func (n encodedNode) UnmarshalMsg(b []byte) ([]byte, error) {
+	return b, errors.New("encoded node can't be decoded")
+}
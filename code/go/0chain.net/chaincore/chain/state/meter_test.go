@@ -0,0 +1,95 @@
+package state
+
+import (
+	"bytes"
+	"testing"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/smartcontract/dbs/event"
+	"github.com/0chain/common/core/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeterUsage_Cost(t *testing.T) {
+	assert.Zero(t, MeterUsage{}.Cost())
+	assert.Equal(t, 2*MeterReadCost+MeterWriteCost+MeterDeleteCost+3*MeterEventCost+
+		MeterReadKBCost+2*MeterWrittenKBCost,
+		MeterUsage{
+			Reads:        2,
+			Writes:       1,
+			Deletes:      1,
+			Events:       3,
+			ReadBytes:    10,
+			WrittenBytes: 1025,
+		}.Cost())
+}
+
+func TestStateContext_meter(t *testing.T) {
+	var (
+		mpt  = util.NewMerklePatriciaTrie(util.NewMemoryNodeDB(), 1, nil)
+		sctx = NewStateContext(&block.Block{}, mpt, &transaction.Transaction{},
+			nil, nil, nil, nil, nil, nil)
+		value = &util.SecureSerializableValue{Buffer: bytes.Repeat([]byte{1}, 2000)}
+	)
+
+	_, err := sctx.InsertTrieNode("key", value)
+	require.NoError(t, err)
+
+	var got util.SecureSerializableValue
+	require.NoError(t, sctx.GetTrieNode("key", &got))
+	assert.Equal(t, value.Buffer, got.Buffer)
+	require.Equal(t, util.ErrValueNotPresent, sctx.GetTrieNode("missing", &got))
+
+	_, err = sctx.DeleteTrieNode("key")
+	require.NoError(t, err)
+	sctx.EmitEvent(event.TypeStats, event.TagAddAllocationCharge, "id", nil)
+
+	usage := sctx.GetMeter().Usage()
+	encoded, err := value.MarshalMsg(nil)
+	require.NoError(t, err)
+	assert.Equal(t, MeterUsage{
+		Reads:        2,
+		Writes:       1,
+		Deletes:      1,
+		Events:       1,
+		ReadBytes:    int64(len(encoded)),
+		WrittenBytes: int64(len(encoded)),
+	}, usage)
+	assert.Equal(t, usage.Cost(), sctx.GetMeter().Cost())
+}
+
+func TestStateContext_meterLimit(t *testing.T) {
+	var (
+		mpt  = util.NewMerklePatriciaTrie(util.NewMemoryNodeDB(), 1, nil)
+		sctx = NewStateContext(&block.Block{}, mpt, &transaction.Transaction{},
+			nil, nil, nil, nil, nil, nil)
+		value = &util.SecureSerializableValue{Buffer: []byte{1}}
+		meter = sctx.GetMeter()
+	)
+
+	meter.SetLimit(MeterWriteCost + MeterWrittenKBCost + MeterReadCost)
+	_, err := sctx.InsertTrieNode("key", value)
+	require.NoError(t, err)
+	assert.False(t, meter.Exceeded())
+
+	// the read of a started KB exceeds the limit
+	var got util.SecureSerializableValue
+	require.Equal(t, ErrMaxCostExceeded, sctx.GetTrieNode("key", &got))
+	assert.True(t, meter.Exceeded())
+	_, err = sctx.InsertTrieNode("other", value)
+	require.Equal(t, ErrMaxCostExceeded, err)
+	_, err = sctx.DeleteTrieNode("key")
+	require.Equal(t, ErrMaxCostExceeded, err)
+
+	// no limit
+	meter.SetLimit(0)
+	assert.False(t, meter.Exceeded())
+	require.NoError(t, sctx.GetTrieNode("key", &got))
+
+	meter.SetLimit(1)
+	meter.Reset()
+	assert.Zero(t, meter.Cost())
+	assert.False(t, meter.Exceeded())
+}
@@ -111,6 +111,7 @@ type StateContextI interface {
 	AddMint(m *state.Mint) error
 	GetTransfers() []*state.Transfer // cannot use in smart contracts or REST endpoints
 	GetSignedTransfers() []*state.SignedTransfer
+	ExecuteOnBehalf(txn *transaction.Transaction, execute func(StateContextI) (string, error)) (string, error)
 	GetMints() []*state.Mint // cannot use in smart contracts or REST endpoints
 	Validate() error
 	GetSignatureScheme() encryption.SignatureScheme
@@ -139,6 +140,8 @@ type StateContext struct {
 	getSignature                  func() encryption.SignatureScheme
 	eventDb                       *event.EventDb
 	mutex                         *sync.Mutex
+	// meter counts the work done on the state context by the transaction
+	meter *Meter
 }
 
 type GetNow func() common.Timestamp
@@ -185,6 +188,7 @@ func NewStateContext(
 		eventDb:                       eventDb,
 		clientStates:                  make(map[string]*state.State),
 		mutex:                         new(sync.Mutex),
+		meter:                         new(Meter),
 	}
 }
 
@@ -297,6 +301,46 @@ func (sc *StateContext) GetSignedTransfers() []*state.SignedTransfer {
 	return sc.signedTransfers
 }
 
+// ExecuteOnBehalf - executes the function with a state context sharing the state
+// with this one, but acting on behalf of the given transaction. Transfers, mints
+// and events of the execution are added to this state context only if the
+// execution succeeds and its transfers are valid for the given transaction.
+// The caller is responsible for the authorization of the transaction.
+func (sc *StateContext) ExecuteOnBehalf(txn *transaction.Transaction,
+	execute func(StateContextI) (string, error)) (string, error) {
+	nested := &StateContext{
+		block:                         sc.block,
+		state:                         sc.state,
+		txn:                           txn,
+		getMagicBlock:                 sc.getMagicBlock,
+		getLastestFinalizedMagicBlock: sc.getLastestFinalizedMagicBlock,
+		getLatestFinalizedBlock:       sc.getLatestFinalizedBlock,
+		getChainCurrentMagicBlock:     sc.getChainCurrentMagicBlock,
+		getSignature:                  sc.getSignature,
+		eventDb:                       sc.eventDb,
+		clientStates:                  sc.clientStates,
+		mutex:                         sc.mutex,
+		meter:                         sc.meter,
+	}
+
+	output, err := execute(nested)
+	if err != nil {
+		return "", err
+	}
+
+	if err := nested.Validate(); err != nil {
+		return "", err
+	}
+
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	sc.transfers = append(sc.transfers, nested.transfers...)
+	sc.signedTransfers = append(sc.signedTransfers, nested.signedTransfers...)
+	sc.mints = append(sc.mints, nested.mints...)
+	sc.events = append(sc.events, nested.events...)
+	return output, nil
+}
+
 // GetMints - get all the mints and fight bad breath
 func (sc *StateContext) GetMints() []*state.Mint {
 	return sc.mints
@@ -319,6 +363,7 @@ func (sc *StateContext) EmitEvent(eventType event.EventType, tag event.EventTag,
 		Index:       index,
 		Data:        data,
 	}
+	sc.meter.event()
 	if len(appenders) != 0 {
 		sc.events = appenders[0](sc.events, e)
 	} else {
@@ -326,6 +371,11 @@ func (sc *StateContext) EmitEvent(eventType event.EventType, tag event.EventTag,
 	}
 }
 
+// GetMeter returns the meter of the work done by the transaction so far.
+func (sc *StateContext) GetMeter() *Meter {
+	return sc.meter
+}
+
 func (sc *StateContext) EmitError(err error) {
 	sc.events = []event.Event{
 		{
@@ -396,8 +446,14 @@ func (sc *StateContext) GetClientState(clientID string) (*state.State, error) {
 	}
 
 	s := &state.State{}
-	path := util.Path(clientID)
-	err := sc.state.GetNodeValue(path, s)
+	d, err := sc.state.GetNodeValueRaw(util.Path(clientID))
+	sc.meter.read(len(d))
+	if merr := sc.meter.check(); merr != nil {
+		return nil, merr
+	}
+	if err == nil {
+		_, err = s.UnmarshalMsg(d)
+	}
 	if err != nil {
 		if err != util.ErrValueNotPresent {
 			return nil, err
@@ -479,11 +535,35 @@ func (sc *StateContext) GetLatestFinalizedBlock() *block.Block {
 }
 
 func (sc *StateContext) getNodeValue(key datastore.Key, v util.MPTSerializable) error {
-	return sc.state.GetNodeValue(util.Path(encryption.Hash(key)), v)
+	d, err := sc.state.GetNodeValueRaw(util.Path(encryption.Hash(key)))
+	sc.meter.read(len(d))
+	if merr := sc.meter.check(); merr != nil {
+		return merr
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = v.UnmarshalMsg(d)
+	return err
 }
 
 func (sc *StateContext) setNodeValue(key datastore.Key, node util.MPTSerializable) (datastore.Key, error) {
-	newKey, err := sc.state.Insert(util.Path(encryption.Hash(key)), node)
+	if node == nil {
+		return sc.deleteNode(key)
+	}
+
+	// encode once, to meter the size and insert it
+	d, err := node.MarshalMsg(nil)
+	if err != nil {
+		return "", err
+	}
+	sc.meter.write(len(d))
+	if err := sc.meter.check(); err != nil {
+		return "", err
+	}
+
+	newKey, err := sc.state.Insert(util.Path(encryption.Hash(key)), encodedNode(d))
 	if err != nil {
 		return "", err
 	}
@@ -492,6 +572,10 @@ func (sc *StateContext) setNodeValue(key datastore.Key, node util.MPTSerializabl
 }
 
 func (sc *StateContext) deleteNode(key datastore.Key) (datastore.Key, error) {
+	sc.meter.delete()
+	if err := sc.meter.check(); err != nil {
+		return "", err
+	}
 	newKey, err := sc.state.Delete(util.Path(encryption.Hash(key)))
 	if err != nil {
 		return "", err
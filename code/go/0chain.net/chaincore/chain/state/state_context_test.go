@@ -5,6 +5,12 @@ import (
 	"fmt"
 	"testing"
 
+	"0chain.net/chaincore/block"
+	"0chain.net/chaincore/config"
+	"0chain.net/chaincore/config/mocks"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/smartcontract/dbs/event"
 	"github.com/0chain/common/core/logging"
 	"github.com/0chain/common/core/util"
 	"github.com/stretchr/testify/require"
@@ -187,3 +193,97 @@ func TestWrongGetItemFunc(t *testing.T) {
 	//}, nil)
 	//require.NoError(t, err)
 }
+
+func TestStateContext_ExecuteOnBehalf(t *testing.T) {
+	chainConfig := &mocks.ChainConfig{}
+	chainConfig.On("IsFeeEnabled").Return(false)
+	config.Configuration().ChainConfig = chainConfig
+
+	const (
+		wallet = "wallet"
+		callee = "callee_sc"
+	)
+	newStateContext := func() *StateContext {
+		b := &block.Block{}
+		b.Round = 1
+		txn := &transaction.Transaction{ClientID: "signer", ToClientID: "multisig_sc"}
+		txn.Hash = "txn_hash"
+		return NewStateContext(b, util.NewMerklePatriciaTrie(util.NewMemoryNodeDB(), 1, nil),
+			txn, nil, nil, nil, nil, nil, nil)
+	}
+	newInnerTxn := func() *transaction.Transaction {
+		txn := &transaction.Transaction{ClientID: wallet, ToClientID: callee, Value: 10}
+		txn.Hash = "txn_hash"
+		return txn
+	}
+	emit := func(sctx StateContextI) {
+		sctx.EmitEvent(event.TypeStats, event.TagAddOrOverwriteUser, wallet, nil)
+	}
+
+	tests := []struct {
+		name    string
+		execute func(sctx StateContextI) (string, error)
+		err     error
+	}{
+		{
+			name: "ok",
+			execute: func(sctx StateContextI) (string, error) {
+				emit(sctx)
+				return "ok", sctx.AddTransfer(state.NewTransfer(wallet, callee, 10))
+			},
+		},
+		{
+			name: "transfer over the value",
+			execute: func(sctx StateContextI) (string, error) {
+				emit(sctx)
+				return "ok", sctx.AddTransfer(state.NewTransfer(wallet, callee, 11))
+			},
+			err: state.ErrInvalidTransfer,
+		},
+		{
+			name: "transfer of the outer client",
+			execute: func(sctx StateContextI) (string, error) {
+				return "ok", sctx.AddTransfer(state.NewTransfer("signer", callee, 1))
+			},
+			err: state.ErrInvalidTransfer,
+		},
+		{
+			name: "callee fails",
+			execute: func(sctx StateContextI) (string, error) {
+				emit(sctx)
+				if err := sctx.AddTransfer(state.NewTransfer(wallet, callee, 10)); err != nil {
+					return "", err
+				}
+				if err := sctx.AddTransfer(state.NewTransfer(callee, "receiver", 5)); err != nil {
+					return "", err
+				}
+				return "", errors.New("call failed")
+			},
+			err: errors.New("call failed"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			sctx := newStateContext()
+			require.NoError(t, sctx.AddTransfer(state.NewTransfer("signer", "multisig_sc", 1)))
+
+			output, err := sctx.ExecuteOnBehalf(newInnerTxn(), tt.execute)
+			if tt.err != nil {
+				require.EqualError(t, err, tt.err.Error())
+				// nothing of the failed call is kept
+				require.Len(t, sctx.GetTransfers(), 1)
+				require.Empty(t, sctx.GetEvents())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "ok", output)
+			require.Equal(t, []*state.Transfer{
+				state.NewTransfer("signer", "multisig_sc", 1),
+				state.NewTransfer(wallet, callee, 10),
+			}, sctx.GetTransfers())
+			require.Len(t, sctx.GetEvents(), 1)
+			require.Equal(t, "txn_hash", sctx.GetEvents()[0].TxHash)
+		})
+	}
+}
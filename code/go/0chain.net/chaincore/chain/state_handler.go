@@ -13,6 +13,7 @@ import (
 
 	"0chain.net/smartcontract/faucetsc"
 	"0chain.net/smartcontract/minersc"
+	"0chain.net/smartcontract/multisigsc"
 	"0chain.net/smartcontract/rest"
 	"0chain.net/smartcontract/storagesc"
 	"0chain.net/smartcontract/vestingsc"
@@ -50,6 +51,7 @@ func SetupScRestApiHandlers() {
 	if c.EventDb != nil {
 		faucetsc.SetupRestHandler(restHandler)
 		minersc.SetupRestHandler(restHandler)
+		multisigsc.SetupRestHandler(restHandler)
 		storagesc.SetupRestHandler(restHandler)
 		vestingsc.SetupRestHandler(restHandler)
 		zcnsc.SetupRestHandler(restHandler)
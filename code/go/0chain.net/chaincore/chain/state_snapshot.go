@@ -0,0 +1,365 @@
+package chain
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"time"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"github.com/0chain/common/core/logging"
+	"github.com/0chain/common/core/util"
+	"go.uber.org/zap"
+)
+
+// The state snapshot file layout, all the integers are big endian:
+//
+//	magic    [8]byte "0CSTSNAP"
+//	version  uint32
+//	header   uint32 length + JSON encoded StateSnapshotHeader
+//	block    uint32 length + msgpack encoded block of the snapshot round
+//	lfmb     uint32 length + msgpack encoded latest finalized magic block
+//	         of the block, the length is zero if it's the block itself
+//	nodes    uint32 key length + key + uint32 node length + encoded node,
+//	         repeated for every node of the client state MPT
+//	end      uint32 zero key length
+//	count    uint64 number of the nodes
+//	checksum [32]byte sha256 of all the preceding bytes
+const (
+	stateSnapshotMagic   = "0CSTSNAP"
+	stateSnapshotVersion = 1
+
+	// snapshotMaxRecordSize limits the records read from a snapshot file.
+	snapshotMaxRecordSize = 64 << 20
+	// snapshotImportBatch is the number of nodes written to the state DB
+	// at once on import.
+	snapshotImportBatch = 1000
+)
+
+// ErrSnapshotChecksum is returned on import of a corrupt snapshot file.
+var ErrSnapshotChecksum = errors.New("state snapshot checksum mismatch")
+
+// StateSnapshotHeader describes a state snapshot.
+type StateSnapshotHeader struct {
+	Round     int64            `json:"round"`
+	BlockHash string           `json:"block_hash"`
+	StateHash string           `json:"state_hash"`
+	CreatedAt common.Timestamp `json:"created_at"`
+}
+
+// StateSnapshot is an imported state snapshot.
+type StateSnapshot struct {
+	Header StateSnapshotHeader
+	Block  *block.Block
+	// LFMB is the latest finalized magic block of the block, it's the
+	// block itself for the magic block rounds.
+	LFMB  *block.Block
+	Nodes uint64
+}
+
+type snapshotWriter struct {
+	w   *bufio.Writer
+	sum hash.Hash
+	err error
+}
+
+func (sw *snapshotWriter) write(p []byte) {
+	if sw.err != nil {
+		return
+	}
+	if _, sw.err = sw.w.Write(p); sw.err == nil {
+		sw.sum.Write(p)
+	}
+}
+
+func (sw *snapshotWriter) writeUint32(v uint32) {
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], v)
+	sw.write(buf[:])
+}
+
+func (sw *snapshotWriter) writeRecord(p []byte) {
+	sw.writeUint32(uint32(len(p)))
+	sw.write(p)
+}
+
+func encodeSnapshotBlock(b *block.Block) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := datastore.WriteMsgpack(buf, b); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ExportStateSnapshot writes the client state MPT of the block, the block
+// and its latest finalized magic block to the writer. All the state nodes
+// should be present in the node DB.
+func ExportStateSnapshot(ctx context.Context, w io.Writer, ndb util.NodeDB, b, lfmb *block.Block) (*StateSnapshotHeader, error) {
+	if len(b.ClientStateHash) == 0 {
+		return nil, common.NewError("export_state_snapshot", "block has no client state hash")
+	}
+
+	header := &StateSnapshotHeader{
+		Round:     b.Round,
+		BlockHash: b.Hash,
+		StateHash: util.ToHex(b.ClientStateHash),
+		CreatedAt: common.Now(),
+	}
+	headerData, err := json.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+	blockData, err := encodeSnapshotBlock(b)
+	if err != nil {
+		return nil, err
+	}
+	var lfmbData []byte
+	if lfmb != nil && lfmb.Hash != b.Hash {
+		if lfmbData, err = encodeSnapshotBlock(lfmb); err != nil {
+			return nil, err
+		}
+	}
+
+	sw := &snapshotWriter{w: bufio.NewWriterSize(w, 1<<20), sum: sha256.New()}
+	sw.write([]byte(stateSnapshotMagic))
+	sw.writeUint32(stateSnapshotVersion)
+	sw.writeRecord(headerData)
+	sw.writeRecord(blockData)
+	sw.writeRecord(lfmbData)
+
+	var (
+		count uint64
+		ts    = time.Now()
+		mpt   = util.NewMerklePatriciaTrie(ndb, util.Sequence(b.Round), b.ClientStateHash)
+	)
+	handler := func(_ context.Context, _ util.Path, key util.Key, node util.Node) error {
+		if node == nil {
+			return common.NewErrorf("export_state_snapshot",
+				"missing state node %s", util.ToHex(key))
+		}
+		sw.writeRecord(key)
+		sw.writeRecord(node.Encode())
+		count++
+		return sw.err
+	}
+	if err := mpt.Iterate(ctx, handler,
+		util.NodeTypeLeafNode|util.NodeTypeFullNode|util.NodeTypeExtensionNode); err != nil {
+		return nil, err
+	}
+
+	sw.writeUint32(0)
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], count)
+	sw.write(buf[:])
+	if sw.err != nil {
+		return nil, sw.err
+	}
+	if _, err := sw.w.Write(sw.sum.Sum(nil)); err != nil {
+		return nil, err
+	}
+	if err := sw.w.Flush(); err != nil {
+		return nil, err
+	}
+
+	logging.Logger.Info("export state snapshot",
+		zap.Int64("round", b.Round),
+		zap.String("block", b.Hash),
+		zap.Uint64("nodes", count),
+		zap.Duration("duration", time.Since(ts)))
+	return header, nil
+}
+
+type snapshotReader struct {
+	r   *bufio.Reader
+	sum hash.Hash
+}
+
+func (sr *snapshotReader) read(p []byte) error {
+	if _, err := io.ReadFull(sr.r, p); err != nil {
+		return err
+	}
+	sr.sum.Write(p)
+	return nil
+}
+
+func (sr *snapshotReader) readUint32() (uint32, error) {
+	var buf [4]byte
+	if err := sr.read(buf[:]); err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint32(buf[:]), nil
+}
+
+func (sr *snapshotReader) readRecord() ([]byte, error) {
+	n, err := sr.readUint32()
+	if err != nil {
+		return nil, err
+	}
+	if n > snapshotMaxRecordSize {
+		return nil, fmt.Errorf("too large record: %d", n)
+	}
+	p := make([]byte, n)
+	if err := sr.read(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func decodeSnapshotBlock(data []byte) (*block.Block, error) {
+	b := datastore.GetEntityMetadata("block").Instance().(*block.Block)
+	if err := datastore.ReadMsgpack(bytes.NewReader(data), b); err != nil {
+		return nil, err
+	}
+	if b.ComputeHash() != b.Hash {
+		return nil, fmt.Errorf("block %s hash mismatch", b.Hash)
+	}
+	return b, nil
+}
+
+// ImportStateSnapshot reads a snapshot written by ExportStateSnapshot and
+// puts the state nodes to the node DB. Every node is verified against its
+// key and the whole file against the checksum, the blocks are verified
+// against their hashes.
+func ImportStateSnapshot(ctx context.Context, r io.Reader, ndb util.NodeDB) (*StateSnapshot, error) {
+	sr := &snapshotReader{r: bufio.NewReaderSize(r, 1<<20), sum: sha256.New()}
+
+	magic := make([]byte, len(stateSnapshotMagic))
+	if err := sr.read(magic); err != nil || string(magic) != stateSnapshotMagic {
+		return nil, common.NewError("import_state_snapshot", "not a state snapshot file")
+	}
+	version, err := sr.readUint32()
+	if err != nil {
+		return nil, common.NewErrorf("import_state_snapshot", "reading version: %v", err)
+	}
+	if version != stateSnapshotVersion {
+		return nil, common.NewErrorf("import_state_snapshot", "unsupported version: %d", version)
+	}
+
+	var ss StateSnapshot
+	headerData, err := sr.readRecord()
+	if err != nil {
+		return nil, common.NewErrorf("import_state_snapshot", "reading header: %v", err)
+	}
+	if err := json.Unmarshal(headerData, &ss.Header); err != nil {
+		return nil, common.NewErrorf("import_state_snapshot", "decoding header: %v", err)
+	}
+
+	blockData, err := sr.readRecord()
+	if err != nil {
+		return nil, common.NewErrorf("import_state_snapshot", "reading block: %v", err)
+	}
+	if ss.Block, err = decodeSnapshotBlock(blockData); err != nil {
+		return nil, common.NewErrorf("import_state_snapshot", "decoding block: %v", err)
+	}
+	if ss.Block.Hash != ss.Header.BlockHash || ss.Block.Round != ss.Header.Round ||
+		util.ToHex(ss.Block.ClientStateHash) != ss.Header.StateHash {
+		return nil, common.NewError("import_state_snapshot", "block doesn't match the header")
+	}
+
+	lfmbData, err := sr.readRecord()
+	if err != nil {
+		return nil, common.NewErrorf("import_state_snapshot", "reading magic block: %v", err)
+	}
+	ss.LFMB = ss.Block
+	if len(lfmbData) > 0 {
+		if ss.LFMB, err = decodeSnapshotBlock(lfmbData); err != nil {
+			return nil, common.NewErrorf("import_state_snapshot", "decoding magic block: %v", err)
+		}
+	}
+	if ss.LFMB.MagicBlock == nil {
+		return nil, common.NewError("import_state_snapshot", "missing magic block")
+	}
+
+	var (
+		keys  = make([]util.Key, 0, snapshotImportBatch)
+		nodes = make([]util.Node, 0, snapshotImportBatch)
+	)
+	flush := func() error {
+		if len(keys) == 0 {
+			return nil
+		}
+		if err := ndb.MultiPutNode(keys, nodes); err != nil {
+			return err
+		}
+		keys, nodes = keys[:0], nodes[:0]
+		return nil
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		key, err := sr.readRecord()
+		if err != nil {
+			return nil, common.NewErrorf("import_state_snapshot", "reading node key: %v", err)
+		}
+		if len(key) == 0 {
+			break
+		}
+		data, err := sr.readRecord()
+		if err != nil {
+			return nil, common.NewErrorf("import_state_snapshot", "reading node: %v", err)
+		}
+		node, err := util.CreateNode(bytes.NewReader(data))
+		if err != nil {
+			return nil, common.NewErrorf("import_state_snapshot", "decoding node: %v", err)
+		}
+		if !bytes.Equal(node.GetHashBytes(), key) {
+			return nil, common.NewErrorf("import_state_snapshot",
+				"node %s hash mismatch", util.ToHex(key))
+		}
+		keys, nodes = append(keys, key), append(nodes, node)
+		ss.Nodes++
+		if len(keys) == snapshotImportBatch {
+			if err := flush(); err != nil {
+				return nil, common.NewErrorf("import_state_snapshot", "saving nodes: %v", err)
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, common.NewErrorf("import_state_snapshot", "saving nodes: %v", err)
+	}
+
+	var buf [8]byte
+	if err := sr.read(buf[:]); err != nil {
+		return nil, common.NewErrorf("import_state_snapshot", "reading nodes count: %v", err)
+	}
+	if count := binary.BigEndian.Uint64(buf[:]); count != ss.Nodes {
+		return nil, common.NewErrorf("import_state_snapshot",
+			"nodes count mismatch: %d, expected %d", ss.Nodes, count)
+	}
+
+	expected := sr.sum.Sum(nil)
+	checksum := make([]byte, len(expected))
+	if _, err := io.ReadFull(sr.r, checksum); err != nil {
+		return nil, common.NewErrorf("import_state_snapshot", "reading checksum: %v", err)
+	}
+	if !bytes.Equal(checksum, expected) {
+		return nil, ErrSnapshotChecksum
+	}
+
+	// make sure the state is complete
+	mpt := util.NewMerklePatriciaTrie(ndb, util.Sequence(ss.Block.Round), ss.Block.ClientStateHash)
+	missing, err := mpt.HasMissingNodes(ctx)
+	if err != nil {
+		return nil, common.NewErrorf("import_state_snapshot", "checking state: %v", err)
+	}
+	if missing {
+		return nil, common.NewError("import_state_snapshot", "state has missing nodes")
+	}
+
+	logging.Logger.Info("import state snapshot",
+		zap.Int64("round", ss.Block.Round),
+		zap.String("block", ss.Block.Hash),
+		zap.Uint64("nodes", ss.Nodes))
+	return &ss, nil
+}
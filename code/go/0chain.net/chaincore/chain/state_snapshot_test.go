@@ -0,0 +1,89 @@
+package chain
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/chaincore/node"
+	"github.com/0chain/common/core/util"
+	"github.com/stretchr/testify/require"
+)
+
+func newSnapshotBlock(t *testing.T, values int) (*block.Block, util.NodeDB) {
+	ndb := util.NewMemoryNodeDB()
+	mpt := util.NewMerklePatriciaTrie(ndb, 1, nil)
+	for i := 0; i < values; i++ {
+		v := util.SecureSerializableValue{Buffer: []byte(fmt.Sprintf("value %d", i))}
+		_, err := mpt.Insert(util.Path(util.Hash(fmt.Sprintf("key %d", i))), &v)
+		require.NoError(t, err)
+	}
+
+	b := block.NewBlock("", 1)
+	b.MinerID = "miner"
+	b.ClientStateHash = mpt.GetRoot()
+	b.MagicBlock = block.NewMagicBlock()
+	b.MagicBlock.Miners = node.NewPool(node.NodeTypeMiner)
+	b.MagicBlock.Sharders = node.NewPool(node.NodeTypeSharder)
+	b.HashBlock()
+	b.LatestFinalizedMagicBlockHash = b.Hash
+	return b, ndb
+}
+
+func TestStateSnapshot(t *testing.T) {
+	ctx := context.Background()
+	b, ndb := newSnapshotBlock(t, 100)
+
+	buf := new(bytes.Buffer)
+	header, err := ExportStateSnapshot(ctx, buf, ndb, b, b)
+	require.NoError(t, err)
+	require.Equal(t, b.Hash, header.BlockHash)
+	snapshot := buf.Bytes()
+
+	t.Run("import", func(t *testing.T) {
+		imported := util.NewMemoryNodeDB()
+		ss, err := ImportStateSnapshot(ctx, bytes.NewReader(snapshot), imported)
+		require.NoError(t, err)
+		require.Equal(t, b.Hash, ss.Block.Hash)
+		require.Equal(t, ss.Block, ss.LFMB)
+		require.EqualValues(t, imported.Size(ctx), ss.Nodes)
+
+		mpt := util.NewMerklePatriciaTrie(imported, 1, b.ClientStateHash)
+		var v util.SecureSerializableValue
+		require.NoError(t, mpt.GetNodeValue(util.Path(util.Hash("key 42")), &v))
+		require.Equal(t, "value 42", string(v.Buffer))
+	})
+
+	t.Run("corrupt", func(t *testing.T) {
+		corrupt := append([]byte(nil), snapshot...)
+		corrupt[len(corrupt)-40] ^= 0xff // the nodes count
+		_, err := ImportStateSnapshot(ctx, bytes.NewReader(corrupt), util.NewMemoryNodeDB())
+		require.Error(t, err)
+
+		corrupt = append([]byte(nil), snapshot...)
+		corrupt[len(corrupt)-1] ^= 0xff // the checksum
+		_, err = ImportStateSnapshot(ctx, bytes.NewReader(corrupt), util.NewMemoryNodeDB())
+		require.ErrorIs(t, err, ErrSnapshotChecksum)
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		_, err := ImportStateSnapshot(ctx, bytes.NewReader(snapshot[:len(snapshot)/2]),
+			util.NewMemoryNodeDB())
+		require.Error(t, err)
+	})
+
+	t.Run("missing nodes", func(t *testing.T) {
+		pruned := util.NewMemoryNodeDB()
+		require.NoError(t, pruned.PutNode(b.ClientStateHash, mustGetNode(t, ndb, b.ClientStateHash)))
+		_, err := ExportStateSnapshot(ctx, new(bytes.Buffer), pruned, b, b)
+		require.Error(t, err)
+	})
+}
+
+func mustGetNode(t *testing.T, ndb util.NodeDB, key util.Key) util.Node {
+	node, err := ndb.GetNode(key)
+	require.NoError(t, err)
+	return node
+}
@@ -76,6 +76,13 @@ func SetupDefaultConfig() {
 	viper.SetDefault("server_chain.health_check.proximity_scan.repeat_interval_mins", "60m")
 	viper.SetDefault("server_chain.health_check.deep_scan.report_status_mins", "15m")
 
+	// Set defaults for block store scrubbing, repeat it every day.
+	viper.SetDefault("server_chain.health_check.block_scrub.enabled", false)
+	viper.SetDefault("server_chain.health_check.block_scrub.batch_size", 100)
+	viper.SetDefault("server_chain.health_check.block_scrub.batch_pause", "1s")
+	viper.SetDefault("server_chain.health_check.block_scrub.settle_secs", "30s")
+	viper.SetDefault("server_chain.health_check.block_scrub.repeat_interval_mins", "1440m")
+
 	// LFB tickets.
 	viper.SetDefault("server_chain.lfb_ticket.rebroadcast_timeout", "16s")
 	viper.SetDefault("server_chain.lfb_ticket.ahead", 2)
@@ -2,6 +2,7 @@ package smartcontract
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
@@ -73,6 +74,61 @@ func ExecuteSmartContract(txn *transaction.Transaction, balances c_state.StateCo
 	return "", common.NewError("invalid_smart_contract_address", "Invalid Smart Contract address")
 }
 
+// ExecuteBatch executes the smart contract calls of the batch transaction in
+// order, each on behalf of its call transaction. The changes of the calls are
+// kept only if all of them succeed. The output is the list of the outputs of
+// the calls.
+func ExecuteBatch(txn *transaction.Transaction, balances c_state.StateContextI) (string, error) {
+	calls, err := txn.GetBatchCalls()
+	if err != nil {
+		return "", err
+	}
+
+	outputs := make([]string, 0, len(calls))
+	for i, call := range calls {
+		callTxn, err := txn.BatchCallTransaction(i, call)
+		if err != nil {
+			return "", fmt.Errorf("batch call %d: %w", i, err)
+		}
+		output, err := balances.ExecuteOnBehalf(callTxn, func(sctx c_state.StateContextI) (string, error) {
+			return ExecuteSmartContract(callTxn, sctx)
+		})
+		if err != nil {
+			return "", fmt.Errorf("batch call %d: %w", i, err)
+		}
+		outputs = append(outputs, output)
+	}
+
+	output, err := json.Marshal(outputs)
+	if err != nil {
+		return "", err
+	}
+	return string(output), nil
+}
+
+// EstimateBatchCost returns the sum of the costs of the calls of the batch
+// transaction.
+func EstimateBatchCost(txn *transaction.Transaction, balances c_state.StateContextI) (int, error) {
+	calls, err := txn.GetBatchCalls()
+	if err != nil {
+		return math.MaxInt, err
+	}
+
+	var cost int
+	for _, call := range calls {
+		callTxn := &transaction.Transaction{ToClientID: call.ToClientID}
+		callCost, err := EstimateTransactionCost(callTxn, sci.SmartContractTransactionData{
+			FunctionName: call.FunctionName,
+			InputData:    call.InputData,
+		}, balances)
+		if err != nil || callCost > math.MaxInt-cost {
+			return math.MaxInt, err
+		}
+		cost += callCost
+	}
+	return cost, nil
+}
+
 func EstimateTransactionCost(t *transaction.Transaction, scData sci.SmartContractTransactionData, balances c_state.StateContextI) (int, error) {
 	contractObj := getSmartContract(t.ToClientID)
 	if contractObj == nil {
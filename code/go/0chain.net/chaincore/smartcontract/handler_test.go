@@ -3,10 +3,12 @@ package smartcontract_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strconv"
 	"testing"
 
 	"0chain.net/smartcontract/stakepool/spenum"
@@ -34,6 +36,7 @@ import (
 	"0chain.net/chaincore/state"
 	"0chain.net/chaincore/transaction"
 	"0chain.net/core/datastore"
+	"0chain.net/core/encryption"
 	"0chain.net/smartcontract/faucetsc"
 	"0chain.net/smartcontract/minersc"
 	"0chain.net/smartcontract/storagesc"
@@ -49,6 +52,7 @@ func init() {
 	viper.Set("server_chain.smart_contract.miner", true)
 	viper.Set("server_chain.smart_contract.vesting", true)
 	setupsc.SetupSmartContracts()
+	ContractMap[batchTestAddress] = &batchTestSC{}
 }
 
 func TestExecuteStats(t *testing.T) {
@@ -463,3 +467,90 @@ func TestExecuteSmartContract(t *testing.T) {
 		})
 	}
 }
+
+var batchTestAddress = encryption.Hash("batch_test_sc")
+
+// batchTestSC echoes the input of its calls, it fails the "fail" ones.
+type batchTestSC struct{}
+
+func (sc *batchTestSC) Execute(_ *transaction.Transaction, funcName string, input []byte,
+	_ chstate.StateContextI) (string, error) {
+	if funcName == "fail" {
+		return "", errors.New("call failed")
+	}
+	return funcName + ":" + string(input), nil
+}
+
+func (sc *batchTestSC) GetHandlerStats(context.Context, url.Values) (interface{}, error) {
+	return nil, nil
+}
+
+func (sc *batchTestSC) GetExecutionStats() map[string]interface{} { return nil }
+
+func (sc *batchTestSC) GetName() string { return "batch_test" }
+
+func (sc *batchTestSC) GetAddress() string { return batchTestAddress }
+
+func (sc *batchTestSC) GetCostTable(chstate.StateContextI) (map[string]int, error) {
+	return map[string]int{"echo": 10}, nil
+}
+
+func TestExecuteBatch(t *testing.T) {
+	t.Parallel()
+
+	balances := &mocks.StateContextI{}
+	var callTxns []*transaction.Transaction
+	balances.On("ExecuteOnBehalf", mock.Anything, mock.Anything).Return(
+		func(txn *transaction.Transaction, execute func(chstate.StateContextI) (string, error)) (string, error) {
+			callTxns = append(callTxns, txn)
+			return execute(balances)
+		})
+
+	newBatch := func(calls ...transaction.BatchCall) *transaction.Transaction {
+		data, err := json.Marshal(calls)
+		require.NoError(t, err)
+		txn := &transaction.Transaction{
+			ClientID:        "client",
+			TransactionType: transaction.TxnTypeBatch,
+			TransactionData: string(data),
+			Fee:             10,
+		}
+		txn.Hash = "batch_hash"
+		return txn
+	}
+	call := func(funcName, input string) transaction.BatchCall {
+		return transaction.BatchCall{
+			ToClientID: batchTestAddress,
+			SmartContractData: transaction.SmartContractData{
+				FunctionName: funcName,
+				InputData:    json.RawMessage(input),
+			},
+		}
+	}
+
+	output, err := ExecuteBatch(newBatch(call("echo", `{"a":1}`), call("echo", `{"b":2}`)), balances)
+	require.NoError(t, err)
+	require.Equal(t, `["echo:{\"a\":1}","echo:{\"b\":2}"]`, output)
+	require.Len(t, callTxns, 2)
+	for i, txn := range callTxns {
+		require.Equal(t, encryption.Hash("batch_hash:"+strconv.Itoa(i)), txn.Hash)
+		require.Equal(t, transaction.TxnTypeSmartContract, txn.TransactionType)
+		require.Equal(t, batchTestAddress, txn.ToClientID)
+		require.Zero(t, txn.Fee)
+	}
+
+	_, err = ExecuteBatch(newBatch(call("echo", "{}"), call("fail", "{}")), balances)
+	require.EqualError(t, err, "batch call 1: call failed")
+
+	unknown := call("echo", "{}")
+	unknown.ToClientID = encryption.Hash("unknown")
+	_, err = ExecuteBatch(newBatch(call("echo", "{}"), unknown), balances)
+	require.EqualError(t, err, "batch call 1: invalid_smart_contract_address: Invalid Smart Contract address")
+
+	_, err = ExecuteBatch(&transaction.Transaction{TransactionData: "{}"}, balances)
+	require.Error(t, err)
+
+	cost, err := EstimateBatchCost(newBatch(call("echo", "{}"), call("echo", "{}")), balances)
+	require.NoError(t, err)
+	require.Equal(t, 20, cost)
+}
@@ -0,0 +1,94 @@
+package transaction
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"0chain.net/core/common"
+	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/currency"
+)
+
+// MaxBatchCalls is the max number of smart contract calls of a batch transaction.
+const MaxBatchCalls = 16
+
+// BatchCall is a smart contract call of a batch transaction, the transaction
+// data of a batch transaction is the ordered list of its calls.
+type BatchCall struct {
+	// ToClientID is the address of the called smart contract.
+	ToClientID string `json:"to_client_id"`
+	// Value is the part of the transaction value the call transfers.
+	Value currency.Coin `json:"value"`
+	SmartContractData
+}
+
+// GetBatchCalls returns the smart contract calls of the batch transaction.
+func (t *Transaction) GetBatchCalls() ([]BatchCall, error) {
+	var calls []BatchCall
+	if err := json.Unmarshal([]byte(t.TransactionData), &calls); err != nil {
+		return nil, fmt.Errorf("invalid batch data: %v", err)
+	}
+	return calls, nil
+}
+
+// validateBatch checks the calls of the batch transaction, their values have
+// to add up to the transaction value.
+func (t *Transaction) validateBatch() error {
+	if t.ToClientID != "" {
+		return common.InvalidRequest("batch transaction can't have a recipient")
+	}
+	calls, err := t.GetBatchCalls()
+	if err != nil {
+		return common.InvalidRequest(err.Error())
+	}
+	if len(calls) == 0 || len(calls) > MaxBatchCalls {
+		return common.InvalidRequest(fmt.Sprintf("batch transaction must have from 1 to %d calls", MaxBatchCalls))
+	}
+
+	var value currency.Coin
+	for i, call := range calls {
+		if !encryption.IsHash(call.ToClientID) {
+			return common.InvalidRequest(fmt.Sprintf("batch call %d: invalid smart contract address", i))
+		}
+		if call.FunctionName == "" {
+			return common.InvalidRequest(fmt.Sprintf("batch call %d: missing function name", i))
+		}
+		if value, err = currency.AddCoin(value, call.Value); err != nil {
+			return common.InvalidRequest(fmt.Sprintf("batch call %d: %v", i, err))
+		}
+	}
+	if value != t.Value {
+		return common.InvalidRequest("batch calls values don't add up to the transaction value")
+	}
+	return nil
+}
+
+// BatchCallHash returns the hash of the index call of the batch transaction.
+func (t *Transaction) BatchCallHash(index int) string {
+	return encryption.Hash(t.Hash + ":" + strconv.Itoa(index))
+}
+
+// BatchCallTransaction returns the transaction the index call of the batch
+// transaction is executed for. It has its own hash derived from the batch
+// one, as the smart contracts identify the entities they create by it, and
+// no fee, as the batch pays it.
+func (t *Transaction) BatchCallTransaction(index int, call BatchCall) (*Transaction, error) {
+	data, err := json.Marshal(call.SmartContractData)
+	if err != nil {
+		return nil, err
+	}
+
+	ct := t.Clone()
+	ct.Hash = t.BatchCallHash(index)
+	ct.TransactionType = TxnTypeSmartContract
+	ct.ToClientID = call.ToClientID
+	ct.Value = call.Value
+	ct.Fee = 0
+	ct.PriorityFee = 0
+	ct.MaxCost = 0
+	ct.TransactionData = string(data)
+	scData := call.SmartContractData
+	ct.SmartContractData = &scData
+	return ct, nil
+}
@@ -0,0 +1,93 @@
+package transaction
+
+import (
+	"encoding/json"
+	"testing"
+
+	"0chain.net/core/encryption"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransaction_validateBatch(t *testing.T) {
+	call := BatchCall{
+		ToClientID: encryption.Hash("sc"),
+		Value:      5,
+		SmartContractData: SmartContractData{
+			FunctionName: "lock",
+			InputData:    json.RawMessage(`{"id":"1"}`),
+		},
+	}
+	newBatch := func(calls ...BatchCall) *Transaction {
+		data, err := json.Marshal(calls)
+		require.NoError(t, err)
+		return &Transaction{
+			ClientID:        "client",
+			TransactionType: TxnTypeBatch,
+			TransactionData: string(data),
+			Value:           10,
+		}
+	}
+
+	require.NoError(t, newBatch(call, call).validateBatch())
+
+	tt := []struct {
+		name string
+		txn  *Transaction
+		err  string
+	}{
+		{name: "no calls", txn: newBatch(), err: "batch transaction must have from 1 to 16 calls"},
+		{name: "value", txn: newBatch(call), err: "batch calls values don't add up to the transaction value"},
+		{name: "recipient", txn: func() *Transaction {
+			txn := newBatch(call, call)
+			txn.ToClientID = encryption.Hash("sc")
+			return txn
+		}(), err: "batch transaction can't have a recipient"},
+		{name: "address", txn: func() *Transaction {
+			invalid := call
+			invalid.ToClientID = "sc"
+			return newBatch(call, invalid)
+		}(), err: "batch call 1: invalid smart contract address"},
+		{name: "data", txn: &Transaction{TransactionType: TxnTypeBatch, TransactionData: "{}"},
+			err: "invalid batch data: json: cannot unmarshal object into Go value of type []transaction.BatchCall"},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			require.EqualError(t, tc.txn.validateBatch(), "invalid_request: Invalid request ("+tc.err+")")
+		})
+	}
+}
+
+func TestTransaction_BatchCallTransaction(t *testing.T) {
+	batch := &Transaction{
+		ClientID:        "client",
+		TransactionType: TxnTypeBatch,
+		Value:           5,
+		Fee:             10,
+		PriorityFee:     1,
+		MaxCost:         100,
+	}
+	batch.Hash = "hash"
+	call := BatchCall{
+		ToClientID: encryption.Hash("sc"),
+		Value:      5,
+		SmartContractData: SmartContractData{
+			FunctionName: "lock",
+			InputData:    json.RawMessage(`{"id":"1"}`),
+		},
+	}
+
+	txn, err := batch.BatchCallTransaction(1, call)
+	require.NoError(t, err)
+	require.Equal(t, encryption.Hash("hash:1"), txn.Hash)
+	require.NotEqual(t, batch.BatchCallHash(0), txn.Hash)
+	require.Equal(t, "hash", batch.Hash)
+	require.Equal(t, "client", txn.ClientID)
+	require.Equal(t, TxnTypeSmartContract, txn.TransactionType)
+	require.Equal(t, call.ToClientID, txn.ToClientID)
+	require.Equal(t, call.Value, txn.Value)
+	require.Equal(t, `{"name":"lock","input":{"id":"1"}}`, txn.TransactionData)
+	require.Equal(t, call.SmartContractData, *txn.SmartContractData)
+	require.Zero(t, txn.Fee)
+	require.Zero(t, txn.PriorityFee)
+	require.Zero(t, txn.MaxCost)
+}
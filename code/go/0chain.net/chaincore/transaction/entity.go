@@ -33,6 +33,10 @@ var TXN_TIME_TOLERANCE int64
 
 var transactionCount uint64 = 0
 
+// baseFeeEnabled is true if the base fee market is enabled at the latest
+// finalized block, the generators earn only the priority fees then.
+var baseFeeEnabled atomic.Bool
+
 // ErrTxnMissingPublicKey is returned if the transaction does not have ClientID and public key
 var (
 	ErrTxnMissingPublicKey = errors.New("transaction missing public key")
@@ -67,17 +71,31 @@ type Transaction struct {
 	CreationDate    common.Timestamp `json:"creation_date" msgpack:"ts"`
 	Fee             currency.Coin    `json:"transaction_fee" msgpack:"f"`
 	Nonce           int64            `json:"transaction_nonce" msgpack:"n"`
+	// MaxCost is the upper bound of the metered execution cost the client
+	// pays for, zero means the cost is taken from the smart contract cost table.
+	MaxCost int `json:"max_cost,omitempty" msgpack:"mc,omitempty"`
+	// PriorityFee is the tip to the block generator and sharders on top of
+	// the base fee, the Fee is the max fee paid when the base fee is enabled.
+	PriorityFee currency.Coin `json:"priority_fee,omitempty" msgpack:"pf,omitempty"`
 
 	TransactionType   int    `json:"transaction_type" msgpack:"tt"`
 	TransactionOutput string `json:"transaction_output,omitempty" msgpack:"o,omitempty"`
 	OutputHash        string `json:"txn_output_hash" msgpack:"oh"`
 	Status            int    `json:"transaction_status" msgpack:"sot"`
+	// Cost is the metered execution cost of the transaction.
+	Cost int `json:"transaction_cost,omitempty" msgpack:"co,omitempty"`
+	// ChargedFee is the fee charged on execution and paid to the block
+	// generator and sharders, up to the Fee of the transaction.
+	ChargedFee currency.Coin `json:"charged_fee,omitempty" msgpack:"cf,omitempty"`
 }
 
 type FeeStats struct {
 	MaxFees  currency.Coin `json:"max_fees"`
 	MeanFees currency.Coin `json:"mean_fees"`
 	MinFees  currency.Coin `json:"min_fees"`
+	// BaseFee is the base fee of a unit of transaction cost of the latest
+	// finalized block, zero when the base fee market is disabled.
+	BaseFee currency.Coin `json:"base_fee"`
 }
 
 var transactionEntityMetadata *datastore.EntityMetadataImpl
@@ -183,6 +201,24 @@ func (t *Transaction) ValidateWrtTimeForBlock(ctx context.Context, ts common.Tim
 	if t.ClientID == t.ToClientID {
 		return common.InvalidRequest("from and to client should be different")
 	}
+	if t.MaxCost < 0 {
+		return common.InvalidRequest("negative max cost")
+	}
+	if t.PriorityFee > t.Fee {
+		return common.InvalidRequest("priority fee is greater than the max fee")
+	}
+	if t.IsMetered() && !t.IsSmartContract() {
+		return common.InvalidRequest("only smart contract transactions can be metered")
+	}
+	if t.TransactionType == TxnTypeBatch {
+		if err := t.validateBatch(); err != nil {
+			return err
+		}
+	}
+	if t.TransactionType == TxnTypeCancel &&
+		(t.Value != 0 || t.ToClientID != "" || t.TransactionData != "") {
+		return common.InvalidRequest("cancel transaction can't have a value, a recipient or data")
+	}
 	err = t.VerifyHash(ctx)
 	if err != nil {
 		return err
@@ -211,6 +247,10 @@ func (t *Transaction) Validate(ctx context.Context) error {
 
 func (t *Transaction) GetScore() (int64, error) {
 	if config.Configuration().ChainConfig.IsFeeEnabled() {
+		// with the base fee the generator earns only the priority fee
+		if baseFeeEnabled.Load() {
+			return t.PriorityFee.Int64()
+		}
 		return t.Fee.Int64()
 	}
 	return 0, nil
@@ -271,9 +311,29 @@ func (t *Transaction) HashData() string {
 	s.WriteString(strconv.FormatUint(uint64(t.Value), 10))
 	s.WriteString(":")
 	s.WriteString(encryption.Hash(t.TransactionData))
+	if t.IsMetered() || t.PriorityFee > 0 {
+		s.WriteString(":")
+		s.WriteString(strconv.Itoa(t.MaxCost))
+	}
+	if t.PriorityFee > 0 {
+		s.WriteString(":")
+		s.WriteString(strconv.FormatUint(uint64(t.PriorityFee), 10))
+	}
 	return s.String()
 }
 
+// IsMetered returns true if the transaction is charged by its metered
+// execution cost instead of the cost table.
+func (t *Transaction) IsMetered() bool {
+	return t.MaxCost > 0
+}
+
+// IsSmartContract returns true for the transactions executing smart
+// contracts, the smart contract and the batch transactions.
+func (t *Transaction) IsSmartContract() bool {
+	return t.TransactionType == TxnTypeSmartContract || t.TransactionType == TxnTypeBatch
+}
+
 /*ComputeHash - compute the hash from the various components of the transaction */
 func (t *Transaction) ComputeHash() string {
 	return encryption.Hash(t.HashData())
@@ -419,6 +479,9 @@ func (t *Transaction) DebugTxn() bool {
 
 /*ComputeOutputHash - compute the hash from the transaction output */
 func (t *Transaction) ComputeOutputHash() string {
+	if t.IsMetered() {
+		return encryption.Hash(t.TransactionOutput + ":" + strconv.Itoa(t.Cost))
+	}
 	if t.TransactionOutput == "" {
 		return encryption.EmptyHash
 	}
@@ -449,10 +512,14 @@ func (t *Transaction) Clone() *Transaction {
 		CreationDate:      t.CreationDate,
 		Fee:               t.Fee,
 		Nonce:             t.Nonce,
+		MaxCost:           t.MaxCost,
+		PriorityFee:       t.PriorityFee,
 		TransactionType:   t.TransactionType,
 		TransactionOutput: t.TransactionOutput,
 		OutputHash:        t.OutputHash,
 		Status:            t.Status,
+		Cost:              t.Cost,
+		ChargedFee:        t.ChargedFee,
 	}
 
 	if t.SmartContractData != nil {
@@ -475,6 +542,12 @@ func SetTxnTimeout(timeout int64) {
 	TXN_TIME_TOLERANCE = timeout
 }
 
+// SetBaseFeeEnabled sets whether the base fee market is enabled, the
+// transactions are scored by their priority fee if it is.
+func SetBaseFeeEnabled(enabled bool) {
+	baseFeeEnabled.Store(enabled)
+}
+
 func GetTransactionCount() uint64 {
 	return atomic.LoadUint64(&transactionCount)
 }
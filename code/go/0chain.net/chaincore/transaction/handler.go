@@ -43,18 +43,7 @@ func PutTransaction(ctx context.Context, entity datastore.Entity) (interface{},
 	if err != nil || cli == nil || cli.PublicKey == "" {
 		return nil, common.NewError("put transaction error", fmt.Sprintf("client %v doesn't exist, please register", txn.ClientID))
 	}
-	if datastore.DoAsync(ctx, txn) {
-		IncTransactionCount()
-		return txn, nil
-	}
-	err = entity.GetEntityMetadata().GetStore().Write(ctx, txn)
-	if err != nil {
-		logging.Logger.Error("put transaction", zap.Error(err), zap.String("txn", txn.Hash), zap.String("txn_obj", datastore.ToJSON(txn).String()))
-		return nil, err
-	}
-
-	IncTransactionCount()
-	return txn, nil
+	return putToPool(ctx, txn)
 }
 
 func PutTransactionWithoutVerifySig(ctx context.Context, entity datastore.Entity) (interface{}, error) {
@@ -76,17 +65,46 @@ func PutTransactionWithoutVerifySig(ctx context.Context, entity datastore.Entity
 	if err != nil || cli == nil || cli.PublicKey == "" {
 		return nil, common.NewError("put transaction error", fmt.Sprintf("client %v doesn't exist, please register", txn.ClientID))
 	}
+	return putToPool(ctx, txn)
+}
 
-	if datastore.DoAsync(ctx, txn) {
-		IncTransactionCount()
-		return txn, nil
-	}
-	err = entity.GetEntityMetadata().GetStore().Write(ctx, txn)
+// PutTransactionResponse is the response of a transaction put in the pool in
+// place of a pooled transaction of the same nonce.
+type PutTransactionResponse struct {
+	*Transaction
+	ReplacedHash string `json:"replaced_hash"`
+}
+
+// putToPool puts the transaction in the pool, in place of the pooled
+// transaction of the same client nonce if any.
+func putToPool(ctx context.Context, txn *Transaction) (interface{}, error) {
+	// the pooled transaction of the nonce is looked up, evicted and indexed
+	// again without other puts of the client in between
+	lock := poolNonceLock(txn.ClientID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	replacedHash, err := ReplaceInPool(ctx, txn)
 	if err != nil {
-		logging.Logger.Error("put transaction", zap.Error(err), zap.String("txn", txn.Hash), zap.String("txn_obj", datastore.ToJSON(txn).String()))
+		logging.Logger.Error("put transaction - replace", zap.Error(err), zap.String("txn", txn.Hash))
 		return nil, err
 	}
 
+	if !datastore.DoAsync(ctx, txn) {
+		err = txn.GetEntityMetadata().GetStore().Write(ctx, txn)
+		if err != nil {
+			logging.Logger.Error("put transaction", zap.Error(err), zap.String("txn", txn.Hash), zap.String("txn_obj", datastore.ToJSON(txn).String()))
+			return nil, err
+		}
+	}
 	IncTransactionCount()
+
+	if err := indexPoolNonce(ctx, txn); err != nil {
+		logging.Logger.Error("put transaction - index nonce", zap.Error(err), zap.String("txn", txn.Hash))
+	}
+
+	if replacedHash != "" {
+		return &PutTransactionResponse{Transaction: txn, ReplacedHash: replacedHash}, nil
+	}
 	return txn, nil
 }
@@ -8,6 +8,7 @@ import (
 
 	"0chain.net/chaincore/client"
 	"0chain.net/chaincore/config"
+	"0chain.net/chaincore/config/mocks"
 	"0chain.net/chaincore/node"
 	"0chain.net/core/common"
 	"0chain.net/core/datastore"
@@ -195,3 +196,65 @@ func processWorker(txnChannel <-chan *Transaction, done chan<- bool) {
 		done <- true
 	}
 }
+
+func TestTransaction_meteredHashes(t *testing.T) {
+	txn := &Transaction{
+		ClientID:          "client",
+		ToClientID:        "to_client",
+		TransactionData:   "data",
+		TransactionOutput: "output",
+		Nonce:             1,
+	}
+	hash, outputHash := txn.ComputeHash(), txn.ComputeOutputHash()
+	require.Equal(t, encryption.Hash("output"), outputHash)
+
+	// the cost of not metered transactions never changes the hashes
+	txn.Cost = 10
+	require.Equal(t, hash, txn.ComputeHash())
+	require.Equal(t, outputHash, txn.ComputeOutputHash())
+
+	txn.MaxCost = 100
+	require.NotEqual(t, hash, txn.ComputeHash())
+	require.Equal(t, encryption.Hash("output:10"), txn.ComputeOutputHash())
+}
+
+func TestTransaction_priorityFeeHash(t *testing.T) {
+	txn := &Transaction{
+		ClientID:        "client",
+		ToClientID:      "to_client",
+		TransactionData: "data",
+		Nonce:           1,
+		Fee:             10,
+	}
+	hash := txn.ComputeHash()
+
+	txn.PriorityFee = 5
+	priorityHash := txn.ComputeHash()
+	require.NotEqual(t, hash, priorityHash)
+
+	txn.PriorityFee = 6
+	require.NotEqual(t, priorityHash, txn.ComputeHash())
+}
+
+func TestTransaction_GetScore(t *testing.T) {
+	chainConfig := &mocks.ChainConfig{}
+	chainConfig.On("IsFeeEnabled").Return(true)
+	config.Configuration().ChainConfig = chainConfig
+	t.Cleanup(func() { SetBaseFeeEnabled(false) })
+
+	txn := &Transaction{Fee: 10}
+	score, err := txn.GetScore()
+	require.NoError(t, err)
+	require.EqualValues(t, 10, score)
+
+	// the max fee doesn't tip the generator with the base fee market
+	SetBaseFeeEnabled(true)
+	score, err = txn.GetScore()
+	require.NoError(t, err)
+	require.Zero(t, score)
+
+	txn.PriorityFee = 3
+	score, err = txn.GetScore()
+	require.NoError(t, err)
+	require.EqualValues(t, 3, score)
+}
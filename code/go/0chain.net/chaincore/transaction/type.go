@@ -13,7 +13,11 @@ const (
 
 	TxnTypeData = 10 // A transaction to just store a piece of data on the block chain
 
+	TxnTypeCancel = 12 // A transaction that only uses its nonce, to cancel the pooled transaction of the same nonce
+
 	TxnTypeSmartContract = 1000 // A smart contract transaction type
+
+	TxnTypeBatch = 1002 // A transaction with a list of smart contract calls executed atomically
 )
 
 var ErrSmartContractContext = common.NewError("smart_contract_execution_ctx_err", "context deadline")
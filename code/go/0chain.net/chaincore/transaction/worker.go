@@ -2,15 +2,40 @@ package transaction
 
 import (
 	"context"
+	"fmt"
+	"hash/fnv"
+	"sync"
 	"time"
 
 	"0chain.net/core/common"
 	"0chain.net/core/datastore"
 	"0chain.net/core/memorystore"
+	"github.com/0chain/common/core/currency"
 	"github.com/0chain/common/core/logging"
+	"github.com/gomodule/redigo/redis"
 	"go.uber.org/zap"
 )
 
+// ReplacementFeeBumpPercent is how much higher, in percent, the fee of a
+// transaction has to be to replace the pooled transaction of its nonce.
+const ReplacementFeeBumpPercent = 10
+
+// ErrReplacementUnderpriced is returned for a transaction with the nonce of a
+// pooled transaction whose fee is not high enough to replace it.
+var ErrReplacementUnderpriced = common.NewError("replacement_underpriced",
+	fmt.Sprintf("the fee, and the priority fee with the base fee enabled, must be at least %d%% "+
+		"higher to replace the pooled transaction", ReplacementFeeBumpPercent))
+
+// poolNonceLocks serialize the puts of the transactions of a client, so that
+// a pooled transaction is replaced by one transaction at most.
+var poolNonceLocks [256]sync.Mutex
+
+func poolNonceLock(clientID string) *sync.Mutex {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(clientID))
+	return &poolNonceLocks[h.Sum32()%uint32(len(poolNonceLocks))]
+}
+
 // SetupWorkers - setup workers */
 func SetupWorkers(ctx context.Context) {
 	go CleanupWorker(ctx)
@@ -131,3 +156,108 @@ func RemoveFromPool(ctx context.Context, txns []datastore.Entity) {
 		logging.Logger.Error("Error in MultiDeleteFromCollection", zap.Error(err))
 	}
 }
+
+// poolNonceKey is the redis key of the hash of the pooled transaction of the
+// client nonce.
+func poolNonceKey(txn *Transaction) string {
+	return fmt.Sprintf("txnnonce:%s:%s:%d", txn.ChainID, txn.ClientID, txn.Nonce)
+}
+
+// minReplacementFee is the fee a transaction needs to replace one paying the
+// given fee.
+func minReplacementFee(pooledFee currency.Coin) (currency.Coin, error) {
+	fee, err := currency.MultFloat64(pooledFee, 1+ReplacementFeeBumpPercent/100.0)
+	if err != nil {
+		return 0, err
+	}
+	if fee <= pooledFee {
+		return pooledFee + 1, nil
+	}
+	return fee, nil
+}
+
+// isReplacementUnderpriced returns true if the transaction doesn't pay enough
+// to replace the pooled one. With the base fee enabled the generators earn
+// the priority fee only, so it has to be bumped as well.
+func isReplacementUnderpriced(txn, pooled *Transaction) (bool, error) {
+	minFee, err := minReplacementFee(pooled.Fee)
+	if err != nil {
+		return false, err
+	}
+	if txn.Fee < minFee {
+		return true, nil
+	}
+	if !baseFeeEnabled.Load() {
+		return false, nil
+	}
+
+	minPriorityFee, err := minReplacementFee(pooled.PriorityFee)
+	if err != nil {
+		return false, err
+	}
+	return txn.PriorityFee < minPriorityFee, nil
+}
+
+// ReplaceInPool evicts the pooled transaction the given one replaces, the one
+// of the same client and nonce, and returns its hash. The hash is empty if
+// there is no such transaction. The replacement has to pay a fee at least
+// ReplacementFeeBumpPercent higher. The caller has to hold the
+// poolNonceLock of the client until the replacement is pooled.
+func ReplaceInPool(ctx context.Context, txn *Transaction) (string, error) {
+	store := transactionEntityMetadata.GetStore()
+	if _, ok := store.(*memorystore.Store); !ok {
+		return "", nil
+	}
+
+	c := memorystore.GetEntityCon(ctx, transactionEntityMetadata)
+	hash, err := redis.String(c.Do("GET", poolNonceKey(txn)))
+	if err == redis.ErrNil || hash == txn.Hash {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	pooled := transactionEntityMetadata.Instance().(*Transaction)
+	err = store.Read(ctx, hash, pooled)
+	if cerr, ok := err.(*common.Error); ok && cerr.Code == datastore.EntityNotFound {
+		// already removed from the pool
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if pooled.ClientID != txn.ClientID || pooled.Nonce != txn.Nonce {
+		return "", nil
+	}
+
+	underpriced, err := isReplacementUnderpriced(txn, pooled)
+	if err != nil {
+		return "", err
+	}
+	if underpriced {
+		return "", ErrReplacementUnderpriced
+	}
+
+	if err := store.Delete(ctx, pooled); err != nil {
+		return "", err
+	}
+	logging.Logger.Info("replaced pooled transaction",
+		zap.String("txn", txn.Hash),
+		zap.String("replaced", hash),
+		zap.String("client", txn.ClientID),
+		zap.Int64("nonce", txn.Nonce))
+	return hash, nil
+}
+
+// indexPoolNonce saves the hash of the pooled transaction of the client
+// nonce, for as long as the transaction can be valid.
+func indexPoolNonce(ctx context.Context, txn *Transaction) error {
+	if _, ok := transactionEntityMetadata.GetStore().(*memorystore.Store); !ok {
+		return nil
+	}
+
+	c := memorystore.GetEntityCon(ctx, transactionEntityMetadata)
+	_, err := c.Do("SET", poolNonceKey(txn), txn.Hash, "EX", 2*TXN_TIME_TOLERANCE+1)
+	return err
+}
@@ -0,0 +1,198 @@
+package transaction
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+
+	"0chain.net/chaincore/config"
+	"0chain.net/chaincore/config/mocks"
+	"0chain.net/chaincore/node"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/core/encryption"
+	"0chain.net/core/memorystore"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/logging"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTxnPool(t *testing.T) context.Context {
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	t.Cleanup(mr.Close)
+
+	memorystore.DefaultPool = &redis.Pool{
+		MaxIdle: 10,
+		Dial: func() (redis.Conn, error) {
+			return redis.Dial("tcp", mr.Addr())
+		},
+	}
+	logging.InitLogging("testing", "")
+	common.SetupRootContext(node.GetNodeContext())
+	SetupEntity(memorystore.GetStorageProvider())
+	memorystore.AddPool("txndb", memorystore.DefaultPool)
+	TXN_TIME_TOLERANCE = 30
+
+	chainConfig := &mocks.ChainConfig{}
+	chainConfig.On("IsFeeEnabled").Return(true)
+	config.Configuration().ChainConfig = chainConfig
+
+	ctx := memorystore.WithEntityConnection(context.Background(), transactionEntityMetadata)
+	t.Cleanup(func() { memorystore.Close(ctx) })
+	return ctx
+}
+
+func newPoolTxn(t *testing.T, fee currency.Coin) *Transaction {
+	txn := transactionEntityMetadata.Instance().(*Transaction)
+	txn.PublicKey = encryption.Hash("public_key")
+	require.NoError(t, txn.ComputeClientID())
+	txn.ToClientID = "to_client"
+	txn.Nonce = 1
+	txn.CreationDate = common.Now()
+	txn.Fee = fee
+	txn.TransactionData = fmt.Sprintf("fee %v", fee)
+	txn.Hash = txn.ComputeHash()
+	return txn
+}
+
+func TestReplaceInPool(t *testing.T) {
+	ctx := setupTxnPool(t)
+
+	pooled := newPoolTxn(t, 100)
+	_, err := putToPool(ctx, pooled)
+	require.NoError(t, err)
+
+	// the same transaction again replaces nothing
+	replaced, err := ReplaceInPool(ctx, pooled)
+	require.NoError(t, err)
+	require.Empty(t, replaced)
+
+	underpriced := newPoolTxn(t, 109)
+	_, err = putToPool(ctx, underpriced)
+	require.Equal(t, ErrReplacementUnderpriced, err)
+
+	replacement := newPoolTxn(t, 110)
+	resp, err := putToPool(ctx, replacement)
+	require.NoError(t, err)
+	require.Equal(t, &PutTransactionResponse{
+		Transaction:  replacement,
+		ReplacedHash: pooled.Hash,
+	}, resp)
+
+	data, err := json.Marshal(resp)
+	require.NoError(t, err)
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &fields))
+	require.Equal(t, pooled.Hash, fields["replaced_hash"])
+	require.Equal(t, replacement.Hash, fields["hash"])
+
+	// the replaced transaction is evicted from the pool
+	err = transactionEntityMetadata.GetStore().Read(ctx, pooled.Hash,
+		transactionEntityMetadata.Instance())
+	cerr, ok := err.(*common.Error)
+	require.True(t, ok)
+	require.Equal(t, datastore.EntityNotFound, cerr.Code)
+
+	// a transaction of another nonce is put next to the pooled one
+	next := newPoolTxn(t, 1)
+	next.Nonce = 2
+	next.Hash = next.ComputeHash()
+	resp, err = putToPool(ctx, next)
+	require.NoError(t, err)
+	require.Equal(t, next, resp)
+}
+
+func TestReplaceInPool_priorityFee(t *testing.T) {
+	ctx := setupTxnPool(t)
+	SetBaseFeeEnabled(true)
+	t.Cleanup(func() { SetBaseFeeEnabled(false) })
+
+	newTxn := func(fee, priorityFee currency.Coin) *Transaction {
+		txn := newPoolTxn(t, fee)
+		txn.PriorityFee = priorityFee
+		txn.TransactionData = fmt.Sprintf("fee %v, priority fee %v", fee, priorityFee)
+		txn.Hash = txn.ComputeHash()
+		return txn
+	}
+
+	pooled := newTxn(100, 10)
+	_, err := putToPool(ctx, pooled)
+	require.NoError(t, err)
+
+	// a higher fee doesn't pay the generator more without a higher tip
+	_, err = putToPool(ctx, newTxn(200, 10))
+	require.Equal(t, ErrReplacementUnderpriced, err)
+	_, err = putToPool(ctx, newTxn(109, 20))
+	require.Equal(t, ErrReplacementUnderpriced, err)
+
+	replacement := newTxn(110, 11)
+	resp, err := putToPool(ctx, replacement)
+	require.NoError(t, err)
+	require.Equal(t, &PutTransactionResponse{
+		Transaction:  replacement,
+		ReplacedHash: pooled.Hash,
+	}, resp)
+}
+
+func TestReplaceInPool_concurrent(t *testing.T) {
+	ctx := setupTxnPool(t)
+
+	pooled := newPoolTxn(t, 100)
+	_, err := putToPool(ctx, pooled)
+	require.NoError(t, err)
+
+	txns := []*Transaction{pooled}
+	for fee := currency.Coin(200); fee < 210; fee++ {
+		txns = append(txns, newPoolTxn(t, fee))
+	}
+
+	var wg sync.WaitGroup
+	for _, txn := range txns[1:] {
+		wg.Add(1)
+		go func(txn *Transaction) {
+			defer wg.Done()
+			cctx := memorystore.WithEntityConnection(context.Background(), transactionEntityMetadata)
+			defer memorystore.Close(cctx)
+			if _, err := putToPool(cctx, txn); err != nil && err != ErrReplacementUnderpriced {
+				t.Error(err)
+			}
+		}(txn)
+	}
+	wg.Wait()
+
+	// a single transaction of the nonce is left in the pool, the indexed one
+	var pooledHashes []string
+	for _, txn := range txns {
+		err := transactionEntityMetadata.GetStore().Read(ctx, txn.Hash,
+			transactionEntityMetadata.Instance())
+		if err == nil {
+			pooledHashes = append(pooledHashes, txn.Hash)
+		}
+	}
+	require.Len(t, pooledHashes, 1)
+	hash, err := redis.String(memorystore.GetEntityCon(ctx, transactionEntityMetadata).
+		Do("GET", poolNonceKey(pooled)))
+	require.NoError(t, err)
+	require.Equal(t, pooledHashes[0], hash)
+}
+
+func TestTransaction_validateCancel(t *testing.T) {
+	txn := &Transaction{
+		ClientID:        "client",
+		ChainID:         config.GetServerChainID(),
+		TransactionType: TxnTypeCancel,
+		CreationDate:    common.Now(),
+	}
+	txn.Hash = txn.ComputeHash()
+	require.NoError(t, txn.ValidateWrtTimeForBlock(context.Background(), txn.CreationDate, false))
+
+	txn.ToClientID = encryption.Hash("to_client")
+	txn.Hash = txn.ComputeHash()
+	require.EqualError(t, txn.ValidateWrtTimeForBlock(context.Background(), txn.CreationDate, false),
+		"invalid_request: Invalid request (cancel transaction can't have a value, a recipient or data)")
+}
@@ -52,6 +52,7 @@ func main() {
 	delayFile := flag.String("delay_file", "", "delay_file")
 	magicBlockFile := flag.String("magic_block_file", "", "magic_block_file")
 	initialStatesFile := flag.String("initial_states", "", "initial_states")
+	snapshotImport := flag.String("state_snapshot_import", "", "start from the state snapshot file block and its client state")
 
 	flag.StringVar(&workdir, "work_dir", "", "work_dir")
 	flag.StringVar(&redisHost, "redis_host", "", "default redis pool host")
@@ -151,6 +152,14 @@ func main() {
 	gb := mc.SetupGenesisBlock(viper.GetString("server_chain.genesis_block.id"),
 		magicBlock, initStates)
 
+	// the node starts on the imported snapshot block, unless the sharders
+	// have finalized a later one by the time the protocol starts
+	if *snapshotImport != "" {
+		if err := importStateSnapshot(ctx, mc, *snapshotImport); err != nil {
+			logging.Logger.Panic("import state snapshot", zap.Error(err))
+		}
+	}
+
 	mb := mc.GetLatestMagicBlock()
 	logging.Logger.Info("Miners in main", zap.Int("size", mb.Miners.Size()))
 
@@ -417,6 +426,32 @@ func readNonGenesisHostAndPort(keysFile *string) (string, string, int, string, s
 
 }
 
+func importStateSnapshot(ctx context.Context, mc *miner.Chain, file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	ss, err := chain.ImportStateSnapshot(ctx, f, mc.GetStateDB())
+	if err != nil {
+		return err
+	}
+
+	mc.SetLatestFinalizedMagicBlock(ss.LFMB)
+	if err := mc.InitBlockState(ss.Block); err != nil {
+		return err
+	}
+	mc.SetLatestFinalizedBlock(ctx, ss.Block)
+
+	logging.Logger.Info("state snapshot imported",
+		zap.Int64("round", ss.Block.Round),
+		zap.String("block", ss.Block.Hash),
+		zap.Int64("magic block round", ss.LFMB.Round),
+		zap.Uint64("nodes", ss.Nodes))
+	return nil
+}
+
 func initEntities(workdir string, redisHost string, redisPort int, redisTxnsHost string, redisTxnsPort int) {
 	if len(redisHost) > 0 && redisPort > 0 {
 		memorystore.InitDefaultPool(redisHost, redisPort)
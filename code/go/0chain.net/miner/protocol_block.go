@@ -147,6 +147,21 @@ func (mc *Chain) createGenerateChallengeTxn(b *block.Block) (*transaction.Transa
 	return brTxn, nil
 }
 
+func (mc *Chain) createRenewAllocationsTxn(b *block.Block) (*transaction.Transaction, error) {
+	raTxn := transaction.Provider().(*transaction.Transaction)
+	raTxn.ClientID = node.Self.ID
+	raTxn.PublicKey = node.Self.PublicKey
+	raTxn.ToClientID = storagesc.ADDRESS
+	raTxn.CreationDate = b.CreationDate
+	raTxn.TransactionType = transaction.TxnTypeSmartContract
+	raTxn.TransactionData = fmt.Sprintf(`{"name":"renew_allocations","input":{"round":%d}}`, b.Round)
+	raTxn.Fee = 0
+	if err := raTxn.ComputeProperties(); err != nil {
+		return nil, err
+	}
+	return raTxn, nil
+}
+
 func (mc *Chain) validateTransaction(b *block.Block,
 	bState util.MerklePatriciaTrieI, txn *transaction.Transaction, waitC chan struct{}) error {
 	if !common.WithinTime(int64(b.CreationDate), int64(txn.CreationDate), transaction.TXN_TIME_TOLERANCE) {
@@ -199,7 +214,7 @@ func (mc *Chain) UpdatePendingBlock(ctx context.Context, b *block.Block, txns []
 
 func (mc *Chain) verifySmartContracts(ctx context.Context, b *block.Block) error {
 	for _, txn := range b.Txns {
-		if txn.TransactionType == transaction.TxnTypeSmartContract {
+		if txn.IsSmartContract() {
 			err := txn.VerifyOutputHash(ctx)
 			if err != nil {
 				logging.Logger.Error("Smart contract output verification failed", zap.Error(err), zap.String("output", txn.TransactionOutput))
@@ -1268,6 +1283,15 @@ func (mc *Chain) buildInTxns(ctx context.Context, lfb, b *block.Block, state uti
 		txns = append(txns, brTxn)
 	}
 
+	if rp := config.SmartContractConfig.GetInt64("smart_contracts.storagesc.auto_renewal.trigger_period"); rp > 0 &&
+		b.Round%rp == 0 {
+		raTxn, err := mc.createRenewAllocationsTxn(b)
+		if err != nil {
+			return nil, 0, err
+		}
+		txns = append(txns, raTxn)
+	}
+
 	if mc.SmartContractSettingUpdatePeriod() != 0 &&
 		b.Round%mc.SmartContractSettingUpdatePeriod() == 0 {
 		cscTxn, err := mc.storageScCommitSettingChangesTx(b)
@@ -1893,6 +1893,11 @@ func StartProtocol(ctx context.Context, gb *block.Block) {
 		lfb = getLatestBlockFromSharders(ctx)
 		mr  *Round
 	)
+	// a node booted from a state snapshot already has a LFB beyond genesis
+	if slfb := mc.GetLatestFinalizedBlock(); slfb != nil && slfb.Round > gb.Round &&
+		(lfb == nil || lfb.Round < slfb.Round) {
+		lfb = slfb
+	}
 	if lfb != nil {
 		mr = mc.startProtocolOnLFB(ctx, lfb)
 	} else {
@@ -0,0 +1,291 @@
+package sharder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/chaincore/round"
+	"0chain.net/core/viper"
+	"0chain.net/sharder/blockstore"
+	. "github.com/0chain/common/core/logging"
+	"go.uber.org/zap"
+)
+
+// maxScrubRecords is the number of the latest corrupt blocks kept to be
+// shown on the diagnostics page.
+const maxScrubRecords = 100
+
+// BlockScrubConfig - configuration of the block store scrubber
+type BlockScrubConfig struct {
+	Enabled        bool
+	BatchSize      int64
+	BatchPause     time.Duration
+	Settle         time.Duration
+	RepeatInterval time.Duration
+}
+
+func getBlockScrubConfig() BlockScrubConfig {
+	return BlockScrubConfig{
+		Enabled:        viper.GetBool("server_chain.health_check.block_scrub.enabled"),
+		BatchSize:      viper.GetInt64("server_chain.health_check.block_scrub.batch_size"),
+		BatchPause:     viper.GetDuration("server_chain.health_check.block_scrub.batch_pause"),
+		Settle:         viper.GetDuration("server_chain.health_check.block_scrub.settle_secs"),
+		RepeatInterval: viper.GetDuration("server_chain.health_check.block_scrub.repeat_interval_mins"),
+	}
+}
+
+// ScrubRecord - a corrupt or missing block found by the scrubber
+type ScrubRecord struct {
+	Round    int64     `json:"round"`
+	Hash     string    `json:"hash"`
+	Reason   string    `json:"reason"`
+	Repaired bool      `json:"repaired"`
+	Time     time.Time `json:"time"`
+}
+
+// BlockScrubStats - progress and results of the block store scrubber
+//
+// swagger:model BlockScrubStats
+type BlockScrubStats struct {
+	Status       HealthCheckStatus `json:"status"`
+	Cycle        int64             `json:"cycle"`
+	CycleStart   time.Time         `json:"cycle_start"`
+	CycleEnd     time.Time         `json:"cycle_end"`
+	LowRound     int64             `json:"low_round"`
+	HighRound    int64             `json:"high_round"`
+	CurrentRound int64             `json:"current_round"`
+
+	// counters of the current cycle
+	Scanned      int64 `json:"scanned"`
+	Skipped      int64 `json:"skipped"`
+	Missing      int64 `json:"missing"`
+	Corrupt      int64 `json:"corrupt"`
+	Repaired     int64 `json:"repaired"`
+	RepairFailed int64 `json:"repair_failed"`
+
+	// Records are the latest corrupt or missing blocks, newest last.
+	Records []ScrubRecord `json:"records"`
+}
+
+// BlockScrubProgress - concurrency safe block store scrubber statistics
+type BlockScrubProgress struct {
+	mu    sync.RWMutex
+	stats BlockScrubStats
+}
+
+func (p *BlockScrubProgress) startCycle(low, high int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.stats = BlockScrubStats{
+		Status:       SyncProgress,
+		Cycle:        p.stats.Cycle + 1,
+		CycleStart:   time.Now().Truncate(time.Second),
+		LowRound:     low,
+		HighRound:    high,
+		CurrentRound: low,
+		Records:      p.stats.Records,
+	}
+}
+
+func (p *BlockScrubProgress) endCycle() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.stats.Status = SyncHiatus
+	p.stats.CycleEnd = time.Now().Truncate(time.Second)
+}
+
+func (p *BlockScrubProgress) scanned(roundNum int64, skipped bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.stats.CurrentRound = roundNum
+	if skipped {
+		p.stats.Skipped++
+		return
+	}
+	p.stats.Scanned++
+}
+
+func (p *BlockScrubProgress) record(rec ScrubRecord, missing bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if missing {
+		p.stats.Missing++
+	} else {
+		p.stats.Corrupt++
+	}
+	if rec.Repaired {
+		p.stats.Repaired++
+	} else {
+		p.stats.RepairFailed++
+	}
+	p.stats.Records = append(p.stats.Records, rec)
+	if len(p.stats.Records) > maxScrubRecords {
+		p.stats.Records = p.stats.Records[len(p.stats.Records)-maxScrubRecords:]
+	}
+}
+
+// Snapshot returns a copy of the statistics.
+func (p *BlockScrubProgress) Snapshot() BlockScrubStats {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	stats := p.stats
+	stats.Records = append([]ScrubRecord(nil), p.stats.Records...)
+	return stats
+}
+
+// verifyStoredBlock checks the block read from the block store is the block
+// of the given hash, its hash matches its content and the merkle roots of
+// its transactions match the stored block summary, if any.
+func verifyStoredBlock(b *block.Block, hash string, bs *block.BlockSummary) error {
+	if b.Hash != hash {
+		return fmt.Errorf("block hash %s doesn't match the round block hash", b.Hash)
+	}
+	for _, txn := range b.Txns {
+		if txn.Hash != txn.ComputeHash() {
+			return fmt.Errorf("transaction %s hash mismatch", txn.Hash)
+		}
+	}
+	if computed := b.ComputeHash(); computed != b.Hash {
+		return fmt.Errorf("computed block hash %s mismatch", computed)
+	}
+	if bs == nil {
+		return nil
+	}
+	if root := b.GetMerkleTree().GetRoot(); root != bs.MerkleTreeRoot {
+		return fmt.Errorf("transactions merkle root %s doesn't match the block summary %s",
+			root, bs.MerkleTreeRoot)
+	}
+	if root := b.GetReceiptsMerkleTree().GetRoot(); root != bs.ReceiptMerkleTreeRoot {
+		return fmt.Errorf("receipts merkle root %s doesn't match the block summary %s",
+			root, bs.ReceiptMerkleTreeRoot)
+	}
+	return nil
+}
+
+// BlockScrubWorker walks the blocks stored up to the latest finalized
+// block, re-verifies them and re-fetches the corrupt or missing ones
+// from other sharders.
+func (sc *Chain) BlockScrubWorker(ctx context.Context) {
+	config := getBlockScrubConfig()
+	if !config.Enabled {
+		Logger.Info("block scrub - disabled")
+		return
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 1
+	}
+
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(config.Settle):
+	}
+
+	for {
+		var high int64
+		if lfb := sc.GetLatestFinalizedBlock(); lfb != nil {
+			high = lfb.Round
+		}
+
+		sc.ScrubStats.startCycle(1, high)
+		Logger.Info("block scrub - cycle start", zap.Int64("high", high))
+
+		for roundNum := int64(1); roundNum <= high; roundNum++ {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+			}
+
+			sc.scrubRound(ctx, roundNum)
+
+			if roundNum%config.BatchSize == 0 {
+				time.Sleep(config.BatchPause)
+			}
+		}
+
+		sc.ScrubStats.endCycle()
+		stats := sc.ScrubStats.Snapshot()
+		Logger.Info("block scrub - cycle end",
+			zap.Int64("cycle", stats.Cycle),
+			zap.Int64("scanned", stats.Scanned),
+			zap.Int64("corrupt", stats.Corrupt),
+			zap.Int64("missing", stats.Missing),
+			zap.Int64("repaired", stats.Repaired),
+			zap.Int64("repair failed", stats.RepairFailed))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(config.RepeatInterval):
+		}
+	}
+}
+
+func (sc *Chain) scrubRound(ctx context.Context, roundNum int64) {
+	r, err := sc.GetRoundFromStore(ctx, roundNum)
+	if err != nil || !sc.isValidRound(r) {
+		// missing rounds are repaired by the health check
+		sc.ScrubStats.scanned(roundNum, true)
+		return
+	}
+	sc.ScrubStats.scanned(roundNum, false)
+
+	bs, _ := sc.hasBlockSummary(ctx, r.BlockHash)
+
+	var missing bool
+	// the stored copy, not the cached one, is verified
+	b, err := blockstore.ReadStored(r.BlockHash)
+	if err != nil {
+		missing = errors.Is(err, os.ErrNotExist) || errors.Is(err, blockstore.ErrBlockNotFound)
+	} else {
+		err = verifyStoredBlock(b, r.BlockHash, bs)
+	}
+	if err == nil {
+		return
+	}
+
+	rec := ScrubRecord{
+		Round:  roundNum,
+		Hash:   r.BlockHash,
+		Reason: err.Error(),
+		Time:   time.Now().Truncate(time.Second),
+	}
+	Logger.Error("block scrub - invalid stored block",
+		zap.Int64("round", roundNum),
+		zap.String("hash", r.BlockHash),
+		zap.Bool("missing", missing),
+		zap.Error(err))
+
+	rec.Repaired = sc.repairScrubbedBlock(ctx, r, bs)
+	sc.ScrubStats.record(rec, missing)
+}
+
+// repairScrubbedBlock re-fetches the block from other sharders and
+// overwrites the stored one.
+func (sc *Chain) repairScrubbedBlock(ctx context.Context, r *round.Round, bs *block.BlockSummary) bool {
+	roundNum, hash := r.Number, r.BlockHash
+	b := sc.requestBlock(ctx, r)
+	if b == nil {
+		Logger.Error("block scrub - can't fetch block from sharders",
+			zap.Int64("round", roundNum), zap.String("hash", hash))
+		return false
+	}
+	if err := verifyStoredBlock(b, hash, bs); err != nil {
+		Logger.Error("block scrub - invalid block fetched from sharders",
+			zap.Int64("round", roundNum), zap.String("hash", hash), zap.Error(err))
+		return false
+	}
+	if err := sc.storeBlock(b); err != nil {
+		return false
+	}
+
+	Logger.Info("block scrub - block repaired",
+		zap.Int64("round", roundNum), zap.String("hash", hash))
+	return true
+}
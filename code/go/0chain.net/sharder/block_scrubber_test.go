@@ -0,0 +1,81 @@
+package sharder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/chaincore/transaction"
+)
+
+func TestVerifyStoredBlock(t *testing.T) {
+	newBlock := func() *block.Block {
+		b := block.NewBlock("", 1)
+		b.MinerID = "miner"
+		txn := &transaction.Transaction{ClientID: "client", ToClientID: "to", TransactionData: "data"}
+		txn.Hash = txn.ComputeHash()
+		b.Txns = []*transaction.Transaction{txn}
+		b.HashBlock()
+		return b
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		b := newBlock()
+		require.NoError(t, verifyStoredBlock(b, b.Hash, b.GetSummary()))
+		require.NoError(t, verifyStoredBlock(b, b.Hash, nil))
+	})
+
+	t.Run("other block", func(t *testing.T) {
+		b := newBlock()
+		require.Error(t, verifyStoredBlock(b, "other", nil))
+	})
+
+	t.Run("corrupt transaction", func(t *testing.T) {
+		b := newBlock()
+		b.Txns[0].TransactionData = "corrupt"
+		require.Error(t, verifyStoredBlock(b, b.Hash, nil))
+	})
+
+	t.Run("corrupt block", func(t *testing.T) {
+		b := newBlock()
+		b.StateChangesCount++
+		require.Error(t, verifyStoredBlock(b, b.Hash, nil))
+	})
+
+	t.Run("summary mismatch", func(t *testing.T) {
+		b := newBlock()
+		bs := b.GetSummary()
+		bs.MerkleTreeRoot = "other"
+		require.Error(t, verifyStoredBlock(b, b.Hash, bs))
+	})
+}
+
+func TestBlockScrubProgress(t *testing.T) {
+	var p BlockScrubProgress
+	p.startCycle(1, 10)
+	p.scanned(1, false)
+	p.scanned(2, true)
+	for i := 0; i < maxScrubRecords+5; i++ {
+		p.record(ScrubRecord{Round: int64(i), Repaired: i%2 == 0}, i%3 == 0)
+	}
+	p.endCycle()
+
+	stats := p.Snapshot()
+	require.Equal(t, SyncHiatus, stats.Status)
+	require.EqualValues(t, 1, stats.Cycle)
+	require.EqualValues(t, 2, stats.CurrentRound)
+	require.EqualValues(t, 1, stats.Scanned)
+	require.EqualValues(t, 1, stats.Skipped)
+	require.EqualValues(t, maxScrubRecords+5, stats.Missing+stats.Corrupt)
+	require.EqualValues(t, maxScrubRecords+5, stats.Repaired+stats.RepairFailed)
+	require.Len(t, stats.Records, maxScrubRecords)
+	require.EqualValues(t, maxScrubRecords+4, stats.Records[maxScrubRecords-1].Round)
+
+	// the records are kept between the cycles, the counters aren't
+	p.startCycle(1, 20)
+	stats = p.Snapshot()
+	require.EqualValues(t, 2, stats.Cycle)
+	require.Zero(t, stats.Corrupt+stats.Missing)
+	require.Len(t, stats.Records, maxScrubRecords)
+}
@@ -0,0 +1,51 @@
+package blockstore
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/core/datastore"
+)
+
+// writeBlock writes the block msgpack encoded and zlib compressed. It's the
+// format blocks are kept in by all the block store backends, so that blocks
+// can be moved between them as is.
+func writeBlock(w io.Writer, b *block.Block) error {
+	zw, err := zlib.NewWriterLevel(w, zlib.BestCompression)
+	if err != nil {
+		return err
+	}
+	if err := datastore.WriteMsgpack(zw, b); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// readBlock reads a block written by writeBlock.
+func readBlock(r io.Reader, provider datastore.EntityMetadata) (*block.Block, error) {
+	zr, err := zlib.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	b := provider.Instance().(*block.Block)
+	if err := datastore.ReadMsgpack(zr, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func encodeBlock(b *block.Block) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := writeBlock(buf, b); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeBlock(data []byte, provider datastore.EntityMetadata) (*block.Block, error) {
+	return readBlock(bytes.NewReader(data), provider)
+}
@@ -3,7 +3,6 @@ package blockstore
 import (
 	"bufio"
 	"bytes"
-	"compress/zlib"
 	"context"
 	"fmt"
 	"math"
@@ -102,14 +101,7 @@ func (bStore *BlockStore) writeToDisk(hash string, b *block.Block) error {
 	defer f.Close()
 
 	bf := bufio.NewWriterSize(f, 64*1024)
-	w, err := zlib.NewWriterLevel(bf, zlib.BestCompression)
-	if err != nil {
-		return err
-	}
-	if err := datastore.WriteMsgpack(w, b); err != nil {
-		return err
-	}
-	if err = w.Close(); err != nil {
+	if err := writeBlock(bf, b); err != nil {
 		return err
 	}
 	return bf.Flush()
@@ -183,17 +175,22 @@ func (bStore *BlockStore) readFromDisk(hash string) (*block.Block, error) {
 	}
 	defer f.Close()
 
-	r, err := zlib.NewReader(f)
-	if err != nil {
-		return nil, err
-	}
-	defer r.Close()
-	b := bStore.blockMetadataProvider.Instance().(*block.Block)
-	err = datastore.ReadMsgpack(r, b)
-	if err != nil {
-		return nil, err
-	}
-	return b, nil
+	return readBlock(f, bStore.blockMetadataProvider)
+}
+
+func (bStore *BlockStore) readStored(hash string) (*block.Block, error) {
+	return bStore.readFromDisk(hash)
+}
+
+// readRaw reads the compressed block from the disk without decoding it.
+func (bStore *BlockStore) readRaw(hash string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(bStore.basePath, getBlockFilePath(hash)))
+}
+
+// delete removes the block from the disk, the cache isn't touched since
+// cached blocks are still valid.
+func (bStore *BlockStore) delete(hash string) error {
+	return os.Remove(filepath.Join(bStore.basePath, getBlockFilePath(hash)))
 }
 
 // ReadWithBlockSummary - read the block given the block summary
@@ -201,12 +198,10 @@ func (bStore *BlockStore) ReadWithBlockSummary(bs *block.BlockSummary) (*block.B
 	return bStore.Read(bs.Hash)
 }
 
-// Init checks for minimum disk size, inodes requirement and assigns
-// block storer to a variable. If any error occurs during initialization
+// initFSStore checks for minimum disk size, inodes requirement and creates
+// the file system block store. If any error occurs during initialization
 // it will panic.
-func Init(sViper *viper.Viper) {
-	logging.Logger.Info("Initializing storage")
-
+func initFSStore(sViper *viper.Viper) *BlockStore {
 	basePath := sViper.GetString("root_dir")
 	if basePath == "" {
 		panic("root dir cannot be empty")
@@ -236,5 +231,5 @@ func Init(sViper *viper.Viper) {
 		bStore.cache = initCache(cViper)
 	}
 
-	SetupStore(bStore)
+	return bStore
 }
@@ -0,0 +1,72 @@
+package blockstore
+
+import (
+	"errors"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/core/datastore"
+	"github.com/0chain/common/core/logging"
+	"go.uber.org/zap"
+)
+
+// ErrBlockNotFound is returned by the object stores for missing blocks.
+var ErrBlockNotFound = errors.New("block not found")
+
+// objectStore keeps compressed blocks by their hashes. It's implemented by
+// the backends that can be used instead of the file system store or as the
+// cold tier of the tiered store.
+type objectStore interface {
+	put(hash string, data []byte) error
+	get(hash string) ([]byte, error)
+	delete(hash string) error
+}
+
+// ObjectBlockStore is a block store on top of an object store.
+type ObjectBlockStore struct {
+	store                 objectStore
+	blockMetadataProvider datastore.EntityMetadata
+}
+
+func newObjectBlockStore(store objectStore) *ObjectBlockStore {
+	return &ObjectBlockStore{
+		store:                 store,
+		blockMetadataProvider: datastore.GetEntityMetadata("block"),
+	}
+}
+
+func (oStore *ObjectBlockStore) Write(b *block.Block) error {
+	data, err := encodeBlock(b)
+	if err != nil {
+		return err
+	}
+	if err := oStore.store.put(b.Hash, data); err != nil {
+		return err
+	}
+
+	if b.MagicBlock != nil && b.Round == b.MagicBlock.StartingRound {
+		logging.Logger.Debug("save magic block",
+			zap.Int64("round", b.Round),
+			zap.String("mb hash", b.MagicBlock.Hash),
+		)
+		return oStore.store.put(b.MagicBlock.Hash, data)
+	}
+	return nil
+}
+
+func (oStore *ObjectBlockStore) Read(hash string) (*block.Block, error) {
+	data, err := oStore.store.get(hash)
+	if err != nil {
+		return nil, err
+	}
+	return decodeBlock(data, oStore.blockMetadataProvider)
+}
+
+// readStored is the same as Read, the object stores have no caches.
+func (oStore *ObjectBlockStore) readStored(hash string) (*block.Block, error) {
+	return oStore.Read(hash)
+}
+
+// ReadWithBlockSummary - read the block given the block summary
+func (oStore *ObjectBlockStore) ReadWithBlockSummary(bs *block.BlockSummary) (*block.Block, error) {
+	return oStore.Read(bs.Hash)
+}
@@ -0,0 +1,71 @@
+package blockstore
+
+import (
+	"os"
+
+	"0chain.net/core/viper"
+	"github.com/0chain/gorocksdb"
+)
+
+// rocksDBStore keeps blocks in a RocksDB database keyed by the block hash.
+type rocksDBStore struct {
+	db *gorocksdb.DB
+	ro *gorocksdb.ReadOptions
+	wo *gorocksdb.WriteOptions
+}
+
+func newRocksDBStore(path string) (*rocksDBStore, error) {
+	if err := os.MkdirAll(path, 0700); err != nil {
+		return nil, err
+	}
+
+	opts := gorocksdb.NewDefaultOptions()
+	opts.SetCreateIfMissing(true)
+	// blocks are zlib compressed already
+	opts.SetCompression(gorocksdb.NoCompression)
+	db, err := gorocksdb.OpenDb(opts, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rocksDBStore{
+		db: db,
+		ro: gorocksdb.NewDefaultReadOptions(),
+		wo: gorocksdb.NewDefaultWriteOptions(),
+	}, nil
+}
+
+func (rs *rocksDBStore) put(hash string, data []byte) error {
+	return rs.db.Put(rs.wo, []byte(hash), data)
+}
+
+func (rs *rocksDBStore) get(hash string) ([]byte, error) {
+	data, err := rs.db.GetBytes(rs.ro, []byte(hash))
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, ErrBlockNotFound
+	}
+	return data, nil
+}
+
+func (rs *rocksDBStore) delete(hash string) error {
+	return rs.db.Delete(rs.wo, []byte(hash))
+}
+
+func initRocksDBStore(rViper *viper.Viper) *rocksDBStore {
+	if rViper == nil {
+		panic("rocksdb block store configuration is missing")
+	}
+	path := rViper.GetString("path")
+	if path == "" {
+		panic("rocksdb path cannot be empty")
+	}
+
+	rs, err := newRocksDBStore(path)
+	if err != nil {
+		panic(err)
+	}
+	return rs
+}
@@ -0,0 +1,265 @@
+package blockstore
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"0chain.net/core/viper"
+)
+
+const (
+	s3Service     = "s3"
+	s3Algorithm   = "AWS4-HMAC-SHA256"
+	s3TimeFormat  = "20060102T150405Z"
+	s3DateFormat  = "20060102"
+	s3DefaultZone = "us-east-1"
+	s3Timeout     = 30 * time.Second
+)
+
+// s3Store keeps blocks as objects of a bucket of an S3 compatible storage,
+// MinIO for example. Requests are signed with AWS signature version 4 and
+// use path style URLs, which all the S3 compatible storages support.
+type s3Store struct {
+	endpoint        *url.URL
+	bucket          string
+	prefix          string
+	region          string
+	accessKeyID     string
+	secretAccessKey string
+	client          *http.Client
+}
+
+type s3Config struct {
+	Endpoint        string
+	UseSSL          bool
+	Bucket          string
+	Prefix          string
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+func newS3Store(cfg s3Config) (*s3Store, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" {
+		return nil, fmt.Errorf("s3 endpoint and bucket cannot be empty")
+	}
+
+	endpoint := cfg.Endpoint
+	if !strings.Contains(endpoint, "://") {
+		scheme := "http"
+		if cfg.UseSSL {
+			scheme = "https"
+		}
+		endpoint = scheme + "://" + endpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid s3 endpoint: %v", err)
+	}
+
+	region := cfg.Region
+	if region == "" {
+		region = s3DefaultZone
+	}
+
+	return &s3Store{
+		endpoint:        u,
+		bucket:          cfg.Bucket,
+		prefix:          cfg.Prefix,
+		region:          region,
+		accessKeyID:     cfg.AccessKeyID,
+		secretAccessKey: cfg.SecretAccessKey,
+		client:          &http.Client{Timeout: s3Timeout},
+	}, nil
+}
+
+func (s *s3Store) objectKey(hash string) string {
+	return s.prefix + hash + "." + extension
+}
+
+func (s *s3Store) put(hash string, data []byte) error {
+	resp, err := s.do(http.MethodPut, path.Join(s.bucket, s.objectKey(hash)), data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return s3Error(resp)
+	}
+	return nil
+}
+
+func (s *s3Store) get(hash string) ([]byte, error) {
+	resp, err := s.do(http.MethodGet, path.Join(s.bucket, s.objectKey(hash)), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return io.ReadAll(resp.Body)
+	case http.StatusNotFound:
+		return nil, ErrBlockNotFound
+	default:
+		return nil, s3Error(resp)
+	}
+}
+
+func (s *s3Store) delete(hash string) error {
+	resp, err := s.do(http.MethodDelete, path.Join(s.bucket, s.objectKey(hash)), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return s3Error(resp)
+	}
+	return nil
+}
+
+// ensureBucket creates the bucket unless it exists.
+func (s *s3Store) ensureBucket() error {
+	resp, err := s.do(http.MethodHead, s.bucket, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	resp, err = s.do(http.MethodPut, s.bucket, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return s3Error(resp)
+	}
+	return nil
+}
+
+func (s *s3Store) do(method, p string, body []byte) (*http.Response, error) {
+	u := *s.endpoint
+	u.Path = "/" + p
+
+	req, err := http.NewRequest(method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = int64(len(body))
+	s.sign(req, body, time.Now().UTC())
+	return s.client.Do(req)
+}
+
+// sign adds the AWS signature version 4 authorization to the request.
+func (s *s3Store) sign(req *http.Request, body []byte, now time.Time) {
+	var (
+		amzDate     = now.Format(s3TimeFormat)
+		date        = now.Format(s3DateFormat)
+		payloadHash = sha256Hex(body)
+		scope       = strings.Join([]string{date, s.region, s3Service, "aws4_request"}, "/")
+	)
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+
+	const signedHeaders = "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		"host:" + req.URL.Host + "\n" +
+			"x-amz-content-sha256:" + payloadHash + "\n" +
+			"x-amz-date:" + amzDate + "\n",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	stringToSign := strings.Join([]string{
+		s3Algorithm,
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.secretAccessKey), date)
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, s3Service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.accessKeyID, scope, signedHeaders, signature))
+}
+
+// canonicalQuery returns the query sorted by the names and values, with the
+// values URI encoded the way the signature version 4 requires.
+func canonicalQuery(query url.Values) string {
+	escape := func(s string) string {
+		return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+	}
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var params []string
+	for _, name := range names {
+		values := append([]string(nil), query[name]...)
+		sort.Strings(values)
+		for _, value := range values {
+			params = append(params, escape(name)+"="+escape(value))
+		}
+	}
+	return strings.Join(params, "&")
+}
+
+func sha256Hex(data []byte) string {
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func s3Error(resp *http.Response) error {
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("s3 request failed: %s %s", resp.Status, strings.TrimSpace(string(msg)))
+}
+
+func initS3Store(sViper *viper.Viper) *s3Store {
+	if sViper == nil {
+		panic("s3 block store configuration is missing")
+	}
+
+	s, err := newS3Store(s3Config{
+		Endpoint:        sViper.GetString("endpoint"),
+		UseSSL:          sViper.GetBool("use_ssl"),
+		Bucket:          sViper.GetString("bucket"),
+		Prefix:          sViper.GetString("prefix"),
+		Region:          sViper.GetString("region"),
+		AccessKeyID:     sViper.GetString("access_key_id"),
+		SecretAccessKey: sViper.GetString("secret_access_key"),
+	})
+	if err != nil {
+		panic(err)
+	}
+	if err := s.ensureBucket(); err != nil {
+		panic(err)
+	}
+	return s
+}
@@ -1,7 +1,12 @@
 package blockstore
 
 import (
+	"fmt"
+
 	"0chain.net/chaincore/block"
+	"0chain.net/core/viper"
+	"github.com/0chain/common/core/logging"
+	"go.uber.org/zap"
 )
 
 /*BlockStoreI - an interface to read and write blocks to some storage */
@@ -11,6 +16,22 @@ type BlockStoreI interface {
 	ReadWithBlockSummary(bs *block.BlockSummary) (*block.Block, error)
 }
 
+// storedReader is implemented by the block stores that can read a block
+// from their storage bypassing the caches.
+type storedReader interface {
+	readStored(hash string) (*block.Block, error)
+}
+
+// ReadStored reads the block as it's kept by the storage of the block store.
+// Unlike Read, it doesn't use nor fill the caches and doesn't queue the block
+// to another tier, the block scrubber verifies the stored blocks this way.
+func ReadStored(hash string) (*block.Block, error) {
+	if sr, ok := store.(storedReader); ok {
+		return sr.readStored(hash)
+	}
+	return store.Read(hash)
+}
+
 /*GetStore - get the block store that's is setup */
 func GetStore() BlockStoreI {
 	return store
@@ -20,3 +41,59 @@ func GetStore() BlockStoreI {
 func SetupStore(s BlockStoreI) {
 	store = s
 }
+
+const (
+	// BackendFS keeps blocks on the file system, it's the default backend.
+	BackendFS = "fs"
+	// BackendRocksDB keeps blocks in a RocksDB database.
+	BackendRocksDB = "rocksdb"
+	// BackendS3 keeps blocks in an S3 compatible object storage.
+	BackendS3 = "s3"
+	// BackendTiered keeps recent blocks on the file system and moves old
+	// blocks to the rocksdb or s3 cold backend.
+	BackendTiered = "tiered"
+)
+
+// Init creates the block store of the configured backend and assigns
+// it to a variable. If any error occurs during initialization it will panic.
+func Init(sViper *viper.Viper) {
+	backend := sViper.GetString("backend")
+	logging.Logger.Info("Initializing storage", zap.String("backend", backend))
+
+	switch backend {
+	case "", BackendFS:
+		SetupStore(initFSStore(sViper))
+	case BackendRocksDB, BackendS3:
+		SetupStore(newObjectBlockStore(initObjectStore(backend, sViper)))
+	case BackendTiered:
+		tViper := sViper.Sub("tiered")
+		if tViper == nil {
+			panic("tiered block store configuration is missing")
+		}
+		rounds := tViper.GetInt64("rounds")
+		if rounds <= 0 {
+			panic("tiered block store rounds should be positive")
+		}
+		coldBackend := tViper.GetString("cold_backend")
+		if coldBackend != BackendRocksDB && coldBackend != BackendS3 {
+			panic(fmt.Sprintf("unsupported cold block store backend: %q", coldBackend))
+		}
+
+		ts := newTieredStore(initFSStore(sViper),
+			newObjectBlockStore(initObjectStore(coldBackend, sViper)), rounds)
+		if err := ts.load(); err != nil {
+			panic(fmt.Sprintf("loading tiered block store: %v", err))
+		}
+		go ts.run()
+		SetupStore(ts)
+	default:
+		panic(fmt.Sprintf("unsupported block store backend: %q", backend))
+	}
+}
+
+func initObjectStore(backend string, sViper *viper.Viper) objectStore {
+	if backend == BackendRocksDB {
+		return initRocksDBStore(sViper.Sub("rocksdb"))
+	}
+	return initS3Store(sViper.Sub("s3"))
+}
@@ -0,0 +1,244 @@
+package blockstore
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"0chain.net/chaincore/block"
+	"github.com/0chain/common/core/logging"
+	"go.uber.org/zap"
+)
+
+// TieredStore keeps recent blocks on the hot local disk store and moves
+// blocks older than the configured number of rounds to the cold store.
+// Reads look at the hot store first and then at the cold one, so the tier
+// a block is on is transparent for the readers.
+type TieredStore struct {
+	hot    *BlockStore
+	cold   *ObjectBlockStore
+	rounds int64
+
+	mu sync.Mutex
+	// latest is the highest round written
+	latest int64
+	// pending blocks to be moved to the cold store, in the write order
+	pending []tieredBlock
+	// queued are the hashes of the pending blocks
+	queued map[string]struct{}
+	moveCh chan struct{}
+}
+
+type tieredBlock struct {
+	round int64
+	hash  string
+}
+
+func newTieredStore(hot *BlockStore, cold *ObjectBlockStore, rounds int64) *TieredStore {
+	return &TieredStore{
+		hot:    hot,
+		cold:   cold,
+		rounds: rounds,
+		queued: make(map[string]struct{}),
+		moveCh: make(chan struct{}, 1),
+	}
+}
+
+func (ts *TieredStore) Write(b *block.Block) error {
+	if err := ts.hot.Write(b); err != nil {
+		return err
+	}
+
+	ts.mu.Lock()
+	if b.Round > ts.latest {
+		ts.latest = b.Round
+	}
+	ts.enqueue(b.Round, b.Hash)
+	if b.MagicBlock != nil && b.Round == b.MagicBlock.StartingRound {
+		ts.enqueue(b.Round, b.MagicBlock.Hash)
+	}
+	ts.mu.Unlock()
+
+	ts.notify()
+	return nil
+}
+
+func (ts *TieredStore) Read(hash string) (*block.Block, error) {
+	b, err := ts.hot.Read(hash)
+	if err == nil {
+		// blocks left on the hot store, by a restart for example
+		if ts.isOld(b.Round) {
+			ts.mu.Lock()
+			ts.enqueue(b.Round, hash)
+			ts.mu.Unlock()
+			ts.notify()
+		}
+		return b, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	return ts.cold.Read(hash)
+}
+
+// readStored reads the block from the tier it's on, old blocks found on the
+// hot store aren't queued to be moved.
+func (ts *TieredStore) readStored(hash string) (*block.Block, error) {
+	b, err := ts.hot.readStored(hash)
+	if err == nil || !errors.Is(err, os.ErrNotExist) {
+		return b, err
+	}
+	return ts.cold.readStored(hash)
+}
+
+// ReadWithBlockSummary - read the block given the block summary
+func (ts *TieredStore) ReadWithBlockSummary(bs *block.BlockSummary) (*block.Block, error) {
+	return ts.Read(bs.Hash)
+}
+
+func (ts *TieredStore) isOld(round int64) bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return round <= ts.latest-ts.rounds
+}
+
+// enqueue adds the block to the pending ones, the mutex should be held.
+func (ts *TieredStore) enqueue(round int64, hash string) {
+	if _, ok := ts.queued[hash]; ok {
+		return
+	}
+	ts.queued[hash] = struct{}{}
+	ts.pending = append(ts.pending, tieredBlock{round: round, hash: hash})
+}
+
+func (ts *TieredStore) notify() {
+	select {
+	case ts.moveCh <- struct{}{}:
+	default:
+	}
+}
+
+// dueBlocks takes the pending blocks old enough to be moved.
+func (ts *TieredStore) dueBlocks() (due []tieredBlock) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	var (
+		threshold = ts.latest - ts.rounds
+		rest      = ts.pending[:0]
+	)
+	for _, tb := range ts.pending {
+		if tb.round <= threshold {
+			due = append(due, tb)
+			continue
+		}
+		rest = append(rest, tb)
+	}
+	ts.pending = rest
+	return
+}
+
+// load rebuilds the latest round and the pending blocks from the blocks on
+// the hot store, they are kept in memory only and lost on a restart.
+func (ts *TieredStore) load() error {
+	suffix := "." + extension
+	err := filepath.WalkDir(ts.hot.basePath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, suffix) {
+			return nil
+		}
+		hash, ok := blockFileHash(ts.hot.basePath, path)
+		if !ok {
+			return nil
+		}
+		b, err := ts.hot.readFromDisk(hash)
+		if err != nil {
+			logging.Logger.Error("tiered store - reading block",
+				zap.String("path", path),
+				zap.Error(err))
+			return nil
+		}
+
+		ts.mu.Lock()
+		if b.Round > ts.latest {
+			ts.latest = b.Round
+		}
+		ts.enqueue(b.Round, hash)
+		ts.mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	ts.notify()
+	return nil
+}
+
+// blockFileHash returns the hash of the block kept in the file of the path,
+// it's the reverse of getBlockFilePath.
+func blockFileHash(basePath, path string) (string, bool) {
+	rel, err := filepath.Rel(basePath, path)
+	if err != nil {
+		return "", false
+	}
+	parts := strings.Split(rel, string(os.PathSeparator))
+	if len(parts) != subDirs+1 {
+		return "", false
+	}
+	return strings.Join(parts[:subDirs], "") +
+		strings.TrimSuffix(parts[subDirs], "."+extension), true
+}
+
+// move copies the block to the cold store and removes it from the hot one.
+func (ts *TieredStore) move(hash string) error {
+	data, err := ts.hot.readRaw(hash)
+	if errors.Is(err, os.ErrNotExist) {
+		// moved already
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := ts.cold.store.put(hash, data); err != nil {
+		return err
+	}
+	if err := ts.hot.delete(hash); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// moveDue moves the due blocks, the blocks failed to be moved are retried
+// on the next run.
+func (ts *TieredStore) moveDue() {
+	for _, tb := range ts.dueBlocks() {
+		err := ts.move(tb.hash)
+
+		ts.mu.Lock()
+		if err != nil {
+			ts.pending = append(ts.pending, tb)
+		} else {
+			delete(ts.queued, tb.hash)
+		}
+		ts.mu.Unlock()
+
+		if err != nil {
+			logging.Logger.Error("tiered store - moving block to cold store",
+				zap.Int64("round", tb.round),
+				zap.String("hash", tb.hash),
+				zap.Error(err))
+		}
+	}
+}
+
+func (ts *TieredStore) run() {
+	for range ts.moveCh {
+		ts.moveDue()
+	}
+}
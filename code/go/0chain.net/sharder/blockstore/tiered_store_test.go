@@ -0,0 +1,178 @@
+package blockstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/core/datastore"
+	"github.com/stretchr/testify/require"
+)
+
+type memObjectStore struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (m *memObjectStore) put(hash string, data []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.objects[hash] = data
+	return nil
+}
+
+func (m *memObjectStore) get(hash string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.objects[hash]
+	if !ok {
+		return nil, ErrBlockNotFound
+	}
+	return data, nil
+}
+
+func (m *memObjectStore) delete(hash string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.objects, hash)
+	return nil
+}
+
+func TestTieredStore(t *testing.T) {
+	basePath := t.TempDir()
+	hot := &BlockStore{
+		basePath:              basePath,
+		blockMetadataProvider: datastore.GetEntityMetadata("block"),
+		cache:                 noOpCache{},
+	}
+	cold := &memObjectStore{objects: make(map[string][]byte)}
+	ts := newTieredStore(hot, newObjectBlockStore(cold), 5)
+
+	hash := func(round int64) string {
+		return fmt.Sprintf("%064d", round)
+	}
+	onHot := func(round int64) bool {
+		_, err := os.Stat(filepath.Join(basePath, getBlockFilePath(hash(round))))
+		return err == nil
+	}
+
+	for round := int64(1); round <= 10; round++ {
+		b := new(block.Block)
+		b.Hash = hash(round)
+		b.Round = round
+		require.NoError(t, ts.Write(b))
+	}
+	ts.moveDue()
+
+	for round := int64(1); round <= 10; round++ {
+		old := round <= 5
+		require.Equal(t, !old, onHot(round), "round %d", round)
+		_, onCold := cold.objects[hash(round)]
+		require.Equal(t, old, onCold, "round %d", round)
+
+		b, err := ts.Read(hash(round))
+		require.NoError(t, err)
+		require.Equal(t, round, b.Round)
+	}
+
+	_, err := ts.Read(hash(11))
+	require.ErrorIs(t, err, ErrBlockNotFound)
+
+	// old blocks left on the hot store are moved once read
+	ts.queued = make(map[string]struct{})
+	ts.pending = nil
+	ts.latest = 20
+	_, err = ts.Read(hash(10))
+	require.NoError(t, err)
+	ts.moveDue()
+	require.False(t, onHot(10))
+	require.Contains(t, cold.objects, hash(10))
+}
+
+func TestTieredStore_load(t *testing.T) {
+	basePath := t.TempDir()
+	hot := &BlockStore{
+		basePath:              basePath,
+		blockMetadataProvider: datastore.GetEntityMetadata("block"),
+		cache:                 noOpCache{},
+	}
+	cold := &memObjectStore{objects: make(map[string][]byte)}
+
+	hash := func(round int64) string {
+		return fmt.Sprintf("%064d", round)
+	}
+	ts := newTieredStore(hot, newObjectBlockStore(cold), 5)
+	for round := int64(1); round <= 10; round++ {
+		b := new(block.Block)
+		b.Hash = hash(round)
+		b.Round = round
+		require.NoError(t, ts.Write(b))
+	}
+
+	// a restart loses the latest round and the pending blocks
+	ts = newTieredStore(hot, newObjectBlockStore(cold), 5)
+	require.NoError(t, ts.load())
+	require.EqualValues(t, 10, ts.latest)
+	require.Len(t, ts.pending, 10)
+
+	ts.moveDue()
+	for round := int64(1); round <= 10; round++ {
+		require.Equal(t, round <= 5, cold.objects[hash(round)] != nil, "round %d", round)
+	}
+}
+
+// staticCache always returns the same cached block and counts the writes.
+type staticCache struct {
+	data   []byte
+	writes int
+}
+
+func (c *staticCache) Write(context.Context, string, *block.Block) error {
+	c.writes++
+	return nil
+}
+
+func (c *staticCache) Read(string) ([]byte, error) {
+	return c.data, nil
+}
+
+func TestTieredStore_readStored(t *testing.T) {
+	cached := new(block.Block)
+	cached.Hash = fmt.Sprintf("%064d", 1)
+	cached.Round = 100
+	buf := new(bytes.Buffer)
+	require.NoError(t, datastore.WriteMsgpack(buf, cached))
+	cache := &staticCache{data: buf.Bytes()}
+
+	hot := &BlockStore{
+		basePath:              t.TempDir(),
+		blockMetadataProvider: datastore.GetEntityMetadata("block"),
+		cache:                 cache,
+	}
+	cold := &memObjectStore{objects: make(map[string][]byte)}
+	ts := newTieredStore(hot, newObjectBlockStore(cold), 5)
+
+	b := new(block.Block)
+	b.Hash = cached.Hash
+	b.Round = 1
+	require.NoError(t, hot.writeToDisk(b.Hash, b))
+	ts.latest = 10
+
+	// the stored block is read, not the cached one, and the old block isn't
+	// queued to be moved
+	b1, err := ts.readStored(b.Hash)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, b1.Round)
+	require.Empty(t, ts.pending)
+	require.Zero(t, cache.writes)
+
+	require.NoError(t, ts.move(b.Hash))
+	b1, err = ts.readStored(b.Hash)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, b1.Round)
+}
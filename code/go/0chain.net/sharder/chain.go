@@ -43,6 +43,7 @@ func SetupSharderChain(c *chain.Chain) {
 	c.SetMagicBlockSaver(sharderChain)
 	sharderChain.BlockSyncStats = &SyncStats{}
 	sharderChain.TieringStats = &MinioStats{}
+	sharderChain.ScrubStats = &BlockScrubProgress{}
 	sharderChain.processingBlocks = cache.NewLRUCache[string, struct{}](1000)
 	c.RoundF = SharderRoundFactory{}
 }
@@ -68,6 +69,7 @@ type Chain struct {
 	SharderStats   Stats
 	BlockSyncStats *SyncStats
 	TieringStats   *MinioStats
+	ScrubStats     *BlockScrubProgress
 
 	processingBlocks *cache.LRU[string, struct{}]
 	pbMutex          sync.RWMutex
@@ -30,6 +30,8 @@ func handlersMap() map[string]func(http.ResponseWriter, *http.Request) {
 		"/v1/state/nodes":                  common.ToJSONResponse(chain.StateNodesHandler),
 		"/v1/block/state_change":           common.ToJSONResponse(BlockStateChangeHandler),
 		"/_transaction_errors":             TransactionErrorWriter,
+		"/_diagnostics/block_scrub":        BlockScrubWriter,
+		"/v1/sharder/get/block_scrub":      common.ToJSONResponse(BlockScrubHandler),
 	}
 
 	handlers := make(map[string]func(http.ResponseWriter, *http.Request))
@@ -280,6 +282,16 @@ func SharderStatsHandler(ctx context.Context, r *http.Request) (interface{}, err
 	}, nil
 }
 
+// swagger:route GET /v1/sharder/get/block_scrub blockscrub
+// a handler to get the block store scrubber progress and the corrupt blocks found
+//
+// responses:
+//  200: BlockScrubStats
+
+func BlockScrubHandler(ctx context.Context, r *http.Request) (interface{}, error) {
+	return GetSharderChain().ScrubStats.Snapshot(), nil
+}
+
 func TransactionErrorWriter(w http.ResponseWriter, r *http.Request) {
 
 	transactionErrors, err := GetSharderChain().Chain.GetEventDb().GetTransactionErrors()
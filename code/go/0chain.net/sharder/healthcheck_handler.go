@@ -50,3 +50,16 @@ func HealthCheckWriter(w http.ResponseWriter, r *http.Request) {
 	fmt.Fprintf(w, "</table>")
 
 }
+
+// BlockScrubWriter - a handler to provide the block store scrubber progress
+func BlockScrubWriter(w http.ResponseWriter, r *http.Request) {
+	sc := GetSharderChain()
+	w.Header().Set("Content-Type", "text/html")
+	chain.PrintCSS(w)
+	diagnostics.WriteStatisticsCSS(w)
+
+	self := node.Self.Underlying()
+	fmt.Fprintf(w, "<div>%v - %v</div>", self.GetPseudoName(), self.Description)
+	fmt.Fprintf(w, "<h2>Block Store Scrub</h2>")
+	sc.WriteBlockScrubStats(w)
+}
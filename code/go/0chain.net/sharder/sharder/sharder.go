@@ -49,6 +49,9 @@ func main() {
 	magicBlockFile := flag.String("magic_block_file", "", "magic_block_file")
 	initialStatesFile := flag.String("initial_states", "", "initial_states")
 	flag.String("nodes_file", "", "nodes_file (deprecated)")
+	snapshotExport := flag.String("state_snapshot_export", "", "export client state at state_snapshot_round to the file and exit")
+	snapshotRound := flag.Int64("state_snapshot_round", 0, "state_snapshot_round (latest stored round by default)")
+	snapshotImport := flag.String("state_snapshot_import", "", "import client state from the snapshot file before start")
 	workdir := ""
 	flag.StringVar(&workdir, "work_dir", "", "work_dir")
 
@@ -176,6 +179,19 @@ func main() {
 
 	sc.SetupGenesisBlock(viper.GetString("server_chain.genesis_block.id"), magicBlock, initStates)
 
+	if *snapshotExport != "" {
+		if err := sc.ExportStateSnapshot(ctx, *snapshotExport, *snapshotRound); err != nil {
+			Logger.Fatal("export state snapshot", zap.Error(err))
+		}
+		Logger.Info("state snapshot exported", zap.String("file", *snapshotExport))
+		return
+	}
+	if *snapshotImport != "" {
+		if err := sc.ImportStateSnapshot(ctx, *snapshotImport); err != nil {
+			Logger.Fatal("import state snapshot", zap.Error(err))
+		}
+	}
+
 	Logger.Info("sharder node", zap.Any("node", node.Self))
 
 	var selfNode = node.Self.Underlying()
@@ -281,6 +297,9 @@ func main() {
 	// Do a proximity scan from finalized block till ProximityWindow
 	go sc.HealthCheckWorker(ctx, sharder.ProximityScan) // 4) progressively checks the health for each round
 
+	// Re-verify the stored blocks and repair the corrupt ones from other sharders
+	go sc.BlockScrubWorker(ctx)
+
 	shutdown := common.HandleShutdown(server, []func(){shutdownIntegrationTests, done, chain.CloseStateDB})
 	Logger.Info("Ready to listen to the requests")
 	chain.StartTime = time.Now().UTC()
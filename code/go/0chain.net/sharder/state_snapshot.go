@@ -0,0 +1,132 @@
+package sharder
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"0chain.net/chaincore/block"
+	"0chain.net/chaincore/chain"
+	"0chain.net/chaincore/round"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/core/ememorystore"
+	. "github.com/0chain/common/core/logging"
+	"go.uber.org/zap"
+)
+
+// ExportStateSnapshot writes the client state of the finalized block of the
+// given round to the snapshot file. The latest stored round is used for
+// a zero round.
+func (sc *Chain) ExportStateSnapshot(ctx context.Context, path string, roundNum int64) error {
+	var (
+		r   *round.Round
+		err error
+	)
+	if roundNum == 0 {
+		r, err = sc.getLatestStoredRound(ctx)
+	} else {
+		r, err = sc.GetRoundFromStore(ctx, roundNum)
+	}
+	if err != nil {
+		return common.NewErrorf("export_state_snapshot", "reading round %d: %v", roundNum, err)
+	}
+	b, err := sc.GetBlockFromStore(r.BlockHash, r.Number)
+	if err != nil {
+		return common.NewErrorf("export_state_snapshot", "reading block %s: %v", r.BlockHash, err)
+	}
+	lfmb, err := sc.loadLatestFinalizedMagicBlockFromStore(ctx, b)
+	if err != nil {
+		return common.NewErrorf("export_state_snapshot", "reading magic block: %v", err)
+	}
+
+	// write to a temporary file first to not leave a partial snapshot
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := chain.ExportStateSnapshot(ctx, tmp, sc.GetStateDB(), b, lfmb); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (sc *Chain) getLatestStoredRound(ctx context.Context) (*round.Round, error) {
+	var (
+		remd = datastore.GetEntityMetadata("round")
+		rctx = ememorystore.WithEntityConnection(ctx, remd)
+	)
+	defer ememorystore.Close(rctx)
+
+	var (
+		conn = ememorystore.GetEntityCon(rctx, remd)
+		iter = conn.Conn.NewIterator(conn.ReadOptions)
+	)
+	defer iter.Close()
+
+	iter.SeekToLast()
+	if !iter.Valid() {
+		return nil, errors.New("no rounds stored")
+	}
+	r := remd.Instance().(*round.Round)
+	if err := datastore.FromJSON(iter.Value().Data(), r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// ImportStateSnapshot loads the client state from the snapshot file and
+// stores the snapshot block, its magic block and round, so the sharder
+// starts from the block as from the latest finalized one.
+func (sc *Chain) ImportStateSnapshot(ctx context.Context, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	ss, err := chain.ImportStateSnapshot(ctx, f, sc.GetStateDB())
+	if err != nil {
+		return err
+	}
+
+	for _, b := range []*block.Block{ss.LFMB, ss.Block} {
+		if err := sc.storeBlock(b); err != nil {
+			return common.NewErrorf("import_state_snapshot",
+				"saving block %s: %v", b.Hash, err)
+		}
+		if err := sc.StoreBlockSummaryFromBlock(b); err != nil {
+			return common.NewErrorf("import_state_snapshot",
+				"saving block summary %s: %v", b.Hash, err)
+		}
+		if ss.LFMB == ss.Block {
+			break
+		}
+	}
+
+	r := round.NewRound(ss.Block.Round)
+	r.SetRandomSeedForNotarizedBlock(ss.Block.GetRoundRandomSeed(),
+		ss.LFMB.MagicBlock.Miners.Size())
+	r.Finalize(ss.Block)
+	if err := sc.StoreRound(r); err != nil {
+		return common.NewErrorf("import_state_snapshot", "saving round: %v", err)
+	}
+
+	Logger.Info("state snapshot imported",
+		zap.Int64("round", ss.Block.Round),
+		zap.String("block", ss.Block.Hash),
+		zap.Int64("magic block round", ss.LFMB.Round),
+		zap.Uint64("nodes", ss.Nodes))
+	return nil
+}
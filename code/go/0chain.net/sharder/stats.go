@@ -2,6 +2,7 @@ package sharder
 
 import (
 	"fmt"
+	"html"
 	"net/http"
 	"time"
 
@@ -235,3 +236,41 @@ func (sc *Chain) WriteMinioStats(w http.ResponseWriter) {
 	fmt.Fprintf(w, "<tr><td>Last Upload time</td class='string'><td>%v</td></tr>", sc.TieringStats.LastUploadTime.Format(HealthCheckDateTimeFormat))
 	fmt.Fprintf(w, "</table>")
 }
+
+// WriteBlockScrubStats -
+func (sc *Chain) WriteBlockScrubStats(w http.ResponseWriter) {
+	stats := sc.ScrubStats.Snapshot()
+
+	var progress float64
+	if rounds := stats.HighRound - stats.LowRound + 1; rounds > 0 && stats.CurrentRound >= stats.LowRound {
+		progress = float64(stats.CurrentRound-stats.LowRound+1) * 100 / float64(rounds)
+	}
+
+	fmt.Fprintf(w, "<table width='100%%'>")
+	fmt.Fprintf(w, "<tr><th class='sheader' colspan='2'>Progress</th></tr>")
+	fmt.Fprintf(w, "<tr><td>Status</td><td class='string'>%v</td></tr>", stats.Status)
+	fmt.Fprintf(w, "<tr><td>Cycle</td><td class='number'>%v</td></tr>", stats.Cycle)
+	fmt.Fprintf(w, "<tr><td>Cycle Start</td><td class='string'>%v</td></tr>", stats.CycleStart.Format(HealthCheckDateTimeFormat))
+	if !stats.CycleEnd.IsZero() {
+		fmt.Fprintf(w, "<tr><td>Cycle End</td><td class='string'>%v</td></tr>", stats.CycleEnd.Format(HealthCheckDateTimeFormat))
+	}
+	fmt.Fprintf(w, "<tr><td>Rounds</td><td class='string'>[%v-%v]</td></tr>", stats.LowRound, stats.HighRound)
+	fmt.Fprintf(w, "<tr><td>Current Round</td><td class='number'>%v (%.2f%%)</td></tr>", stats.CurrentRound, progress)
+	fmt.Fprintf(w, "<tr><td>Scanned</td><td class='number'>%v</td></tr>", stats.Scanned)
+	fmt.Fprintf(w, "<tr><td>Skipped (no round)</td><td class='number'>%v</td></tr>", stats.Skipped)
+	fmt.Fprintf(w, "<tr><td>Corrupt</td><td class='number'>%v</td></tr>", stats.Corrupt)
+	fmt.Fprintf(w, "<tr><td>Missing</td><td class='number'>%v</td></tr>", stats.Missing)
+	fmt.Fprintf(w, "<tr><td>Repaired</td><td class='number'>%v</td></tr>", stats.Repaired)
+	fmt.Fprintf(w, "<tr><td>Repair Failed</td><td class='number'>%v</td></tr>", stats.RepairFailed)
+	fmt.Fprintf(w, "</table>")
+
+	fmt.Fprintf(w, "<table width='100%%'>")
+	fmt.Fprintf(w, "<tr><th class='sheader' colspan='5'>Corrupt Blocks (latest %d)</th></tr>", maxScrubRecords)
+	fmt.Fprintf(w, "<tr><td>Round</td><td>Hash</td><td>Reason</td><td>Repaired</td><td>Time</td></tr>")
+	for i := len(stats.Records) - 1; i >= 0; i-- {
+		rec := stats.Records[i]
+		fmt.Fprintf(w, "<tr><td class='number'>%v</td><td class='string'>%v</td><td class='string'>%v</td><td class='string'>%v</td><td class='string'>%v</td></tr>",
+			rec.Round, rec.Hash, html.EscapeString(rec.Reason), rec.Repaired, rec.Time.Format(HealthCheckDateTimeFormat))
+	}
+	fmt.Fprintf(w, "</table>")
+}
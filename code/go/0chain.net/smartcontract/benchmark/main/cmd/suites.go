@@ -12,6 +12,7 @@ import (
 	"0chain.net/core/viper"
 	"0chain.net/smartcontract/faucetsc"
 	"0chain.net/smartcontract/minersc"
+	"0chain.net/smartcontract/multisigsc"
 	"0chain.net/smartcontract/rest"
 	"0chain.net/smartcontract/storagesc"
 	"0chain.net/smartcontract/vestingsc"
@@ -71,6 +72,7 @@ func runSuites(
 	}
 	faucetsc.SetupRestHandler(restSetup)
 	minersc.SetupRestHandler(restSetup)
+	multisigsc.SetupRestHandler(restSetup)
 	storagesc.SetupRestHandler(restSetup)
 	vestingsc.SetupRestHandler(restSetup)
 	zcnsc.SetupRestHandler(restSetup)
@@ -45,6 +45,22 @@ type Allocation struct {
 	WritePool                currency.Coin `json:"write_pool"`
 	ThirdPartyExtendable     bool          `json:"third_party_extendable"`
 	FileOptions              uint16        `json:"file_options"`
+	AutoReplaceBlobbers      bool          `json:"auto_replace_blobbers"`
+	Tier                     string        `json:"tier"`
+	MinReputation            float64       `json:"min_reputation"`
+	PlacementMinDistanceKm   float64       `json:"placement_min_distance_km"`
+	PlacementMinRegions      int           `json:"placement_min_regions"`
+	PlacementMinLatitude     float64       `json:"placement_min_latitude"`
+	PlacementMaxLatitude     float64       `json:"placement_max_latitude"`
+	PlacementMinLongitude    float64       `json:"placement_min_longitude"`
+	PlacementMaxLongitude    float64       `json:"placement_max_longitude"`
+	Degraded                 bool          `json:"degraded"`
+	DegradedBlobbers         string        `json:"degraded_blobbers"` // comma separated ids
+	PendingOwner             string        `json:"pending_owner"`
+	PendingOwnerPublicKey    string        `json:"pending_owner_public_key"`
+	PendingOwnerExpiration   int64         `json:"pending_owner_expiration"`
+	AutoRenew                bool          `json:"auto_renew"`
+	RenewalBudget            currency.Coin `json:"renewal_budget"`
 
 	//ref
 	User  User                    `gorm:"foreignKey:Owner;references:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
@@ -78,6 +94,30 @@ func (edb *EventDb) GetClientsAllocation(clientID string, limit common.Paginatio
 	return allocs, nil
 }
 
+// GetDegradedAllocations returns the active allocations having killed or
+// shut down blobbers not replaced yet, of the owner if given.
+func (edb *EventDb) GetDegradedAllocations(owner string, limit common.Pagination) ([]Allocation, error) {
+	allocs := make([]Allocation, 0)
+
+	query := edb.Store.Get().
+		Preload("Terms").
+		Model(&Allocation{}).
+		Where("degraded = ? AND finalized = ? AND cancelled = ?", true, false, false)
+	if owner != "" {
+		query = query.Where("owner = ?", owner)
+	}
+	err := query.Limit(limit.Limit).Offset(limit.Offset).
+		Order(clause.OrderByColumn{
+			Column: clause.Column{Name: "start_time"},
+			Desc:   limit.IsDescending,
+		}).Find(&allocs).Error
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving degraded allocations: %v", err)
+	}
+
+	return allocs, nil
+}
+
 func (edb *EventDb) GetActiveAllocationsCount() (int64, error) {
 	var count int64
 	result := edb.Store.Get().Model(&Allocation{}).Where("finalized = ? AND cancelled = ?", false, false).Count(&count)
@@ -124,6 +164,14 @@ func (edb *EventDb) updateAllocations(allocs []Allocation) error {
 		"latest_closed_challenge_txn",
 		"third_party_extendable",
 		"file_options",
+		"auto_replace_blobbers",
+		"degraded",
+		"degraded_blobbers",
+		"pending_owner",
+		"pending_owner_public_key",
+		"pending_owner_expiration",
+		"auto_renew",
+		"renewal_budget",
 	}
 
 	columns, err := Columnize(allocs)
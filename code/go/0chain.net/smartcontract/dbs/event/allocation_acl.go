@@ -0,0 +1,57 @@
+package event
+
+import (
+	common2 "0chain.net/smartcontract/common"
+	"0chain.net/smartcontract/dbs/model"
+	"gorm.io/gorm/clause"
+)
+
+// AllocationACL is a collaborator of an allocation and the operations it is
+// permitted, see storagesc.AllocationACL for the permission bits.
+//
+// swagger:model AllocationACL
+type AllocationACL struct {
+	model.UpdatableModel
+	AllocationID string `json:"allocation_id" gorm:"uniqueIndex:idx_aacl_alloc_client,priority:1"`
+	ClientID     string `json:"client_id" gorm:"uniqueIndex:idx_aacl_alloc_client,priority:2;index:idx_aacl_client"`
+	Permissions  uint16 `json:"permissions"`
+	TxnHash      string `json:"txn_hash"`
+	Round        int64  `json:"round"`
+}
+
+func (edb *EventDb) addOrUpdateAllocationACL(acl AllocationACL) error {
+	return edb.Store.Get().Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "allocation_id"}, {Name: "client_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"permissions", "txn_hash", "round", "updated_at"}),
+	}).Create(&acl).Error
+}
+
+// deleteAllocationACL removes the collaborator of the allocation, or all the
+// collaborators of the allocation if the client is not given.
+func (edb *EventDb) deleteAllocationACL(acl AllocationACL) error {
+	query := edb.Store.Get().Where("allocation_id = ?", acl.AllocationID)
+	if acl.ClientID != "" {
+		query = query.Where("client_id = ?", acl.ClientID)
+	}
+	return query.Delete(&AllocationACL{}).Error
+}
+
+// GetAllocationACLs returns the collaborators of the allocation, if given,
+// and the allocations the client collaborates on, if given.
+func (edb *EventDb) GetAllocationACLs(
+	allocationID, clientID string, limit common2.Pagination,
+) ([]AllocationACL, error) {
+	query := edb.Store.Get().Model(&AllocationACL{})
+	if allocationID != "" {
+		query = query.Where("allocation_id = ?", allocationID)
+	}
+	if clientID != "" {
+		query = query.Where("client_id = ?", clientID)
+	}
+
+	var acls []AllocationACL
+	return acls, query.Offset(limit.Offset).Limit(limit.Limit).Order(clause.OrderByColumn{
+		Column: clause.Column{Name: "id"},
+		Desc:   limit.IsDescending,
+	}).Find(&acls).Error
+}
@@ -0,0 +1,128 @@
+package event
+
+import (
+	common2 "0chain.net/smartcontract/common"
+	"0chain.net/smartcontract/dbs/model"
+	"github.com/0chain/common/core/currency"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AllocationChargeType is the kind of token movement of an allocation
+// charge.
+type AllocationChargeType string
+
+const (
+	// ChargeWrite is moved from the write pool to the challenge pool on
+	// write markers.
+	ChargeWrite AllocationChargeType = "write"
+	// ChargeRead is paid to the blobber from a read pool on read markers.
+	ChargeRead AllocationChargeType = "read"
+	// ChargeChallengeReward is paid to the blobber from the challenge pool,
+	// out of the write charges.
+	ChargeChallengeReward AllocationChargeType = "challenge_reward"
+	// ChargeValidatorReward is paid to the validators from the challenge
+	// pool, out of the write charges.
+	ChargeValidatorReward AllocationChargeType = "validator_reward"
+	// ChargeMinLockDemand is paid to the blobber from the write pool when
+	// the allocation is finalized or canceled.
+	ChargeMinLockDemand AllocationChargeType = "min_lock_demand"
+	// ChargeCancellation is paid to the blobber from the write pool when
+	// the allocation is canceled or finalized.
+	ChargeCancellation AllocationChargeType = "cancellation"
+	// ChargeRefund is moved back from the challenge pool to the write pool,
+	// the refunds are the owner's.
+	ChargeRefund AllocationChargeType = "refund"
+	// ChargeUnlock is returned from the write pool to the owner.
+	ChargeUnlock AllocationChargeType = "unlock"
+)
+
+// AllocationCharge is an itemized token movement paid by the client for the
+// allocation, the statements of the allocations and clients are made of
+// them. The write pool charges are split between the clients that locked the
+// tokens, the challenge pool rewards have no client.
+//
+// swagger:model AllocationCharge
+type AllocationCharge struct {
+	model.ImmutableModel
+	AllocationID string               `json:"allocation_id" gorm:"index:idx_acharge_alloc_ts,priority:1"`
+	ClientID     string               `json:"client_id" gorm:"index:idx_acharge_client_ts,priority:1"`
+	BlobberID    string               `json:"blobber_id"`
+	Type         AllocationChargeType `json:"type"`
+	Amount       currency.Coin        `json:"amount"`
+	Timestamp    int64                `json:"timestamp" gorm:"index:idx_acharge_alloc_ts,priority:2;index:idx_acharge_client_ts,priority:2"`
+	TxnHash      string               `json:"txn_hash"`
+	Round        int64                `json:"round"`
+}
+
+// AllocationChargeTotal is the sum of the charges of a type paid to a
+// blobber, the blobber is empty for the charges not paid to blobbers.
+//
+// swagger:model AllocationChargeTotal
+type AllocationChargeTotal struct {
+	Type      AllocationChargeType `json:"type"`
+	BlobberID string               `json:"blobber_id"`
+	Amount    currency.Coin        `json:"amount"`
+	Count     int64                `json:"count"`
+}
+
+func (edb *EventDb) addAllocationCharge(c AllocationCharge) error {
+	return edb.Store.Get().Create(&c).Error
+}
+
+// AllocationChargesFilter selects the charges of the allocation and of
+// the client, if given, in the [From, To] timestamps range.
+type AllocationChargesFilter struct {
+	AllocationID string
+	ClientID     string
+	From         int64
+	To           int64
+}
+
+func (edb *EventDb) allocationChargesQuery(f AllocationChargesFilter) *gorm.DB {
+	query := edb.Store.Get().Model(&AllocationCharge{}).
+		Where("timestamp BETWEEN ? AND ?", f.From, f.To)
+	if f.AllocationID != "" {
+		query = query.Where("allocation_id = ?", f.AllocationID)
+	}
+	if f.ClientID != "" {
+		query = query.Where("client_id = ?", f.ClientID)
+	}
+	return query
+}
+
+// GetAllocationCharges returns the charges selected by the filter ordered
+// by time.
+func (edb *EventDb) GetAllocationCharges(
+	f AllocationChargesFilter, limit common2.Pagination,
+) ([]AllocationCharge, error) {
+	var charges []AllocationCharge
+	return charges, edb.allocationChargesQuery(f).
+		Offset(limit.Offset).Limit(limit.Limit).
+		Order(clause.OrderByColumn{Column: clause.Column{Name: "timestamp"}, Desc: limit.IsDescending}).
+		Order(clause.OrderByColumn{Column: clause.Column{Name: "id"}, Desc: limit.IsDescending}).
+		Find(&charges).Error
+}
+
+// EachAllocationChargesBatch calls the function with all the charges
+// selected by the filter, in batches of the size ordered by id.
+func (edb *EventDb) EachAllocationChargesBatch(
+	f AllocationChargesFilter, size int, fn func([]AllocationCharge) error,
+) error {
+	var charges []AllocationCharge
+	return edb.allocationChargesQuery(f).
+		FindInBatches(&charges, size, func(_ *gorm.DB, _ int) error {
+			return fn(charges)
+		}).Error
+}
+
+// GetAllocationChargeTotals returns the sums of the charges selected by the
+// filter by type and blobber.
+func (edb *EventDb) GetAllocationChargeTotals(f AllocationChargesFilter) ([]AllocationChargeTotal, error) {
+	var totals []AllocationChargeTotal
+	return totals, edb.allocationChargesQuery(f).
+		Select("type, blobber_id, SUM(amount) AS amount, COUNT(*) AS count").
+		Group("type, blobber_id").
+		Order("type, blobber_id").
+		Scan(&totals).Error
+}
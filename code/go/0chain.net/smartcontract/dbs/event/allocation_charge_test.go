@@ -0,0 +1,65 @@
+package event
+
+import (
+	"testing"
+
+	"0chain.net/chaincore/config"
+	common2 "0chain.net/smartcontract/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocationCharges(t *testing.T) {
+	eventDb, err := NewInMemoryEventDb(config.DbAccess{}, config.DbSettings{
+		PartitionChangePeriod: 1,
+	})
+	require.NoError(t, err)
+	defer eventDb.Close()
+	require.NoError(t, eventDb.Drop())
+	require.NoError(t, eventDb.AutoMigrate())
+
+	charges := []AllocationCharge{
+		{AllocationID: "alloc1", ClientID: "owner", BlobberID: "b1", Type: ChargeWrite, Amount: 100, Timestamp: 10},
+		{AllocationID: "alloc1", ClientID: "owner", BlobberID: "b2", Type: ChargeWrite, Amount: 100, Timestamp: 10},
+		{AllocationID: "alloc1", ClientID: "owner", BlobberID: "b1", Type: ChargeChallengeReward, Amount: 80, Timestamp: 20},
+		{AllocationID: "alloc1", ClientID: "owner", BlobberID: "b1", Type: ChargeRefund, Amount: 20, Timestamp: 20},
+		{AllocationID: "alloc1", ClientID: "reader", BlobberID: "b2", Type: ChargeRead, Amount: 5, Timestamp: 30},
+		{AllocationID: "alloc2", ClientID: "owner", BlobberID: "b1", Type: ChargeWrite, Amount: 50, Timestamp: 40},
+	}
+	for _, c := range charges {
+		require.NoError(t, eventDb.addAllocationCharge(c))
+	}
+
+	filter := AllocationChargesFilter{AllocationID: "alloc1", From: 10, To: 20}
+	got, err := eventDb.GetAllocationCharges(filter, common2.Pagination{Limit: 3})
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+	assert.Equal(t, ChargeWrite, got[0].Type)
+	assert.Equal(t, ChargeChallengeReward, got[2].Type)
+
+	got, err = eventDb.GetAllocationCharges(filter, common2.Pagination{Offset: 3, Limit: 3})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, ChargeRefund, got[0].Type)
+
+	totals, err := eventDb.GetAllocationChargeTotals(AllocationChargesFilter{ClientID: "owner", To: 100})
+	require.NoError(t, err)
+	assert.Equal(t, []AllocationChargeTotal{
+		{Type: ChargeChallengeReward, BlobberID: "b1", Amount: 80, Count: 1},
+		{Type: ChargeRefund, BlobberID: "b1", Amount: 20, Count: 1},
+		{Type: ChargeWrite, BlobberID: "b1", Amount: 150, Count: 2},
+		{Type: ChargeWrite, BlobberID: "b2", Amount: 100, Count: 1},
+	}, totals)
+
+	// all the charges regardless of the pagination
+	var batches, all int
+	err = eventDb.EachAllocationChargesBatch(AllocationChargesFilter{To: 100}, 4,
+		func(cs []AllocationCharge) error {
+			batches++
+			all += len(cs)
+			return nil
+		})
+	require.NoError(t, err)
+	assert.Equal(t, 2, batches)
+	assert.Equal(t, len(charges), all)
+}
@@ -0,0 +1,49 @@
+package event
+
+import (
+	common2 "0chain.net/smartcontract/common"
+	"0chain.net/smartcontract/dbs/model"
+	"github.com/0chain/common/core/currency"
+	"gorm.io/gorm/clause"
+)
+
+// AllocationRenewalWarning is emitted when the auto-renewal of an allocation
+// stops because its renewal budget or write pool can't cover the next
+// renewal period, or it can't be extended. The cost is zero if unknown.
+//
+// swagger:model AllocationRenewalWarning
+type AllocationRenewalWarning struct {
+	model.UpdatableModel
+	AllocationID string        `json:"allocation_id" gorm:"index:idx_arw_allocation"`
+	Owner        string        `json:"owner" gorm:"index:idx_arw_owner"`
+	Budget       currency.Coin `json:"budget"`
+	WritePool    currency.Coin `json:"write_pool"`
+	Cost         currency.Coin `json:"cost"`
+	Reason       string        `json:"reason"`
+	TxnHash      string        `json:"txn_hash"`
+	Round        int64         `json:"round"`
+}
+
+func (edb *EventDb) addAllocationRenewalWarning(w AllocationRenewalWarning) error {
+	return edb.Store.Get().Create(&w).Error
+}
+
+// GetAllocationRenewalWarnings returns the renewal warnings of the
+// allocation, if given, and of the allocations of the owner, if given.
+func (edb *EventDb) GetAllocationRenewalWarnings(
+	allocationID, owner string, limit common2.Pagination,
+) ([]AllocationRenewalWarning, error) {
+	query := edb.Store.Get().Model(&AllocationRenewalWarning{})
+	if allocationID != "" {
+		query = query.Where("allocation_id = ?", allocationID)
+	}
+	if owner != "" {
+		query = query.Where("owner = ?", owner)
+	}
+
+	var ws []AllocationRenewalWarning
+	return ws, query.Offset(limit.Offset).Limit(limit.Limit).Order(clause.OrderByColumn{
+		Column: clause.Column{Name: "round"},
+		Desc:   limit.IsDescending,
+	}).Find(&ws).Error
+}
@@ -51,7 +51,8 @@ type Blobber struct {
 	ChallengesPassed    uint64        `json:"challenges_passed"`
 	ChallengesCompleted uint64        `json:"challenges_completed"`
 	OpenChallenges      uint64        `json:"open_challenges"`
-	RankMetric          float64       `json:"rank_metric" gorm:"index"` // currently ChallengesPassed / ChallengesCompleted
+	RankMetric          float64       `json:"rank_metric" gorm:"index"`          // currently ChallengesPassed / ChallengesCompleted
+	Reputation          float64       `json:"reputation" gorm:"index;default:1"` // rolling challenges pass rate, see storagesc
 	TotalBlockRewards   currency.Coin `json:"total_block_rewards"`
 	TotalStorageIncome  currency.Coin `json:"total_storage_income"`
 	TotalReadIncome     currency.Coin `json:"total_read_income"`
@@ -59,6 +60,7 @@ type Blobber struct {
 
 	WriteMarkers []WriteMarker `gorm:"foreignKey:BlobberID;references:ID"`
 	ReadMarkers  []ReadMarker  `gorm:"foreignKey:BlobberID;references:ID"`
+	Tiers        []BlobberTier `json:"tiers" gorm:"foreignKey:BlobberID;references:ID"`
 
 	CreationRound int64 `json:"creation_round" gorm:"index:idx_blobber_creation_round"`
 }
@@ -73,6 +75,7 @@ func (edb *EventDb) GetBlobber(id string) (*Blobber, error) {
 	var blobber Blobber
 	err := edb.Store.Get().
 		Preload("Rewards").
+		Preload("Tiers").
 		Model(&Blobber{}).Where("id = ?", id).First(&blobber).Error
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving blobber %v, error %v", id, err)
@@ -84,6 +87,7 @@ func (edb *EventDb) GetBlobbers(limit common2.Pagination) ([]Blobber, error) {
 	var blobbers []Blobber
 	result := edb.Store.Get().
 		Preload("Rewards").
+		Preload("Tiers").
 		Model(&Blobber{}).Offset(limit.Offset).
 		Where("is_killed = ? AND is_shutdown = ?", false, false).
 		Limit(limit.Limit).
@@ -100,6 +104,7 @@ func (edb *EventDb) GetActiveBlobbers(limit common2.Pagination) ([]Blobber, erro
 	var blobbers []Blobber
 	result := edb.Store.Get().
 		Preload("Rewards").
+		Preload("Tiers").
 		Model(&Blobber{}).Offset(limit.Offset).
 		Where("last_health_check > ? AND is_killed = ? AND is_shutdown = ?",
 			common.ToTime(now).Add(-ActiveBlobbersTimeLimit).Unix(), false, false).
@@ -119,6 +124,10 @@ func (edb *EventDb) GetBlobbersByRank(limit common2.Pagination) ([]string, error
 		Select("id").
 		Where("is_killed = ? AND is_shutdown = ?", false, false).
 		Offset(limit.Offset).Limit(limit.Limit).
+		Order(clause.OrderByColumn{
+			Column: clause.Column{Name: "reputation"},
+			Desc:   true,
+		}).
 		Order(clause.OrderByColumn{
 			Column: clause.Column{Name: "rank_metric"},
 			Desc:   true,
@@ -145,12 +154,16 @@ func (edb *EventDb) GeBlobberByLatLong(
 
 func (edb *EventDb) GetBlobbersFromIDs(ids []string) ([]Blobber, error) {
 	var blobbers []Blobber
-	result := edb.Store.Get().Preload("Rewards").
+	result := edb.Store.Get().Preload("Rewards").Preload("Tiers").
+		Preload("Tiers").
 		Model(&Blobber{}).Order("id").Where("id IN ?", ids).Find(&blobbers)
 	return blobbers, result.Error
 }
 
 func (edb *EventDb) deleteBlobber(id string) error {
+	if err := edb.setBlobberTiers(BlobberTiers{BlobberID: id}); err != nil {
+		return err
+	}
 	return edb.Store.Get().Model(&Blobber{}).Where("id = ?", id).Delete(&Blobber{}).Error
 }
 
@@ -191,6 +204,8 @@ type AllocationQuery struct {
 	AllocationSize     int64
 	AllocationSizeInGB float64
 	NumberOfDataShards int
+	// Tier the blobbers should offer, the price ranges are of the tier.
+	Tier string
 }
 
 func (edb *EventDb) GetBlobberIdsFromUrls(urls []string, data common2.Pagination) ([]string, error) {
@@ -205,20 +220,27 @@ func (edb *EventDb) GetBlobberIdsFromUrls(urls []string, data common2.Pagination
 
 func (edb *EventDb) GetBlobbersFromParams(allocation AllocationQuery, limit common2.Pagination, now common.Timestamp) ([]string, error) {
 	dbStore := edb.Store.Get().Model(&Blobber{})
-	dbStore = dbStore.Where("read_price between ? and ?", allocation.ReadPriceRange.Min, allocation.ReadPriceRange.Max)
-	dbStore = dbStore.Where("write_price between ? and ?", allocation.WritePriceRange.Min, allocation.WritePriceRange.Max)
+	// the terms of the tier are used instead of the blobber ones
+	terms := "blobbers"
+	if allocation.Tier != "" {
+		terms = "blobber_tiers"
+		dbStore = dbStore.Joins("JOIN blobber_tiers ON blobber_tiers.blobber_id = blobbers.id AND blobber_tiers.name = ?",
+			allocation.Tier)
+	}
+	dbStore = dbStore.Where(terms+".read_price between ? and ?", allocation.ReadPriceRange.Min, allocation.ReadPriceRange.Max)
+	dbStore = dbStore.Where(terms+".write_price between ? and ?", allocation.WritePriceRange.Min, allocation.WritePriceRange.Max)
 	dbStore = dbStore.Where("capacity - allocated >= ?", allocation.AllocationSize)
 	dbStore = dbStore.Where("last_health_check > ?", common.ToTime(now).Add(-ActiveBlobbersTimeLimit).Unix())
-	dbStore = dbStore.Where("(total_stake - offers_total) > ? * write_price", allocation.AllocationSizeInGB)
+	dbStore = dbStore.Where("(total_stake - offers_total) > ? * "+terms+".write_price", allocation.AllocationSizeInGB)
 	dbStore = dbStore.Where("is_killed = false")
 	dbStore = dbStore.Where("is_shutdown = false")
 	dbStore = dbStore.Where("is_available = true")
 	dbStore = dbStore.Limit(limit.Limit).Offset(limit.Offset).Order(clause.OrderByColumn{
-		Column: clause.Column{Name: "write_price"},
+		Column: clause.Column{Table: terms, Name: "write_price"},
 		Desc:   limit.IsDescending,
 	})
 	var blobberIDs []string
-	return blobberIDs, dbStore.Select("id").Find(&blobberIDs).Error
+	return blobberIDs, dbStore.Select("blobbers.id").Find(&blobberIDs).Error
 }
 
 func (edb *EventDb) addBlobbers(blobbers []Blobber) error {
@@ -377,6 +399,23 @@ func (edb *EventDb) updateOpenBlobberChallenges(blobbers []Blobber) error {
 		AddUpdate("open_challenges", openChallengesList).Exec(edb).Error
 }
 
+func mergeUpdateBlobberReputationEvents() *eventsMergerImpl[Blobber] {
+	return newEventsMerger[Blobber](TagUpdateBlobberReputation, withUniqueEventOverwrite())
+}
+
+func (edb *EventDb) updateBlobberReputation(blobbers []Blobber) error {
+	blobberIdList := make([]string, 0, len(blobbers))
+	reputationList := make([]float64, 0, len(blobbers))
+
+	for _, blobber := range blobbers {
+		blobberIdList = append(blobberIdList, blobber.ID)
+		reputationList = append(reputationList, blobber.Reputation)
+	}
+
+	return CreateBuilder("blobbers", "id", blobberIdList).
+		AddUpdate("reputation", reputationList).Exec(edb).Error
+}
+
 func (edb *EventDb) updateBlobberChallenges(blobbers []Blobber) error {
 	blobberIdList := make([]string, 0, len(blobbers))
 	challengesPassedList := make([]uint64, 0, len(blobbers))
@@ -0,0 +1,45 @@
+package event
+
+import (
+	common2 "0chain.net/smartcontract/common"
+	"0chain.net/smartcontract/dbs/model"
+	"gorm.io/gorm/clause"
+)
+
+// BlobberReplacement is a killed or shut down blobber of an allocation
+// replaced by the smart contract. The new blobber restores the data of the
+// allocation from the other blobbers.
+//
+// swagger:model BlobberReplacement
+type BlobberReplacement struct {
+	model.UpdatableModel
+	AllocationID string `json:"allocation_id" gorm:"index:idx_brepl_allocation"`
+	OldBlobberID string `json:"old_blobber_id" gorm:"index:idx_brepl_old_blobber"`
+	NewBlobberID string `json:"new_blobber_id" gorm:"index:idx_brepl_new_blobber"`
+	TxnHash      string `json:"txn_hash"`
+	Round        int64  `json:"round"`
+}
+
+func (edb *EventDb) addBlobberReplacement(br BlobberReplacement) error {
+	return edb.Store.Get().Create(&br).Error
+}
+
+// GetBlobberReplacements returns the replacements of the allocation, if
+// given, and of the blobber, either replaced or replacing, if given.
+func (edb *EventDb) GetBlobberReplacements(
+	allocationID, blobberID string, limit common2.Pagination,
+) ([]BlobberReplacement, error) {
+	query := edb.Store.Get().Model(&BlobberReplacement{})
+	if allocationID != "" {
+		query = query.Where("allocation_id = ?", allocationID)
+	}
+	if blobberID != "" {
+		query = query.Where("old_blobber_id = ? OR new_blobber_id = ?", blobberID, blobberID)
+	}
+
+	var brs []BlobberReplacement
+	return brs, query.Offset(limit.Offset).Limit(limit.Limit).Order(clause.OrderByColumn{
+		Column: clause.Column{Name: "round"},
+		Desc:   limit.IsDescending,
+	}).Find(&brs).Error
+}
@@ -0,0 +1,41 @@
+package event
+
+import (
+	"time"
+
+	"0chain.net/smartcontract/dbs/model"
+	"github.com/0chain/common/core/currency"
+)
+
+// BlobberTier is a storage tier a blobber offers in addition to its default
+// terms, see storagesc.StorageTier.
+//
+// swagger:model BlobberTier
+type BlobberTier struct {
+	model.UpdatableModel
+	BlobberID                  string        `json:"blobber_id" gorm:"uniqueIndex:idx_btier_blobber_name,priority:1"`
+	Name                       string        `json:"name" gorm:"uniqueIndex:idx_btier_blobber_name,priority:2;index:idx_btier_name"`
+	ReadPrice                  currency.Coin `json:"read_price"`
+	WritePrice                 currency.Coin `json:"write_price"`
+	MinLockDemand              float64       `json:"min_lock_demand"`
+	LatencyClass               int           `json:"latency_class"`
+	MaxChallengeCompletionTime time.Duration `json:"max_challenge_completion_time"`
+}
+
+// BlobberTiers are all the storage tiers of a blobber, they replace the
+// ones it offered before.
+type BlobberTiers struct {
+	BlobberID string        `json:"blobber_id"`
+	Tiers     []BlobberTier `json:"tiers"`
+}
+
+func (edb *EventDb) setBlobberTiers(bt BlobberTiers) error {
+	err := edb.Store.Get().Where("blobber_id = ?", bt.BlobberID).Delete(&BlobberTier{}).Error
+	if err != nil || len(bt.Tiers) == 0 {
+		return err
+	}
+	for i := range bt.Tiers {
+		bt.Tiers[i].BlobberID = bt.BlobberID
+	}
+	return edb.Store.Get().Create(&bt.Tiers).Error
+}
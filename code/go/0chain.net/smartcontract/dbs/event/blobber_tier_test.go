@@ -0,0 +1,71 @@
+package event
+
+import (
+	"testing"
+	"time"
+
+	"0chain.net/core/common"
+	common2 "0chain.net/smartcontract/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobberTiers(t *testing.T) {
+	edb, clean := GetTestEventDB(t)
+	defer clean()
+
+	now := common.Now()
+	blobbers := make([]Blobber, 0, 2)
+	for _, id := range []string{"hot", "cold"} {
+		blobbers = append(blobbers, Blobber{
+			Provider: Provider{
+				ID:              id,
+				TotalStake:      1e12,
+				LastHealthCheck: now,
+			},
+			BaseURL:     id + ".com",
+			ReadPrice:   100,
+			WritePrice:  1000,
+			Capacity:    10 * 1024 * 1024 * 1024,
+			IsAvailable: true,
+		})
+	}
+	require.NoError(t, edb.addBlobbers(blobbers))
+	require.NoError(t, edb.setBlobberTiers(BlobberTiers{
+		BlobberID: "cold",
+		Tiers: []BlobberTier{
+			{Name: "archive", ReadPrice: 1, WritePrice: 10, LatencyClass: 3,
+				MaxChallengeCompletionTime: time.Minute},
+		},
+	}))
+
+	b, err := edb.GetBlobber("cold")
+	require.NoError(t, err)
+	require.Len(t, b.Tiers, 1)
+	require.Equal(t, "archive", b.Tiers[0].Name)
+	require.EqualValues(t, 10, b.Tiers[0].WritePrice)
+	require.Equal(t, time.Minute, b.Tiers[0].MaxChallengeCompletionTime)
+
+	query := AllocationQuery{AllocationSize: 1024, AllocationSizeInGB: 1}
+	query.ReadPriceRange.Max = 50
+	query.WritePriceRange.Max = 500
+	limit := common2.Pagination{Limit: 10}
+
+	// the default terms of both blobbers are out of the ranges
+	ids, err := edb.GetBlobbersFromParams(query, limit, now)
+	require.NoError(t, err)
+	require.Empty(t, ids)
+
+	query.Tier = "archive"
+	ids, err = edb.GetBlobbersFromParams(query, limit, now)
+	require.NoError(t, err)
+	require.Equal(t, []string{"cold"}, ids)
+
+	// the tiers are replaced
+	require.NoError(t, edb.setBlobberTiers(BlobberTiers{BlobberID: "cold"}))
+	ids, err = edb.GetBlobbersFromParams(query, limit, now)
+	require.NoError(t, err)
+	require.Empty(t, ids)
+	b, err = edb.GetBlobber("cold")
+	require.NoError(t, err)
+	require.Empty(t, b.Tiers)
+}
@@ -26,6 +26,8 @@ type DelegatePool struct {
 	Reward               currency.Coin     `json:"reward"`       // unclaimed reward
 	TotalReward          currency.Coin     `json:"total_reward"` // total reward paid to pool
 	TotalPenalty         currency.Coin     `json:"total_penalty"`
+	TotalCompound        currency.Coin     `json:"total_compound"` // total reward moved to balance
+	AutoCompound         bool              `json:"auto_compound"`
 	Status               spenum.PoolStatus `json:"status" gorm:"index:idx_dprov_active,priority:3;index:idx_ddel_active,priority:3;index:idx_dp_total_staked,priority:2"`
 	RoundCreated         int64             `json:"round_created"`
 	RoundPoolLastUpdated int64             `json:"round_pool_last_updated"`
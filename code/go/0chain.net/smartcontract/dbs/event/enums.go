@@ -112,6 +112,18 @@ const (
 	TagAddBridgeMint
 	TagKillProvider
 	TagShutdownProvider
+	TagAddOrOverwriteMultisigWallet
+	TagAddOrOverwriteMultisigProposal
+	TagDeleteMultisigProposal
+	TagAddMultisigVote
+	TagSetProviderUnbonding
+	TagAddBlobberReplacement
+	TagAddAllocationRenewalWarning
+	TagUpdateBlobberReputation
+	TagAddOrUpdateAllocationACL
+	TagDeleteAllocationACL
+	TagAddAllocationCharge
+	TagSetBlobberTiers
 	NumberOfTags
 )
 
@@ -206,6 +218,18 @@ func initTagString() {
 	TagString[TagAddBurnTicket] = "TagAddBurnTicket"
 	TagString[TagKillProvider] = "TagKillProvider"
 	TagString[TagShutdownProvider] = "TagShutdownProvider"
+	TagString[TagAddOrOverwriteMultisigWallet] = "TagAddOrOverwriteMultisigWallet"
+	TagString[TagAddOrOverwriteMultisigProposal] = "TagAddOrOverwriteMultisigProposal"
+	TagString[TagDeleteMultisigProposal] = "TagDeleteMultisigProposal"
+	TagString[TagAddMultisigVote] = "TagAddMultisigVote"
+	TagString[TagSetProviderUnbonding] = "TagSetProviderUnbonding"
+	TagString[TagAddBlobberReplacement] = "TagAddBlobberReplacement"
+	TagString[TagAddAllocationRenewalWarning] = "TagAddAllocationRenewalWarning"
+	TagString[TagUpdateBlobberReputation] = "TagUpdateBlobberReputation"
+	TagString[TagAddOrUpdateAllocationACL] = "TagAddOrUpdateAllocationACL"
+	TagString[TagDeleteAllocationACL] = "TagDeleteAllocationACL"
+	TagString[TagAddAllocationCharge] = "TagAddAllocationCharge"
+	TagString[TagSetBlobberTiers] = "TagSetBlobberTiers"
 	TagString[NumberOfTags] = "invalid"
 }
 
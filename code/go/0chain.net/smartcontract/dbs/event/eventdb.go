@@ -154,6 +154,17 @@ func (edb *EventDb) AutoMigrate() error {
 		&ChallengePool{},
 		&RewardDelegate{},
 		&RewardProvider{},
+		&RewardCompound{},
+		&MultisigWallet{},
+		&MultisigSigner{},
+		&MultisigProposal{},
+		&MultisigVote{},
+		&UnbondingEntry{},
+		&BlobberReplacement{},
+		&AllocationRenewalWarning{},
+		&AllocationACL{},
+		&AllocationCharge{},
+		&BlobberTier{},
 	); err != nil {
 		return err
 	}
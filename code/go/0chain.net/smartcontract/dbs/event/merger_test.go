@@ -675,6 +675,35 @@ func TestMergeStakePoolRewardsEvents(t *testing.T) {
 	}
 }
 
+func TestMergeStakePoolRewardsEventsCompounds(t *testing.T) {
+	withCompounds := func(e Event, compounds map[string]currency.Coin) Event {
+		spu := e.Data.(dbs.StakePoolReward)
+		spu.DelegateCompounds = compounds
+		e.Data = spu
+		return e
+	}
+
+	em := mergeStakePoolRewardsEvents()
+	for _, e := range []Event{
+		makeStakePoolRewardEvent("b_1", 100, map[string]currency.Coin{"bp_1": 10}, nil),
+		withCompounds(makeStakePoolRewardEvent("b_1", 100, map[string]currency.Coin{"bp_1": 10}, nil),
+			map[string]currency.Coin{"bp_1": 4}),
+		withCompounds(makeStakePoolRewardEvent("b_1", 100, map[string]currency.Coin{"bp_1": 10}, nil),
+			map[string]currency.Coin{"bp_1": 6}),
+	} {
+		require.True(t, em.filter(e))
+	}
+
+	mergedEvent, err := em.merge(0, "")
+	require.NoError(t, err)
+
+	poolRewards, ok := fromEvent[[]dbs.StakePoolReward](mergedEvent.Data)
+	require.True(t, ok)
+	require.Len(t, *poolRewards, 1)
+	require.EqualValues(t, 30, (*poolRewards)[0].DelegateRewards["bp_1"])
+	require.EqualValues(t, 10, (*poolRewards)[0].DelegateCompounds["bp_1"])
+}
+
 func makeStakePoolRewardEvent(id string, reward currency.Coin,
 	delegateRewards map[string]currency.Coin, delegatePenalties map[string]currency.Coin) Event {
 	return Event{
@@ -0,0 +1,153 @@
+package event
+
+import (
+	common2 "0chain.net/smartcontract/common"
+	"0chain.net/smartcontract/dbs/model"
+	"github.com/0chain/common/core/currency"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MultisigWallet is a registered multi-signature wallet with its current
+// signers and threshold.
+type MultisigWallet struct {
+	model.UpdatableModel
+	ClientID         string           `json:"client_id" gorm:"uniqueIndex"`
+	SignatureScheme  string           `json:"signature_scheme"`
+	PublicKey        string           `json:"public_key"`
+	NumRequired      int              `json:"num_required"`
+	SignerSetVersion int64            `json:"signer_set_version"`
+	Signers          []MultisigSigner `json:"signers" gorm:"-"`
+}
+
+// MultisigSigner is a signer of a multi-signature wallet.
+type MultisigSigner struct {
+	model.UpdatableModel
+	WalletID    string `json:"wallet_id" gorm:"uniqueIndex:idx_msig_signer,priority:1"`
+	ThresholdID string `json:"threshold_id" gorm:"uniqueIndex:idx_msig_signer,priority:2"`
+	PublicKey   string `json:"public_key"`
+}
+
+// MultisigProposal is a proposal of a multi-signature wallet collecting votes
+// of its signers. A proposal is removed when the smart contract prunes it.
+type MultisigProposal struct {
+	model.UpdatableModel
+	WalletID          string        `json:"wallet_id" gorm:"uniqueIndex:idx_msig_proposal,priority:1"`
+	ProposalID        string        `json:"proposal_id" gorm:"uniqueIndex:idx_msig_proposal,priority:2"`
+	ToClientID        string        `json:"to_client_id"`
+	Amount            currency.Coin `json:"amount"`
+	FunctionName      string        `json:"function_name,omitempty"`
+	InputData         string        `json:"input_data,omitempty"`
+	ExpirationDate    int64         `json:"expiration_date" gorm:"index"`
+	NumVotes          int           `json:"num_votes"`
+	NumRequired       int           `json:"num_required"`
+	SignerSetVersion  int64         `json:"signer_set_version"`
+	ExecutedInTxnHash string        `json:"executed_in_txn_hash"`
+}
+
+// MultisigVote is a vote of a signer for a proposal of a multi-signature
+// wallet.
+type MultisigVote struct {
+	model.UpdatableModel
+	WalletID          string `json:"wallet_id" gorm:"index:idx_msig_vote,priority:1"`
+	ProposalID        string `json:"proposal_id" gorm:"index:idx_msig_vote,priority:2"`
+	SignerThresholdID string `json:"signer_threshold_id"`
+	TxnHash           string `json:"txn_hash"`
+	Round             int64  `json:"round"`
+}
+
+func (edb *EventDb) GetMultisigWallet(clientID string) (MultisigWallet, error) {
+	var w MultisigWallet
+	err := edb.Store.Get().Model(&MultisigWallet{}).
+		Where("client_id = ?", clientID).
+		Take(&w).Error
+	if err != nil {
+		return w, err
+	}
+
+	return w, edb.Store.Get().Model(&MultisigSigner{}).
+		Where("wallet_id = ?", clientID).
+		Order("id").
+		Find(&w.Signers).Error
+}
+
+// GetMultisigProposals returns proposals of the wallet not executed and not
+// expired at the given time.
+func (edb *EventDb) GetMultisigProposals(walletID string, now int64, limit common2.Pagination) ([]MultisigProposal, error) {
+	var proposals []MultisigProposal
+	return proposals, edb.Store.Get().Model(&MultisigProposal{}).
+		Where("wallet_id = ? AND executed_in_txn_hash = '' AND expiration_date > ?", walletID, now).
+		Offset(limit.Offset).Limit(limit.Limit).Order(clause.OrderByColumn{
+		Column: clause.Column{Name: "id"},
+		Desc:   limit.IsDescending,
+	}).Find(&proposals).Error
+}
+
+func (edb *EventDb) GetMultisigProposal(walletID, proposalID string) (MultisigProposal, error) {
+	var p MultisigProposal
+	return p, edb.Store.Get().Model(&MultisigProposal{}).
+		Where("wallet_id = ? AND proposal_id = ?", walletID, proposalID).
+		Take(&p).Error
+}
+
+func (edb *EventDb) GetMultisigVotes(walletID, proposalID string, limit common2.Pagination) ([]MultisigVote, error) {
+	var votes []MultisigVote
+	return votes, edb.Store.Get().Model(&MultisigVote{}).
+		Where("wallet_id = ? AND proposal_id = ?", walletID, proposalID).
+		Offset(limit.Offset).Limit(limit.Limit).Order(clause.OrderByColumn{
+		Column: clause.Column{Name: "id"},
+		Desc:   limit.IsDescending,
+	}).Find(&votes).Error
+}
+
+// addOrOverwriteMultisigWallet saves the wallet and replaces its signers.
+func (edb *EventDb) addOrOverwriteMultisigWallet(w MultisigWallet) error {
+	db := edb.Store.Get()
+	err := db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "client_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"updated_at",
+			"signature_scheme", "public_key", "num_required", "signer_set_version"}),
+	}).Create(&w).Error
+	if err != nil {
+		return err
+	}
+
+	err = db.Where("wallet_id = ?", w.ClientID).Delete(&MultisigSigner{}).Error
+	if err != nil {
+		return err
+	}
+	if len(w.Signers) == 0 {
+		return nil
+	}
+	for i := range w.Signers {
+		w.Signers[i].WalletID = w.ClientID
+	}
+	return db.Create(&w.Signers).Error
+}
+
+func (edb *EventDb) addOrOverwriteMultisigProposal(p MultisigProposal) error {
+	return edb.Store.Get().Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "wallet_id"}, {Name: "proposal_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"updated_at",
+			"to_client_id", "amount", "function_name", "input_data",
+			"expiration_date", "num_votes", "num_required",
+			"signer_set_version", "executed_in_txn_hash"}),
+	}).Create(&p).Error
+}
+
+// deleteMultisigProposal removes a pruned proposal with its votes.
+func (edb *EventDb) deleteMultisigProposal(p MultisigProposal) error {
+	return edb.Store.Get().Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("wallet_id = ? AND proposal_id = ?", p.WalletID, p.ProposalID).
+			Delete(&MultisigVote{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("wallet_id = ? AND proposal_id = ?", p.WalletID, p.ProposalID).
+			Delete(&MultisigProposal{}).Error
+	})
+}
+
+func (edb *EventDb) addMultisigVote(v MultisigVote) error {
+	return edb.Store.Get().Create(&v).Error
+}
@@ -0,0 +1,98 @@
+package event
+
+import (
+	"testing"
+
+	common2 "0chain.net/smartcontract/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultisigWallet(t *testing.T) {
+	eventDb, clean := GetTestEventDB(t)
+	defer clean()
+
+	w := MultisigWallet{
+		ClientID:        "wallet",
+		SignatureScheme: "bls0chain",
+		PublicKey:       "key",
+		NumRequired:     2,
+		Signers: []MultisigSigner{
+			{ThresholdID: "id1", PublicKey: "key1"},
+			{ThresholdID: "id2", PublicKey: "key2"},
+			{ThresholdID: "id3", PublicKey: "key3"},
+		},
+	}
+	require.NoError(t, eventDb.addOrOverwriteMultisigWallet(w))
+
+	got, err := eventDb.GetMultisigWallet("wallet")
+	require.NoError(t, err)
+	require.Equal(t, 2, got.NumRequired)
+	require.Len(t, got.Signers, 3)
+
+	// rotate the signers
+	w.Signers = w.Signers[1:]
+	w.NumRequired = 2
+	w.SignerSetVersion = 1
+	require.NoError(t, eventDb.addOrOverwriteMultisigWallet(w))
+
+	got, err = eventDb.GetMultisigWallet("wallet")
+	require.NoError(t, err)
+	require.EqualValues(t, 1, got.SignerSetVersion)
+	require.Len(t, got.Signers, 2)
+	require.Equal(t, "id2", got.Signers[0].ThresholdID)
+}
+
+func TestMultisigProposals(t *testing.T) {
+	eventDb, clean := GetTestEventDB(t)
+	defer clean()
+
+	limit := common2.Pagination{Limit: 20}
+
+	for _, p := range []MultisigProposal{
+		{WalletID: "wallet", ProposalID: "open", ExpirationDate: 200, NumVotes: 1},
+		{WalletID: "wallet", ProposalID: "expired", ExpirationDate: 50, NumVotes: 1},
+		{WalletID: "wallet", ProposalID: "executed", ExpirationDate: 200,
+			NumVotes: 2, ExecutedInTxnHash: "hash"},
+		{WalletID: "other", ProposalID: "open", ExpirationDate: 200},
+	} {
+		require.NoError(t, eventDb.addOrOverwriteMultisigProposal(p))
+	}
+
+	proposals, err := eventDb.GetMultisigProposals("wallet", 100, limit)
+	require.NoError(t, err)
+	require.Len(t, proposals, 1)
+	require.Equal(t, "open", proposals[0].ProposalID)
+
+	// the second vote
+	require.NoError(t, eventDb.addMultisigVote(MultisigVote{
+		WalletID: "wallet", ProposalID: "open", SignerThresholdID: "id1",
+	}))
+	require.NoError(t, eventDb.addMultisigVote(MultisigVote{
+		WalletID: "wallet", ProposalID: "open", SignerThresholdID: "id2",
+	}))
+	require.NoError(t, eventDb.addOrOverwriteMultisigProposal(MultisigProposal{
+		WalletID: "wallet", ProposalID: "open", ExpirationDate: 200, NumVotes: 2,
+	}))
+
+	p, err := eventDb.GetMultisigProposal("wallet", "open")
+	require.NoError(t, err)
+	require.Equal(t, 2, p.NumVotes)
+
+	votes, err := eventDb.GetMultisigVotes("wallet", "open", limit)
+	require.NoError(t, err)
+	require.Len(t, votes, 2)
+
+	// pruned
+	require.NoError(t, eventDb.deleteMultisigProposal(MultisigProposal{
+		WalletID: "wallet", ProposalID: "open",
+	}))
+	_, err = eventDb.GetMultisigProposal("wallet", "open")
+	require.Error(t, err)
+
+	votes, err = eventDb.GetMultisigVotes("wallet", "open", limit)
+	require.NoError(t, err)
+	require.Empty(t, votes)
+
+	_, err = eventDb.GetMultisigProposal("other", "open")
+	require.NoError(t, err)
+}
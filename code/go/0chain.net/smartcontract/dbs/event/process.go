@@ -106,6 +106,7 @@ func mergeEvents(round int64, block string, events []Event) ([]Event, error) {
 
 			mergeUpdateBlobberChallengesEvents(),
 			mergeAddChallengesToBlobberEvents(),
+			mergeUpdateBlobberReputationEvents(),
 			mergeUpdateAllocChallengesEvents(),
 
 			mergeUpdateBlobbersEvents(),
@@ -737,6 +738,12 @@ func (edb *EventDb) addStat(event Event) (err error) {
 		}
 
 		return edb.updateBlobberChallenges(*bs)
+	case TagUpdateBlobberReputation:
+		bs, ok := fromEvent[[]Blobber](event.Data)
+		if !ok {
+			return ErrInvalidEventData
+		}
+		return edb.updateBlobberReputation(*bs)
 
 	case TagUpdateAllocationChallenge:
 		as, ok := fromEvent[[]Allocation](event.Data)
@@ -883,6 +890,82 @@ func (edb *EventDb) addStat(event Event) (err error) {
 			return ErrInvalidEventData
 		}
 		return edb.providersSetBoolean(*u, "is_killed", true)
+	case TagAddOrOverwriteMultisigWallet:
+		w, ok := fromEvent[MultisigWallet](event.Data)
+		if !ok {
+			return ErrInvalidEventData
+		}
+		return edb.addOrOverwriteMultisigWallet(*w)
+	case TagAddOrOverwriteMultisigProposal:
+		p, ok := fromEvent[MultisigProposal](event.Data)
+		if !ok {
+			return ErrInvalidEventData
+		}
+		return edb.addOrOverwriteMultisigProposal(*p)
+	case TagDeleteMultisigProposal:
+		p, ok := fromEvent[MultisigProposal](event.Data)
+		if !ok {
+			return ErrInvalidEventData
+		}
+		return edb.deleteMultisigProposal(*p)
+	case TagAddMultisigVote:
+		v, ok := fromEvent[MultisigVote](event.Data)
+		if !ok {
+			return ErrInvalidEventData
+		}
+		v.TxnHash = event.TxHash
+		v.Round = event.BlockNumber
+		return edb.addMultisigVote(*v)
+	case TagSetProviderUnbonding:
+		pu, ok := fromEvent[ProviderUnbonding](event.Data)
+		if !ok {
+			return ErrInvalidEventData
+		}
+		return edb.setProviderUnbonding(*pu)
+	case TagAddBlobberReplacement:
+		br, ok := fromEvent[BlobberReplacement](event.Data)
+		if !ok {
+			return ErrInvalidEventData
+		}
+		br.TxnHash = event.TxHash
+		br.Round = event.BlockNumber
+		return edb.addBlobberReplacement(*br)
+	case TagAddAllocationRenewalWarning:
+		w, ok := fromEvent[AllocationRenewalWarning](event.Data)
+		if !ok {
+			return ErrInvalidEventData
+		}
+		w.TxnHash = event.TxHash
+		w.Round = event.BlockNumber
+		return edb.addAllocationRenewalWarning(*w)
+	case TagAddOrUpdateAllocationACL:
+		acl, ok := fromEvent[AllocationACL](event.Data)
+		if !ok {
+			return ErrInvalidEventData
+		}
+		acl.TxnHash = event.TxHash
+		acl.Round = event.BlockNumber
+		return edb.addOrUpdateAllocationACL(*acl)
+	case TagDeleteAllocationACL:
+		acl, ok := fromEvent[AllocationACL](event.Data)
+		if !ok {
+			return ErrInvalidEventData
+		}
+		return edb.deleteAllocationACL(*acl)
+	case TagAddAllocationCharge:
+		c, ok := fromEvent[AllocationCharge](event.Data)
+		if !ok {
+			return ErrInvalidEventData
+		}
+		c.TxnHash = event.TxHash
+		c.Round = event.BlockNumber
+		return edb.addAllocationCharge(*c)
+	case TagSetBlobberTiers:
+		bt, ok := fromEvent[BlobberTiers](event.Data)
+		if !ok {
+			return ErrInvalidEventData
+		}
+		return edb.setBlobberTiers(*bt)
 	default:
 		return nil
 	}
@@ -0,0 +1,49 @@
+package event
+
+import (
+	"0chain.net/smartcontract/dbs"
+	"0chain.net/smartcontract/dbs/model"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/currency"
+)
+
+// RewardCompound is a reward of an auto-compounding delegate pool moved into
+// its stake, unlike RewardMint which records the rewards collected.
+//
+// swagger:model RewardCompound
+type RewardCompound struct {
+	model.UpdatableModel
+	Amount       currency.Coin   `json:"amount"`
+	BlockNumber  int64           `json:"block_number" gorm:"index:idx_rew_comp_pool,priority:2"`
+	PoolID       string          `json:"pool_id" gorm:"index:idx_rew_comp_pool,priority:1"`
+	ProviderType spenum.Provider `json:"provider_type"`
+	ProviderID   string          `json:"provider_id"`
+}
+
+func (edb *EventDb) insertRewardCompounds(spus []dbs.StakePoolReward, round int64) error {
+	var rcs []RewardCompound
+	for _, sp := range spus {
+		for poolId, amount := range sp.DelegateCompounds {
+			rcs = append(rcs, RewardCompound{
+				Amount:       amount,
+				BlockNumber:  round,
+				PoolID:       poolId,
+				ProviderType: sp.Type,
+				ProviderID:   sp.ID,
+			})
+		}
+	}
+	if len(rcs) == 0 {
+		return nil
+	}
+	return edb.Get().Create(&rcs).Error
+}
+
+func (edb *EventDb) GetRewardCompounds(poolID, providerID string, start, end int64) ([]RewardCompound, error) {
+	var rcs []RewardCompound
+	return rcs, edb.Get().Model(&RewardCompound{}).
+		Where("pool_id = ? AND provider_id = ? AND block_number >= ? AND block_number < ?",
+			poolID, providerID, start, end).
+		Order("block_number").
+		Find(&rcs).Error
+}
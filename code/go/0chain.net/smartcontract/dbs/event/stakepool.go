@@ -24,6 +24,19 @@ type providerPenaltiesDelegates struct {
 	delegatePools map[string]map[string]currency.Coin
 }
 
+func aggregateProviderCompounds(spus []dbs.StakePoolReward) map[string]map[string]currency.Coin {
+	dpCompoundsMap := make(map[string]map[string]currency.Coin)
+	for _, sp := range spus {
+		for poolId, c := range sp.DelegateCompounds {
+			if _, found := dpCompoundsMap[sp.ID]; !found {
+				dpCompoundsMap[sp.ID] = make(map[string]currency.Coin, len(sp.DelegateCompounds))
+			}
+			dpCompoundsMap[sp.ID][poolId] = dpCompoundsMap[sp.ID][poolId] + c
+		}
+	}
+	return dpCompoundsMap
+}
+
 func aggregateProviderRewards(spus []dbs.StakePoolReward) (*providerRewardsDelegates, error) {
 	var (
 		rewardsMap   = make(map[string]currency.Coin)
@@ -106,6 +119,14 @@ func withProviderRewardsPenaltiesAdded() eventMergeMiddleware {
 			a.DelegatePenalties[k] += v
 		}
 
+		// merge delegate pool compounded rewards
+		if a.DelegateCompounds == nil && len(b.DelegateCompounds) > 0 {
+			a.DelegateCompounds = make(map[string]currency.Coin, len(b.DelegateCompounds))
+		}
+		for k, v := range b.DelegateCompounds {
+			a.DelegateCompounds[k] += v
+		}
+
 		return a, nil
 	})
 }
@@ -153,6 +174,16 @@ func (edb *EventDb) rewardUpdate(spus []dbs.StakePoolReward, round int64) error
 		}
 	}
 
+	// compounded rewards are applied after the rewards they were taken from
+	if compounds := aggregateProviderCompounds(spus); len(compounds) > 0 {
+		if err := edb.compoundProviderDelegates(compounds, round); err != nil {
+			return fmt.Errorf("could not compound delegate pool rewards: %v", err)
+		}
+		if err := edb.insertRewardCompounds(spus, round); err != nil {
+			return err
+		}
+	}
+
 	if edb.Debug() {
 		if err := edb.insertProviderReward(spus, round); err != nil {
 			return err
@@ -252,6 +283,29 @@ func (edb *EventDb) rewardProviderDelegates(dps map[string]map[string]currency.C
 	return ret.Error
 }
 
+func (edb *EventDb) compoundProviderDelegates(dps map[string]map[string]currency.Coin, round int64) error {
+	var poolIds []string
+	var providerIds []string
+	var compound []uint64
+	var lastUpdated []uint64
+	for id, pools := range dps {
+		for poolId, c := range pools {
+			poolIds = append(poolIds, poolId)
+			providerIds = append(providerIds, id)
+			compound = append(compound, uint64(c))
+			lastUpdated = append(lastUpdated, uint64(round))
+		}
+	}
+
+	return CreateBuilder("delegate_pools", "pool_id", poolIds).
+		AddCompositeId("provider_id", providerIds).
+		AddUpdate("balance", compound, "delegate_pools.balance + t.balance").
+		AddUpdate("reward", compound, "delegate_pools.reward - t.reward").
+		AddUpdate("total_compound", compound, "delegate_pools.total_compound + t.total_compound").
+		AddUpdate("round_pool_last_updated", lastUpdated).
+		Exec(edb).Error
+}
+
 func (edb *EventDb) penaltyProviderDelegates(dps map[string]map[string]currency.Coin, round int64) error {
 
 	var poolIds []string
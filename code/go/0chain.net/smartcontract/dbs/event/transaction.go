@@ -26,10 +26,13 @@ type Transaction struct {
 	CreationDate      int64         `json:"creation_date"  gorm:"index:idx_tcreation_date"`
 	Fee               currency.Coin `json:"fee"`
 	Nonce             int64         `json:"nonce"`
+	MaxCost           int           `json:"max_cost"`
 	TransactionType   int           `json:"transaction_type"`
 	TransactionOutput string        `json:"transaction_output"`
 	OutputHash        string        `json:"output_hash"`
 	Status            int           `json:"status"`
+	Cost              int           `json:"cost"`
+	PriorityFee       currency.Coin `json:"priority_fee"`
 }
 
 type TransactionErrors struct {
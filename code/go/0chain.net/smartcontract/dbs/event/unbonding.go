@@ -0,0 +1,59 @@
+package event
+
+import (
+	common2 "0chain.net/smartcontract/common"
+	"0chain.net/smartcontract/dbs/model"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/currency"
+	"gorm.io/gorm/clause"
+)
+
+// UnbondingEntry is stake unlocked from a delegate pool waiting for the
+// unbonding period to be over before it can be withdrawn.
+//
+// swagger:model UnbondingEntry
+type UnbondingEntry struct {
+	model.UpdatableModel
+	DelegateID   string          `json:"delegate_id" gorm:"index:idx_unbonding_delegate"`
+	ProviderID   string          `json:"provider_id" gorm:"index:idx_unbonding_provider,priority:1"`
+	ProviderType spenum.Provider `json:"provider_type" gorm:"index:idx_unbonding_provider,priority:2"`
+	Amount       currency.Coin   `json:"amount"`
+	MaturesAt    int64           `json:"matures_at"` // round the stake can be withdrawn
+}
+
+// ProviderUnbonding is the unbonding queue of the stake pool of a provider.
+type ProviderUnbonding struct {
+	ProviderID   string
+	ProviderType spenum.Provider
+	Entries      []UnbondingEntry
+}
+
+// setProviderUnbonding replaces the unbonding entries of the provider.
+func (edb *EventDb) setProviderUnbonding(pu ProviderUnbonding) error {
+	err := edb.Store.Get().
+		Where("provider_id = ? AND provider_type = ?", pu.ProviderID, pu.ProviderType).
+		Delete(&UnbondingEntry{}).Error
+	if err != nil || len(pu.Entries) == 0 {
+		return err
+	}
+
+	for i := range pu.Entries {
+		pu.Entries[i].ProviderID = pu.ProviderID
+		pu.Entries[i].ProviderType = pu.ProviderType
+	}
+	return edb.Store.Get().Create(&pu.Entries).Error
+}
+
+// GetUserUnbondingEntries returns the unbonding entries of the client for
+// the stake pools of the given types of providers.
+func (edb *EventDb) GetUserUnbondingEntries(
+	clientID string, providerTypes []spenum.Provider, limit common2.Pagination,
+) ([]UnbondingEntry, error) {
+	var entries []UnbondingEntry
+	return entries, edb.Store.Get().Model(&UnbondingEntry{}).
+		Where("delegate_id = ? AND provider_type IN ?", clientID, providerTypes).
+		Offset(limit.Offset).Limit(limit.Limit).Order(clause.OrderByColumn{
+		Column: clause.Column{Name: "matures_at"},
+		Desc:   limit.IsDescending,
+	}).Find(&entries).Error
+}
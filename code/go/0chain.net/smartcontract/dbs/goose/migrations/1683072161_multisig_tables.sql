@@ -0,0 +1,67 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE multisig_wallets (
+    id bigserial PRIMARY KEY,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    client_id text,
+    signature_scheme text,
+    public_key text,
+    num_required bigint,
+    signer_set_version bigint
+);
+
+CREATE UNIQUE INDEX idx_multisig_wallets_client_id ON multisig_wallets USING btree (client_id);
+
+CREATE TABLE multisig_signers (
+    id bigserial PRIMARY KEY,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    wallet_id text,
+    threshold_id text,
+    public_key text
+);
+
+CREATE UNIQUE INDEX idx_msig_signer ON multisig_signers USING btree (wallet_id, threshold_id);
+
+CREATE TABLE multisig_proposals (
+    id bigserial PRIMARY KEY,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    wallet_id text,
+    proposal_id text,
+    to_client_id text,
+    amount bigint,
+    function_name text,
+    input_data text,
+    expiration_date bigint,
+    num_votes bigint,
+    num_required bigint,
+    signer_set_version bigint,
+    executed_in_txn_hash text
+);
+
+CREATE UNIQUE INDEX idx_msig_proposal ON multisig_proposals USING btree (wallet_id, proposal_id);
+CREATE INDEX idx_multisig_proposals_expiration_date ON multisig_proposals USING btree (expiration_date);
+
+CREATE TABLE multisig_votes (
+    id bigserial PRIMARY KEY,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    wallet_id text,
+    proposal_id text,
+    signer_threshold_id text,
+    txn_hash text,
+    round bigint
+);
+
+CREATE INDEX idx_msig_vote ON multisig_votes USING btree (wallet_id, proposal_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS multisig_votes;
+DROP TABLE IF EXISTS multisig_proposals;
+DROP TABLE IF EXISTS multisig_signers;
+DROP TABLE IF EXISTS multisig_wallets;
+-- +goose StatementEnd
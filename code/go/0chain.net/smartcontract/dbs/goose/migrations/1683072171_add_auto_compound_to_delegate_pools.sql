@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE delegate_pools ADD COLUMN IF NOT EXISTS auto_compound boolean DEFAULT false;
+ALTER TABLE delegate_pools ADD COLUMN IF NOT EXISTS total_compound bigint DEFAULT 0;
+
+CREATE TABLE reward_compounds (
+    id bigserial PRIMARY KEY,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    amount bigint,
+    block_number bigint,
+    pool_id text,
+    provider_type bigint,
+    provider_id text
+);
+
+CREATE INDEX idx_rew_comp_pool ON reward_compounds USING btree (pool_id, block_number);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_rew_comp_pool;
+DROP TABLE IF EXISTS reward_compounds;
+ALTER TABLE delegate_pools DROP COLUMN IF EXISTS total_compound;
+ALTER TABLE delegate_pools DROP COLUMN IF EXISTS auto_compound;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE unbonding_entries (
+    id bigserial PRIMARY KEY,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    delegate_id text,
+    provider_id text,
+    provider_type bigint,
+    amount bigint,
+    matures_at bigint
+);
+
+CREATE INDEX idx_unbonding_delegate ON unbonding_entries USING btree (delegate_id);
+CREATE INDEX idx_unbonding_provider ON unbonding_entries USING btree (provider_id, provider_type);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS unbonding_entries;
+-- +goose StatementEnd
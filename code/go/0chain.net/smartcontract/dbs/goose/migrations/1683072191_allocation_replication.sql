@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE allocations ADD COLUMN IF NOT EXISTS auto_replace_blobbers boolean DEFAULT false;
+ALTER TABLE allocations ADD COLUMN IF NOT EXISTS degraded boolean DEFAULT false;
+
+CREATE TABLE blobber_replacements (
+    id bigserial PRIMARY KEY,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    allocation_id text,
+    old_blobber_id text,
+    new_blobber_id text,
+    txn_hash text,
+    round bigint
+);
+
+CREATE INDEX idx_brepl_allocation ON blobber_replacements USING btree (allocation_id);
+CREATE INDEX idx_brepl_old_blobber ON blobber_replacements USING btree (old_blobber_id);
+CREATE INDEX idx_brepl_new_blobber ON blobber_replacements USING btree (new_blobber_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS blobber_replacements;
+ALTER TABLE allocations DROP COLUMN IF EXISTS degraded;
+ALTER TABLE allocations DROP COLUMN IF EXISTS auto_replace_blobbers;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE allocations ADD COLUMN IF NOT EXISTS pending_owner text DEFAULT '';
+ALTER TABLE allocations ADD COLUMN IF NOT EXISTS pending_owner_public_key text DEFAULT '';
+ALTER TABLE allocations ADD COLUMN IF NOT EXISTS pending_owner_expiration bigint DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE allocations DROP COLUMN IF EXISTS pending_owner_expiration;
+ALTER TABLE allocations DROP COLUMN IF EXISTS pending_owner_public_key;
+ALTER TABLE allocations DROP COLUMN IF EXISTS pending_owner;
+-- +goose StatementEnd
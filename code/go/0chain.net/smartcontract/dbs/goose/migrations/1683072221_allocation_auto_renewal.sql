@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE allocations ADD COLUMN IF NOT EXISTS auto_renew boolean DEFAULT false;
+ALTER TABLE allocations ADD COLUMN IF NOT EXISTS renewal_budget bigint DEFAULT 0;
+
+CREATE TABLE allocation_renewal_warnings (
+    id bigserial PRIMARY KEY,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    allocation_id text,
+    owner text,
+    budget bigint,
+    write_pool bigint,
+    cost bigint,
+    reason text,
+    txn_hash text,
+    round bigint
+);
+
+CREATE INDEX idx_arw_allocation ON allocation_renewal_warnings USING btree (allocation_id);
+CREATE INDEX idx_arw_owner ON allocation_renewal_warnings USING btree (owner);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS allocation_renewal_warnings;
+ALTER TABLE allocations DROP COLUMN IF EXISTS renewal_budget;
+ALTER TABLE allocations DROP COLUMN IF EXISTS auto_renew;
+-- +goose StatementEnd
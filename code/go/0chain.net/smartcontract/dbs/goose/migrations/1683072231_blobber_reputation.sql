@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE blobbers ADD COLUMN IF NOT EXISTS reputation numeric DEFAULT 1;
+CREATE INDEX idx_blobbers_reputation ON blobbers USING btree (reputation);
+ALTER TABLE allocations ADD COLUMN IF NOT EXISTS min_reputation numeric DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE allocations DROP COLUMN IF EXISTS min_reputation;
+DROP INDEX IF EXISTS idx_blobbers_reputation;
+ALTER TABLE blobbers DROP COLUMN IF EXISTS reputation;
+-- +goose StatementEnd
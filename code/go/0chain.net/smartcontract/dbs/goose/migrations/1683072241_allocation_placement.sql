@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE allocations ADD COLUMN IF NOT EXISTS placement_min_distance_km numeric DEFAULT 0;
+ALTER TABLE allocations ADD COLUMN IF NOT EXISTS placement_min_regions bigint DEFAULT 0;
+ALTER TABLE allocations ADD COLUMN IF NOT EXISTS placement_min_latitude numeric DEFAULT 0;
+ALTER TABLE allocations ADD COLUMN IF NOT EXISTS placement_max_latitude numeric DEFAULT 0;
+ALTER TABLE allocations ADD COLUMN IF NOT EXISTS placement_min_longitude numeric DEFAULT 0;
+ALTER TABLE allocations ADD COLUMN IF NOT EXISTS placement_max_longitude numeric DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE allocations DROP COLUMN IF EXISTS placement_max_longitude;
+ALTER TABLE allocations DROP COLUMN IF EXISTS placement_min_longitude;
+ALTER TABLE allocations DROP COLUMN IF EXISTS placement_max_latitude;
+ALTER TABLE allocations DROP COLUMN IF EXISTS placement_min_latitude;
+ALTER TABLE allocations DROP COLUMN IF EXISTS placement_min_regions;
+ALTER TABLE allocations DROP COLUMN IF EXISTS placement_min_distance_km;
+-- +goose StatementEnd
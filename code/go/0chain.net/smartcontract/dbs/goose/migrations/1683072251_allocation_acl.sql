@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE allocation_acls (
+    id bigserial PRIMARY KEY,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    allocation_id text,
+    client_id text,
+    permissions bigint,
+    txn_hash text,
+    round bigint
+);
+
+CREATE UNIQUE INDEX idx_aacl_alloc_client ON allocation_acls USING btree (allocation_id, client_id);
+CREATE INDEX idx_aacl_client ON allocation_acls USING btree (client_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS allocation_acls;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE allocation_charges (
+    id bigserial PRIMARY KEY,
+    created_at timestamp with time zone,
+    allocation_id text,
+    client_id text,
+    blobber_id text,
+    type text,
+    amount bigint,
+    "timestamp" bigint,
+    txn_hash text,
+    round bigint
+);
+
+CREATE INDEX idx_acharge_alloc_ts ON allocation_charges USING btree (allocation_id, "timestamp");
+CREATE INDEX idx_acharge_client_ts ON allocation_charges USING btree (client_id, "timestamp");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS allocation_charges;
+-- +goose StatementEnd
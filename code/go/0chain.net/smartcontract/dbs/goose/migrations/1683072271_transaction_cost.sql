@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS max_cost bigint DEFAULT 0;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS cost bigint DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE transactions DROP COLUMN IF EXISTS cost;
+ALTER TABLE transactions DROP COLUMN IF EXISTS max_cost;
+-- +goose StatementEnd
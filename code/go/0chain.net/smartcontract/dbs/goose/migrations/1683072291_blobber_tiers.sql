@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE blobber_tiers (
+    id bigserial PRIMARY KEY,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    blobber_id text,
+    name text,
+    read_price bigint,
+    write_price bigint,
+    min_lock_demand numeric,
+    latency_class bigint,
+    max_challenge_completion_time bigint
+);
+
+CREATE UNIQUE INDEX idx_btier_blobber_name ON blobber_tiers USING btree (blobber_id, name);
+CREATE INDEX idx_btier_name ON blobber_tiers USING btree (name);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS blobber_tiers;
+-- +goose StatementEnd
@@ -46,6 +46,8 @@ type StakePoolReward struct {
 	DelegateRewards map[string]currency.Coin `json:"delegate_rewards"`
 	// penalties delegate pools
 	DelegatePenalties map[string]currency.Coin `json:"delegate_penalties"`
+	// rewards of auto-compounding delegate pools moved to their stake
+	DelegateCompounds map[string]currency.Coin `json:"delegate_compounds,omitempty"`
 	// challenge id
 	ChallengeID string `json:"challenge_id"`
 }
@@ -87,7 +87,10 @@ func (tb *testBalances) GetMints() []*state.Mint                    { return nil
 func (tb *testBalances) SetStateContext(*state.State) error         { return nil }
 func (tb *testBalances) GetTransfers() []*state.Transfer            { return nil }
 func (tb *testBalances) AddSignedTransfer(st *state.SignedTransfer) {}
-func (tb *testBalances) GetEventDB() *event.EventDb                 { return nil }
+func (tb *testBalances) ExecuteOnBehalf(*transaction.Transaction, func(cstate.StateContextI) (string, error)) (string, error) {
+	return "", nil
+}
+func (tb *testBalances) GetEventDB() *event.EventDb { return nil }
 func (tb *testBalances) EmitEvent(event.EventType, event.EventTag, string, interface{}, ...cstate.Appender) {
 }
 func (tb *testBalances) EmitError(error)                       {}
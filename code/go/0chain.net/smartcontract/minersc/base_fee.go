@@ -0,0 +1,121 @@
+package minersc
+
+import (
+	"math/big"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/util"
+)
+
+//go:generate msgp -io=false -tests=false -v
+
+// BaseFeeKey is the key of the base fee node.
+var BaseFeeKey = globalKeyHash("base_fee")
+
+// BaseFeeNode is the EIP-1559 like base fee of a block. The pay fees
+// transaction of a block, run after the transactions of the clients, moves it
+// to the next block from the cost the block used of the max block cost. The
+// chain adds the cost of the block transactions to it.
+type BaseFeeNode struct {
+	// Round of the block the base fee is for.
+	Round int64 `json:"round"`
+	// BaseFee is the price of a unit of transaction cost.
+	BaseFee currency.Coin `json:"base_fee"`
+	// UsedCost is the cost of the block transactions executed so far.
+	UsedCost int `json:"used_cost"`
+	// Pool receives the base fees, they are burned if it's empty.
+	Pool string `json:"pool"`
+}
+
+// Fee returns the base fee of the given transaction cost.
+func (bf *BaseFeeNode) Fee(cost int) (currency.Coin, error) {
+	return currency.MultCoin(bf.BaseFee, currency.Coin(cost))
+}
+
+// Save the base fee node.
+func (bf *BaseFeeNode) Save(balances cstate.StateContextI) error {
+	_, err := balances.InsertTrieNode(BaseFeeKey, bf)
+	return err
+}
+
+// GetBaseFee returns the base fee node of the block of the given round, or
+// nil if the base fee market is disabled for the block.
+func GetBaseFee(balances cstate.CommonStateContextI, round int64) (*BaseFeeNode, error) {
+	bf := new(BaseFeeNode)
+	err := balances.GetTrieNode(BaseFeeKey, bf)
+	switch err {
+	case nil:
+	case util.ErrValueNotPresent:
+		return nil, nil
+	default:
+		return nil, err
+	}
+	if bf.Round != round {
+		return nil, nil
+	}
+	return bf, nil
+}
+
+// nextBaseFee moves the base fee by at most 1/denominator towards the cost
+// the previous block used: it increases if more than half of the max block
+// cost is used and decreases otherwise.
+func nextBaseFee(baseFee currency.Coin, usedCost, maxBlockCost, denominator int) currency.Coin {
+	targetCost := maxBlockCost / 2
+	if targetCost <= 0 || denominator <= 0 || usedCost == targetCost {
+		return baseFee
+	}
+
+	diff := usedCost - targetCost
+	if diff < 0 {
+		diff = -diff
+	}
+	delta := new(big.Int).SetUint64(uint64(baseFee))
+	delta.Mul(delta, big.NewInt(int64(diff)))
+	delta.Quo(delta, big.NewInt(int64(targetCost)))
+	delta.Quo(delta, big.NewInt(int64(denominator)))
+
+	if usedCost > targetCost {
+		if delta.Sign() == 0 {
+			delta.SetInt64(1)
+		}
+		return baseFee + currency.Coin(delta.Uint64())
+	}
+	return baseFee - currency.Coin(delta.Uint64())
+}
+
+// updateBaseFee moves the base fee node from the block of the given round to
+// the next one, the node is removed when the base fee market is disabled.
+func updateBaseFee(gn *GlobalNode, round int64, maxBlockCost int,
+	balances cstate.StateContextI) error {
+
+	bf := new(BaseFeeNode)
+	err := balances.GetTrieNode(BaseFeeKey, bf)
+	switch err {
+	case nil:
+	case util.ErrValueNotPresent:
+		if gn.MinBaseFee == 0 {
+			return nil
+		}
+		bf.BaseFee = gn.MinBaseFee
+	default:
+		return err
+	}
+
+	if gn.MinBaseFee == 0 {
+		_, err = balances.DeleteTrieNode(BaseFeeKey)
+		return err
+	}
+
+	if bf.Round > 0 {
+		bf.BaseFee = nextBaseFee(bf.BaseFee, bf.UsedCost, maxBlockCost,
+			gn.BaseFeeChangeDenominator)
+	}
+	if bf.BaseFee < gn.MinBaseFee {
+		bf.BaseFee = gn.MinBaseFee
+	}
+	bf.Round = round + 1
+	bf.UsedCost = 0
+	bf.Pool = gn.BaseFeePool
+	return bf.Save(balances)
+}
@@ -0,0 +1,90 @@
+package minersc
+
+// Code generated by github.com/tinylib/msgp DO NOT EDIT.
+
+import (
+	"github.com/tinylib/msgp/msgp"
+)
+
+// MarshalMsg implements msgp.Marshaler
+func (z *BaseFeeNode) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 4
+	// string "Round"
+	o = append(o, 0x84, 0xa5, 0x52, 0x6f, 0x75, 0x6e, 0x64)
+	o = msgp.AppendInt64(o, z.Round)
+	// string "BaseFee"
+	o = append(o, 0xa7, 0x42, 0x61, 0x73, 0x65, 0x46, 0x65, 0x65)
+	o, err = z.BaseFee.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "BaseFee")
+		return
+	}
+	// string "UsedCost"
+	o = append(o, 0xa8, 0x55, 0x73, 0x65, 0x64, 0x43, 0x6f, 0x73, 0x74)
+	o = msgp.AppendInt(o, z.UsedCost)
+	// string "Pool"
+	o = append(o, 0xa4, 0x50, 0x6f, 0x6f, 0x6c)
+	o = msgp.AppendString(o, z.Pool)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *BaseFeeNode) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Round":
+			z.Round, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Round")
+				return
+			}
+		case "BaseFee":
+			bts, err = z.BaseFee.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "BaseFee")
+				return
+			}
+		case "UsedCost":
+			z.UsedCost, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "UsedCost")
+				return
+			}
+		case "Pool":
+			z.Pool, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Pool")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *BaseFeeNode) Msgsize() (s int) {
+	s = 1 + 6 + msgp.Int64Size + 8 + z.BaseFee.Msgsize() + 9 + msgp.IntSize + 5 + msgp.StringPrefixSize + len(z.Pool)
+	return
+}
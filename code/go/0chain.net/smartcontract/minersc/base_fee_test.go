@@ -0,0 +1,82 @@
+package minersc
+
+import (
+	"testing"
+
+	"github.com/0chain/common/core/currency"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextBaseFee(t *testing.T) {
+	tt := []struct {
+		name     string
+		baseFee  currency.Coin
+		usedCost int
+		want     currency.Coin
+	}{
+		{name: "target", baseFee: 800, usedCost: 500, want: 800},
+		{name: "full block", baseFee: 800, usedCost: 1000, want: 900},
+		{name: "empty block", baseFee: 800, usedCost: 0, want: 700},
+		{name: "small increase", baseFee: 1, usedCost: 600, want: 2},
+		{name: "small decrease", baseFee: 1, usedCost: 400, want: 1},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, nextBaseFee(tc.baseFee, tc.usedCost, 1000, 8))
+		})
+	}
+}
+
+func TestUpdateBaseFee(t *testing.T) {
+	var (
+		balances = newTestBalances()
+		gn       = &GlobalNode{BaseFeeChangeDenominator: 8}
+	)
+
+	// disabled
+	require.NoError(t, updateBaseFee(gn, 1, 1000, balances))
+	bf, err := GetBaseFee(balances, 2)
+	require.NoError(t, err)
+	require.Nil(t, bf)
+
+	// the pay fees transaction of a block sets the base fee of the next one
+	gn.MinBaseFee = 80
+	gn.BaseFeePool = "pool"
+	require.NoError(t, updateBaseFee(gn, 2, 1000, balances))
+	bf, err = GetBaseFee(balances, 3)
+	require.NoError(t, err)
+	require.Equal(t, &BaseFeeNode{Round: 3, BaseFee: 80, Pool: "pool"}, bf)
+
+	// the transactions of the block after the pay fees one don't pay it
+	bf, err = GetBaseFee(balances, 2)
+	require.NoError(t, err)
+	require.Nil(t, bf)
+
+	// full block
+	bf, err = GetBaseFee(balances, 3)
+	require.NoError(t, err)
+	bf.UsedCost = 1000
+	require.NoError(t, bf.Save(balances))
+	require.NoError(t, updateBaseFee(gn, 3, 1000, balances))
+	bf, err = GetBaseFee(balances, 4)
+	require.NoError(t, err)
+	require.Equal(t, &BaseFeeNode{Round: 4, BaseFee: 90, Pool: "pool"}, bf)
+
+	// empty block can't go below the min base fee
+	require.NoError(t, updateBaseFee(gn, 4, 1000, balances))
+	require.NoError(t, updateBaseFee(gn, 5, 1000, balances))
+	bf, err = GetBaseFee(balances, 6)
+	require.NoError(t, err)
+	require.Equal(t, currency.Coin(80), bf.BaseFee)
+
+	fee, err := bf.Fee(10)
+	require.NoError(t, err)
+	require.Equal(t, currency.Coin(800), fee)
+
+	gn.MinBaseFee = 0
+	require.NoError(t, updateBaseFee(gn, 6, 1000, balances))
+	bf, err = GetBaseFee(balances, 7)
+	require.NoError(t, err)
+	require.Nil(t, bf)
+}
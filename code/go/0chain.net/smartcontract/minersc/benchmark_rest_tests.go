@@ -26,6 +26,13 @@ func BenchmarkRestTests(
 				},
 				Endpoint: mrh.getUserPools,
 			},
+			{
+				FuncName: "getUserUnbondingEntries",
+				Params: map[string]string{
+					"client_id": data.Clients[0],
+				},
+				Endpoint: mrh.getUserUnbondingEntries,
+			},
 			{
 				FuncName: "getStakePoolStat",
 				Params: map[string]string{
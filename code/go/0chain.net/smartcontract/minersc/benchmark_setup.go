@@ -104,6 +104,12 @@ func AddMockNodes(
 				pool.Status = spenum.Pending
 			}
 			newNode.Pools[poolId] = &pool
+			if j == 0 {
+				newNode.Unbonding = []*stakepool.UnbondingEntry{{
+					DelegateID: poolId,
+					Amount:     delegatePoolBalance,
+				}}
+			}
 			if eventDb.Debug() {
 				for bk := int64(1); bk <= viper.GetInt64(benchmark.NumBlocks); bk++ {
 					dRewards = append(dRewards, event.RewardDelegate{
@@ -325,6 +325,9 @@ func BenchmarkTests(
 					"cost.sharder_keep":                            "111",
 					"cost.kill_miner":                              "111",
 					"cost.kill_sharder":                            "111",
+					"cost.stake_pool_auto_compound":                "111",
+					"cost.stake_pool_redelegate":                   "111",
+					"cost.stake_pool_withdraw_unbonded":            "111",
 				},
 			}).Encode(),
 		},
@@ -403,6 +406,47 @@ func BenchmarkTests(
 				ProviderID:   data.Miners[0],
 			}).Encode(),
 		},
+		{
+			name:     "miner.stake_pool_auto_compound",
+			endpoint: msc.stakePoolAutoCompound,
+			txn: &transaction.Transaction{
+				ClientID:     getMinerDelegatePoolId(0, 0, spenum.Miner),
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: (&stakepool.AutoCompoundRequest{
+				ProviderType: spenum.Miner,
+				ProviderID:   data.Miners[0],
+				AutoCompound: true,
+			}).Encode(),
+		},
+		{
+			name:     "miner.stake_pool_redelegate",
+			endpoint: msc.stakePoolRedelegate,
+			txn: &transaction.Transaction{
+				ClientID:     getMinerDelegatePoolId(0, 0, spenum.Miner),
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: (&stakepool.RedelegateRequest{
+				ProviderType:   spenum.Miner,
+				FromProviderID: data.Miners[0],
+				ToProviderID:   data.Miners[1],
+			}).Encode(),
+		},
+		{
+			name:     "miner.stake_pool_withdraw_unbonded",
+			endpoint: msc.stakePoolWithdrawUnbonded,
+			txn: &transaction.Transaction{
+				ClientID:     getMinerDelegatePoolId(0, 0, spenum.Miner),
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: (&stakepool.StakePoolRequest{
+				ProviderType: spenum.Miner,
+				ProviderID:   data.Miners[0],
+			}).Encode(),
+		},
 		{
 			name:     "miner.sharder_keep",
 			endpoint: msc.sharderKeep,
@@ -6,9 +6,22 @@ import (
 	"0chain.net/core/common"
 	"0chain.net/smartcontract/stakepool"
 	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/currency"
 	"github.com/0chain/common/core/util"
 )
 
+func init() {
+	getMaxStake := func(balances cstate.CommonStateContextI) (currency.Coin, error) {
+		gn, err := getGlobalNode(balances)
+		if err != nil {
+			return 0, err
+		}
+		return gn.MaxStake, nil
+	}
+	stakepool.RegisterMaxStakeGetter(spenum.Miner, getMaxStake)
+	stakepool.RegisterMaxStakeGetter(spenum.Sharder, getMaxStake)
+}
+
 func (msc *MinerSmartContract) addToDelegatePool(t *transaction.Transaction,
 	input []byte, gn *GlobalNode, balances cstate.StateContextI) (
 	resp string, err error) {
@@ -54,5 +67,28 @@ func (msc *MinerSmartContract) deleteFromDelegatePool(
 	t *transaction.Transaction, inputData []byte, gn *GlobalNode,
 	balances cstate.StateContextI) (resp string, err error) {
 
-	return stakepool.StakePoolUnlock(t, inputData, balances, msc.getStakePoolAdapter)
+	return stakepool.StakePoolUnlock(t, inputData, balances, msc.getStakePoolAdapter, gn.UnbondingRounds)
+}
+
+func (msc *MinerSmartContract) stakePoolRedelegate(
+	t *transaction.Transaction, inputData []byte, gn *GlobalNode,
+	balances cstate.StateContextI) (resp string, err error) {
+
+	return stakepool.StakePoolRedelegate(t, inputData, balances,
+		stakepool.ValidationSettings{MaxStake: gn.MaxStake, MinStake: gn.MinStake, MaxNumDelegates: gn.MaxDelegates},
+		msc.getStakePoolAdapter)
+}
+
+func (msc *MinerSmartContract) stakePoolWithdrawUnbonded(
+	t *transaction.Transaction, inputData []byte, _ *GlobalNode,
+	balances cstate.StateContextI) (resp string, err error) {
+
+	return stakepool.StakePoolWithdrawUnbonded(t, inputData, balances, msc.getStakePoolAdapter)
+}
+
+func (msc *MinerSmartContract) stakePoolAutoCompound(
+	t *transaction.Transaction, inputData []byte, gn *GlobalNode,
+	balances cstate.StateContextI) (resp string, err error) {
+
+	return stakepool.StakePoolAutoCompound(t, inputData, balances, msc.getStakePoolAdapter)
 }
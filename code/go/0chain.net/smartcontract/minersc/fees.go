@@ -287,6 +287,14 @@ func (msc *MinerSmartContract) payFees(t *transaction.Transaction,
 		return "", common.NewError("pay_fees", fmt.Sprintf("bad round, block %v but input %v", b.Round, inputRound.Round))
 	}
 
+	var maxBlockCost int
+	if gn.MinBaseFee > 0 {
+		maxBlockCost = configuration.ChainConfig.MaxBlockCost()
+	}
+	if err := updateBaseFee(gn, b.Round, maxBlockCost, balances); err != nil {
+		return "", common.NewErrorf("pay_fees", "updating base fee: %v", err)
+	}
+
 	fees, err := msc.sumFee(b, true)
 	if err != nil {
 		return "", err
@@ -507,6 +515,9 @@ func getMagicBlockSharders(balances cstate.StateContextI) []string {
 	return sharderKeys
 }
 
+// sumFee sums the fees charged for the block transactions paid to the
+// generator and the sharders, the priority fees only if the base fee market
+// is enabled.
 func (msc *MinerSmartContract) sumFee(b *block.Block,
 	updateStats bool) (currency.Coin, error) {
 
@@ -519,7 +530,7 @@ func (msc *MinerSmartContract) sumFee(b *block.Block,
 		feeStats = stat.(metrics.Counter)
 	}
 	for _, txn := range b.Txns {
-		totalMaxFee, err = currency.AddCoin(totalMaxFee, txn.Fee)
+		totalMaxFee, err = currency.AddCoin(totalMaxFee, txn.ChargedFee)
 		if err != nil {
 			return 0, err
 		}
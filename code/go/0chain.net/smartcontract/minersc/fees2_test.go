@@ -307,7 +307,7 @@ func testPayFees(t *testing.T, minerStakes []float64, sharderStakes [][]float64,
 		},
 	}
 	for _, fee := range runtime.fees {
-		ctx.block.Txns = append(ctx.block.Txns, &transaction.Transaction{Fee: fee})
+		ctx.block.Txns = append(ctx.block.Txns, &transaction.Transaction{Fee: fee, ChargedFee: fee})
 	}
 	var phaseNode = &PhaseNode{
 		Phase:      runtime.phase,
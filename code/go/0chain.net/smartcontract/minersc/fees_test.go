@@ -12,6 +12,7 @@ import (
 	"0chain.net/chaincore/block"
 	cstate "0chain.net/chaincore/chain/state"
 	"0chain.net/chaincore/node"
+	"0chain.net/chaincore/transaction"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -184,6 +185,23 @@ func computeShardersPayments(gn *GlobalNode, msc *MinerSmartContract, b *block.B
 	return sharderR + sharderF, nil
 }
 
+func Test_sumFee(t *testing.T) {
+	var (
+		msc = newTestMinerSC()
+		b   = block.Provider().(*block.Block)
+	)
+	// the fees charged for the metered transactions are below their fees
+	b.Txns = []*transaction.Transaction{
+		{Fee: 10, ChargedFee: 10, PriorityFee: 2},
+		{Fee: 10, ChargedFee: 4, MaxCost: 100, PriorityFee: 1},
+		{Fee: 0},
+	}
+
+	fees, err := msc.sumFee(b, false)
+	require.NoError(t, err)
+	assert.EqualValues(t, 14, fees)
+}
+
 func Test_payFees(t *testing.T) {
 	t.Skip("Needs to be reworked. We now no longer pay fees with transfers and mints")
 	const stakeVal, stakeHolders = 10e10, 5
@@ -447,6 +465,7 @@ func Test_payFees(t *testing.T) {
 		balances.blockSharders = extractBlockSharders(sharders, 3)
 		// add fees
 		tx.Fee = 100e10
+		tx.ChargedFee = tx.Fee
 		b.Txns = append(b.Txns, tx)
 		var gn, err = getGlobalNode(balances)
 		require.NoError(t, err, "getting global node")
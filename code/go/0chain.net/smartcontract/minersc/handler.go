@@ -41,6 +41,7 @@ func GetEndpoints(rh rest.RestHandlerI) []rest.Endpoint {
 		rest.MakeEndpoint(miner+"/globalSettings", common.UserRateLimit(mrh.getGlobalSettings)),
 		rest.MakeEndpoint(miner+"/getNodepool", common.UserRateLimit(mrh.getNodePool)),
 		rest.MakeEndpoint(miner+"/getUserPools", common.UserRateLimit(mrh.getUserPools)),
+		rest.MakeEndpoint(miner+"/getUserUnbondingEntries", common.UserRateLimit(mrh.getUserUnbondingEntries)),
 		rest.MakeEndpoint(miner+"/getStakePoolStat", common.UserRateLimit(mrh.getStakePoolStat)),
 		rest.MakeEndpoint(miner+"/getMinerList", common.UserRateLimit(mrh.getMinerList)),
 		rest.MakeEndpoint(miner+"/get_miners_stats", common.UserRateLimit(mrh.getMinersStats)),
@@ -974,6 +975,64 @@ func (mrh *MinerRestHandler) getUserPools(w http.ResponseWriter, r *http.Request
 	common.Respond(w, r, ups, nil)
 }
 
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d9/getUserUnbondingEntries getUserUnbondingEntries
+//
+//	user's stake unlocked from miners and sharders, waiting for the unbonding period to be over
+//
+// parameters:
+//
+//	+name: client_id
+//	 description: client for which to get unbonding entries
+//	 required: true
+//	 in: query
+//	 type: string
+//	+name: offset
+//	 description: offset
+//	 in: query
+//	 type: string
+//	+name: limit
+//	 description: limit
+//	 in: query
+//	 type: string
+//	+name: sort
+//	 description: desc or asc
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200: []UnbondingEntry
+//	400:
+//	500:
+func (mrh *MinerRestHandler) getUserUnbondingEntries(w http.ResponseWriter, r *http.Request) {
+	clientID := r.URL.Query().Get("client_id")
+	if clientID == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("no client_id"))
+		return
+	}
+
+	pagination, err := common2.GetOffsetLimitOrderParam(r.URL.Query())
+	if err != nil {
+		common.Respond(w, r, nil, err)
+		return
+	}
+
+	edb := mrh.GetQueryStateContext().GetEventDB()
+	if edb == nil {
+		common.Respond(w, r, nil, common.NewErrInternal("no db connection"))
+		return
+	}
+
+	entries, err := edb.GetUserUnbondingEntries(clientID,
+		[]spenum.Provider{spenum.Miner, spenum.Sharder}, pagination)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("getting unbonding entries: "+err.Error()))
+		return
+	}
+
+	common.Respond(w, r, entries, nil)
+}
+
 func toUPS(pool event.DelegatePool) stakepool.DelegatePoolStat {
 
 	dp := stakepool.DelegatePoolStat{
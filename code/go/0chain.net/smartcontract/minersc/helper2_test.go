@@ -28,13 +28,16 @@ type mockStateContext struct {
 	LastestFinalizedMagicBlock *block.Block
 }
 
-func (sc *mockStateContext) SetMagicBlock(_ *block.MagicBlock)                     {}
-func (sc *mockStateContext) GetState() util.MerklePatriciaTrieI                    { return nil }
-func (sc *mockStateContext) GetTransaction() *transaction.Transaction              { return nil }
-func (sc *mockStateContext) GetSignedTransfers() []*state.SignedTransfer           { return nil }
-func (sc *mockStateContext) Validate() error                                       { return nil }
-func (sc *mockStateContext) GetSignatureScheme() encryption.SignatureScheme        { return nil }
-func (sc *mockStateContext) AddSignedTransfer(_ *state.SignedTransfer)             {}
+func (sc *mockStateContext) SetMagicBlock(_ *block.MagicBlock)              {}
+func (sc *mockStateContext) GetState() util.MerklePatriciaTrieI             { return nil }
+func (sc *mockStateContext) GetTransaction() *transaction.Transaction       { return nil }
+func (sc *mockStateContext) GetSignedTransfers() []*state.SignedTransfer    { return nil }
+func (sc *mockStateContext) Validate() error                                { return nil }
+func (sc *mockStateContext) GetSignatureScheme() encryption.SignatureScheme { return nil }
+func (sc *mockStateContext) AddSignedTransfer(_ *state.SignedTransfer)      {}
+func (sc *mockStateContext) ExecuteOnBehalf(*transaction.Transaction, func(cstate.StateContextI) (string, error)) (string, error) {
+	return "", nil
+}
 func (sc *mockStateContext) DeleteTrieNode(_ datastore.Key) (datastore.Key, error) { return "", nil }
 func (sc *mockStateContext) GetClientBalance(_ datastore.Key) (currency.Coin, error) {
 	return 0, nil
@@ -58,6 +58,9 @@ func (msc *MinerSmartContract) InitSmartContractFunctions() {
 
 	msc.smartContractFunctions["addToDelegatePool"] = msc.addToDelegatePool
 	msc.smartContractFunctions["deleteFromDelegatePool"] = msc.deleteFromDelegatePool
+	msc.smartContractFunctions["stake_pool_auto_compound"] = msc.stakePoolAutoCompound
+	msc.smartContractFunctions["stake_pool_redelegate"] = msc.stakePoolRedelegate
+	msc.smartContractFunctions["stake_pool_withdraw_unbonded"] = msc.stakePoolWithdrawUnbonded
 
 	msc.smartContractFunctions["sharder_keep"] = msc.sharderKeep
 }
@@ -259,7 +259,16 @@ type GlobalNode struct {
 	RewardRoundFrequency int64          `json:"reward_round_frequency"`
 	OwnerId              string         `json:"owner_id"`
 	CooldownPeriod       int64          `json:"cooldown_period"`
+	UnbondingRounds      int64          `json:"unbonding_rounds"`
 	Cost                 map[string]int `json:"cost"`
+	// MinBaseFee is the floor of the base fee, the price of a unit of
+	// transaction cost. Zero disables the base fee market.
+	MinBaseFee currency.Coin `json:"min_base_fee"`
+	// BaseFeeChangeDenominator bounds the change of the base fee between
+	// blocks, it changes at most by 1/BaseFeeChangeDenominator.
+	BaseFeeChangeDenominator int `json:"base_fee_change_denominator"`
+	// BaseFeePool receives the base fees, they are burned if it's empty.
+	BaseFeePool string `json:"base_fee_pool"`
 }
 
 func (gn *GlobalNode) readConfig() (err error) {
@@ -299,6 +308,13 @@ func (gn *GlobalNode) readConfig() (err error) {
 	}
 	gn.OwnerId = config.SmartContractConfig.GetString(pfx + SettingName[OwnerId])
 	gn.CooldownPeriod = config.SmartContractConfig.GetInt64(pfx + SettingName[CooldownPeriod])
+	gn.UnbondingRounds = config.SmartContractConfig.GetInt64(pfx + SettingName[UnbondingRounds])
+	gn.MinBaseFee, err = currency.ParseZCN(config.SmartContractConfig.GetFloat64(pfx + SettingName[MinBaseFee]))
+	if err != nil {
+		return
+	}
+	gn.BaseFeeChangeDenominator = config.SmartContractConfig.GetInt(pfx + SettingName[BaseFeeChangeDenominator])
+	gn.BaseFeePool = config.SmartContractConfig.GetString(pfx + SettingName[BaseFeePool])
 	gn.Cost = config.SmartContractConfig.GetStringMapInt(pfx + "cost")
 	return nil
 }
@@ -335,6 +351,10 @@ func (gn *GlobalNode) validate() error {
 		return fmt.Errorf("%s cannot be negative: %d",
 			NumShardersRewarded.String(), gn.NumShardersRewarded)
 	}
+	if gn.MinBaseFee > 0 && gn.BaseFeeChangeDenominator <= 0 {
+		return fmt.Errorf("%s must be positive when the base fee is enabled: %d",
+			BaseFeeChangeDenominator.String(), gn.BaseFeeChangeDenominator)
+	}
 	return nil
 }
 
@@ -415,6 +435,14 @@ func (gn *GlobalNode) Get(key Setting) (interface{}, error) {
 		return gn.OwnerId, nil
 	case CooldownPeriod:
 		return gn.CooldownPeriod, nil
+	case UnbondingRounds:
+		return gn.UnbondingRounds, nil
+	case MinBaseFee:
+		return gn.MinBaseFee, nil
+	case BaseFeeChangeDenominator:
+		return gn.BaseFeeChangeDenominator, nil
+	case BaseFeePool:
+		return gn.BaseFeePool, nil
 	default:
 		return nil, errors.New("Setting not implemented")
 	}
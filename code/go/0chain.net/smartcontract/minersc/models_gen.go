@@ -308,9 +308,9 @@ func (z *DKGMinerNodes) Msgsize() (s int) {
 // MarshalMsg implements msgp.Marshaler
 func (z *GlobalNode) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 28
+	// map header, size 32
 	// string "ViewChange"
-	o = append(o, 0xde, 0x0, 0x1c, 0xaa, 0x56, 0x69, 0x65, 0x77, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65)
+	o = append(o, 0xde, 0x0, 0x20, 0xaa, 0x56, 0x69, 0x65, 0x77, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65)
 	o = msgp.AppendInt64(o, z.ViewChange)
 	// string "MaxN"
 	o = append(o, 0xa4, 0x4d, 0x61, 0x78, 0x4e)
@@ -418,6 +418,9 @@ func (z *GlobalNode) MarshalMsg(b []byte) (o []byte, err error) {
 	// string "CooldownPeriod"
 	o = append(o, 0xae, 0x43, 0x6f, 0x6f, 0x6c, 0x64, 0x6f, 0x77, 0x6e, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64)
 	o = msgp.AppendInt64(o, z.CooldownPeriod)
+	// string "UnbondingRounds"
+	o = append(o, 0xaf, 0x55, 0x6e, 0x62, 0x6f, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x73)
+	o = msgp.AppendInt64(o, z.UnbondingRounds)
 	// string "Cost"
 	o = append(o, 0xa4, 0x43, 0x6f, 0x73, 0x74)
 	o = msgp.AppendMapHeader(o, uint32(len(z.Cost)))
@@ -431,6 +434,19 @@ func (z *GlobalNode) MarshalMsg(b []byte) (o []byte, err error) {
 		o = msgp.AppendString(o, k)
 		o = msgp.AppendInt(o, za0002)
 	}
+	// string "MinBaseFee"
+	o = append(o, 0xaa, 0x4d, 0x69, 0x6e, 0x42, 0x61, 0x73, 0x65, 0x46, 0x65, 0x65)
+	o, err = z.MinBaseFee.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "MinBaseFee")
+		return
+	}
+	// string "BaseFeeChangeDenominator"
+	o = append(o, 0xb8, 0x42, 0x61, 0x73, 0x65, 0x46, 0x65, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x44, 0x65, 0x6e, 0x6f, 0x6d, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72)
+	o = msgp.AppendInt(o, z.BaseFeeChangeDenominator)
+	// string "BaseFeePool"
+	o = append(o, 0xab, 0x42, 0x61, 0x73, 0x65, 0x46, 0x65, 0x65, 0x50, 0x6f, 0x6f, 0x6c)
+	o = msgp.AppendString(o, z.BaseFeePool)
 	return
 }
 
@@ -625,6 +641,12 @@ func (z *GlobalNode) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "CooldownPeriod")
 				return
 			}
+		case "UnbondingRounds":
+			z.UnbondingRounds, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "UnbondingRounds")
+				return
+			}
 		case "Cost":
 			var zb0002 uint32
 			zb0002, bts, err = msgp.ReadMapHeaderBytes(bts)
@@ -655,6 +677,24 @@ func (z *GlobalNode) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				}
 				z.Cost[za0001] = za0002
 			}
+		case "MinBaseFee":
+			bts, err = z.MinBaseFee.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MinBaseFee")
+				return
+			}
+		case "BaseFeeChangeDenominator":
+			z.BaseFeeChangeDenominator, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "BaseFeeChangeDenominator")
+				return
+			}
+		case "BaseFeePool":
+			z.BaseFeePool, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "BaseFeePool")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -675,13 +715,14 @@ func (z *GlobalNode) Msgsize() (s int) {
 	} else {
 		s += z.PrevMagicBlock.Msgsize()
 	}
-	s += 7 + z.Minted.Msgsize() + 21 + msgp.Int64Size + 8 + msgp.StringPrefixSize + len(z.OwnerId) + 15 + msgp.Int64Size + 5 + msgp.MapHeaderSize
+	s += 7 + z.Minted.Msgsize() + 21 + msgp.Int64Size + 8 + msgp.StringPrefixSize + len(z.OwnerId) + 15 + msgp.Int64Size + 16 + msgp.Int64Size + 5 + msgp.MapHeaderSize
 	if z.Cost != nil {
 		for za0001, za0002 := range z.Cost {
 			_ = za0002
 			s += msgp.StringPrefixSize + len(za0001) + msgp.IntSize
 		}
 	}
+	s += 11 + z.MinBaseFee.Msgsize() + 25 + msgp.IntSize + 12 + msgp.StringPrefixSize + len(z.BaseFeePool)
 	return
 }
 
@@ -45,6 +45,10 @@ const (
 	MaxMint
 	OwnerId
 	CooldownPeriod
+	UnbondingRounds
+	MinBaseFee
+	BaseFeeChangeDenominator
+	BaseFeePool
 	CostAddMiner
 	CostAddSharder
 	CostDeleteMiner
@@ -65,6 +69,9 @@ const (
 	CostSharderKeep
 	CostKillMiner
 	CostKillSharder
+	CostStakePoolAutoCompound
+	CostStakePoolRedelegate
+	CostWithdrawUnbonded
 	NumberOfSettings
 )
 
@@ -112,6 +119,10 @@ func initSettingName() {
 	SettingName[MaxMint] = "max_mint"
 	SettingName[OwnerId] = "owner_id"
 	SettingName[CooldownPeriod] = "cooldown_period"
+	SettingName[UnbondingRounds] = "unbonding_rounds"
+	SettingName[MinBaseFee] = "min_base_fee"
+	SettingName[BaseFeeChangeDenominator] = "base_fee_change_denominator"
+	SettingName[BaseFeePool] = "base_fee_pool"
 	SettingName[CostAddMiner] = "cost.add_miner"
 	SettingName[CostAddSharder] = "cost.add_sharder"
 	SettingName[CostDeleteMiner] = "cost.delete_miner"
@@ -132,6 +143,9 @@ func initSettingName() {
 	SettingName[CostSharderKeep] = "cost.sharder_keep"
 	SettingName[CostKillMiner] = "cost.kill_miner"
 	SettingName[CostKillSharder] = "cost.kill_sharder"
+	SettingName[CostStakePoolAutoCompound] = "cost.stake_pool_auto_compound"
+	SettingName[CostStakePoolRedelegate] = "cost.stake_pool_redelegate"
+	SettingName[CostWithdrawUnbonded] = "cost.stake_pool_withdraw_unbonded"
 }
 
 func initSettings() {
@@ -162,6 +176,10 @@ func initSettings() {
 		MaxMint.String():                     {MaxMint, smartcontract.CurrencyCoin},
 		OwnerId.String():                     {OwnerId, smartcontract.Key},
 		CooldownPeriod.String():              {CooldownPeriod, smartcontract.Int64},
+		UnbondingRounds.String():             {UnbondingRounds, smartcontract.Int64},
+		MinBaseFee.String():                  {MinBaseFee, smartcontract.CurrencyCoin},
+		BaseFeeChangeDenominator.String():    {BaseFeeChangeDenominator, smartcontract.Int},
+		BaseFeePool.String():                 {BaseFeePool, smartcontract.Key},
 		CostAddMiner.String():                {CostAddMiner, smartcontract.Cost},
 		CostAddSharder.String():              {CostAddSharder, smartcontract.Cost},
 		CostDeleteMiner.String():             {CostDeleteMiner, smartcontract.Cost},
@@ -182,6 +200,9 @@ func initSettings() {
 		CostSharderKeep.String():             {CostSharderKeep, smartcontract.Cost},
 		CostKillMiner.String():               {CostKillMiner, smartcontract.Cost},
 		CostKillSharder.String():             {CostKillSharder, smartcontract.Cost},
+		CostStakePoolAutoCompound.String():   {CostStakePoolAutoCompound, smartcontract.Cost},
+		CostStakePoolRedelegate.String():     {CostStakePoolRedelegate, smartcontract.Cost},
+		CostWithdrawUnbonded.String():        {CostWithdrawUnbonded, smartcontract.Cost},
 	}
 }
 
@@ -203,6 +224,8 @@ func (gn *GlobalNode) setInt(key string, change int) error {
 		gn.NumShardersRewarded = change
 	case NumSharderDelegatesRewarded:
 		gn.NumSharderDelegatesRewarded = change
+	case BaseFeeChangeDenominator:
+		gn.BaseFeeChangeDenominator = change
 	default:
 		return fmt.Errorf("key: %v not implemented as int", key)
 	}
@@ -219,6 +242,8 @@ func (gn *GlobalNode) setBalance(key string, change currency.Coin) error {
 		gn.MaxStake = change
 	case BlockReward:
 		gn.BlockReward = change
+	case MinBaseFee:
+		gn.MinBaseFee = change
 	default:
 		return fmt.Errorf("key: %v not implemented as balance", key)
 	}
@@ -233,6 +258,8 @@ func (gn *GlobalNode) setInt64(key string, change int64) error {
 		gn.Epoch = change
 	case CooldownPeriod:
 		gn.CooldownPeriod = change
+	case UnbondingRounds:
+		gn.UnbondingRounds = change
 	default:
 		return fmt.Errorf("key: %v not implemented as int64", key)
 	}
@@ -265,6 +292,8 @@ func (gn *GlobalNode) setKey(key string, change string) {
 	switch Settings[key].Setting {
 	case OwnerId:
 		gn.OwnerId = change
+	case BaseFeePool:
+		gn.BaseFeePool = change
 	default:
 		panic("key: " + key + "not implemented as key")
 	}
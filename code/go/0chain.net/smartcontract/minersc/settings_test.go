@@ -221,6 +221,9 @@ func TestUpdateSettings(t *testing.T) {
 					"cost.sharder_keep":                            "111",
 					"cost.kill_miner":                              "111",
 					"cost.kill_sharder":                            "111",
+					"cost.stake_pool_auto_compound":                "111",
+					"cost.stake_pool_redelegate":                   "111",
+					"cost.stake_pool_withdraw_unbonded":            "111",
 				},
 			},
 		},
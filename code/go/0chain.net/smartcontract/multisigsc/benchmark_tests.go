@@ -61,6 +61,12 @@ func (bt BenchTest) Run(balances cstate.TimedQueryStateContext, _ *testing.B) er
 			bt.input,
 			balances,
 		)
+	case SetThresholdFuncName:
+		_, err = msc.setThreshold(
+			bt.txn.ClientID,
+			bt.input,
+			balances,
+		)
 	default:
 		panic("unknown endpoint: " + bt.endpoint)
 	}
@@ -127,6 +133,35 @@ func BenchmarkTests(
 				return bytes
 			}(),
 		},
+		{
+			name:     "multi_sig." + SetThresholdFuncName,
+			endpoint: SetThresholdFuncName,
+			txn: &transaction.Transaction{
+				ClientID:     data.Clients[1],
+				CreationDate: creationTime,
+			},
+			input: func() []byte {
+				tr := thresholdRequest{
+					NumRequired: MaxSigners - 1,
+					GroupKey: groupKeyProof{
+						PublicKey: data.PublicKeys[1],
+					},
+				}
+				msg := Wallet{
+					ClientID:         data.Clients[1],
+					SignerSetVersion: 1,
+				}.groupKeyProofMessage()
+				for i := 0; i < tr.NumRequired; i++ {
+					_ = sigScheme.SetPublicKey(data.PublicKeys[i])
+					sigScheme.SetPrivateKey(data.PrivateKeys[i])
+					signature, _ := sigScheme.Sign(msg)
+					tr.GroupKey.SignerThresholdIDs = append(tr.GroupKey.SignerThresholdIDs, data.Clients[i])
+					tr.GroupKey.SignerSignatures = append(tr.GroupKey.SignerSignatures, signature)
+				}
+				bytes, _ := json.Marshal(&tr)
+				return bytes
+			}(),
+		},
 	}
 	var testsI []bk.BenchTestI
 	for _, test := range tests {
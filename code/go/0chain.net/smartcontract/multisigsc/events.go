@@ -0,0 +1,63 @@
+package multisigsc
+
+import (
+	"0chain.net/chaincore/chain/state"
+	"0chain.net/smartcontract/dbs/event"
+)
+
+func emitAddOrOverwriteWallet(w Wallet, balances state.StateContextI) {
+	ew := event.MultisigWallet{
+		ClientID:         w.ClientID,
+		SignatureScheme:  w.SignatureScheme,
+		PublicKey:        w.PublicKey,
+		NumRequired:      w.NumRequired,
+		SignerSetVersion: w.SignerSetVersion,
+		Signers:          make([]event.MultisigSigner, 0, len(w.SignerThresholdIDs)),
+	}
+	for i, id := range w.SignerThresholdIDs {
+		ew.Signers = append(ew.Signers, event.MultisigSigner{
+			WalletID:    w.ClientID,
+			ThresholdID: id,
+			PublicKey:   w.SignerPublicKeys[i],
+		})
+	}
+
+	balances.EmitEvent(event.TypeStats, event.TagAddOrOverwriteMultisigWallet, w.ClientID, ew)
+}
+
+func emitAddOrOverwriteProposal(w Wallet, p proposal, balances state.StateContextI) {
+	ep := event.MultisigProposal{
+		WalletID:          p.Transfer.ClientID,
+		ProposalID:        p.ProposalID,
+		ToClientID:        p.Transfer.ToClientID,
+		Amount:            p.Transfer.Amount,
+		ExpirationDate:    int64(p.ExpirationDate),
+		NumVotes:          len(p.SignerSignatures),
+		NumRequired:       w.NumRequired,
+		SignerSetVersion:  p.SignerSetVersion,
+		ExecutedInTxnHash: p.ExecutedInTxnHash,
+	}
+	if p.Call != nil {
+		ep.FunctionName = p.Call.FunctionName
+		ep.InputData = p.Call.InputData
+	}
+
+	balances.EmitEvent(event.TypeStats, event.TagAddOrOverwriteMultisigProposal, p.getKey(), ep)
+}
+
+func emitDeleteProposal(ref proposalRef, balances state.StateContextI) {
+	balances.EmitEvent(event.TypeStats, event.TagDeleteMultisigProposal,
+		getProposalKey(ref.ClientID, ref.ProposalID), event.MultisigProposal{
+			WalletID:   ref.ClientID,
+			ProposalID: ref.ProposalID,
+		})
+}
+
+func emitAddVote(ref proposalRef, signerThresholdID string, balances state.StateContextI) {
+	balances.EmitEvent(event.TypeStats, event.TagAddMultisigVote,
+		getProposalKey(ref.ClientID, ref.ProposalID), event.MultisigVote{
+			WalletID:          ref.ClientID,
+			ProposalID:        ref.ProposalID,
+			SignerThresholdID: signerThresholdID,
+		})
+}
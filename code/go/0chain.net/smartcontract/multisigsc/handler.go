@@ -0,0 +1,250 @@
+package multisigsc
+
+import (
+	"net/http"
+
+	"0chain.net/core/common"
+	"0chain.net/smartcontract"
+	common2 "0chain.net/smartcontract/common"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/rest"
+)
+
+type MultisigRestHandler struct {
+	rest.RestHandlerI
+}
+
+func NewMultisigRestHandler(rh rest.RestHandlerI) *MultisigRestHandler {
+	return &MultisigRestHandler{rh}
+}
+
+func SetupRestHandler(rh rest.RestHandlerI) {
+	rh.Register(GetEndpoints(rh))
+}
+
+func GetEndpoints(rh rest.RestHandlerI) []rest.Endpoint {
+	mrh := NewMultisigRestHandler(rh)
+	multisig := "/v1/screst/" + Address
+	return []rest.Endpoint{
+		rest.MakeEndpoint(multisig+"/wallet", common.UserRateLimit(mrh.getWallet)),
+		rest.MakeEndpoint(multisig+"/proposals", common.UserRateLimit(mrh.getProposals)),
+		rest.MakeEndpoint(multisig+"/proposal", common.UserRateLimit(mrh.getProposal)),
+		rest.MakeEndpoint(multisig+"/votes", common.UserRateLimit(mrh.getVotes)),
+	}
+}
+
+// swagger:route GET /v1/screst/27b5ef7120252b79f9dd9c05505dd28f328c80f6863ee446daede08a84d651a7/wallet wallet
+// Gets signers and threshold of a multi-sig wallet
+//
+// parameters:
+//
+//	+name: client_id
+//	 description: client id of the multi-sig wallet
+//	 required: true
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200: MultisigWallet
+//	400:
+//	404:
+//	500:
+func (mrh *MultisigRestHandler) getWallet(w http.ResponseWriter, r *http.Request) {
+	clientID := r.URL.Query().Get("client_id")
+	if clientID == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("missing client_id"))
+		return
+	}
+
+	edb := mrh.GetQueryStateContext().GetEventDB()
+	if edb == nil {
+		common.Respond(w, r, nil, common.NewErrInternal("no db connection"))
+		return
+	}
+
+	wallet, err := edb.GetMultisigWallet(clientID)
+	if err != nil {
+		common.Respond(w, r, nil, smartcontract.NewErrNoResourceOrErrInternal(err, true, "can't get wallet"))
+		return
+	}
+	common.Respond(w, r, wallet, nil)
+}
+
+// swagger:route GET /v1/screst/27b5ef7120252b79f9dd9c05505dd28f328c80f6863ee446daede08a84d651a7/proposals proposals
+// Gets open proposals of a multi-sig wallet, those neither executed nor expired
+//
+// parameters:
+//
+//	+name: client_id
+//	 description: client id of the multi-sig wallet
+//	 required: true
+//	 in: query
+//	 type: string
+//	+name: offset
+//	 description: offset
+//	 in: query
+//	 type: string
+//	+name: limit
+//	 description: limit
+//	 in: query
+//	 type: string
+//	+name: sort
+//	 description: desc or asc
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200: []MultisigProposal
+//	400:
+//	500:
+func (mrh *MultisigRestHandler) getProposals(w http.ResponseWriter, r *http.Request) {
+	clientID := r.URL.Query().Get("client_id")
+	if clientID == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("missing client_id"))
+		return
+	}
+
+	limit, err := common2.GetOffsetLimitOrderParam(r.URL.Query())
+	if err != nil {
+		common.Respond(w, r, nil, err)
+		return
+	}
+
+	sctx := mrh.GetQueryStateContext()
+	edb := sctx.GetEventDB()
+	if edb == nil {
+		common.Respond(w, r, nil, common.NewErrInternal("no db connection"))
+		return
+	}
+
+	// expiration is checked against the LFB, not the clock of this node
+	lfb := sctx.GetLatestFinalizedBlock()
+	if lfb == nil {
+		common.Respond(w, r, nil, common.NewErrInternal("no latest finalized block"))
+		return
+	}
+
+	proposals, err := edb.GetMultisigProposals(clientID, int64(lfb.CreationDate), limit)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("can't get proposals", err.Error()))
+		return
+	}
+	if proposals == nil {
+		proposals = []event.MultisigProposal{}
+	}
+	common.Respond(w, r, proposals, nil)
+}
+
+// swagger:route GET /v1/screst/27b5ef7120252b79f9dd9c05505dd28f328c80f6863ee446daede08a84d651a7/proposal proposal
+// Gets a proposal of a multi-sig wallet with number of votes collected and expiration date
+//
+// parameters:
+//
+//	+name: client_id
+//	 description: client id of the multi-sig wallet
+//	 required: true
+//	 in: query
+//	 type: string
+//	+name: proposal_id
+//	 description: id of the proposal
+//	 required: true
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200: MultisigProposal
+//	400:
+//	404:
+//	500:
+func (mrh *MultisigRestHandler) getProposal(w http.ResponseWriter, r *http.Request) {
+	var (
+		clientID   = r.URL.Query().Get("client_id")
+		proposalID = r.URL.Query().Get("proposal_id")
+	)
+	if clientID == "" || proposalID == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("missing client_id or proposal_id"))
+		return
+	}
+
+	edb := mrh.GetQueryStateContext().GetEventDB()
+	if edb == nil {
+		common.Respond(w, r, nil, common.NewErrInternal("no db connection"))
+		return
+	}
+
+	p, err := edb.GetMultisigProposal(clientID, proposalID)
+	if err != nil {
+		common.Respond(w, r, nil, smartcontract.NewErrNoResourceOrErrInternal(err, true, "can't get proposal"))
+		return
+	}
+	common.Respond(w, r, p, nil)
+}
+
+// swagger:route GET /v1/screst/27b5ef7120252b79f9dd9c05505dd28f328c80f6863ee446daede08a84d651a7/votes votes
+// Gets votes collected by a proposal of a multi-sig wallet
+//
+// parameters:
+//
+//	+name: client_id
+//	 description: client id of the multi-sig wallet
+//	 required: true
+//	 in: query
+//	 type: string
+//	+name: proposal_id
+//	 description: id of the proposal
+//	 required: true
+//	 in: query
+//	 type: string
+//	+name: offset
+//	 description: offset
+//	 in: query
+//	 type: string
+//	+name: limit
+//	 description: limit
+//	 in: query
+//	 type: string
+//	+name: sort
+//	 description: desc or asc
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200: []MultisigVote
+//	400:
+//	500:
+func (mrh *MultisigRestHandler) getVotes(w http.ResponseWriter, r *http.Request) {
+	var (
+		clientID   = r.URL.Query().Get("client_id")
+		proposalID = r.URL.Query().Get("proposal_id")
+	)
+	if clientID == "" || proposalID == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("missing client_id or proposal_id"))
+		return
+	}
+
+	limit, err := common2.GetOffsetLimitOrderParam(r.URL.Query())
+	if err != nil {
+		common.Respond(w, r, nil, err)
+		return
+	}
+
+	edb := mrh.GetQueryStateContext().GetEventDB()
+	if edb == nil {
+		common.Respond(w, r, nil, common.NewErrInternal("no db connection"))
+		return
+	}
+
+	votes, err := edb.GetMultisigVotes(clientID, proposalID, limit)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("can't get votes", err.Error()))
+		return
+	}
+	if votes == nil {
+		votes = []event.MultisigVote{}
+	}
+	common.Respond(w, r, votes, nil)
+}
@@ -3,14 +3,17 @@ package multisigsc
 import (
 	"encoding/hex"
 	"encoding/json"
+	"strconv"
 
+	"0chain.net/chaincore/smartcontract"
+	sci "0chain.net/chaincore/smartcontractinterface"
 	"0chain.net/chaincore/state"
 	"0chain.net/core/common"
 	"0chain.net/core/datastore"
 	"0chain.net/core/encryption"
 )
 
-//msgp:ignore Vote
+//msgp:ignore Vote signerRequest thresholdRequest groupKeyProof
 //go:generate msgp -io=false -tests=false -unexported -v
 
 const (
@@ -19,6 +22,9 @@ const (
 	MaxSigners   = 20
 	MinSigners   = 2
 	MaxFieldSize = 256
+	MaxInputSize = 8 * 1024 // Maximal size of input of a proposed smart contract call.
+
+	MaxPrunedProposals = 2 // Proposals pruned by a vote at most.
 )
 
 type Wallet struct {
@@ -30,6 +36,10 @@ type Wallet struct {
 	SignerPublicKeys   []string `json:"signer_public_keys"`
 
 	NumRequired int `json:"num_required"`
+
+	// Incremented on every change of the signers or the threshold. Proposals
+	// created against another version of the signer set are stale.
+	SignerSetVersion int64 `json:"signer_set_version"`
 }
 
 func (w Wallet) Encode() []byte {
@@ -120,6 +130,96 @@ func (w Wallet) valid(forClientID string) (bool, error) {
 	return true, nil
 }
 
+// Add a signer to the wallet. The resulting wallet must be validated.
+func (w *Wallet) addSigner(sr signerRequest) {
+	w.SignerThresholdIDs = append(w.SignerThresholdIDs, sr.ThresholdID)
+	w.SignerPublicKeys = append(w.SignerPublicKeys, sr.PublicKey)
+	w.SignerSetVersion++
+}
+
+// Remove a signer from the wallet. The resulting wallet must be validated.
+func (w *Wallet) removeSigner(signerThresholdID string) bool {
+	for i, id := range w.SignerThresholdIDs {
+		if id != signerThresholdID {
+			continue
+		}
+		w.SignerThresholdIDs = append(w.SignerThresholdIDs[:i:i], w.SignerThresholdIDs[i+1:]...)
+		w.SignerPublicKeys = append(w.SignerPublicKeys[:i:i], w.SignerPublicKeys[i+1:]...)
+		w.SignerSetVersion++
+		return true
+	}
+	return false
+}
+
+// Change number of signatures required. The resulting wallet must be
+// validated.
+func (w *Wallet) setThreshold(numRequired int) {
+	w.NumRequired = numRequired
+	w.SignerSetVersion++
+}
+
+// Input of the function adding a signer to a multi-sig wallet. For the
+// removal only the threshold ID is required.
+type signerRequest struct {
+	ThresholdID string `json:"signer_threshold_id"`
+	PublicKey   string `json:"signer_public_key"`
+
+	GroupKey groupKeyProof `json:"group_key"`
+}
+
+// Input of the function changing the threshold of a multi-sig wallet.
+type thresholdRequest struct {
+	NumRequired int `json:"num_required"`
+
+	GroupKey groupKeyProof `json:"group_key"`
+}
+
+// Proof that the changed signer set of a wallet holds shares of the wallet's
+// group key. The client ID of the wallet is derived from the group key, so
+// the signers reshare the group secret rather than replace it. The proof is
+// made of NumRequired signature shares of the new signers on the key proof
+// message of the wallet, which reconstruct to a signature by the group key.
+type groupKeyProof struct {
+	PublicKey          string   `json:"public_key"`
+	SignerThresholdIDs []string `json:"signer_threshold_ids"`
+	SignerSignatures   []string `json:"signer_signatures"`
+}
+
+// Message signed by the signers of the wallet to prove a change of its signer
+// set. It includes the new signer set version, so a proof can't be replayed.
+func (w Wallet) groupKeyProofMessage() string {
+	return encryption.Hash(w.ClientID + ":" + strconv.FormatInt(w.SignerSetVersion, 10))
+}
+
+// Verify the group key proof against the changed wallet. The resulting
+// wallet must be valid.
+func (w Wallet) verifyGroupKeyProof(gp groupKeyProof) error {
+	if gp.PublicKey != w.PublicKey {
+		return common.NewError("group_key_no_match", "the group public key doesn't match the wallet")
+	}
+	if len(gp.SignerThresholdIDs) != w.NumRequired ||
+		len(gp.SignerSignatures) != w.NumRequired {
+		return common.NewError("group_key_proof_size", "the group key proof must have a signature share of each of the required signers")
+	}
+	if hasDuplicates(gp.SignerThresholdIDs) {
+		return common.NewError("duplicate_signer_ids", "duplicate threshold ids present")
+	}
+
+	sig, err := w.reconstructSignature(gp.SignerThresholdIDs, gp.SignerSignatures)
+	if err != nil {
+		return common.NewError("group_key_proof_invalid", err.Error())
+	}
+
+	scheme := encryption.GetSignatureScheme(w.SignatureScheme)
+	if err := scheme.SetPublicKey(w.PublicKey); err != nil {
+		return err
+	}
+	if ok, err := scheme.Verify(sig, w.groupKeyProofMessage()); err != nil || !ok {
+		return common.NewError("group_key_proof_invalid", "the signers don't hold shares of the group key")
+	}
+	return nil
+}
+
 func isPublicKeyForClientID(publicKey, clientID string) bool {
 	publicKeyBytes, err := hex.DecodeString(publicKey)
 	if err != nil {
@@ -151,10 +251,32 @@ func (w Wallet) isVoteAuthorized(signingClientID string, v Vote) bool {
 		return false
 	}
 
+	if v.Call != nil {
+		// Signers of a smart contract call sign the whole call, not only the
+		// transfer of the tokens.
+		return w.verifyCallSignature(publicKey, v.Signature, v.Transfer, v.getCall())
+	}
+
 	err := w.makeSignedTransferForVote(publicKey, v).VerifySignature(false)
 	return err == nil
 }
 
+func (w Wallet) verifyCallSignature(publicKey, sig string, t state.Transfer, call *SmartContractCall) bool {
+	scheme := encryption.GetSignatureScheme(w.SignatureScheme)
+	if err := scheme.SetPublicKey(publicKey); err != nil {
+		return false
+	}
+
+	ok, err := scheme.Verify(sig, call.hash(t))
+	return err == nil && ok
+}
+
+// Check the reconstructed threshold signature of a smart contract call proposal
+// against the public key of the multi-sig wallet.
+func (w Wallet) isProposalCallAuthorized(p proposal) bool {
+	return w.verifyCallSignature(w.PublicKey, p.ClientSignature, p.Transfer, p.Call)
+}
+
 func (w Wallet) makeSignedTransferForVote(signingPublicKey string, v Vote) state.SignedTransfer {
 	return state.SignedTransfer{
 		Transfer:   v.Transfer,
@@ -216,11 +338,17 @@ func (w Wallet) publicKeyForThresholdID(signerThresholdID string) string {
 // y-intercept of this polynomial is the proposal's signature. (This process is
 // called reconstruction in the literature.)
 func (w Wallet) constructTransferSignature(p proposal) (string, error) {
+	// All of the SignerSignatures are signatures on the transfer, which means
+	// this reconstructed signature will be, too.
+	return w.reconstructSignature(p.SignerThresholdIDs, p.SignerSignatures)
+}
+
+func (w Wallet) reconstructSignature(signerThresholdIDs, signerSignatures []string) (string, error) {
 	t := w.NumRequired
 	n := len(w.SignerThresholdIDs)
 	rec := encryption.GetReconstructSignatureScheme(w.SignatureScheme, t, n)
 
-	for i, id := range p.SignerThresholdIDs {
+	for i, id := range signerThresholdIDs {
 		publicKey := w.publicKeyForThresholdID(id)
 		if publicKey == "" {
 			// Logic error?
@@ -239,7 +367,7 @@ func (w Wallet) constructTransferSignature(p proposal) (string, error) {
 			return "", err
 		}
 
-		sig := p.SignerSignatures[i]
+		sig := signerSignatures[i]
 
 		err = rec.Add(tss, sig)
 		if err != nil {
@@ -247,8 +375,6 @@ func (w Wallet) constructTransferSignature(p proposal) (string, error) {
 		}
 	}
 
-	// All of the SignerSignatures are signatures on the transfer, which means
-	// this reconstructed signature will be, too.
 	return rec.Reconstruct()
 }
 
@@ -258,10 +384,20 @@ type Vote struct {
 	// Client ID in transfer is that of the multi-sig wallet, not the signer.
 	Transfer state.Transfer `json:"transfer"`
 
+	// Optional smart contract call executed on behalf of the multi-sig wallet
+	// instead of the plain transfer. In this case the transfer's ToClientID is
+	// the smart contract address and the Amount is the call's value, which may
+	// be zero.
+	Call *sci.SmartContractTransactionData `json:"call,omitempty"`
+
 	Signature string `json:"signature"`
 }
 
 func (v Vote) notTooBig() bool {
+	if v.Call != nil && (len(v.Call.FunctionName) > MaxFieldSize ||
+		len(v.Call.InputData) > MaxInputSize) {
+		return false
+	}
 	return len(v.ProposalID) <= MaxFieldSize &&
 		len(v.Transfer.ClientID) <= MaxFieldSize &&
 		len(v.Transfer.ToClientID) <= MaxFieldSize &&
@@ -269,7 +405,36 @@ func (v Vote) notTooBig() bool {
 }
 
 func (v Vote) hasValidAmount() bool {
-	return v.Transfer.Amount > 0
+	return v.Transfer.Amount > 0 || v.Call != nil
+}
+
+// Check that the proposed call targets an existing smart contract and doesn't
+// reenter the voting of the multi-sig smart contract.
+func (v Vote) validateCall() error {
+	if v.Call == nil {
+		return nil
+	}
+	if v.Call.FunctionName == "" {
+		return common.NewError("err_vote_invalid_call", " missing function name")
+	}
+	if smartcontract.GetSmartContract(v.Transfer.ToClientID) == nil {
+		return common.NewError("err_vote_invalid_call", " unknown smart contract "+v.Transfer.ToClientID)
+	}
+	if v.Transfer.ToClientID == Address &&
+		(v.Call.FunctionName == RegisterFuncName || v.Call.FunctionName == VoteFuncName) {
+		return common.NewError("err_vote_invalid_call", " can't call "+v.Call.FunctionName+" of multi-sig smart contract")
+	}
+	return nil
+}
+
+func (v Vote) getCall() *SmartContractCall {
+	if v.Call == nil {
+		return nil
+	}
+	return &SmartContractCall{
+		FunctionName: v.Call.FunctionName,
+		InputData:    string(v.Call.InputData),
+	}
 }
 
 func (v Vote) hasSignature() bool {
@@ -284,7 +449,27 @@ func (v Vote) getProposalRef() proposalRef {
 }
 
 func (v Vote) isCompatibleWithProposal(p proposal) bool {
-	return v.Transfer == p.Transfer
+	if v.Transfer != p.Transfer {
+		return false
+	}
+	call := v.getCall()
+	if call == nil || p.Call == nil {
+		return call == p.Call
+	}
+	return *call == *p.Call
+}
+
+// A smart contract function call proposed to be executed on behalf of a
+// multi-sig wallet. The InputData is the raw JSON input of the function.
+type SmartContractCall struct {
+	FunctionName string `json:"name"`
+	InputData    string `json:"input"`
+}
+
+// The hash signers sign voting for a smart contract call. It covers the call
+// and the transfer of the call's value.
+func (c *SmartContractCall) hash(t state.Transfer) string {
+	return encryption.Hash(string(t.Encode()) + ":" + c.FunctionName + ":" + c.InputData)
 }
 
 // Uniquely identifies a proposal. Can be used to refer to one.
@@ -317,6 +502,9 @@ type proposal struct {
 
 	Transfer state.Transfer `json:"transfer"`
 
+	// Smart contract call approved by the proposal, nil for a plain transfer.
+	Call *SmartContractCall `json:"call,omitempty"`
+
 	// Pertinent data from votes.
 	SignerThresholdIDs []string `json:"signer_threshold_ids"`
 	SignerSignatures   []string `json:"signer_signatures"`
@@ -324,6 +512,9 @@ type proposal struct {
 	// Filled upon completing a proposal.
 	ClientSignature   string `json:"client_signature"`
 	ExecutedInTxnHash string `json:"executed_in_txn_hash"`
+
+	// Version of the wallet's signer set the votes were collected against.
+	SignerSetVersion int64 `json:"signer_set_version"`
 }
 
 func (p *proposal) Encode() []byte {
@@ -344,6 +535,12 @@ func (p proposal) isExpired(now common.Timestamp) bool {
 	return now >= p.ExpirationDate
 }
 
+// Check if the proposal was built against an old signer set of the wallet.
+// Such a proposal never executes and is discarded.
+func (p proposal) isStale(w Wallet) bool {
+	return p.ExecutedInTxnHash == "" && p.SignerSetVersion != w.SignerSetVersion
+}
+
 func (p proposal) ref() proposalRef {
 	return proposalRef{
 		ClientID:   p.Transfer.ClientID,
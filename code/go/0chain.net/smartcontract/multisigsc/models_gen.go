@@ -6,12 +6,73 @@ import (
 	"github.com/tinylib/msgp/msgp"
 )
 
+// MarshalMsg implements msgp.Marshaler
+func (z SmartContractCall) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "FunctionName"
+	o = append(o, 0x82, 0xac, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65)
+	o = msgp.AppendString(o, z.FunctionName)
+	// string "InputData"
+	o = append(o, 0xa9, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x44, 0x61, 0x74, 0x61)
+	o = msgp.AppendString(o, z.InputData)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *SmartContractCall) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "FunctionName":
+			z.FunctionName, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "FunctionName")
+				return
+			}
+		case "InputData":
+			z.InputData, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "InputData")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z SmartContractCall) Msgsize() (s int) {
+	s = 1 + 13 + msgp.StringPrefixSize + len(z.FunctionName) + 10 + msgp.StringPrefixSize + len(z.InputData)
+	return
+}
+
 // MarshalMsg implements msgp.Marshaler
 func (z *Wallet) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 6
+	// map header, size 7
 	// string "ClientID"
-	o = append(o, 0x86, 0xa8, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x44)
+	o = append(o, 0x87, 0xa8, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x44)
 	o = msgp.AppendString(o, z.ClientID)
 	// string "SignatureScheme"
 	o = append(o, 0xaf, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x53, 0x63, 0x68, 0x65, 0x6d, 0x65)
@@ -34,6 +95,9 @@ func (z *Wallet) MarshalMsg(b []byte) (o []byte, err error) {
 	// string "NumRequired"
 	o = append(o, 0xab, 0x4e, 0x75, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64)
 	o = msgp.AppendInt(o, z.NumRequired)
+	// string "SignerSetVersion"
+	o = append(o, 0xb0, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x53, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e)
+	o = msgp.AppendInt64(o, z.SignerSetVersion)
 	return
 }
 
@@ -117,6 +181,12 @@ func (z *Wallet) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "NumRequired")
 				return
 			}
+		case "SignerSetVersion":
+			z.SignerSetVersion, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "SignerSetVersion")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -139,7 +209,7 @@ func (z *Wallet) Msgsize() (s int) {
 	for za0002 := range z.SignerPublicKeys {
 		s += msgp.StringPrefixSize + len(z.SignerPublicKeys[za0002])
 	}
-	s += 12 + msgp.IntSize
+	s += 12 + msgp.IntSize + 17 + msgp.Int64Size
 	return
 }
 
@@ -277,9 +347,9 @@ func (z *expirationQueue) Msgsize() (s int) {
 // MarshalMsg implements msgp.Marshaler
 func (z *proposal) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 9
+	// map header, size 11
 	// string "ProposalID"
-	o = append(o, 0x89, 0xaa, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x49, 0x44)
+	o = append(o, 0x8b, 0xaa, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x49, 0x44)
 	o = msgp.AppendString(o, z.ProposalID)
 	// string "ExpirationDate"
 	o = append(o, 0xae, 0x45, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x65)
@@ -313,6 +383,19 @@ func (z *proposal) MarshalMsg(b []byte) (o []byte, err error) {
 		err = msgp.WrapError(err, "Transfer")
 		return
 	}
+	// string "Call"
+	o = append(o, 0xa4, 0x43, 0x61, 0x6c, 0x6c)
+	if z.Call == nil {
+		o = msgp.AppendNil(o)
+	} else {
+		// map header, size 2
+		// string "FunctionName"
+		o = append(o, 0x82, 0xac, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65)
+		o = msgp.AppendString(o, z.Call.FunctionName)
+		// string "InputData"
+		o = append(o, 0xa9, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x44, 0x61, 0x74, 0x61)
+		o = msgp.AppendString(o, z.Call.InputData)
+	}
 	// string "SignerThresholdIDs"
 	o = append(o, 0xb2, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x49, 0x44, 0x73)
 	o = msgp.AppendArrayHeader(o, uint32(len(z.SignerThresholdIDs)))
@@ -331,6 +414,9 @@ func (z *proposal) MarshalMsg(b []byte) (o []byte, err error) {
 	// string "ExecutedInTxnHash"
 	o = append(o, 0xb1, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x64, 0x49, 0x6e, 0x54, 0x78, 0x6e, 0x48, 0x61, 0x73, 0x68)
 	o = msgp.AppendString(o, z.ExecutedInTxnHash)
+	// string "SignerSetVersion"
+	o = append(o, 0xb0, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x53, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e)
+	o = msgp.AppendInt64(o, z.SignerSetVersion)
 	return
 }
 
@@ -440,17 +526,63 @@ func (z *proposal) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "Transfer")
 				return
 			}
+		case "Call":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.Call = nil
+			} else {
+				if z.Call == nil {
+					z.Call = new(SmartContractCall)
+				}
+				var zb0004 uint32
+				zb0004, bts, err = msgp.ReadMapHeaderBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Call")
+					return
+				}
+				for zb0004 > 0 {
+					zb0004--
+					field, bts, err = msgp.ReadMapKeyZC(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Call")
+						return
+					}
+					switch msgp.UnsafeString(field) {
+					case "FunctionName":
+						z.Call.FunctionName, bts, err = msgp.ReadStringBytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Call", "FunctionName")
+							return
+						}
+					case "InputData":
+						z.Call.InputData, bts, err = msgp.ReadStringBytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Call", "InputData")
+							return
+						}
+					default:
+						bts, err = msgp.Skip(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Call")
+							return
+						}
+					}
+				}
+			}
 		case "SignerThresholdIDs":
-			var zb0004 uint32
-			zb0004, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			var zb0005 uint32
+			zb0005, bts, err = msgp.ReadArrayHeaderBytes(bts)
 			if err != nil {
 				err = msgp.WrapError(err, "SignerThresholdIDs")
 				return
 			}
-			if cap(z.SignerThresholdIDs) >= int(zb0004) {
-				z.SignerThresholdIDs = (z.SignerThresholdIDs)[:zb0004]
+			if cap(z.SignerThresholdIDs) >= int(zb0005) {
+				z.SignerThresholdIDs = (z.SignerThresholdIDs)[:zb0005]
 			} else {
-				z.SignerThresholdIDs = make([]string, zb0004)
+				z.SignerThresholdIDs = make([]string, zb0005)
 			}
 			for za0001 := range z.SignerThresholdIDs {
 				z.SignerThresholdIDs[za0001], bts, err = msgp.ReadStringBytes(bts)
@@ -460,16 +592,16 @@ func (z *proposal) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				}
 			}
 		case "SignerSignatures":
-			var zb0005 uint32
-			zb0005, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			var zb0006 uint32
+			zb0006, bts, err = msgp.ReadArrayHeaderBytes(bts)
 			if err != nil {
 				err = msgp.WrapError(err, "SignerSignatures")
 				return
 			}
-			if cap(z.SignerSignatures) >= int(zb0005) {
-				z.SignerSignatures = (z.SignerSignatures)[:zb0005]
+			if cap(z.SignerSignatures) >= int(zb0006) {
+				z.SignerSignatures = (z.SignerSignatures)[:zb0006]
 			} else {
-				z.SignerSignatures = make([]string, zb0005)
+				z.SignerSignatures = make([]string, zb0006)
 			}
 			for za0002 := range z.SignerSignatures {
 				z.SignerSignatures[za0002], bts, err = msgp.ReadStringBytes(bts)
@@ -490,6 +622,12 @@ func (z *proposal) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "ExecutedInTxnHash")
 				return
 			}
+		case "SignerSetVersion":
+			z.SignerSetVersion, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "SignerSetVersion")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -504,7 +642,13 @@ func (z *proposal) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *proposal) Msgsize() (s int) {
-	s = 1 + 11 + msgp.StringPrefixSize + len(z.ProposalID) + 15 + z.ExpirationDate.Msgsize() + 5 + 1 + 9 + msgp.StringPrefixSize + len(z.Next.ClientID) + 11 + msgp.StringPrefixSize + len(z.Next.ProposalID) + 5 + 1 + 9 + msgp.StringPrefixSize + len(z.Prev.ClientID) + 11 + msgp.StringPrefixSize + len(z.Prev.ProposalID) + 9 + z.Transfer.Msgsize() + 19 + msgp.ArrayHeaderSize
+	s = 1 + 11 + msgp.StringPrefixSize + len(z.ProposalID) + 15 + z.ExpirationDate.Msgsize() + 5 + 1 + 9 + msgp.StringPrefixSize + len(z.Next.ClientID) + 11 + msgp.StringPrefixSize + len(z.Next.ProposalID) + 5 + 1 + 9 + msgp.StringPrefixSize + len(z.Prev.ClientID) + 11 + msgp.StringPrefixSize + len(z.Prev.ProposalID) + 9 + z.Transfer.Msgsize() + 5
+	if z.Call == nil {
+		s += msgp.NilSize
+	} else {
+		s += 1 + 13 + msgp.StringPrefixSize + len(z.Call.FunctionName) + 10 + msgp.StringPrefixSize + len(z.Call.InputData)
+	}
+	s += 19 + msgp.ArrayHeaderSize
 	for za0001 := range z.SignerThresholdIDs {
 		s += msgp.StringPrefixSize + len(z.SignerThresholdIDs[za0001])
 	}
@@ -512,7 +656,7 @@ func (z *proposal) Msgsize() (s int) {
 	for za0002 := range z.SignerSignatures {
 		s += msgp.StringPrefixSize + len(z.SignerSignatures[za0002])
 	}
-	s += 16 + msgp.StringPrefixSize + len(z.ClientSignature) + 18 + msgp.StringPrefixSize + len(z.ExecutedInTxnHash)
+	s += 16 + msgp.StringPrefixSize + len(z.ClientSignature) + 18 + msgp.StringPrefixSize + len(z.ExecutedInTxnHash) + 17 + msgp.Int64Size
 	return
 }
 
@@ -3,6 +3,7 @@ package multisigsc
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
 	"time"
@@ -20,11 +21,14 @@ import (
 )
 
 const (
-	name             = "multisig"
-	Address          = "27b5ef7120252b79f9dd9c05505dd28f328c80f6863ee446daede08a84d651a7"
-	RegisterFuncName = "register"
-	VoteFuncName     = "vote"
-	LogTimingInfo    = false
+	name                 = "multisig"
+	Address              = "27b5ef7120252b79f9dd9c05505dd28f328c80f6863ee446daede08a84d651a7"
+	RegisterFuncName     = "register"
+	VoteFuncName         = "vote"
+	AddSignerFuncName    = "addsigner"
+	RemoveSignerFuncName = "removesigner"
+	SetThresholdFuncName = "setthreshold"
+	LogTimingInfo        = false
 )
 
 type MultiSigSmartContract struct {
@@ -74,6 +78,12 @@ func (ms *MultiSigSmartContract) Execute(t *transaction.Transaction, funcName st
 		return ms.register(t.ClientID, inputData, balances)
 	case VoteFuncName:
 		return ms.vote(t.Hash, t.ClientID, balances.GetBlock().CreationDate, inputData, balances)
+	case AddSignerFuncName:
+		return ms.addSigner(t.ClientID, inputData, balances)
+	case RemoveSignerFuncName:
+		return ms.removeSigner(t.ClientID, inputData, balances)
+	case SetThresholdFuncName:
+		return ms.setThreshold(t.ClientID, inputData, balances)
 	default:
 		return "err_execute_function_not_found: no multi sig smart contract function with that name: " + funcName, nil
 	}
@@ -94,6 +104,9 @@ func (ms MultiSigSmartContract) register(registeringClientID string, inputData [
 		return "err_register_formatting: incorrect request format", err
 	}
 
+	// A new wallet always starts with the first version of its signer set.
+	w.SignerSetVersion = 0
+
 	// Check for silly parameters that don't make sense. Not a comprehensive
 	// check so errors might still pop up down the line.
 	isValid, err := w.valid(registeringClientID)
@@ -125,6 +138,8 @@ func (ms MultiSigSmartContract) register(registeringClientID string, inputData [
 		return "", err
 	}
 
+	emitAddOrOverwriteWallet(w, balances)
+
 	return "success: multi-signature wallet registered", nil
 }
 
@@ -156,15 +171,44 @@ func (ms MultiSigSmartContract) vote(currentTxnHash, signingClientID string, now
 	if !v.hasSignature() {
 		return "", common.NewError("err_vote_no_signature", " must sign vote")
 	}
+	if err := v.validateCall(); err != nil {
+		return "", err
+	}
+
+	// Check that the multi-sig wallet is registered.
+	w, err := ms.getWallet(v.Transfer.ClientID, balances)
+	if err != nil {
+		// I/O error.
+		return "", err
+	}
+	if w.isEmpty() {
+		return "", common.NewError("err_vote_wallet_not_registered", " wallet not registered")
+	}
 
 	// Every vote is associated with a proposal. If an appropriate proposal does
 	// not exist yet, create one.
-	p, err := ms.findOrCreateProposal(now, v, balances)
+	p, err := ms.findOrCreateProposal(now, w, v, balances)
 	if err != nil {
 		// I/O error.
 		return "", err
 	}
 
+	// Votes collected before the last change of the signers can't be used,
+	// start the proposal over.
+	if p.isStale(w) {
+		err = ms.prune(p.ref(), balances)
+		if err != nil {
+			// I/O error.
+			return "", err
+		}
+
+		p, err = ms.createProposal(now, w, v, balances)
+		if err != nil {
+			// I/O error.
+			return "", err
+		}
+	}
+
 	// Ensure all voters are on the same page.
 	if !v.isCompatibleWithProposal(p) {
 		return "", common.NewError("err_vote_not_compatible", " previous votes for same proposal differed")
@@ -175,16 +219,6 @@ func (ms MultiSigSmartContract) vote(currentTxnHash, signingClientID string, now
 		return "success 0: proposal previously executed in transaction hash " + p.ExecutedInTxnHash, nil
 	}
 
-	// Check that the multi-sig wallet is registered.
-	w, err := ms.getWallet(v.Transfer.ClientID, balances)
-	if err != nil {
-		// I/O error.
-		return "", err
-	}
-	if w.isEmpty() {
-		return "", common.NewError("err_vote_wallet_not_registered", " wallet not registered")
-	}
-
 	// Check that the voter is registered on the wallet and that the signature
 	// is valid.
 	signerThresholdID := w.thresholdIdForSigner(signingClientID)
@@ -215,6 +249,9 @@ func (ms MultiSigSmartContract) vote(currentTxnHash, signingClientID string, now
 		return "", err
 	}
 
+	emitAddOrOverwriteProposal(w, p, balances)
+	emitAddVote(p.ref(), signerThresholdID, balances)
+
 	remaining--
 
 	// If more votes are still needed we must wait for them. Nothing more to do.
@@ -232,6 +269,10 @@ func (ms MultiSigSmartContract) vote(currentTxnHash, signingClientID string, now
 
 	p.ClientSignature = thresholdSignature
 
+	if p.Call != nil {
+		return ms.executeCall(currentTxnHash, now, w, p, balances)
+	}
+
 	// Request the transfer. The blockchain will validate the signature and
 	// execute the transfer soon. If the signature is found to be invalid,
 	// this vote transaction will fail.
@@ -247,34 +288,194 @@ func (ms MultiSigSmartContract) vote(currentTxnHash, signingClientID string, now
 		return "", err
 	}
 
+	emitAddOrOverwriteProposal(w, p, balances)
+
 	msg := "success 0: transfer executed with signature " + p.ClientSignature
 	return msg, nil
 }
 
-// Prune the oldest proposal if it has expired.
-func (ms MultiSigSmartContract) pruneExpirationQueue(now common.Timestamp, balances state.StateContextI) error {
-	q, err := ms.getOrCreateExpirationQueue(balances)
-	if err != nil {
-		return err
+// Add a signer to a registered multi-sig wallet. Only the wallet itself can
+// change its signers, so the change is made by a quorum-approved proposal
+// calling this function.
+func (ms MultiSigSmartContract) addSigner(clientID string, inputData []byte, balances state.StateContextI) (string, error) {
+	var sr signerRequest
+	if err := json.Unmarshal(inputData, &sr); err != nil {
+		return "", common.NewError("err_add_signer_formatting", "incorrect request format: "+err.Error())
 	}
 
-	// Reference to oldest proposal.
-	ref := q.Head
+	return ms.updateWallet(clientID, "err_add_signer", sr.GroupKey, func(w *Wallet) error {
+		w.addSigner(sr)
+		return nil
+	}, balances)
+}
+
+// Remove a signer from a registered multi-sig wallet by a quorum-approved
+// proposal.
+func (ms MultiSigSmartContract) removeSigner(clientID string, inputData []byte, balances state.StateContextI) (string, error) {
+	var sr signerRequest
+	if err := json.Unmarshal(inputData, &sr); err != nil {
+		return "", common.NewError("err_remove_signer_formatting", "incorrect request format: "+err.Error())
+	}
 
-	if ref == (proposalRef{}) {
-		// No proposals currently exist.
+	return ms.updateWallet(clientID, "err_remove_signer", sr.GroupKey, func(w *Wallet) error {
+		if !w.removeSigner(sr.ThresholdID) {
+			return errors.New("no such signer")
+		}
 		return nil
+	}, balances)
+}
+
+// Change number of signatures required by a registered multi-sig wallet by
+// a quorum-approved proposal.
+func (ms MultiSigSmartContract) setThreshold(clientID string, inputData []byte, balances state.StateContextI) (string, error) {
+	var tr thresholdRequest
+	if err := json.Unmarshal(inputData, &tr); err != nil {
+		return "", common.NewError("err_set_threshold_formatting", "incorrect request format: "+err.Error())
 	}
 
-	p, err := ms.getProposal(ref, balances)
+	return ms.updateWallet(clientID, "err_set_threshold", tr.GroupKey, func(w *Wallet) error {
+		w.setThreshold(tr.NumRequired)
+		return nil
+	}, balances)
+}
+
+// Apply a change to the signer set of the wallet of the client and save the
+// wallet if the result is valid and the new signers prove they hold shares of
+// the group key. Proposals of the wallet in flight become stale and are pruned.
+func (ms MultiSigSmartContract) updateWallet(clientID, errCode string, gp groupKeyProof, update func(w *Wallet) error, balances state.StateContextI) (string, error) {
+	w, err := ms.getWallet(clientID, balances)
 	if err != nil {
-		return err
+		if err == util.ErrValueNotPresent {
+			return "", common.NewError(errCode, "multi-sig wallet not registered")
+		}
+		// I/O error.
+		return "", err
 	}
 
-	if p.isExpired(now) {
-		return ms.prune(ref, balances)
+	if err = update(&w); err != nil {
+		return "", common.NewError(errCode, err.Error())
 	}
 
+	if _, err = w.valid(clientID); err != nil {
+		return "", common.NewError(errCode, err.Error())
+	}
+
+	if err = w.verifyGroupKeyProof(gp); err != nil {
+		return "", common.NewError(errCode, err.Error())
+	}
+
+	err = ms.putWallet(w, balances)
+	if err != nil {
+		// I/O error.
+		return "", err
+	}
+
+	emitAddOrOverwriteWallet(w, balances)
+
+	return fmt.Sprintf("success: multi-signature wallet updated, %d of %d signers required",
+		w.NumRequired, len(w.SignerThresholdIDs)), nil
+}
+
+// Execute the smart contract call of an approved proposal on behalf of the
+// multi-sig wallet. The call is executed as an inner transaction of the vote
+// transaction, so a failure of the call fails the vote.
+func (ms MultiSigSmartContract) executeCall(currentTxnHash string, now common.Timestamp, w Wallet, p proposal, balances state.StateContextI) (string, error) {
+	if !w.isProposalCallAuthorized(p) {
+		return "", common.NewError("err_vote_recover", " invalid threshold signature on the call")
+	}
+
+	if p.Transfer.Amount > 0 {
+		balance, err := balances.GetClientBalance(w.ClientID)
+		if err != nil && err != util.ErrValueNotPresent {
+			return "", err
+		}
+		if balance < p.Transfer.Amount {
+			return "", common.NewError("err_vote_insufficient_balance", " not enough tokens on the wallet for the call")
+		}
+	}
+
+	data, err := json.Marshal(smartcontractinterface.SmartContractTransactionData{
+		FunctionName: p.Call.FunctionName,
+		InputData:    json.RawMessage(p.Call.InputData),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	txn := &transaction.Transaction{
+		ClientID:        w.ClientID,
+		PublicKey:       w.PublicKey,
+		ToClientID:      p.Transfer.ToClientID,
+		Value:           p.Transfer.Amount,
+		CreationDate:    now,
+		TransactionType: transaction.TxnTypeSmartContract,
+		TransactionData: string(data),
+		SmartContractData: &transaction.SmartContractData{
+			FunctionName: p.Call.FunctionName,
+			InputData:    json.RawMessage(p.Call.InputData),
+		},
+	}
+	txn.Hash = currentTxnHash
+
+	output, err := balances.ExecuteOnBehalf(txn, func(b state.StateContextI) (string, error) {
+		return smartcontract.ExecuteSmartContract(txn, b)
+	})
+	if err != nil {
+		return "", common.NewError("err_vote_call", " executing the call: "+err.Error())
+	}
+
+	p.ExecutedInTxnHash = currentTxnHash
+
+	err = ms.putProposal(&p, balances)
+	if err != nil {
+		// I/O error.
+		return "", err
+	}
+
+	emitAddOrOverwriteProposal(w, p, balances)
+
+	return "success 0: call executed with output " + output, nil
+}
+
+// Prune the oldest proposals up to the first one which is neither expired nor
+// stale, at most MaxPrunedProposals of them so a vote doesn't pay for the
+// whole backlog.
+func (ms MultiSigSmartContract) pruneExpirationQueue(now common.Timestamp, balances state.StateContextI) error {
+	for i := 0; i < MaxPrunedProposals; i++ {
+		q, err := ms.getOrCreateExpirationQueue(balances)
+		if err != nil {
+			return err
+		}
+
+		// Reference to oldest proposal.
+		ref := q.Head
+
+		if ref == (proposalRef{}) {
+			// No proposals currently exist.
+			return nil
+		}
+
+		p, err := ms.getProposal(ref, balances)
+		if err != nil {
+			return err
+		}
+
+		if !p.isExpired(now) {
+			// Proposals built against an old signer set of their wallet are
+			// pruned without waiting for the expiration.
+			w, err := ms.getWallet(p.Transfer.ClientID, balances)
+			if err != nil && err != util.ErrValueNotPresent {
+				return err
+			}
+			if !w.isEmpty() && !p.isStale(w) {
+				return nil
+			}
+		}
+
+		if err := ms.prune(ref, balances); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -344,10 +545,12 @@ func (ms MultiSigSmartContract) prune(ref proposalRef, balances c_state.StateCon
 		return err
 	}
 
+	emitDeleteProposal(ref, balances)
+
 	return nil
 }
 
-func (ms MultiSigSmartContract) findOrCreateProposal(now common.Timestamp, v Vote, balances state.StateContextI) (proposal, error) {
+func (ms MultiSigSmartContract) findOrCreateProposal(now common.Timestamp, w Wallet, v Vote, balances state.StateContextI) (proposal, error) {
 	// Start by trying to find an existing proposal.
 	p, err := ms.getProposal(v.getProposalRef(), balances)
 	if err != nil {
@@ -366,7 +569,7 @@ func (ms MultiSigSmartContract) findOrCreateProposal(now common.Timestamp, v Vot
 
 	// If it didn't exist or was expired, create it and update expiration queue.
 	if p.isEmpty() {
-		p, err = ms.createProposal(now, v, balances)
+		p, err = ms.createProposal(now, w, v, balances)
 		if err != nil {
 			return proposal{}, err
 		}
@@ -376,7 +579,7 @@ func (ms MultiSigSmartContract) findOrCreateProposal(now common.Timestamp, v Vot
 }
 
 // Create a proposal and add it to the expiration queue. Performs I/O.
-func (ms MultiSigSmartContract) createProposal(now common.Timestamp, v Vote, balances state.StateContextI) (proposal, error) {
+func (ms MultiSigSmartContract) createProposal(now common.Timestamp, w Wallet, v Vote, balances state.StateContextI) (proposal, error) {
 	q, err := ms.getOrCreateExpirationQueue(balances)
 	if err != nil {
 		if err != util.ErrValueNotPresent && err != util.ErrNodeNotFound {
@@ -393,12 +596,15 @@ func (ms MultiSigSmartContract) createProposal(now common.Timestamp, v Vote, bal
 		Prev: q.Tail,
 
 		Transfer: v.Transfer,
+		Call:     v.getCall(),
 
 		SignerThresholdIDs: []string{},
 		SignerSignatures:   []string{},
 
 		ClientSignature:   "",
 		ExecutedInTxnHash: "",
+
+		SignerSetVersion: w.SignerSetVersion,
 	}
 
 	err = ms.putProposal(&p, balances)
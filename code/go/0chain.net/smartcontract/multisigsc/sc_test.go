@@ -0,0 +1,365 @@
+package multisigsc
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"testing"
+
+	"0chain.net/chaincore/block"
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/config"
+	"0chain.net/chaincore/config/mocks"
+	"0chain.net/chaincore/smartcontract"
+	sci "0chain.net/chaincore/smartcontractinterface"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/encryption"
+	"0chain.net/smartcontract/dbs/event"
+	"github.com/0chain/common/core/logging"
+	"github.com/0chain/common/core/util"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	logging.InitLogging("testing", "")
+	smartcontract.ContractMap[callTestAddress] = &callTestSC{}
+}
+
+var callTestAddress = encryption.Hash("multisig_call_test_sc")
+
+// callTestSC takes the value of its calls, it fails the "fail" ones after
+// the transfer.
+type callTestSC struct{}
+
+func (sc *callTestSC) Execute(txn *transaction.Transaction, funcName string, _ []byte,
+	balances cstate.StateContextI) (string, error) {
+	err := balances.AddTransfer(state.NewTransfer(txn.ClientID, txn.ToClientID, txn.Value))
+	if err != nil {
+		return "", err
+	}
+	balances.EmitEvent(event.TypeStats, event.TagAddOrOverwriteUser, txn.ClientID, nil)
+	if funcName == "fail" {
+		return "", errors.New("call failed")
+	}
+	return "paid", nil
+}
+
+func (sc *callTestSC) GetHandlerStats(context.Context, url.Values) (interface{}, error) {
+	return nil, nil
+}
+
+func (sc *callTestSC) GetExecutionStats() map[string]interface{} { return nil }
+
+func (sc *callTestSC) GetName() string { return "multisig_call_test" }
+
+func (sc *callTestSC) GetAddress() string { return callTestAddress }
+
+func (sc *callTestSC) GetCostTable(cstate.StateContextI) (map[string]int, error) {
+	return nil, nil
+}
+
+// Group key of a multi-sig wallet and its shares held by the signers.
+type testWallet struct {
+	groupKey encryption.SignatureScheme
+	signers  []encryption.ThresholdSignatureScheme
+}
+
+func newTestWallet(t *testing.T, numRequired, numSigners int) testWallet {
+	groupKey := encryption.GetSignatureScheme(encryption.SignatureSchemeBls0chain)
+	require.NoError(t, groupKey.GenerateKeys())
+
+	signers, err := encryption.GenerateThresholdKeyShares(encryption.SignatureSchemeBls0chain,
+		numRequired, numSigners, groupKey)
+	require.NoError(t, err)
+
+	return testWallet{groupKey: groupKey, signers: signers}
+}
+
+func clientIDForKey(publicKey string) string {
+	b, _ := hex.DecodeString(publicKey)
+	return encryption.Hash(b)
+}
+
+func (tw testWallet) clientID() string {
+	return clientIDForKey(tw.groupKey.GetPublicKey())
+}
+
+// Wallet of the group key with the first numSigners signers.
+func (tw testWallet) wallet(numRequired, numSigners int) Wallet {
+	w := Wallet{
+		ClientID:        tw.clientID(),
+		SignatureScheme: encryption.SignatureSchemeBls0chain,
+		PublicKey:       tw.groupKey.GetPublicKey(),
+		NumRequired:     numRequired,
+	}
+	for _, s := range tw.signers[:numSigners] {
+		w.SignerThresholdIDs = append(w.SignerThresholdIDs, s.GetID())
+		w.SignerPublicKeys = append(w.SignerPublicKeys, s.GetPublicKey())
+	}
+	return w
+}
+
+// Proof of the next signer set version of the wallet signed by the signers.
+func (tw testWallet) groupKeyProof(t *testing.T, w Wallet, signers ...encryption.ThresholdSignatureScheme) groupKeyProof {
+	msg := Wallet{
+		ClientID:         w.ClientID,
+		SignerSetVersion: w.SignerSetVersion + 1,
+	}.groupKeyProofMessage()
+
+	gp := groupKeyProof{PublicKey: tw.groupKey.GetPublicKey()}
+	for _, s := range signers {
+		sig, err := s.Sign(msg)
+		require.NoError(t, err)
+		gp.SignerThresholdIDs = append(gp.SignerThresholdIDs, s.GetID())
+		gp.SignerSignatures = append(gp.SignerSignatures, sig)
+	}
+	return gp
+}
+
+func newTestBalances() *cstate.StateContext {
+	b := &block.Block{}
+	b.Round = 1
+	txn := &transaction.Transaction{}
+	txn.Hash = "txn_hash"
+	return cstate.NewStateContext(
+		b,
+		util.NewMerklePatriciaTrie(util.NewMemoryNodeDB(), 1, nil),
+		txn, nil, nil, nil, nil, nil, nil)
+}
+
+func mustJSON(t *testing.T, v interface{}) []byte {
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	return b
+}
+
+func TestUpdateWallet(t *testing.T) {
+	var (
+		ms       = MultiSigSmartContract{}
+		balances = newTestBalances()
+		tw       = newTestWallet(t, 2, 4)
+		w        = tw.wallet(2, 3)
+		newcomer = tw.signers[3]
+	)
+	require.NoError(t, ms.putWallet(w, balances))
+
+	addSigner := func(gp groupKeyProof, signer encryption.ThresholdSignatureScheme) error {
+		_, err := ms.addSigner(w.ClientID, mustJSON(t, signerRequest{
+			ThresholdID: signer.GetID(),
+			PublicKey:   signer.GetPublicKey(),
+			GroupKey:    gp,
+		}), balances)
+		return err
+	}
+
+	// the new signer set has to prove it holds shares of the group key
+	err := addSigner(groupKeyProof{PublicKey: w.PublicKey}, newcomer)
+	require.EqualError(t, err, "err_add_signer: group_key_proof_size: the group key proof must have a signature share of each of the required signers")
+
+	// the group key can't be replaced
+	other := newTestWallet(t, 2, 4)
+	gp := other.groupKeyProof(t, w, other.signers[0], other.signers[3])
+	err = addSigner(gp, newcomer)
+	require.EqualError(t, err, "err_add_signer: group_key_no_match: the group public key doesn't match the wallet")
+
+	// a share of another group key doesn't reconstruct the group signature
+	gp = tw.groupKeyProof(t, w, tw.signers[0], other.signers[3])
+	err = addSigner(gp, other.signers[3])
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "err_add_signer: group_key_proof_invalid")
+
+	got, err := ms.getWallet(w.ClientID, balances)
+	require.NoError(t, err)
+	require.Equal(t, w, got)
+
+	gp = tw.groupKeyProof(t, w, tw.signers[0], newcomer)
+	require.NoError(t, addSigner(gp, newcomer))
+
+	w, err = ms.getWallet(w.ClientID, balances)
+	require.NoError(t, err)
+	require.Len(t, w.SignerThresholdIDs, 4)
+	require.EqualValues(t, 1, w.SignerSetVersion)
+
+	// a proof is valid for a single signer set version only
+	setThreshold := func(gp groupKeyProof) error {
+		_, err := ms.setThreshold(w.ClientID, mustJSON(t, thresholdRequest{
+			NumRequired: 3,
+			GroupKey:    gp,
+		}), balances)
+		return err
+	}
+	replayed := tw.groupKeyProof(t, Wallet{ClientID: w.ClientID}, tw.signers[0], tw.signers[1], tw.signers[2])
+	err = setThreshold(replayed)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "err_set_threshold: group_key_proof_invalid")
+
+	gp = tw.groupKeyProof(t, w, tw.signers[0], tw.signers[1], tw.signers[2])
+	require.NoError(t, setThreshold(gp))
+
+	w, err = ms.getWallet(w.ClientID, balances)
+	require.NoError(t, err)
+	require.Equal(t, 3, w.NumRequired)
+	require.EqualValues(t, 2, w.SignerSetVersion)
+
+	gp = tw.groupKeyProof(t, w, tw.signers[0], tw.signers[1], tw.signers[2])
+	_, err = ms.removeSigner(w.ClientID, mustJSON(t, signerRequest{
+		ThresholdID: newcomer.GetID(),
+		GroupKey:    gp,
+	}), balances)
+	require.NoError(t, err)
+
+	w, err = ms.getWallet(w.ClientID, balances)
+	require.NoError(t, err)
+	require.Equal(t, tw.wallet(3, 3).SignerThresholdIDs, w.SignerThresholdIDs)
+
+	var updates int
+	for _, e := range balances.GetEvents() {
+		if e.Tag == event.TagAddOrOverwriteMultisigWallet {
+			updates++
+		}
+	}
+	require.Equal(t, 3, updates)
+}
+
+func TestPruneExpirationQueue(t *testing.T) {
+	var (
+		ms       = MultiSigSmartContract{}
+		balances = newTestBalances()
+		w        = Wallet{ClientID: "wallet"}
+	)
+	require.NoError(t, ms.putWallet(w, balances))
+
+	var refs []proposalRef
+	for i, id := range []string{"p1", "p2", "p3", "p4"} {
+		p, err := ms.createProposal(common.Timestamp(10*i), w, Vote{
+			ProposalID: id,
+			Transfer:   state.Transfer{ClientID: w.ClientID, ToClientID: "to", Amount: 1},
+		}, balances)
+		require.NoError(t, err)
+		refs = append(refs, p.ref())
+	}
+
+	// the expired proposals at the head of the queue are pruned, at most
+	// MaxPrunedProposals of them at once
+	require.NoError(t, ms.pruneExpirationQueue(ExpirationTime+20, balances))
+
+	q, err := ms.getOrCreateExpirationQueue(balances)
+	require.NoError(t, err)
+	require.Equal(t, expirationQueue{Head: refs[2], Tail: refs[3]}, q)
+	for _, ref := range refs[:2] {
+		p, err := ms.getProposal(ref, balances)
+		require.NoError(t, err)
+		require.True(t, p.isEmpty())
+	}
+
+	require.NoError(t, ms.pruneExpirationQueue(ExpirationTime+20, balances))
+
+	q, err = ms.getOrCreateExpirationQueue(balances)
+	require.NoError(t, err)
+	require.Equal(t, expirationQueue{Head: refs[3], Tail: refs[3]}, q)
+
+	p, err := ms.getProposal(refs[3], balances)
+	require.NoError(t, err)
+	require.Equal(t, proposalRef{}, p.Prev)
+
+	// the proposals of an old signer set are pruned before they expire
+	w.SignerSetVersion++
+	require.NoError(t, ms.putWallet(w, balances))
+	require.NoError(t, ms.pruneExpirationQueue(ExpirationTime+10, balances))
+
+	q, err = ms.getOrCreateExpirationQueue(balances)
+	require.NoError(t, err)
+	require.Equal(t, expirationQueue{}, q)
+}
+
+func TestVoteCall(t *testing.T) {
+	chainConfig := &mocks.ChainConfig{}
+	chainConfig.On("IsFeeEnabled").Return(false)
+	config.Configuration().ChainConfig = chainConfig
+
+	tw := newTestWallet(t, 2, 3)
+	w := tw.wallet(2, 3)
+
+	newVote := func(t *testing.T, funcName string, signer encryption.ThresholdSignatureScheme) []byte {
+		v := Vote{
+			ProposalID: "proposal",
+			Transfer:   state.Transfer{ClientID: w.ClientID, ToClientID: callTestAddress, Amount: 5},
+			Call: &sci.SmartContractTransactionData{
+				FunctionName: funcName,
+				InputData:    json.RawMessage("{}"),
+			},
+		}
+		sig, err := signer.Sign(v.getCall().hash(v.Transfer))
+		require.NoError(t, err)
+		v.Signature = sig
+		return mustJSON(t, v)
+	}
+
+	tests := []struct {
+		name     string
+		funcName string
+		output   string
+		err      string
+	}{
+		{
+			name:     "ok",
+			funcName: "pay",
+			output:   "success 0: call executed with output paid",
+		},
+		{
+			name:     "callee fails",
+			funcName: "fail",
+			err:      "err_vote_call:  executing the call: call failed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := MultiSigSmartContract{}
+			balances := newTestBalances()
+			require.NoError(t, ms.putWallet(w, balances))
+			s := &state.State{Balance: 10}
+			require.NoError(t, s.SetTxnHash(encryption.Hash("txn")))
+			_, err := balances.SetClientState(w.ClientID, s)
+			require.NoError(t, err)
+
+			output, err := ms.vote("vote_1", clientIDForKey(tw.signers[0].GetPublicKey()), 1,
+				newVote(t, tt.funcName, tw.signers[0]), balances)
+			require.NoError(t, err)
+			require.Equal(t, "success 1: need 1 more votes", output)
+
+			output, err = ms.vote("vote_2", clientIDForKey(tw.signers[1].GetPublicKey()), 1,
+				newVote(t, tt.funcName, tw.signers[1]), balances)
+
+			var calleeEvents []event.Event
+			for _, e := range balances.GetEvents() {
+				if e.Tag == event.TagAddOrOverwriteUser {
+					calleeEvents = append(calleeEvents, e)
+				}
+			}
+
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				// the transfers and the events of the failed call are dropped
+				require.Empty(t, balances.GetTransfers())
+				require.Empty(t, calleeEvents)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.output, output)
+			require.Equal(t, []*state.Transfer{
+				state.NewTransfer(w.ClientID, callTestAddress, 5),
+			}, balances.GetTransfers())
+			require.Len(t, calleeEvents, 1)
+			require.Equal(t, "vote_2", calleeEvents[0].TxHash)
+
+			p, err := ms.getProposal(proposalRef{ClientID: w.ClientID, ProposalID: "proposal"}, balances)
+			require.NoError(t, err)
+			require.Equal(t, "vote_2", p.ExecutedInTxnHash)
+		})
+	}
+}
@@ -428,6 +428,29 @@ func (p *Partitions) Save(state state.StateContextI) error {
 	return nil
 }
 
+// ForEachID calls f with the ID of each item in order, until f returns true.
+func (p *Partitions) ForEachID(state state.StateContextI, f func(id string) (stop bool, err error)) error {
+	return p.foreach(state, func(id string, data []byte, _ int) ([]byte, bool, error) {
+		stop, err := f(id)
+		return data, stop, err
+	})
+}
+
+// PartitionIDs returns the IDs of the items of the partition at the index,
+// the index is in [0, NumPartitions).
+func (p *Partitions) PartitionIDs(state state.StateContextI, index int) ([]string, error) {
+	part, err := p.getPartition(state, index)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(part.Items))
+	for _, it := range part.Items {
+		ids = append(ids, it.ID)
+	}
+	return ids, nil
+}
+
 func (p *Partitions) foreach(state state.StateContextI, f func(string, []byte, int) ([]byte, bool, error)) error {
 	for i := 0; i < p.partitionsNum(); i++ {
 		part, err := p.getPartition(state, i)
@@ -207,6 +207,32 @@ func TestPartitionsForeach(t *testing.T) {
 	require.Equal(t, "new item", vv.V)
 }
 
+func TestPartitionIDs(t *testing.T) {
+	balances := &mockStateContextI{data: make(map[string][]byte)}
+	parts, err := newPartitions("test_rs", 10)
+	require.NoError(t, err)
+
+	for i := 0; i < 15; i++ {
+		require.NoError(t, parts.Add(balances, &testItem{ID: fmt.Sprintf("k%d", i)}))
+	}
+	require.NoError(t, parts.Save(balances))
+
+	p1, err := GetPartitions(balances, "test_rs")
+	require.NoError(t, err)
+	require.Equal(t, 2, p1.NumPartitions)
+
+	ids, err := p1.PartitionIDs(balances, 1)
+	require.NoError(t, err)
+	require.Equal(t, []string{"k10", "k11", "k12", "k13", "k14"}, ids)
+
+	ids, err = p1.PartitionIDs(balances, 0)
+	require.NoError(t, err)
+	require.Len(t, ids, 10)
+
+	_, err = p1.PartitionIDs(balances, 2)
+	require.EqualError(t, err, "partition id 2 greater than number of partitions 2")
+}
+
 func TestPartitionsAdd(t *testing.T) {
 	tt := []struct {
 		name      string
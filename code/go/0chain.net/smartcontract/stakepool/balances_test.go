@@ -20,9 +20,15 @@ import (
 	"0chain.net/core/common"
 	"0chain.net/core/datastore"
 	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/logging"
 	"github.com/0chain/common/core/util"
+	"go.uber.org/zap"
 )
 
+func init() {
+	logging.Logger = zap.NewNop()
+}
+
 //
 // helper for tests implements chainState.StateContextI
 //
@@ -69,7 +75,7 @@ func (tb *testBalances) GetTransaction() *transaction.Transaction {
 }
 
 // stubs
-func (tb *testBalances) GetBlock() *block.Block                      { return &block.Block{} }
+func (tb *testBalances) GetBlock() *block.Block                      { return tb.block }
 func (tb *testBalances) GetState() util.MerklePatriciaTrieI          { return nil }
 func (tb *testBalances) Validate() error                             { return nil }
 func (tb *testBalances) GetMints() []*state.Mint                     { return nil }
@@ -79,6 +85,9 @@ func (tb *testBalances) GetTransfers() []*state.Transfer             { return ni
 func (tb *testBalances) GetMagicBlock(round int64) *block.MagicBlock { return nil }
 func (tb *testBalances) SetMagicBlock(block *block.MagicBlock)       {}
 func (tb *testBalances) AddSignedTransfer(st *state.SignedTransfer)  {}
+func (tb *testBalances) ExecuteOnBehalf(*transaction.Transaction, func(cstate.StateContextI) (string, error)) (string, error) {
+	return "", nil
+}
 func (tb *testBalances) GetSignedTransfers() []*state.SignedTransfer { return nil }
 func (tb *testBalances) GetEventDB() *event.EventDb                  { return nil }
 func (tb *testBalances) EmitEvent(event.EventType, event.EventTag, string, interface{}, ...cstate.Appender) {
@@ -0,0 +1,164 @@
+package stakepool
+
+import (
+	"encoding/json"
+	"fmt"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/currency"
+)
+
+// maxStakeGetters returns the maximal stake of a delegate pool for the type
+// of provider, the smart contracts of the providers register them
+var maxStakeGetters = make(map[spenum.Provider]func(balances cstate.CommonStateContextI) (currency.Coin, error))
+
+// RegisterMaxStakeGetter registers the function returning the maximal stake
+// of a delegate pool for the type of provider. Auto-compounded rewards never
+// move a delegate pool balance over the limit.
+func RegisterMaxStakeGetter(p spenum.Provider, get func(balances cstate.CommonStateContextI) (currency.Coin, error)) {
+	maxStakeGetters[p] = get
+}
+
+func getMaxStake(p spenum.Provider, balances cstate.CommonStateContextI) (currency.Coin, bool, error) {
+	get, ok := maxStakeGetters[p]
+	if !ok {
+		return 0, false, nil
+	}
+	maxStake, err := get(balances)
+	if err != nil {
+		return 0, false, err
+	}
+	return maxStake, true, nil
+}
+
+// AutoCompoundRequest switches auto-compounding of rewards of the delegate
+// pool of the transaction client.
+type AutoCompoundRequest struct {
+	ProviderType spenum.Provider `json:"provider_type,omitempty"`
+	ProviderID   string          `json:"provider_id,omitempty"`
+	AutoCompound bool            `json:"auto_compound"`
+}
+
+func (acr *AutoCompoundRequest) Encode() []byte {
+	bytes, _ := json.Marshal(acr)
+	return bytes
+}
+
+func (acr *AutoCompoundRequest) decode(p []byte) error {
+	return json.Unmarshal(p, acr)
+}
+
+// StakePoolAutoCompound switches auto-compounding of the delegate pool of
+// the transaction client. Rewards of an auto-compounding delegate pool are
+// moved into its stake on every distribution instead of waiting for the
+// collect_reward.
+func StakePoolAutoCompound(t *transaction.Transaction, input []byte, balances cstate.StateContextI,
+	get func(providerType spenum.Provider, providerID string, balances cstate.CommonStateContextI) (AbstractStakePool, error),
+) (resp string, err error) {
+	var acr AutoCompoundRequest
+	if err = acr.decode(input); err != nil {
+		return "", common.NewErrorf("stake_pool_auto_compound_failed",
+			"invalid request: %v", err)
+	}
+
+	var sp AbstractStakePool
+	if sp, err = get(acr.ProviderType, acr.ProviderID, balances); err != nil {
+		return "", common.NewErrorf("stake_pool_auto_compound_failed",
+			"can't get stake pool: %v", err)
+	}
+
+	dp, ok := sp.GetPools()[t.ClientID]
+	if !ok {
+		return "", common.NewErrorf("stake_pool_auto_compound_failed",
+			"no such delegate pool: %v", t.ClientID)
+	}
+	if dp.Status != spenum.Active && dp.Status != spenum.Pending {
+		return "", common.NewErrorf("stake_pool_auto_compound_failed",
+			"delegate pool in %s status", dp.Status)
+	}
+
+	dp.AutoCompound = acr.AutoCompound
+	if err = sp.Save(acr.ProviderType, acr.ProviderID, balances); err != nil {
+		return "", common.NewErrorf("stake_pool_auto_compound_failed",
+			"saving stake pool: %v", err)
+	}
+
+	dpUpdate := newDelegatePoolUpdate(t.ClientID, acr.ProviderID, acr.ProviderType)
+	dpUpdate.Updates["auto_compound"] = dp.AutoCompound
+	dpUpdate.emitUpdate(balances)
+
+	return toJson(acr), nil
+}
+
+// compoundRewards moves rewards of auto-compounding delegate pools to their
+// stake, up to the maximal stake of the provider type. The tokens of the
+// rewards are minted to the minter smart contract holding the stake.
+func (sp *StakePool) compoundRewards(
+	providerId string,
+	providerType spenum.Provider,
+	spUpdate *StakePoolReward,
+	balances cstate.StateContextI,
+) error {
+	var (
+		total            currency.Coin
+		maxStake         currency.Coin
+		limited, fetched bool
+	)
+	for _, id := range sp.OrderedPoolIds() {
+		dp := sp.Pools[id]
+		if !dp.AutoCompound || dp.Reward == 0 || dp.Status != spenum.Active {
+			continue
+		}
+
+		if !fetched {
+			var err error
+			if maxStake, limited, err = getMaxStake(providerType, balances); err != nil {
+				return fmt.Errorf("can't get max stake: %v", err)
+			}
+			fetched = true
+		}
+
+		amount := dp.Reward
+		if limited {
+			if dp.Balance >= maxStake {
+				continue
+			}
+			if room := maxStake - dp.Balance; amount > room {
+				amount = room
+			}
+		}
+
+		newBalance, err := currency.AddCoin(dp.Balance, amount)
+		if err != nil {
+			return err
+		}
+		if total, err = currency.AddCoin(total, amount); err != nil {
+			return err
+		}
+		dp.Balance = newBalance
+		dp.Reward -= amount
+		spUpdate.DelegateCompounds[id] = amount
+	}
+
+	if total == 0 {
+		return nil
+	}
+
+	minter, err := cstate.GetMinter(sp.Minter)
+	if err != nil {
+		return err
+	}
+	if err := balances.AddMint(&state.Mint{
+		Minter:     minter,
+		ToClientID: minter,
+		Amount:     total,
+	}); err != nil {
+		return fmt.Errorf("minting compounded rewards: %v", err)
+	}
+
+	return sp.EmitStakeEvent(providerType, providerId, balances)
+}
@@ -0,0 +1,48 @@
+package stakepool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/currency"
+)
+
+func TestStakePool_DistributeRewardsAutoCompound(t *testing.T) {
+	const providerID = "provider_id"
+
+	RegisterMaxStakeGetter(spenum.Blobber, func(cstate.CommonStateContextI) (currency.Coin, error) {
+		return 110, nil
+	})
+	defer delete(maxStakeGetters, spenum.Blobber)
+
+	var (
+		balances = newTestBalances(t, false)
+		sp       = NewStakePool()
+	)
+	sp.Pools["manual"] = &DelegatePool{DelegateID: "manual", Balance: 100}
+	sp.Pools["compound"] = &DelegatePool{DelegateID: "compound", Balance: 100, AutoCompound: true}
+
+	// under the max stake, the whole reward is compounded
+	err := sp.DistributeRewards(10, providerID, spenum.Blobber, spenum.BlockRewardBlobber, balances)
+	require.NoError(t, err)
+	require.EqualValues(t, 5, sp.Pools["manual"].Reward)
+	require.EqualValues(t, 100, sp.Pools["manual"].Balance)
+	require.EqualValues(t, 0, sp.Pools["compound"].Reward)
+	require.EqualValues(t, 105, sp.Pools["compound"].Balance)
+
+	// the max stake caps the compounded amount, the rest is left to collect
+	err = sp.DistributeRewards(20, providerID, spenum.Blobber, spenum.BlockRewardBlobber, balances)
+	require.NoError(t, err)
+	require.EqualValues(t, 110, sp.Pools["compound"].Balance)
+	require.NotZero(t, sp.Pools["compound"].Reward)
+
+	// the max stake reached, nothing compounded
+	reward := sp.Pools["compound"].Reward
+	err = sp.DistributeRewards(20, providerID, spenum.Blobber, spenum.BlockRewardBlobber, balances)
+	require.NoError(t, err)
+	require.EqualValues(t, 110, sp.Pools["compound"].Balance)
+	require.Greater(t, sp.Pools["compound"].Reward, reward)
+}
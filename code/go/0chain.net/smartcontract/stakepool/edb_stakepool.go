@@ -31,6 +31,7 @@ func NewStakePoolReward(pId string, pType spenum.Provider, rewardType spenum.Rew
 	spu.Type = pType
 	spu.DelegateRewards = make(map[string]currency.Coin)
 	spu.DelegatePenalties = make(map[string]currency.Coin)
+	spu.DelegateCompounds = make(map[string]currency.Coin)
 	spu.RewardType = rewardType
 
 	var challengeID string
@@ -64,6 +65,7 @@ func stakePoolRewardToStakePoolRewardEvent(spu StakePoolReward) *dbs.StakePoolRe
 		Reward:          spu.Reward,
 		DelegateRewards: spu.DelegateRewards,
 		DelegatePenalties: spu.DelegatePenalties,
+		DelegateCompounds: spu.DelegateCompounds,
 		RewardType:      spu.RewardType,
 		ChallengeID:     spu.ChallengeID,
 	}
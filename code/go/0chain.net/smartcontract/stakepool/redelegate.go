@@ -0,0 +1,207 @@
+package stakepool
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/currency"
+)
+
+// RedelegateRequest moves the stake of the delegate pool of the transaction
+// client from a provider to another provider of the same type.
+type RedelegateRequest struct {
+	ProviderType   spenum.Provider `json:"provider_type,omitempty"`
+	FromProviderID string          `json:"from_provider_id"`
+	ToProviderID   string          `json:"to_provider_id"`
+}
+
+func (rr *RedelegateRequest) Encode() []byte {
+	bytes, _ := json.Marshal(rr)
+	return bytes
+}
+
+func (rr *RedelegateRequest) decode(p []byte) error {
+	return json.Unmarshal(p, rr)
+}
+
+// RedelegateResponse is the response of a redelegation.
+type RedelegateResponse struct {
+	Client         string          `json:"client"`
+	ProviderType   spenum.Provider `json:"provider_type"`
+	FromProviderID string          `json:"from_provider_id"`
+	ToProviderID   string          `json:"to_provider_id"`
+	Amount         currency.Coin   `json:"amount"`
+	Reward         currency.Coin   `json:"reward"`
+}
+
+// Undelegate removes the delegate pool of the client from the stake pool,
+// rewards of the delegate pool are minted to the client. The stake stays
+// with the smart contract, the caller moves it to another stake pool.
+func (sp *StakePool) Undelegate(
+	clientID string,
+	providerType spenum.Provider,
+	providerID string,
+	balances cstate.StateContextI,
+) (*DelegatePool, currency.Coin, error) {
+	dp, ok := sp.Pools[clientID]
+	if !ok {
+		return nil, 0, fmt.Errorf("no such delegate pool: %q", clientID)
+	}
+	if dp.DelegateID != clientID {
+		return nil, 0, errors.New("trying to undelegate not by delegate pool owner")
+	}
+	if dp.Status != spenum.Active && dp.Status != spenum.Pending {
+		return nil, 0, fmt.Errorf("delegate pool in %s status", dp.Status)
+	}
+
+	reward, err := sp.MintRewards(clientID, providerID, providerType, balances)
+	if err != nil {
+		return nil, 0, fmt.Errorf("minting rewards: %v", err)
+	}
+
+	delete(sp.Pools, clientID)
+
+	dpUpdate := newDelegatePoolUpdate(clientID, providerID, providerType)
+	dpUpdate.Updates["balance"] = currency.Coin(0)
+	dpUpdate.Updates["status"] = spenum.Deleted
+	dpUpdate.emitUpdate(balances)
+
+	return dp, reward, nil
+}
+
+// StakePoolRedelegate moves the stake of the delegate pool of the transaction
+// client to a provider of the same type without unlocking it. The stake
+// keeps the time it has been staked at, rewards of the moved delegate pool
+// are paid to the client. The stake isn't moved through the unbonding queue,
+// it's slashed with the provider it's redelegated to.
+func StakePoolRedelegate(t *transaction.Transaction, input []byte, balances cstate.StateContextI, vs ValidationSettings,
+	get func(providerType spenum.Provider, providerID string, balances cstate.CommonStateContextI) (AbstractStakePool, error),
+) (resp string, err error) {
+	var rr RedelegateRequest
+	if err = rr.decode(input); err != nil {
+		return "", common.NewErrorf("stake_pool_redelegate_failed",
+			"invalid request: %v", err)
+	}
+	if rr.FromProviderID == "" || rr.ToProviderID == "" {
+		return "", common.NewError("stake_pool_redelegate_failed",
+			"missing provider id")
+	}
+	if rr.FromProviderID == rr.ToProviderID {
+		return "", common.NewError("stake_pool_redelegate_failed",
+			"can't redelegate to the same provider")
+	}
+
+	var from, to AbstractStakePool
+	if from, err = get(rr.ProviderType, rr.FromProviderID, balances); err != nil {
+		return "", common.NewErrorf("stake_pool_redelegate_failed",
+			"can't get stake pool of %s: %v", rr.FromProviderID, err)
+	}
+	if to, err = get(rr.ProviderType, rr.ToProviderID, balances); err != nil {
+		return "", common.NewErrorf("stake_pool_redelegate_failed",
+			"can't get stake pool of %s: %v", rr.ToProviderID, err)
+	}
+	if from.IsDead() {
+		return "", common.NewErrorf("stake_pool_redelegate_failed",
+			"provider %s is killed, the stake can only be unlocked", rr.FromProviderID)
+	}
+	if to.IsDead() {
+		return "", common.NewErrorf("stake_pool_redelegate_failed",
+			"provider %s is killed", rr.ToProviderID)
+	}
+
+	src, ok := from.GetPools()[t.ClientID]
+	if !ok {
+		return "", common.NewErrorf("stake_pool_redelegate_failed",
+			"no such delegate pool: %v", t.ClientID)
+	}
+	if err = validateRedelegation(t.ClientID, src.Balance, to, vs); err != nil {
+		return "", common.NewErrorf("stake_pool_redelegate_failed",
+			"can't redelegate to %s: %v", rr.ToProviderID, err)
+	}
+
+	moved, reward, err := from.Undelegate(t.ClientID, rr.ProviderType, rr.FromProviderID, balances)
+	if err != nil {
+		return "", common.NewErrorf("stake_pool_redelegate_failed", "%v", err)
+	}
+	if err = delegateTo(t.ClientID, moved, to, &rr, balances); err != nil {
+		return "", common.NewError("stake_pool_redelegate_failed", err.Error())
+	}
+	if err = saveRedelegation(from, to, &rr, balances); err != nil {
+		return "", common.NewError("stake_pool_redelegate_failed", err.Error())
+	}
+
+	return toJson(&RedelegateResponse{
+		Client:         t.ClientID,
+		ProviderType:   rr.ProviderType,
+		FromProviderID: rr.FromProviderID,
+		ToProviderID:   rr.ToProviderID,
+		Amount:         moved.Balance,
+		Reward:         reward,
+	}), nil
+}
+
+// validateRedelegation checks the stake can be locked in the stake pool the
+// client redelegates to.
+func validateRedelegation(clientID string, amount currency.Coin, to AbstractStakePool, vs ValidationSettings) error {
+	if dst, ok := to.GetPools()[clientID]; ok &&
+		dst.Status != spenum.Active && dst.Status != spenum.Pending {
+		return fmt.Errorf("delegate pool in %s status", dst.Status)
+	}
+	_, err := validateLockRequest(&transaction.Transaction{ClientID: clientID, Value: amount}, to, vs)
+	return err
+}
+
+// delegateTo adds the moved stake to the delegate pool of the client in the
+// stake pool the client redelegates to.
+func delegateTo(clientID string, moved *DelegatePool, to AbstractStakePool, rr *RedelegateRequest,
+	balances cstate.StateContextI) error {
+	dst, ok := to.GetPools()[clientID]
+	if !ok {
+		dst = &DelegatePool{
+			Status:       spenum.Active,
+			DelegateID:   clientID,
+			RoundCreated: balances.GetBlock().Round,
+			StakedAt:     moved.StakedAt,
+			AutoCompound: moved.AutoCompound,
+		}
+		to.GetPools()[clientID] = dst
+		dst.EmitNew(clientID, rr.ToProviderID, rr.ProviderType, balances)
+	}
+
+	var err error
+	if dst.Balance, err = currency.AddCoin(dst.Balance, moved.Balance); err != nil {
+		return err
+	}
+	// the stake can't be unlocked earlier moving it between providers
+	if moved.StakedAt > dst.StakedAt {
+		dst.StakedAt = moved.StakedAt
+	}
+
+	dpUpdate := newDelegatePoolUpdate(clientID, rr.ToProviderID, rr.ProviderType)
+	dpUpdate.Updates["balance"] = dst.Balance
+	dpUpdate.Updates["staked_at"] = dst.StakedAt
+	dpUpdate.emitUpdate(balances)
+	return nil
+}
+
+func saveRedelegation(from, to AbstractStakePool, rr *RedelegateRequest, balances cstate.StateContextI) error {
+	if err := from.Save(rr.ProviderType, rr.FromProviderID, balances); err != nil {
+		return fmt.Errorf("saving stake pool of %s: %v", rr.FromProviderID, err)
+	}
+	if err := to.Save(rr.ProviderType, rr.ToProviderID, balances); err != nil {
+		return fmt.Errorf("saving stake pool of %s: %v", rr.ToProviderID, err)
+	}
+
+	if err := from.EmitStakeEvent(rr.ProviderType, rr.FromProviderID, balances); err != nil {
+		return fmt.Errorf("stake pool staking error: %v", err)
+	}
+	if err := to.EmitStakeEvent(rr.ProviderType, rr.ToProviderID, balances); err != nil {
+		return fmt.Errorf("stake pool staking error: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package stakepool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/smartcontract/stakepool/spenum"
+)
+
+func TestStakePoolRedelegate(t *testing.T) {
+	const clientID = "client"
+
+	vs := ValidationSettings{MinStake: 1, MaxStake: 150, MaxNumDelegates: 2}
+	setup := func() (map[string]*StakePool, func(spenum.Provider, string, cstate.CommonStateContextI) (AbstractStakePool, error)) {
+		pools := map[string]*StakePool{"from": NewStakePool(), "to": NewStakePool()}
+		pools["from"].Pools[clientID] = &DelegatePool{DelegateID: clientID, Balance: 100, StakedAt: 20}
+		return pools, func(_ spenum.Provider, id string, _ cstate.CommonStateContextI) (AbstractStakePool, error) {
+			return pools[id], nil
+		}
+	}
+	redelegate := func(get func(spenum.Provider, string, cstate.CommonStateContextI) (AbstractStakePool, error), from, to string) error {
+		balances := newTestBalances(t, false)
+		txn := &transaction.Transaction{ClientID: clientID}
+		balances.setTransaction(t, txn)
+		input := (&RedelegateRequest{ProviderType: spenum.Blobber, FromProviderID: from, ToProviderID: to}).Encode()
+		_, err := StakePoolRedelegate(txn, input, balances, vs, get)
+		return err
+	}
+
+	t.Run("new delegate pool", func(t *testing.T) {
+		pools, get := setup()
+		require.NoError(t, redelegate(get, "from", "to"))
+		require.NotContains(t, pools["from"].Pools, clientID)
+		require.EqualValues(t, 100, pools["to"].Pools[clientID].Balance)
+		require.EqualValues(t, 20, pools["to"].Pools[clientID].StakedAt)
+	})
+
+	t.Run("existing delegate pool", func(t *testing.T) {
+		pools, get := setup()
+		pools["to"].Pools[clientID] = &DelegatePool{DelegateID: clientID, Balance: 50, StakedAt: 10}
+		require.NoError(t, redelegate(get, "from", "to"))
+		require.NotContains(t, pools["from"].Pools, clientID)
+		require.EqualValues(t, 150, pools["to"].Pools[clientID].Balance)
+		require.EqualValues(t, 20, pools["to"].Pools[clientID].StakedAt)
+	})
+
+	t.Run("max stake", func(t *testing.T) {
+		pools, get := setup()
+		pools["to"].Pools[clientID] = &DelegatePool{DelegateID: clientID, Balance: 51}
+		require.Error(t, redelegate(get, "from", "to"))
+		require.EqualValues(t, 100, pools["from"].Pools[clientID].Balance)
+	})
+
+	t.Run("max delegates", func(t *testing.T) {
+		pools, get := setup()
+		pools["to"].Pools["a"] = &DelegatePool{DelegateID: "a", Balance: 10}
+		pools["to"].Pools["b"] = &DelegatePool{DelegateID: "b", Balance: 10}
+		require.Error(t, redelegate(get, "from", "to"))
+		require.Contains(t, pools["from"].Pools, clientID)
+	})
+
+	t.Run("min stake", func(t *testing.T) {
+		pools, get := setup()
+		pools["from"].Pools[clientID].Balance = 0
+		require.Error(t, redelegate(get, "from", "to"))
+		require.Contains(t, pools["from"].Pools, clientID)
+	})
+
+	t.Run("killed source provider", func(t *testing.T) {
+		pools, get := setup()
+		pools["from"].HasBeenKilled = true
+		require.Error(t, redelegate(get, "from", "to"))
+		require.Contains(t, pools["from"].Pools, clientID)
+	})
+
+	t.Run("slashed with the target provider", func(t *testing.T) {
+		pools, get := setup()
+		require.NoError(t, redelegate(get, "from", "to"))
+		require.Empty(t, pools["from"].Unbonding)
+		require.NoError(t, pools["to"].SlashFraction(0.5, "to", spenum.Blobber, newTestBalances(t, false)))
+		require.EqualValues(t, 50, pools["to"].Pools[clientID].Balance)
+	})
+
+	t.Run("same provider", func(t *testing.T) {
+		_, get := setup()
+		require.Error(t, redelegate(get, "from", "from"))
+	})
+}
@@ -46,6 +46,9 @@ type AbstractStakePool interface {
 	Kill(float64, string, spenum.Provider, cstate.StateContextI) error
 	IsDead() bool
 	SlashFraction(float64, string, spenum.Provider, cstate.StateContextI) error
+	Unbond(clientID string, maturesAt int64, providerType spenum.Provider, providerID string, balances cstate.StateContextI) (currency.Coin, error)
+	WithdrawUnbonded(sscID, clientID string, providerType spenum.Provider, providerID string, balances cstate.StateContextI) (currency.Coin, error)
+	Undelegate(clientID string, providerType spenum.Provider, providerID string, balances cstate.StateContextI) (*DelegatePool, currency.Coin, error)
 }
 
 // StakePool holds delegate information for an 0chain providers
@@ -55,6 +58,7 @@ type StakePool struct {
 	Settings      Settings                 `json:"settings"`
 	Minter        cstate.ApprovedMinter    `json:"minter"`
 	HasBeenKilled bool                     `json:"is_dead"`
+	Unbonding     []*UnbondingEntry        `json:"unbonding,omitempty"`
 }
 
 type Settings struct {
@@ -70,6 +74,7 @@ type DelegatePool struct {
 	RoundCreated int64             `json:"round_created"` // used for cool down
 	DelegateID   string            `json:"delegate_id"`
 	StakedAt     common.Timestamp  `json:"staked_at"`
+	AutoCompound bool              `json:"auto_compound"` // move rewards to stake
 }
 
 // swagger:model stakePoolStat
@@ -92,11 +97,13 @@ type DelegatePoolStat struct {
 	ProviderId   string          `json:"provider_id"`   // id
 	ProviderType spenum.Provider `json:"provider_type"` // ype
 
-	TotalReward  currency.Coin    `json:"total_reward"`
-	TotalPenalty currency.Coin    `json:"total_penalty"`
-	Status       string           `json:"status"`
-	RoundCreated int64            `json:"round_created"`
-	StakedAt     common.Timestamp `json:"staked_at"`
+	TotalReward   currency.Coin    `json:"total_reward"`
+	TotalPenalty  currency.Coin    `json:"total_penalty"`
+	TotalCompound currency.Coin    `json:"total_compound"`
+	Status        string           `json:"status"`
+	RoundCreated  int64            `json:"round_created"`
+	StakedAt      common.Timestamp `json:"staked_at"`
+	AutoCompound  bool             `json:"auto_compound"`
 }
 
 // swagger:model userPoolStat
@@ -125,6 +132,7 @@ func ToProviderStakePoolStats(provider *event.Provider, delegatePools []event.De
 			Status:       spenum.PoolStatus(dp.Status).String(),
 			RoundCreated: dp.RoundCreated,
 			StakedAt:     dp.StakedAt,
+			AutoCompound: dp.AutoCompound,
 		}
 		dpStats.Balance = dp.Balance
 
@@ -134,6 +142,8 @@ func ToProviderStakePoolStats(provider *event.Provider, delegatePools []event.De
 
 		dpStats.TotalReward = dp.TotalReward
 
+		dpStats.TotalCompound = dp.TotalCompound
+
 		newBal, err := currency.AddCoin(spStat.Balance, dpStats.Balance)
 		if err != nil {
 			return nil, err
@@ -347,7 +357,8 @@ func (sp *StakePool) SlashFraction(
 		}
 	}
 	sp.EmitStakePoolBalanceUpdate(providerId, providerType, balances)
-	return nil
+	_, err := sp.SlashUnbonding(killSlashFraction, providerType, providerId, balances)
+	return err
 }
 
 // DistributeRewardsRandN distributes rewards to randomly selected N delegate pools
@@ -443,6 +454,9 @@ func (sp *StakePool) DistributeRewardsRandN(
 			return err
 		}
 	}
+	if err := sp.compoundRewards(providerId, providerType, spUpdate, balances); err != nil {
+		return err
+	}
 	if err := spUpdate.Emit(event.TagStakePoolReward, balances); err != nil {
 		return err
 	}
@@ -610,6 +624,9 @@ func (sp *StakePool) DistributeRewards(
 			return err
 		}
 	}
+	if err := sp.compoundRewards(providerId, providerType, spUpdate, balances); err != nil {
+		return err
+	}
 	if err := spUpdate.Emit(event.TagStakePoolReward, balances); err != nil {
 		return err
 	}
@@ -781,9 +798,12 @@ func validateLockRequest(t *transaction.Transaction, sp AbstractStakePool, vs Va
 	return "", nil
 }
 
-// StakePoolUnlock unlock tokens from provider, stake pool can return excess tokens from stake pool
+// StakePoolUnlock unlock tokens from provider, stake pool can return excess tokens from stake pool.
+// With a positive unbondingRounds the stake is moved to the unbonding queue
+// instead, it's withdrawn by StakePoolWithdrawUnbonded once it matures.
 func StakePoolUnlock(t *transaction.Transaction, input []byte, balances cstate.StateContextI,
 	get func(providerType spenum.Provider, providerID string, balances cstate.CommonStateContextI) (AbstractStakePool, error),
+	unbondingRounds int64,
 ) (resp string, err error) {
 	var spr StakePoolRequest
 
@@ -799,6 +819,7 @@ func StakePoolUnlock(t *transaction.Transaction, input []byte, balances cstate.S
 	if err != nil {
 		return "", err
 	}
+
 	dp, ok := sp.GetPools()[t.ClientID]
 	if !ok {
 		return "", common.NewErrorf("stake_pool_unlock_failed", "no such delegate pool: %v ", t.ClientID)
@@ -813,15 +834,26 @@ func StakePoolUnlock(t *transaction.Transaction, input []byte, balances cstate.S
 		}
 	}
 
+	// The unbonding stake stays in the stake pool, so it's unbonded before
+	// the unlock is emitted and the unlock is emitted with the rewards only.
+	if unbondingRounds > 0 {
+		maturesAt := balances.GetBlock().Round + unbondingRounds
+		if _, err = sp.Unbond(t.ClientID, maturesAt, spr.ProviderType, spr.ProviderID, balances); err != nil {
+			return "", common.NewErrorf("stake_pool_unlock_failed",
+				"unbonding tokens: %v", err)
+		}
+	}
+
 	output, err := sp.UnlockPool(t.ClientID, spr.ProviderType, spr.ProviderID, balances)
 	if err != nil {
 		return "", common.NewErrorf("stake_pool_unlock_failed", "%v", err)
 	}
 
-	err = sp.Empty(t.ToClientID, t.ClientID, t.ClientID, balances)
-	if err != nil {
-		return "", common.NewErrorf("stake_pool_unlock_failed",
-			"unlocking tokens: %v", err)
+	if unbondingRounds <= 0 {
+		if err = sp.Empty(t.ToClientID, t.ClientID, t.ClientID, balances); err != nil {
+			return "", common.NewErrorf("stake_pool_unlock_failed",
+				"unlocking tokens: %v", err)
+		}
 	}
 
 	err = sp.DeletePool(t.ClientID, spr.ProviderType, spr.ProviderID, balances)
@@ -9,9 +9,9 @@ import (
 // MarshalMsg implements msgp.Marshaler
 func (z *DelegatePool) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 6
+	// map header, size 7
 	// string "Balance"
-	o = append(o, 0x86, 0xa7, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65)
+	o = append(o, 0x87, 0xa7, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65)
 	o, err = z.Balance.MarshalMsg(o)
 	if err != nil {
 		err = msgp.WrapError(err, "Balance")
@@ -44,6 +44,9 @@ func (z *DelegatePool) MarshalMsg(b []byte) (o []byte, err error) {
 		err = msgp.WrapError(err, "StakedAt")
 		return
 	}
+	// string "AutoCompound"
+	o = append(o, 0xac, 0x41, 0x75, 0x74, 0x6f, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x75, 0x6e, 0x64)
+	o = msgp.AppendBool(o, z.AutoCompound)
 	return
 }
 
@@ -101,6 +104,12 @@ func (z *DelegatePool) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "StakedAt")
 				return
 			}
+		case "AutoCompound":
+			z.AutoCompound, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "AutoCompound")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -115,16 +124,16 @@ func (z *DelegatePool) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *DelegatePool) Msgsize() (s int) {
-	s = 1 + 8 + z.Balance.Msgsize() + 7 + z.Reward.Msgsize() + 7 + z.Status.Msgsize() + 13 + msgp.Int64Size + 11 + msgp.StringPrefixSize + len(z.DelegateID) + 9 + z.StakedAt.Msgsize()
+	s = 1 + 8 + z.Balance.Msgsize() + 7 + z.Reward.Msgsize() + 7 + z.Status.Msgsize() + 13 + msgp.Int64Size + 11 + msgp.StringPrefixSize + len(z.DelegateID) + 9 + z.StakedAt.Msgsize() + 13 + msgp.BoolSize
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
 func (z *DelegatePoolStat) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 12
+	// map header, size 14
 	// string "ID"
-	o = append(o, 0x8c, 0xa2, 0x49, 0x44)
+	o = append(o, 0x8e, 0xa2, 0x49, 0x44)
 	o = msgp.AppendString(o, z.ID)
 	// string "Balance"
 	o = append(o, 0xa7, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65)
@@ -170,6 +179,13 @@ func (z *DelegatePoolStat) MarshalMsg(b []byte) (o []byte, err error) {
 		err = msgp.WrapError(err, "TotalPenalty")
 		return
 	}
+	// string "TotalCompound"
+	o = append(o, 0xad, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x75, 0x6e, 0x64)
+	o, err = z.TotalCompound.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "TotalCompound")
+		return
+	}
 	// string "Status"
 	o = append(o, 0xa6, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73)
 	o = msgp.AppendString(o, z.Status)
@@ -183,6 +199,9 @@ func (z *DelegatePoolStat) MarshalMsg(b []byte) (o []byte, err error) {
 		err = msgp.WrapError(err, "StakedAt")
 		return
 	}
+	// string "AutoCompound"
+	o = append(o, 0xac, 0x41, 0x75, 0x74, 0x6f, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x75, 0x6e, 0x64)
+	o = msgp.AppendBool(o, z.AutoCompound)
 	return
 }
 
@@ -258,6 +277,12 @@ func (z *DelegatePoolStat) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "TotalPenalty")
 				return
 			}
+		case "TotalCompound":
+			bts, err = z.TotalCompound.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "TotalCompound")
+				return
+			}
 		case "Status":
 			z.Status, bts, err = msgp.ReadStringBytes(bts)
 			if err != nil {
@@ -276,6 +301,12 @@ func (z *DelegatePoolStat) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "StakedAt")
 				return
 			}
+		case "AutoCompound":
+			z.AutoCompound, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "AutoCompound")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -290,7 +321,7 @@ func (z *DelegatePoolStat) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *DelegatePoolStat) Msgsize() (s int) {
-	s = 1 + 3 + msgp.StringPrefixSize + len(z.ID) + 8 + z.Balance.Msgsize() + 11 + msgp.StringPrefixSize + len(z.DelegateID) + 8 + z.Rewards.Msgsize() + 8 + msgp.BoolSize + 11 + msgp.StringPrefixSize + len(z.ProviderId) + 13 + z.ProviderType.Msgsize() + 12 + z.TotalReward.Msgsize() + 13 + z.TotalPenalty.Msgsize() + 7 + msgp.StringPrefixSize + len(z.Status) + 13 + msgp.Int64Size + 9 + z.StakedAt.Msgsize()
+	s = 1 + 3 + msgp.StringPrefixSize + len(z.ID) + 8 + z.Balance.Msgsize() + 11 + msgp.StringPrefixSize + len(z.DelegateID) + 8 + z.Rewards.Msgsize() + 8 + msgp.BoolSize + 11 + msgp.StringPrefixSize + len(z.ProviderId) + 13 + z.ProviderType.Msgsize() + 12 + z.TotalReward.Msgsize() + 13 + z.TotalPenalty.Msgsize() + 14 + z.TotalCompound.Msgsize() + 7 + msgp.StringPrefixSize + len(z.Status) + 13 + msgp.Int64Size + 9 + z.StakedAt.Msgsize() + 13 + msgp.BoolSize
 	return
 }
 
@@ -367,9 +398,9 @@ func (z Settings) Msgsize() (s int) {
 // MarshalMsg implements msgp.Marshaler
 func (z *StakePool) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 5
+	// map header, size 6
 	// string "Pools"
-	o = append(o, 0x85, 0xa5, 0x50, 0x6f, 0x6f, 0x6c, 0x73)
+	o = append(o, 0x86, 0xa5, 0x50, 0x6f, 0x6f, 0x6c, 0x73)
 	o = msgp.AppendMapHeader(o, uint32(len(z.Pools)))
 	keys_za0001 := make([]string, 0, len(z.Pools))
 	for k := range z.Pools {
@@ -418,6 +449,20 @@ func (z *StakePool) MarshalMsg(b []byte) (o []byte, err error) {
 	// string "HasBeenKilled"
 	o = append(o, 0xad, 0x48, 0x61, 0x73, 0x42, 0x65, 0x65, 0x6e, 0x4b, 0x69, 0x6c, 0x6c, 0x65, 0x64)
 	o = msgp.AppendBool(o, z.HasBeenKilled)
+	// string "Unbonding"
+	o = append(o, 0xa9, 0x55, 0x6e, 0x62, 0x6f, 0x6e, 0x64, 0x69, 0x6e, 0x67)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Unbonding)))
+	for za0003 := range z.Unbonding {
+		if z.Unbonding[za0003] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			o, err = z.Unbonding[za0003].MarshalMsg(o)
+			if err != nil {
+				err = msgp.WrapError(err, "Unbonding", za0003)
+				return
+			}
+		}
+	}
 	return
 }
 
@@ -539,6 +584,36 @@ func (z *StakePool) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "HasBeenKilled")
 				return
 			}
+		case "Unbonding":
+			var zb0004 uint32
+			zb0004, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Unbonding")
+				return
+			}
+			if cap(z.Unbonding) >= int(zb0004) {
+				z.Unbonding = (z.Unbonding)[:zb0004]
+			} else {
+				z.Unbonding = make([]*UnbondingEntry, zb0004)
+			}
+			for za0003 := range z.Unbonding {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					z.Unbonding[za0003] = nil
+				} else {
+					if z.Unbonding[za0003] == nil {
+						z.Unbonding[za0003] = new(UnbondingEntry)
+					}
+					bts, err = z.Unbonding[za0003].UnmarshalMsg(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Unbonding", za0003)
+						return
+					}
+				}
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -565,7 +640,14 @@ func (z *StakePool) Msgsize() (s int) {
 			}
 		}
 	}
-	s += 7 + z.Reward.Msgsize() + 9 + 1 + 15 + msgp.StringPrefixSize + len(z.Settings.DelegateWallet) + 16 + msgp.IntSize + 19 + msgp.Float64Size + 7 + z.Minter.Msgsize() + 14 + msgp.BoolSize
+	s += 7 + z.Reward.Msgsize() + 9 + 1 + 15 + msgp.StringPrefixSize + len(z.Settings.DelegateWallet) + 16 + msgp.IntSize + 19 + msgp.Float64Size + 7 + z.Minter.Msgsize() + 14 + msgp.BoolSize + 10 + msgp.ArrayHeaderSize
+	for za0003 := range z.Unbonding {
+		if z.Unbonding[za0003] == nil {
+			s += msgp.NilSize
+		} else {
+			s += z.Unbonding[za0003].Msgsize()
+		}
+	}
 	return
 }
 
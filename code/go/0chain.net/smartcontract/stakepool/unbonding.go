@@ -0,0 +1,234 @@
+package stakepool
+
+import (
+	"errors"
+	"fmt"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/currency"
+)
+
+//go:generate msgp -v -io=false -tests=false
+
+// UnbondingEntry is stake unlocked from a delegate pool and kept by the
+// stake pool until the unbonding period is over. The stake doesn't earn
+// rewards any longer, but it is still slashed with the stake pool.
+type UnbondingEntry struct {
+	DelegateID string        `json:"delegate_id"`
+	Amount     currency.Coin `json:"amount"`
+	MaturesAt  int64         `json:"matures_at"` // round the stake can be withdrawn
+}
+
+// UnbondingWithdrawal is the response of the withdrawal of matured unbonding
+// stake.
+type UnbondingWithdrawal struct {
+	ClientID     string          `json:"client_id"`
+	ProviderID   string          `json:"provider_id"`
+	ProviderType spenum.Provider `json:"provider_type"`
+	Amount       currency.Coin   `json:"amount"`
+}
+
+// Unbond moves the stake of the delegate pool of the client to the unbonding
+// queue. The stake can be withdrawn after the maturity round.
+func (sp *StakePool) Unbond(
+	clientID string,
+	maturesAt int64,
+	providerType spenum.Provider,
+	providerID string,
+	balances cstate.StateContextI,
+) (currency.Coin, error) {
+	dp, ok := sp.Pools[clientID]
+	if !ok {
+		return 0, fmt.Errorf("no such delegate pool: %q", clientID)
+	}
+	if dp.DelegateID != clientID {
+		return 0, errors.New("trying to unlock not by delegate pool owner")
+	}
+
+	amount := dp.Balance
+	if amount > 0 {
+		var merged bool
+		for _, ue := range sp.Unbonding {
+			if ue.DelegateID == clientID && ue.MaturesAt == maturesAt {
+				var err error
+				if ue.Amount, err = currency.AddCoin(ue.Amount, amount); err != nil {
+					return 0, err
+				}
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			sp.Unbonding = append(sp.Unbonding, &UnbondingEntry{
+				DelegateID: clientID,
+				Amount:     amount,
+				MaturesAt:  maturesAt,
+			})
+		}
+		sp.emitUnbonding(providerType, providerID, balances)
+	}
+
+	dp.Balance = 0
+	dp.Status = spenum.Deleted
+	return amount, nil
+}
+
+// WithdrawUnbonded transfers the matured unbonding stake of the client from
+// the smart contract back to the client. The stake leaves the stake pool only
+// now, so the unlock of it is emitted here.
+func (sp *StakePool) WithdrawUnbonded(
+	sscID, clientID string,
+	providerType spenum.Provider,
+	providerID string,
+	balances cstate.StateContextI,
+) (currency.Coin, error) {
+	var (
+		round     = balances.GetBlock().Round
+		total     currency.Coin
+		pending   = make([]*UnbondingEntry, 0, len(sp.Unbonding))
+		nextRound int64
+	)
+	for _, ue := range sp.Unbonding {
+		if ue.DelegateID != clientID {
+			pending = append(pending, ue)
+			continue
+		}
+		if ue.MaturesAt > round {
+			if nextRound == 0 || ue.MaturesAt < nextRound {
+				nextRound = ue.MaturesAt
+			}
+			pending = append(pending, ue)
+			continue
+		}
+		var err error
+		if total, err = currency.AddCoin(total, ue.Amount); err != nil {
+			return 0, err
+		}
+	}
+	if len(pending) == len(sp.Unbonding) {
+		if nextRound > 0 {
+			return 0, fmt.Errorf("no matured unbonding stake, next stake matures at round %d", nextRound)
+		}
+		return 0, fmt.Errorf("no unbonding stake of %q", clientID)
+	}
+
+	if total > 0 {
+		if err := balances.AddTransfer(state.NewTransfer(sscID, clientID, total)); err != nil {
+			return 0, err
+		}
+		amount, err := total.Int64()
+		if err != nil {
+			return 0, err
+		}
+		balances.EmitEvent(event.TypeStats, event.TagUnlockStakePool, clientID, event.DelegatePoolLock{
+			Client:       clientID,
+			ProviderId:   providerID,
+			ProviderType: providerType,
+			Amount:       amount,
+			Total:        amount,
+		})
+	}
+	sp.Unbonding = pending
+	sp.emitUnbonding(providerType, providerID, balances)
+	return total, nil
+}
+
+// StakePoolWithdrawUnbonded withdraws the matured unbonding stake of the
+// client from the stake pool of the provider.
+func StakePoolWithdrawUnbonded(t *transaction.Transaction, input []byte, balances cstate.StateContextI,
+	get func(providerType spenum.Provider, providerID string, balances cstate.CommonStateContextI) (AbstractStakePool, error),
+) (string, error) {
+	var spr StakePoolRequest
+	if err := spr.decode(input); err != nil {
+		return "", common.NewErrorf("stake_pool_withdraw_unbonded_failed",
+			"can't decode request: %v", err)
+	}
+
+	sp, err := get(spr.ProviderType, spr.ProviderID, balances)
+	if err != nil {
+		return "", common.NewErrorf("stake_pool_withdraw_unbonded_failed",
+			"can't get related stake pool: %v", err)
+	}
+
+	withdrawn, err := sp.WithdrawUnbonded(t.ToClientID, t.ClientID, spr.ProviderType, spr.ProviderID, balances)
+	if err != nil {
+		return "", common.NewErrorf("stake_pool_withdraw_unbonded_failed",
+			"withdrawing unbonded tokens: %v", err)
+	}
+
+	if err = sp.Save(spr.ProviderType, spr.ProviderID, balances); err != nil {
+		return "", common.NewErrorf("stake_pool_withdraw_unbonded_failed",
+			"saving stake pool: %v", err)
+	}
+
+	return toJson(&UnbondingWithdrawal{
+		ClientID:     t.ClientID,
+		ProviderID:   spr.ProviderID,
+		ProviderType: spr.ProviderType,
+		Amount:       withdrawn,
+	}), nil
+}
+
+// UnbondingStake returns the total stake in the unbonding queue of the
+// stake pool.
+func (sp *StakePool) UnbondingStake() (total currency.Coin, err error) {
+	for _, ue := range sp.Unbonding {
+		if total, err = currency.AddCoin(total, ue.Amount); err != nil {
+			return 0, err
+		}
+	}
+	return total, nil
+}
+
+// SlashUnbonding slashes the ratio of every unbonding entry of the stake
+// pool, the stake is slashed until it's withdrawn. It returns the slashed
+// amount.
+func (sp *StakePool) SlashUnbonding(
+	ratio float64,
+	providerType spenum.Provider,
+	providerID string,
+	balances cstate.StateContextI,
+) (slashed currency.Coin, err error) {
+	for _, ue := range sp.Unbonding {
+		ueSlash, err := currency.MultFloat64(ue.Amount, ratio)
+		if err != nil {
+			return 0, err
+		}
+		if ueSlash > ue.Amount {
+			ueSlash = ue.Amount
+		}
+		ue.Amount -= ueSlash
+		if slashed, err = currency.AddCoin(slashed, ueSlash); err != nil {
+			return 0, err
+		}
+	}
+	if slashed > 0 {
+		sp.emitUnbonding(providerType, providerID, balances)
+	}
+	return slashed, nil
+}
+
+func (sp *StakePool) emitUnbonding(
+	providerType spenum.Provider,
+	providerID string,
+	balances cstate.StateContextI,
+) {
+	pu := event.ProviderUnbonding{
+		ProviderID:   providerID,
+		ProviderType: providerType,
+		Entries:      make([]event.UnbondingEntry, 0, len(sp.Unbonding)),
+	}
+	for _, ue := range sp.Unbonding {
+		pu.Entries = append(pu.Entries, event.UnbondingEntry{
+			DelegateID: ue.DelegateID,
+			Amount:     ue.Amount,
+			MaturesAt:  ue.MaturesAt,
+		})
+	}
+	balances.EmitEvent(event.TypeStats, event.TagSetProviderUnbonding, providerID, pu)
+}
@@ -0,0 +1,63 @@
+package stakepool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"0chain.net/chaincore/transaction"
+	"0chain.net/smartcontract/stakepool/spenum"
+)
+
+func TestStakePool_Unbonding(t *testing.T) {
+	const (
+		providerID = "provider_id"
+		sscID      = "ssc_id"
+		clientID   = "client"
+	)
+
+	var (
+		balances = newTestBalances(t, false)
+		sp       = NewStakePool()
+	)
+	balances.setTransaction(t, &transaction.Transaction{ClientID: clientID, ToClientID: sscID})
+	balances.balances[sscID] = 300
+	sp.Pools[clientID] = &DelegatePool{DelegateID: clientID, Balance: 100}
+	sp.Pools["other"] = &DelegatePool{DelegateID: "other", Balance: 200}
+
+	balances.block.Round = 10
+	amount, err := sp.Unbond(clientID, 20, spenum.Blobber, providerID, balances)
+	require.NoError(t, err)
+	require.EqualValues(t, 100, amount)
+	require.EqualValues(t, 0, sp.Pools[clientID].Balance)
+	require.Equal(t, spenum.Deleted, sp.Pools[clientID].Status)
+	require.Len(t, sp.Unbonding, 1)
+
+	// the unbonding stake is slashed with the stake pool
+	require.NoError(t, sp.SlashFraction(0.5, providerID, spenum.Blobber, balances))
+	require.EqualValues(t, 100, sp.Pools["other"].Balance)
+	require.EqualValues(t, 50, sp.Unbonding[0].Amount)
+
+	// the unbonding stake is slashed by a penalty ratio of the stake pool
+	slashed, err := sp.SlashUnbonding(0.2, spenum.Blobber, providerID, balances)
+	require.NoError(t, err)
+	require.EqualValues(t, 10, slashed)
+	require.EqualValues(t, 40, sp.Unbonding[0].Amount)
+
+	// nothing matured yet
+	_, err = sp.WithdrawUnbonded(sscID, clientID, spenum.Blobber, providerID, balances)
+	require.EqualError(t, err, "no matured unbonding stake, next stake matures at round 20")
+	require.Len(t, sp.Unbonding, 1)
+	require.Empty(t, balances.transfers)
+
+	_, err = sp.WithdrawUnbonded(sscID, "other", spenum.Blobber, providerID, balances)
+	require.EqualError(t, err, `no unbonding stake of "other"`)
+
+	balances.block.Round = 20
+	amount, err = sp.WithdrawUnbonded(sscID, clientID, spenum.Blobber, providerID, balances)
+	require.NoError(t, err)
+	require.EqualValues(t, 40, amount)
+	require.Empty(t, sp.Unbonding)
+	require.EqualValues(t, 40, balances.balances[clientID])
+	require.EqualValues(t, 260, balances.balances[sscID])
+}
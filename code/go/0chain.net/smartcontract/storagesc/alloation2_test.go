@@ -365,7 +365,7 @@ func testCancelAllocation(
 	for i, blobberChallenges := range challenges {
 		blobberID := strconv.Itoa(i)
 
-		err := partitionsChallengeReadyBlobberAddOrUpdate(ctx, blobberID, 1000)
+		err := partitionsChallengeReadyBlobberAddOrUpdate(ctx, blobberID, 1000, 1000)
 		require.NoError(t, err)
 
 		for _, created := range blobberChallenges {
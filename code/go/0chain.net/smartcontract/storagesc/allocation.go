@@ -83,6 +83,17 @@ type newAllocationRequest struct {
 	ThirdPartyExtendable bool             `json:"third_party_extendable"`
 	FileOptionsChanged   bool             `json:"file_options_changed"`
 	FileOptions          uint16           `json:"file_options"`
+	AutoReplaceBlobbers  bool             `json:"auto_replace_blobbers"`
+	AutoRenew            bool             `json:"auto_renew"`
+	RenewalBudget        currency.Coin    `json:"renewal_budget"`
+	// Tier is the storage tier the blobbers should offer, empty for their
+	// default terms.
+	Tier string `json:"tier"`
+	// MinReputation is the lowest reputation in [0, 1] of the blobbers
+	// the allocation accepts.
+	MinReputation float64 `json:"min_reputation"`
+	// Placement restricts the geolocation of the blobbers.
+	Placement PlacementPolicy `json:"placement"`
 }
 
 // storageAllocation from the request
@@ -99,6 +110,12 @@ func (nar *newAllocationRequest) storageAllocation() (sa *StorageAllocation) {
 	sa.WritePriceRange = nar.WritePriceRange
 	sa.ThirdPartyExtendable = nar.ThirdPartyExtendable
 	sa.FileOptions = nar.FileOptions
+	sa.AutoReplaceBlobbers = nar.AutoReplaceBlobbers
+	sa.AutoRenew = nar.AutoRenew
+	sa.RenewalBudget = nar.RenewalBudget
+	sa.Tier = nar.Tier
+	sa.MinReputation = nar.MinReputation
+	sa.Placement = nar.Placement
 
 	return
 }
@@ -120,6 +137,20 @@ func (nar *newAllocationRequest) validate(now time.Time, conf *Config) error {
 		return errors.New("invalid write_price range")
 	}
 
+	if nar.MinReputation < 0 || nar.MinReputation > 1 {
+		return errors.New("invalid min_reputation, should be in range [0, 1]")
+	}
+
+	if err := nar.Placement.validate(nar.DataShards + nar.ParityShards); err != nil {
+		return fmt.Errorf("invalid placement: %v", err)
+	}
+
+	if nar.Tier != "" {
+		if err := validateStorageTierName(nar.Tier); err != nil {
+			return fmt.Errorf("invalid tier: %v", err)
+		}
+	}
+
 	if nar.Size < conf.MinAllocSize {
 		return errors.New("insufficient allocation size")
 	}
@@ -358,6 +389,12 @@ func (sc *StorageSmartContract) newAllocationRequestInternal(
 	}
 	m.tick("create_challenge_pool")
 
+	if sa.AutoRenew {
+		if err := partitionsAutoRenewAllocationsSet(balances, sa.ID, true); err != nil {
+			return "", common.NewError("allocation_creation_failed", err.Error())
+		}
+	}
+
 	if resp, err = sc.addAllocation(sa, balances); err != nil {
 		logging.Logger.Error("new_allocation_request_failed: error adding allocation",
 			zap.String("txn", txn.Hash),
@@ -473,10 +510,15 @@ func validateBlobbers(
 		return nil, 0, errors.New("Not enough blobbers to honor the allocation: " + strings.Join(errs, ", "))
 	}
 
+	list, err := sa.Placement.selectBlobbers(list, size)
+	if err != nil {
+		return nil, 0, errors.New("Not enough blobbers to honor the allocation placement: " + err.Error())
+	}
+
 	sa.BlobberAllocs = make([]*BlobberAllocation, 0)
 	sa.Stats = &StorageAllocationStats{}
 
-	return list[:size], bSize, nil
+	return list, bSize, nil
 }
 
 type updateAllocationRequest struct {
@@ -492,6 +534,13 @@ type updateAllocationRequest struct {
 	SetThirdPartyExtendable bool             `json:"set_third_party_extendable"`
 	FileOptionsChanged      bool             `json:"file_options_changed"`
 	FileOptions             uint16           `json:"file_options"`
+	// AutoReplaceBlobbersChanged sets the AutoReplaceBlobbers policy.
+	AutoReplaceBlobbersChanged bool `json:"auto_replace_blobbers_changed"`
+	AutoReplaceBlobbers        bool `json:"auto_replace_blobbers"`
+	// AutoRenewChanged sets the AutoRenew policy and the RenewalBudget.
+	AutoRenewChanged bool          `json:"auto_renew_changed"`
+	AutoRenew        bool          `json:"auto_renew"`
+	RenewalBudget    currency.Coin `json:"renewal_budget"`
 }
 
 func (uar *updateAllocationRequest) decode(b []byte) error {
@@ -509,6 +558,8 @@ func (uar *updateAllocationRequest) validate(
 		len(uar.Name) == 0 &&
 		(!uar.SetThirdPartyExtendable || (uar.SetThirdPartyExtendable && alloc.ThirdPartyExtendable)) &&
 		(!uar.FileOptionsChanged || uar.FileOptions == alloc.FileOptions) &&
+		(!uar.AutoReplaceBlobbersChanged || uar.AutoReplaceBlobbers == alloc.AutoReplaceBlobbers) &&
+		(!uar.AutoRenewChanged || (uar.AutoRenew == alloc.AutoRenew && uar.RenewalBudget == alloc.RenewalBudget)) &&
 		(alloc.Owner == uar.OwnerID) {
 		return errors.New("update allocation changes nothing")
 	} else {
@@ -774,6 +825,7 @@ func (sc *StorageSmartContract) adjustChallengePool(
 	odr, ndr common.Timestamp,
 	oterms []Terms,
 	timeUnit time.Duration,
+	now common.Timestamp,
 	balances chainstate.StateContextI,
 ) error {
 	changes, err := alloc.challengePoolChanges(odr, ndr, timeUnit, oterms)
@@ -786,18 +838,35 @@ func (sc *StorageSmartContract) adjustChallengePool(
 		return fmt.Errorf("adjust_challenge_pool: %v", err)
 	}
 
+	// nothing is moved if the write pool can't cover all the changes
+	var cost currency.Coin
+	for _, ch := range changes {
+		if ch > 0 {
+			if cost, err = currency.AddCoin(cost, ch); err != nil {
+				return fmt.Errorf("adjust_challenge_pool: %v", err)
+			}
+		}
+	}
+	if cost > alloc.WritePool {
+		return fmt.Errorf("adjust_challenge_pool: %w",
+			&writePoolShortError{WritePool: alloc.WritePool, Cost: cost})
+	}
+
 	var changed bool
 	sum := currency.Coin(0)
-	for _, ch := range changes {
+	for i, ch := range changes {
 		_, err = ch.Int64()
 		if err != nil {
 			return err
 		}
 		switch {
 		case ch > 0:
-			err = alloc.moveToChallengePool(cp, ch)
+			var paid []*WritePoolLock
+			paid, err = alloc.moveToChallengePool(cp, ch)
 			sum += ch
 			changed = true
+			emitWritePoolCharges(alloc, paid, alloc.BlobberAllocs[i].BlobberID,
+				event.ChargeWrite, now, balances)
 		default:
 			// no changes for the blobber
 		}
@@ -844,6 +913,10 @@ func (sc *StorageSmartContract) extendAllocation(
 		originalTerms = make([]Terms, 0, len(alloc.BlobberAllocs))
 		// original allocation duration remains
 		originalRemainingDuration = alloc.Expiration - txn.CreationDate
+
+		// stake pools of the changed offers
+		changedPools   []*stakePool
+		changedPoolIDs []string
 	)
 
 	// adjust the expiration if changed, boundaries has already checked
@@ -885,8 +958,13 @@ func (sc *StorageSmartContract) extendAllocation(
 			Delta:        diff,
 		})
 
-		// update terms using weighted average
-		details.Terms, err = weightedAverage(&details.Terms, &b.Terms,
+		// update terms using weighted average, the terms are kept if the
+		// blobber doesn't offer the storage tier anymore
+		terms, ok := alloc.blobberTerms(b)
+		if !ok {
+			terms = details.Terms
+		}
+		details.Terms, err = weightedAverage(&details.Terms, &terms,
 			txn.CreationDate, prevExpiration, alloc.Expiration, details.Size,
 			diff)
 		if err != nil {
@@ -941,11 +1019,8 @@ func (sc *StorageSmartContract) extendAllocation(
 					return fmt.Errorf("reduce offer: %v", err)
 				}
 			}
-			if err = sp.Save(spenum.Blobber, details.BlobberID, balances); err != nil {
-				return fmt.Errorf("can't save stake pool of %s: %v", details.BlobberID,
-					err)
-			}
-
+			changedPools = append(changedPools, sp)
+			changedPoolIDs = append(changedPoolIDs, details.BlobberID)
 		}
 	}
 
@@ -958,9 +1033,17 @@ func (sc *StorageSmartContract) extendAllocation(
 
 	// add more tokens to related challenge pool, or move some tokens back
 	var remainingDuration = alloc.Expiration - txn.CreationDate
-	err = sc.adjustChallengePool(alloc, originalRemainingDuration, remainingDuration, originalTerms, conf.TimeUnit, balances)
+	err = sc.adjustChallengePool(alloc, originalRemainingDuration, remainingDuration, originalTerms, conf.TimeUnit,
+		txn.CreationDate, balances)
 	if err != nil {
-		return common.NewErrorf("allocation_extending_failed", "%v", err)
+		return fmt.Errorf("allocation_extending_failed: %w", err)
+	}
+
+	// the stake pools are saved once nothing can fail the extension
+	for i, sp := range changedPools {
+		if err = sp.Save(spenum.Blobber, changedPoolIDs[i], balances); err != nil {
+			return fmt.Errorf("can't save stake pool of %s: %v", changedPoolIDs[i], err)
+		}
 	}
 	return nil
 }
@@ -1041,7 +1124,7 @@ func (sc *StorageSmartContract) reduceAllocation(
 	// new allocation duration remains
 	var remainingDuration = alloc.Expiration - txn.CreationDate
 	err = sc.adjustChallengePool(alloc, originalRemainingDuration, remainingDuration, nil, conf.TimeUnit,
-		balances)
+		txn.CreationDate, balances)
 	if err != nil {
 		return common.NewErrorf("allocation_reducing_failed", "%v", err)
 	}
@@ -1176,13 +1259,17 @@ func (sc *StorageSmartContract) updateAllocationRequestInternal(
 
 		if request.UpdateTerms {
 			for i, bd := range alloc.BlobberAllocs {
-				if bd.Terms.WritePrice >= blobbers[i].Terms.WritePrice {
-					bd.Terms.WritePrice = blobbers[i].Terms.WritePrice
+				terms, ok := alloc.blobberTerms(blobbers[i])
+				if !ok {
+					continue
+				}
+				if bd.Terms.WritePrice >= terms.WritePrice {
+					bd.Terms.WritePrice = terms.WritePrice
 				}
-				if bd.Terms.ReadPrice >= blobbers[i].Terms.ReadPrice {
-					bd.Terms.ReadPrice = blobbers[i].Terms.ReadPrice
+				if bd.Terms.ReadPrice >= terms.ReadPrice {
+					bd.Terms.ReadPrice = terms.ReadPrice
 				}
-				bd.Terms.MinLockDemand = blobbers[i].Terms.MinLockDemand
+				bd.Terms.MinLockDemand = terms.MinLockDemand
 			}
 		}
 
@@ -1211,7 +1298,20 @@ func (sc *StorageSmartContract) updateAllocationRequestInternal(
 			alloc.FileOptions = request.FileOptions
 		}
 
+		if request.AutoReplaceBlobbersChanged {
+			alloc.AutoReplaceBlobbers = request.AutoReplaceBlobbers
+		}
+
+		if request.AutoRenewChanged {
+			alloc.AutoRenew = request.AutoRenew
+			alloc.RenewalBudget = request.RenewalBudget
+			if err := partitionsAutoRenewAllocationsSet(balances, alloc.ID, alloc.AutoRenew); err != nil {
+				return "", common.NewError("allocation_updating_failed", err.Error())
+			}
+		}
+
 		if len(request.RemoveBlobberId) > 0 {
+			alloc.removeDegradedBlobber(request.RemoveBlobberId)
 			balances.EmitEvent(event.TypeStats, event.TagDeleteAllocationBlobberTerm, t.Hash, []event.AllocationBlobberTerm{
 				{
 					AllocationID: alloc.ID,
@@ -1221,11 +1321,20 @@ func (sc *StorageSmartContract) updateAllocationRequestInternal(
 		}
 
 		if request.OwnerID != alloc.Owner {
+			prevOwner := alloc.Owner
 			alloc.Owner = request.OwnerID
 			if request.OwnerPublicKey == "" {
 				return "", common.NewError("allocation_updating_failed", "owner public key is required when updating owner id")
 			}
 			alloc.OwnerPublicKey = request.OwnerPublicKey
+			alloc.clearPendingOwner()
+			if _, err := alloc.moveWritePoolLocks(prevOwner); err != nil {
+				return "", common.NewError("allocation_updating_failed", err.Error())
+			}
+			if err := sc.deleteAllocationACL(alloc.ID, balances); err != nil {
+				return "", common.NewError("allocation_updating_failed",
+					"removing allocation collaborators: "+err.Error())
+			}
 		}
 
 	}
@@ -1359,7 +1468,7 @@ func (sc *StorageSmartContract) canceledPassRates(alloc *StorageAllocation,
 				ba.Stats = new(StorageAllocationStats) // make sure
 			}
 
-			var expire = oc.CreatedAt + toSeconds(getMaxChallengeCompletionTime())
+			var expire = oc.CreatedAt + toSeconds(ba.challengeCompletionTime())
 			if expire < now {
 				ba.Stats.FailedChallenges++
 				alloc.Stats.FailedChallenges++
@@ -1460,6 +1569,11 @@ func (sc *StorageSmartContract) cancelAllocationRequest(
 		return "", common.NewError("alloc_cancel_failed", err.Error())
 	}
 
+	if err := sc.deleteAllocationACL(alloc.ID, balances); err != nil {
+		return "", common.NewError("alloc_cancel_failed",
+			"removing allocation collaborators: "+err.Error())
+	}
+
 	alloc.Finalized, alloc.Canceled = true, true
 	_, err = balances.InsertTrieNode(alloc.GetKey(sc.ID), alloc)
 	if err != nil {
@@ -1543,6 +1657,11 @@ func (sc *StorageSmartContract) finalizeAllocation(
 		return "", common.NewError("fini_alloc_failed", err.Error())
 	}
 
+	if err := sc.deleteAllocationACL(alloc.ID, balances); err != nil {
+		return "", common.NewError("fini_alloc_failed",
+			"removing allocation collaborators: "+err.Error())
+	}
+
 	alloc.Finalized = true
 	_, err = balances.InsertTrieNode(alloc.GetKey(sc.ID), alloc)
 	if err != nil {
@@ -1582,7 +1701,7 @@ func (sc *StorageSmartContract) finishAllocation(
 				return fmt.Errorf("paying min_lock for blobber %v"+
 					"ammount was short by %v", d.BlobberID, delta)
 			}
-			alloc.WritePool, err = currency.MinusCoin(alloc.WritePool, delta)
+			paid, err := alloc.payFromWritePool(delta)
 			if err != nil {
 				return err
 			}
@@ -1605,6 +1724,8 @@ func (sc *StorageSmartContract) finishAllocation(
 			if err != nil {
 				return err
 			}
+			emitWritePoolCharges(alloc, paid, d.BlobberID,
+				event.ChargeMinLockDemand, t.CreationDate, balances)
 		}
 	}
 
@@ -1640,6 +1761,8 @@ func (sc *StorageSmartContract) finishAllocation(
 			if err != nil {
 				return fmt.Errorf("pass payments: %v", err)
 			}
+			emitAllocationCharge(alloc, "", d.BlobberID,
+				event.ChargeChallengeReward, reward, t.CreationDate, balances)
 		}
 	}
 
@@ -1655,6 +1778,8 @@ func (sc *StorageSmartContract) finishAllocation(
 			return err
 		}
 
+		emitAllocationCharge(alloc, alloc.Owner, "",
+			event.ChargeRefund, cp.Balance, t.CreationDate, balances)
 		err = alloc.moveFromChallengePool(cp, cp.Balance)
 		if err != nil {
 			return fmt.Errorf("failed to move challenge pool back to write pool: %v", err)
@@ -1671,7 +1796,7 @@ func (sc *StorageSmartContract) finishAllocation(
 		logging.Logger.Error("insufficient funds, %v, for cancellation charge, %v. distributing the remaining write pool.")
 	}
 
-	alloc.WritePool, err = currency.MinusCoin(alloc.WritePool, cancellationCharge)
+	paid, err := alloc.payFromWritePool(cancellationCharge)
 	if err != nil {
 		return fmt.Errorf("failed to deduct cancellation charges from write pool: %v", err)
 	}
@@ -1700,6 +1825,10 @@ func (sc *StorageSmartContract) finishAllocation(
 		if err != nil {
 			return fmt.Errorf("failed to distribute rewards, blobber: %s, err: %v", ba.BlobberID, err)
 		}
+		var blobberPaid []*WritePoolLock
+		blobberPaid, paid = takeWritePoolLocks(paid, reward)
+		emitWritePoolCharges(alloc, blobberPaid, ba.BlobberID,
+			event.ChargeCancellation, t.CreationDate, balances)
 
 		if err = sps[i].Save(spenum.Blobber, ba.BlobberID, balances); err != nil {
 			return fmt.Errorf("failed to save stake pool: %s, err: %v", ba.BlobberID, err)
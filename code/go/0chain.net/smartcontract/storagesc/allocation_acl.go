@@ -0,0 +1,222 @@
+package storagesc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/smartcontract/dbs/event"
+	"github.com/0chain/common/core/util"
+)
+
+//go:generate msgp -io=false -tests=false -unexported=true -v
+
+//
+// allocation access-control list (collaborators)
+//
+
+// The permissions of a collaborator, the file operation bits are the ones
+// of the allocation FileOptions.
+const (
+	aclPermissionUpload uint16 = 1 << iota
+	aclPermissionDelete
+	aclPermissionUpdate
+	aclPermissionMove
+	aclPermissionCopy
+	aclPermissionRename
+	// aclPermissionReadWithoutPayment lets the collaborator download the
+	// allocation files paid from the read pool of the allocation owner.
+	aclPermissionReadWithoutPayment
+
+	aclAllPermissions = aclPermissionReadWithoutPayment<<1 - 1
+)
+
+// maxAllocationCollaborators is the max number of collaborators of an
+// allocation.
+const maxAllocationCollaborators = 100
+
+func allocationACLKey(scKey, allocID string) datastore.Key {
+	return scKey + ":allocacl:" + allocID
+}
+
+// AllocationACL is the access-control list of an allocation, the blobbers
+// authorize the operations of the collaborators against it.
+// swagger:model AllocationACL
+type AllocationACL struct {
+	AllocationID string `json:"allocation_id"`
+	// Permissions are the permission bits of each collaborator.
+	Permissions map[string]uint16 `json:"permissions"`
+}
+
+func newAllocationACL(allocID string) *AllocationACL {
+	return &AllocationACL{
+		AllocationID: allocID,
+		Permissions:  make(map[string]uint16),
+	}
+}
+
+// can returns true if the client has all the given permissions.
+func (acl *AllocationACL) can(clientID string, permissions uint16) bool {
+	return acl.Permissions[clientID]&permissions == permissions
+}
+
+func (acl *AllocationACL) save(sscKey string, balances cstate.StateContextI) (err error) {
+	_, err = balances.InsertTrieNode(allocationACLKey(sscKey, acl.AllocationID), acl)
+	return
+}
+
+func (sc *StorageSmartContract) getAllocationACL(allocID datastore.Key,
+	balances cstate.CommonStateContextI) (acl *AllocationACL, err error) {
+
+	acl = newAllocationACL(allocID)
+	err = balances.GetTrieNode(allocationACLKey(sc.ID, allocID), acl)
+	return
+}
+
+// deleteAllocationACL removes all the collaborators of the allocation. It's
+// called when the allocation is finalized or canceled and when it changes
+// hands, the new owner doesn't pay for the reads of the collaborators the
+// previous owner has chosen.
+func (sc *StorageSmartContract) deleteAllocationACL(allocID datastore.Key,
+	balances cstate.StateContextI) error {
+
+	_, err := sc.getAllocationACL(allocID, balances)
+	switch err {
+	case nil:
+	case util.ErrValueNotPresent:
+		return nil
+	default:
+		return err
+	}
+	if _, err := balances.DeleteTrieNode(allocationACLKey(sc.ID, allocID)); err != nil {
+		return err
+	}
+
+	balances.EmitEvent(event.TypeStats, event.TagDeleteAllocationACL, allocID,
+		event.AllocationACL{AllocationID: allocID})
+	return nil
+}
+
+// canReadWithoutPayment returns true if the reader is a collaborator of the
+// allocation whose downloads are paid by the owner.
+func (sc *StorageSmartContract) canReadWithoutPayment(alloc *StorageAllocation,
+	readerID string, balances cstate.StateContextI) (bool, error) {
+
+	if readerID == alloc.Owner {
+		return false, nil
+	}
+	acl, err := sc.getAllocationACL(alloc.ID, balances)
+	switch err {
+	case nil:
+		return acl.can(readerID, aclPermissionReadWithoutPayment), nil
+	case util.ErrValueNotPresent:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+type updateAllocationACLRequest struct {
+	AllocationID string `json:"allocation_id"`
+	ClientID     string `json:"client_id"`
+	// Permissions of the collaborator, zero removes it.
+	Permissions uint16 `json:"permissions"`
+}
+
+func (req *updateAllocationACLRequest) decode(input []byte) error {
+	return json.Unmarshal(input, req)
+}
+
+func (req *updateAllocationACLRequest) validate() error {
+	if req.ClientID == "" {
+		return fmt.Errorf("missing client_id")
+	}
+	if req.Permissions&^aclAllPermissions != 0 {
+		return fmt.Errorf("invalid permissions %d, should be in range [0, %d]",
+			req.Permissions, aclAllPermissions)
+	}
+	return nil
+}
+
+// updateAllocationACL adds, updates or removes a collaborator of the
+// allocation. Only the owner of the allocation can change its collaborators.
+func (sc *StorageSmartContract) updateAllocationACL(
+	txn *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	var req updateAllocationACLRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError("update_allocation_acl_failed",
+			"invalid request: "+err.Error())
+	}
+	if err := req.validate(); err != nil {
+		return "", common.NewError("update_allocation_acl_failed", err.Error())
+	}
+
+	alloc, err := sc.getAllocation(req.AllocationID, balances)
+	if err != nil {
+		return "", common.NewError("update_allocation_acl_failed",
+			"can't get allocation: "+err.Error())
+	}
+	if txn.ClientID != alloc.Owner {
+		return "", common.NewError("update_allocation_acl_failed",
+			"only owner can update the allocation collaborators")
+	}
+	if alloc.Finalized || alloc.Canceled {
+		return "", common.NewError("update_allocation_acl_failed",
+			"allocation is finalized or canceled")
+	}
+	if req.ClientID == alloc.Owner {
+		return "", common.NewError("update_allocation_acl_failed",
+			"the owner can't be a collaborator")
+	}
+
+	acl, err := sc.getAllocationACL(alloc.ID, balances)
+	if err != nil && err != util.ErrValueNotPresent {
+		return "", common.NewError("update_allocation_acl_failed",
+			"can't get allocation collaborators: "+err.Error())
+	}
+	if err == util.ErrValueNotPresent {
+		acl = newAllocationACL(alloc.ID)
+	}
+
+	_, exists := acl.Permissions[req.ClientID]
+	switch {
+	case req.Permissions == 0 && !exists:
+		return "", common.NewError("update_allocation_acl_failed",
+			"client is not a collaborator of the allocation")
+	case req.Permissions == 0:
+		delete(acl.Permissions, req.ClientID)
+	case !exists && len(acl.Permissions) >= maxAllocationCollaborators:
+		return "", common.NewErrorf("update_allocation_acl_failed",
+			"max %d collaborators per allocation", maxAllocationCollaborators)
+	default:
+		acl.Permissions[req.ClientID] = req.Permissions
+	}
+
+	if err := acl.save(sc.ID, balances); err != nil {
+		return "", common.NewError("update_allocation_acl_failed",
+			"saving allocation collaborators: "+err.Error())
+	}
+
+	tag := event.TagAddOrUpdateAllocationACL
+	if req.Permissions == 0 {
+		tag = event.TagDeleteAllocationACL
+	}
+	balances.EmitEvent(event.TypeStats, tag, alloc.ID, event.AllocationACL{
+		AllocationID: alloc.ID,
+		ClientID:     req.ClientID,
+		Permissions:  req.Permissions,
+	})
+
+	resp, err := json.Marshal(acl)
+	if err != nil {
+		return "", common.NewError("update_allocation_acl_failed",
+			"encoding allocation collaborators: "+err.Error())
+	}
+	return string(resp), nil
+}
@@ -0,0 +1,165 @@
+package storagesc
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/encryption"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageSmartContract_updateAllocationACL(t *testing.T) {
+	const (
+		allocID      = "alloc_hex"
+		owner        = "owner_hex"
+		collaborator = "collaborator_hex"
+	)
+
+	var (
+		ssc      = newTestStorageSC()
+		balances = newTestBalances(t, false)
+		now      = toSeconds(time.Hour)
+	)
+
+	alloc := &StorageAllocation{
+		ID:         allocID,
+		Owner:      owner,
+		Expiration: now + toSeconds(time.Hour),
+	}
+	require.NoError(t, alloc.save(balances, ssc.ID))
+
+	update := func(clientID string, req *updateAllocationACLRequest) error {
+		input, err := json.Marshal(req)
+		require.NoError(t, err)
+		tx := transaction.Transaction{
+			ClientID:     clientID,
+			ToClientID:   ADDRESS,
+			CreationDate: now,
+		}
+		tx.Hash = encryption.Hash(clientID + string(input))
+		balances.setTransaction(t, &tx)
+		_, err = ssc.updateAllocationACL(&tx, input, balances)
+		return err
+	}
+
+	req := &updateAllocationACLRequest{
+		AllocationID: allocID,
+		ClientID:     collaborator,
+		Permissions:  aclPermissionUpload | aclPermissionReadWithoutPayment,
+	}
+	requireErrMsg(t, update(collaborator, req),
+		"update_allocation_acl_failed: only owner can update the allocation collaborators")
+	requireErrMsg(t, update(owner, &updateAllocationACLRequest{AllocationID: allocID, ClientID: owner, Permissions: 1}),
+		"update_allocation_acl_failed: the owner can't be a collaborator")
+	requireErrMsg(t, update(owner, &updateAllocationACLRequest{AllocationID: allocID, ClientID: collaborator, Permissions: 128}),
+		"update_allocation_acl_failed: invalid permissions 128, should be in range [0, 127]")
+
+	require.NoError(t, update(owner, req))
+	acl, err := ssc.getAllocationACL(allocID, balances)
+	require.NoError(t, err)
+	assert.True(t, acl.can(collaborator, aclPermissionUpload))
+	assert.False(t, acl.can(collaborator, aclPermissionUpload|aclPermissionDelete))
+
+	ok, err := ssc.canReadWithoutPayment(alloc, collaborator, balances)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = ssc.canReadWithoutPayment(alloc, owner, balances)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// revoke
+	req.Permissions = 0
+	require.NoError(t, update(owner, req))
+	acl, err = ssc.getAllocationACL(allocID, balances)
+	require.NoError(t, err)
+	assert.Empty(t, acl.Permissions)
+	requireErrMsg(t, update(owner, req),
+		"update_allocation_acl_failed: client is not a collaborator of the allocation")
+}
+
+func TestStorageSmartContract_commitBlobberRead_collaborator(t *testing.T) {
+	var (
+		ssc          = newTestStorageSC()
+		balances     = newTestBalances(t, false)
+		owner        = newClient(2000*x10, balances)
+		collaborator = newClient(100*x10, balances)
+		tp, exp      = int64(0), int64(toSeconds(time.Hour))
+	)
+
+	setConfig(t, balances)
+
+	tp += 100
+	allocID, blobs := addAllocation(t, ssc, owner, tp, exp, 0, balances)
+	alloc, err := ssc.getAllocation(allocID, balances)
+	require.NoError(t, err)
+	b1 := testGetBlobber(blobs, alloc, 0)
+	require.NotNil(t, b1)
+
+	tp += 100
+	tx := newTransaction(owner.id, ssc.ID, 0, tp)
+	balances.setTransaction(t, tx)
+	_, err = ssc.updateAllocationACL(tx, mustEncode(t, &updateAllocationACLRequest{
+		AllocationID: allocID,
+		ClientID:     collaborator.id,
+		Permissions:  aclPermissionReadWithoutPayment,
+	}), balances)
+	require.NoError(t, err)
+
+	// the owner funds the reads
+	tp += 100
+	readPoolFund, err := currency.ParseZCN(2)
+	require.NoError(t, err)
+	tx = newTransaction(owner.id, ssc.ID, readPoolFund, tp)
+	balances.setTransaction(t, tx)
+	_, err = ssc.readPoolLock(tx, mustEncode(t, &readPoolLockRequest{
+		TargetId: owner.id,
+	}), balances)
+	require.NoError(t, err)
+
+	commitRead := func(counter int64) error {
+		tp += 100
+		var rm ReadConnection
+		rm.ReadMarker = &ReadMarker{
+			ClientID:        collaborator.id,
+			ClientPublicKey: collaborator.pk,
+			BlobberID:       b1.id,
+			AllocationID:    allocID,
+			OwnerID:         owner.id,
+			Timestamp:       common.Timestamp(tp),
+			ReadCounter:     counter,
+		}
+		rm.ReadMarker.Signature, err = collaborator.scheme.Sign(
+			encryption.Hash(rm.ReadMarker.GetHashData()))
+		require.NoError(t, err)
+
+		tp += 100
+		tx := newTransaction(b1.id, ssc.ID, 0, tp)
+		balances.setTransaction(t, tx)
+		_, err := ssc.commitBlobberRead(tx, mustEncode(t, &rm), balances)
+		return err
+	}
+
+	require.NoError(t, commitRead(1*GB/(64*KB)))
+
+	rp, err := ssc.getReadPool(owner.id, balances)
+	require.NoError(t, err)
+	require.EqualValues(t, readPoolFund-1e10, rp.Balance)
+	_, err = ssc.getReadPool(collaborator.id, balances)
+	require.Equal(t, util.ErrValueNotPresent, err)
+
+	// the collaborators are gone with the allocation owner
+	require.NoError(t, ssc.deleteAllocationACL(allocID, balances))
+	_, err = ssc.getAllocationACL(allocID, balances)
+	require.Equal(t, util.ErrValueNotPresent, err)
+	require.Error(t, commitRead(2*GB/(64*KB)))
+
+	rp, err = ssc.getReadPool(owner.id, balances)
+	require.NoError(t, err)
+	require.EqualValues(t, readPoolFund-1e10, rp.Balance)
+}
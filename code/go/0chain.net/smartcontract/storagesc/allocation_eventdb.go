@@ -2,6 +2,7 @@ package storagesc
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"0chain.net/chaincore/transaction"
@@ -85,17 +86,26 @@ func allocationTableToStorageAllocationBlobbers(alloc *event.Allocation, eventDb
 	}
 
 	sa := &StorageAllocation{
-		ID:                   alloc.AllocationID,
-		Tx:                   alloc.TransactionID,
-		DataShards:           alloc.DataShards,
-		ParityShards:         alloc.ParityShards,
-		Size:                 alloc.Size,
-		Expiration:           common.Timestamp(alloc.Expiration),
-		Owner:                alloc.Owner,
-		OwnerPublicKey:       alloc.OwnerPublicKey,
-		WritePool:            alloc.WritePool,
-		ThirdPartyExtendable: alloc.ThirdPartyExtendable,
-		FileOptions:          alloc.FileOptions,
+		ID:                     alloc.AllocationID,
+		Tx:                     alloc.TransactionID,
+		DataShards:             alloc.DataShards,
+		ParityShards:           alloc.ParityShards,
+		Size:                   alloc.Size,
+		Expiration:             common.Timestamp(alloc.Expiration),
+		Owner:                  alloc.Owner,
+		OwnerPublicKey:         alloc.OwnerPublicKey,
+		WritePool:              alloc.WritePool,
+		ThirdPartyExtendable:   alloc.ThirdPartyExtendable,
+		FileOptions:            alloc.FileOptions,
+		AutoReplaceBlobbers:    alloc.AutoReplaceBlobbers,
+		Tier:                   alloc.Tier,
+		MinReputation:          alloc.MinReputation,
+		DegradedBlobbers:       splitDegradedBlobbers(alloc.DegradedBlobbers),
+		PendingOwner:           alloc.PendingOwner,
+		PendingOwnerPublicKey:  alloc.PendingOwnerPublicKey,
+		PendingOwnerExpiration: common.Timestamp(alloc.PendingOwnerExpiration),
+		AutoRenew:              alloc.AutoRenew,
+		RenewalBudget:          alloc.RenewalBudget,
 		Stats: &StorageAllocationStats{
 			UsedSize:                  alloc.UsedSize,
 			NumWrites:                 alloc.NumWrites,
@@ -118,6 +128,16 @@ func allocationTableToStorageAllocationBlobbers(alloc *event.Allocation, eventDb
 		MovedBack:         alloc.MovedBack,
 		MovedToValidators: alloc.MovedToValidators,
 		TimeUnit:          time.Duration(alloc.TimeUnit),
+		Placement: PlacementPolicy{
+			MinDistanceKm: alloc.PlacementMinDistanceKm,
+			MinRegions:    alloc.PlacementMinRegions,
+			BoundingBox: GeoBoundingBox{
+				MinLatitude:  alloc.PlacementMinLatitude,
+				MaxLatitude:  alloc.PlacementMaxLatitude,
+				MinLongitude: alloc.PlacementMinLongitude,
+				MaxLongitude: alloc.PlacementMaxLongitude,
+			},
+		},
 	}
 
 	return &StorageAllocationBlobbers{
@@ -126,32 +146,57 @@ func allocationTableToStorageAllocationBlobbers(alloc *event.Allocation, eventDb
 	}, nil
 }
 
+// splitDegradedBlobbers returns the degraded blobbers of the allocation
+// table, kept as comma separated ids.
+func splitDegradedBlobbers(ids string) []string {
+	if ids == "" {
+		return nil
+	}
+	return strings.Split(ids, ",")
+}
+
 func storageAllocationToAllocationTable(sa *StorageAllocation) *event.Allocation {
 	alloc := &event.Allocation{
-		AllocationID:         sa.ID,
-		TransactionID:        sa.Tx,
-		DataShards:           sa.DataShards,
-		ParityShards:         sa.ParityShards,
-		Size:                 sa.Size,
-		Expiration:           int64(sa.Expiration),
-		Terms:                sa.buildEventBlobberTerms(),
-		Owner:                sa.Owner,
-		OwnerPublicKey:       sa.OwnerPublicKey,
-		ReadPriceMin:         sa.ReadPriceRange.Min,
-		ReadPriceMax:         sa.ReadPriceRange.Max,
-		WritePriceMin:        sa.WritePriceRange.Min,
-		WritePriceMax:        sa.WritePriceRange.Max,
-		StartTime:            int64(sa.StartTime),
-		Finalized:            sa.Finalized,
-		Cancelled:            sa.Canceled,
-		UsedSize:             sa.UsedSize,
-		MovedToChallenge:     sa.MovedToChallenge,
-		MovedBack:            sa.MovedBack,
-		MovedToValidators:    sa.MovedToValidators,
-		TimeUnit:             int64(sa.TimeUnit),
-		WritePool:            sa.WritePool,
-		ThirdPartyExtendable: sa.ThirdPartyExtendable,
-		FileOptions:          sa.FileOptions,
+		AllocationID:           sa.ID,
+		TransactionID:          sa.Tx,
+		DataShards:             sa.DataShards,
+		ParityShards:           sa.ParityShards,
+		Size:                   sa.Size,
+		Expiration:             int64(sa.Expiration),
+		Terms:                  sa.buildEventBlobberTerms(),
+		Owner:                  sa.Owner,
+		OwnerPublicKey:         sa.OwnerPublicKey,
+		ReadPriceMin:           sa.ReadPriceRange.Min,
+		ReadPriceMax:           sa.ReadPriceRange.Max,
+		WritePriceMin:          sa.WritePriceRange.Min,
+		WritePriceMax:          sa.WritePriceRange.Max,
+		StartTime:              int64(sa.StartTime),
+		Finalized:              sa.Finalized,
+		Cancelled:              sa.Canceled,
+		UsedSize:               sa.UsedSize,
+		MovedToChallenge:       sa.MovedToChallenge,
+		MovedBack:              sa.MovedBack,
+		MovedToValidators:      sa.MovedToValidators,
+		TimeUnit:               int64(sa.TimeUnit),
+		WritePool:              sa.WritePool,
+		ThirdPartyExtendable:   sa.ThirdPartyExtendable,
+		FileOptions:            sa.FileOptions,
+		AutoReplaceBlobbers:    sa.AutoReplaceBlobbers,
+		Tier:                   sa.Tier,
+		MinReputation:          sa.MinReputation,
+		PlacementMinDistanceKm: sa.Placement.MinDistanceKm,
+		PlacementMinRegions:    sa.Placement.MinRegions,
+		PlacementMinLatitude:   sa.Placement.BoundingBox.MinLatitude,
+		PlacementMaxLatitude:   sa.Placement.BoundingBox.MaxLatitude,
+		PlacementMinLongitude:  sa.Placement.BoundingBox.MinLongitude,
+		PlacementMaxLongitude:  sa.Placement.BoundingBox.MaxLongitude,
+		Degraded:               len(sa.DegradedBlobbers) > 0,
+		DegradedBlobbers:       strings.Join(sa.DegradedBlobbers, ","),
+		PendingOwner:           sa.PendingOwner,
+		PendingOwnerPublicKey:  sa.PendingOwnerPublicKey,
+		PendingOwnerExpiration: int64(sa.PendingOwnerExpiration),
+		AutoRenew:              sa.AutoRenew,
+		RenewalBudget:          sa.RenewalBudget,
 	}
 
 	if sa.Stats != nil {
@@ -184,29 +229,45 @@ func (sa *StorageAllocation) buildEventBlobberTerms() []event.AllocationBlobberT
 
 func (sa *StorageAllocation) buildDbUpdates() event.Allocation {
 	eAlloc := event.Allocation{
-		AllocationID:         sa.ID,
-		TransactionID:        sa.Tx,
-		DataShards:           sa.DataShards,
-		ParityShards:         sa.ParityShards,
-		Size:                 sa.Size,
-		Expiration:           int64(sa.Expiration),
-		Owner:                sa.Owner,
-		OwnerPublicKey:       sa.OwnerPublicKey,
-		ReadPriceMin:         sa.ReadPriceRange.Min,
-		ReadPriceMax:         sa.ReadPriceRange.Max,
-		WritePriceMin:        sa.WritePriceRange.Min,
-		WritePriceMax:        sa.WritePriceRange.Max,
-		StartTime:            int64(sa.StartTime),
-		Finalized:            sa.Finalized,
-		Cancelled:            sa.Canceled,
-		UsedSize:             sa.UsedSize,
-		MovedToChallenge:     sa.MovedToChallenge,
-		MovedBack:            sa.MovedBack,
-		MovedToValidators:    sa.MovedToValidators,
-		TimeUnit:             int64(sa.TimeUnit),
-		WritePool:            sa.WritePool,
-		ThirdPartyExtendable: sa.ThirdPartyExtendable,
-		FileOptions:          sa.FileOptions,
+		AllocationID:           sa.ID,
+		TransactionID:          sa.Tx,
+		DataShards:             sa.DataShards,
+		ParityShards:           sa.ParityShards,
+		Size:                   sa.Size,
+		Expiration:             int64(sa.Expiration),
+		Owner:                  sa.Owner,
+		OwnerPublicKey:         sa.OwnerPublicKey,
+		ReadPriceMin:           sa.ReadPriceRange.Min,
+		ReadPriceMax:           sa.ReadPriceRange.Max,
+		WritePriceMin:          sa.WritePriceRange.Min,
+		WritePriceMax:          sa.WritePriceRange.Max,
+		StartTime:              int64(sa.StartTime),
+		Finalized:              sa.Finalized,
+		Cancelled:              sa.Canceled,
+		UsedSize:               sa.UsedSize,
+		MovedToChallenge:       sa.MovedToChallenge,
+		MovedBack:              sa.MovedBack,
+		MovedToValidators:      sa.MovedToValidators,
+		TimeUnit:               int64(sa.TimeUnit),
+		WritePool:              sa.WritePool,
+		ThirdPartyExtendable:   sa.ThirdPartyExtendable,
+		FileOptions:            sa.FileOptions,
+		AutoReplaceBlobbers:    sa.AutoReplaceBlobbers,
+		Tier:                   sa.Tier,
+		MinReputation:          sa.MinReputation,
+		PlacementMinDistanceKm: sa.Placement.MinDistanceKm,
+		PlacementMinRegions:    sa.Placement.MinRegions,
+		PlacementMinLatitude:   sa.Placement.BoundingBox.MinLatitude,
+		PlacementMaxLatitude:   sa.Placement.BoundingBox.MaxLatitude,
+		PlacementMinLongitude:  sa.Placement.BoundingBox.MinLongitude,
+		PlacementMaxLongitude:  sa.Placement.BoundingBox.MaxLongitude,
+		Degraded:               len(sa.DegradedBlobbers) > 0,
+		DegradedBlobbers:       strings.Join(sa.DegradedBlobbers, ","),
+		PendingOwner:           sa.PendingOwner,
+		PendingOwnerPublicKey:  sa.PendingOwnerPublicKey,
+		PendingOwnerExpiration: int64(sa.PendingOwnerExpiration),
+		AutoRenew:              sa.AutoRenew,
+		RenewalBudget:          sa.RenewalBudget,
 	}
 
 	if sa.Stats != nil {
@@ -260,6 +321,24 @@ func getClientAllocationsFromDb(clientID string, eventDb *event.EventDb, limit c
 	return sas, nil
 }
 
+func getDegradedAllocationsFromDb(owner string, eventDb *event.EventDb, limit common2.Pagination) ([]*StorageAllocationBlobbers, error) {
+	allocs, err := eventDb.GetDegradedAllocations(owner, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	sas := make([]*StorageAllocationBlobbers, 0, len(allocs))
+	for _, alloc := range allocs {
+		sa, err := allocationTableToStorageAllocationBlobbers(&alloc, eventDb)
+		if err != nil {
+			return nil, err
+		}
+		sas = append(sas, sa)
+	}
+
+	return sas, nil
+}
+
 func emitAddOrOverwriteAllocationBlobberTerms(sa *StorageAllocation, balances cstate.StateContextI, t *transaction.Transaction) {
 	balances.EmitEvent(event.TypeStats, event.TagAddOrOverwriteAllocationBlobberTerm, t.Hash, sa.buildEventBlobberTerms())
 }
@@ -0,0 +1,208 @@
+package storagesc
+
+import (
+	"encoding/json"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/smartcontract/dbs/event"
+	"github.com/0chain/common/core/currency"
+)
+
+//
+// allocation ownership transfer
+//
+
+type transferOwnershipRequest struct {
+	AllocationID string `json:"allocation_id"`
+	// NewOwnerID is the client the allocation is transferred to, empty
+	// cancels the pending transfer.
+	NewOwnerID        string `json:"new_owner_id"`
+	NewOwnerPublicKey string `json:"new_owner_public_key"`
+}
+
+func (tr *transferOwnershipRequest) decode(input []byte) error {
+	return json.Unmarshal(input, tr)
+}
+
+type acceptOwnershipRequest struct {
+	AllocationID string `json:"allocation_id"`
+}
+
+func (ar *acceptOwnershipRequest) decode(input []byte) error {
+	return json.Unmarshal(input, ar)
+}
+
+// hasPendingOwner returns true if the allocation has an ownership transfer
+// not accepted yet and not expired.
+func (sa *StorageAllocation) hasPendingOwner(now common.Timestamp) bool {
+	return sa.PendingOwner != "" && now <= sa.PendingOwnerExpiration
+}
+
+// moveWritePoolLocks moves the write pool locks of the previous owner to the
+// owner and returns the owner's part of the write pool, the locks of the
+// other clients stay theirs.
+func (sa *StorageAllocation) moveWritePoolLocks(prevOwner string) (currency.Coin, error) {
+	var (
+		others currency.Coin
+		err    error
+	)
+	for _, l := range sa.WritePoolLocks {
+		if l.ClientID == prevOwner {
+			l.ClientID = sa.Owner
+			continue
+		}
+		if l.ClientID != sa.Owner {
+			if others, err = currency.AddCoin(others, l.Amount); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if others > sa.WritePool {
+		return 0, nil
+	}
+	return sa.WritePool - others, nil
+}
+
+func (sa *StorageAllocation) clearPendingOwner() {
+	sa.PendingOwner = ""
+	sa.PendingOwnerPublicKey = ""
+	sa.PendingOwnerExpiration = 0
+}
+
+// transferAllocationOwnership records a pending new owner of the allocation.
+// The allocation keeps its owner until the new one accepts the transfer with
+// the accept_allocation_ownership transaction within the configured timeout.
+func (sc *StorageSmartContract) transferAllocationOwnership(
+	txn *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	var req transferOwnershipRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError("transfer_allocation_ownership_failed",
+			"invalid request: "+err.Error())
+	}
+
+	conf, err := sc.getConfig(balances, false)
+	if err != nil {
+		return "", common.NewError("transfer_allocation_ownership_failed",
+			"can't get SC configurations: "+err.Error())
+	}
+
+	alloc, err := sc.getAllocation(req.AllocationID, balances)
+	if err != nil {
+		return "", common.NewError("transfer_allocation_ownership_failed",
+			"can't get allocation: "+err.Error())
+	}
+
+	if txn.ClientID != alloc.Owner {
+		return "", common.NewError("transfer_allocation_ownership_failed",
+			"only owner can transfer the allocation")
+	}
+	if alloc.Finalized || alloc.Canceled || alloc.Expiration < txn.CreationDate {
+		return "", common.NewError("transfer_allocation_ownership_failed",
+			"allocation is finalized, canceled or expired")
+	}
+
+	switch {
+	case req.NewOwnerID == "":
+		if alloc.PendingOwner == "" {
+			return "", common.NewError("transfer_allocation_ownership_failed",
+				"no pending ownership transfer to cancel")
+		}
+		alloc.clearPendingOwner()
+	case req.NewOwnerID == alloc.Owner:
+		return "", common.NewError("transfer_allocation_ownership_failed",
+			"new owner is the current owner")
+	case req.NewOwnerPublicKey == "":
+		return "", common.NewError("transfer_allocation_ownership_failed",
+			"missing new owner public key")
+	case conf.OwnershipTransferTimeout == 0:
+		return "", common.NewError("transfer_allocation_ownership_failed",
+			"ownership transfers are disabled")
+	default:
+		alloc.PendingOwner = req.NewOwnerID
+		alloc.PendingOwnerPublicKey = req.NewOwnerPublicKey
+		alloc.PendingOwnerExpiration = txn.CreationDate +
+			common.ToSeconds(conf.OwnershipTransferTimeout)
+	}
+
+	if err := alloc.save(balances, sc.ID); err != nil {
+		return "", common.NewError("transfer_allocation_ownership_failed",
+			"saving allocation: "+err.Error())
+	}
+	balances.EmitEvent(event.TypeStats, event.TagUpdateAllocation, alloc.ID, alloc.buildDbUpdates())
+
+	return string(alloc.Encode()), nil
+}
+
+// acceptAllocationOwnership makes the pending owner of the allocation its
+// owner. The write pool of the allocation is accounted to the new owner from
+// now on, the collaborators chosen by the previous owner are removed.
+func (sc *StorageSmartContract) acceptAllocationOwnership(
+	txn *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	var req acceptOwnershipRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError("accept_allocation_ownership_failed",
+			"invalid request: "+err.Error())
+	}
+
+	alloc, err := sc.getAllocation(req.AllocationID, balances)
+	if err != nil {
+		return "", common.NewError("accept_allocation_ownership_failed",
+			"can't get allocation: "+err.Error())
+	}
+
+	if alloc.PendingOwner == "" || txn.ClientID != alloc.PendingOwner {
+		return "", common.NewError("accept_allocation_ownership_failed",
+			"no pending ownership transfer to the client")
+	}
+	if !alloc.hasPendingOwner(txn.CreationDate) {
+		return "", common.NewError("accept_allocation_ownership_failed",
+			"ownership transfer expired")
+	}
+	if alloc.Finalized || alloc.Canceled {
+		return "", common.NewError("accept_allocation_ownership_failed",
+			"allocation is finalized or canceled")
+	}
+
+	prevOwner := alloc.Owner
+	alloc.Owner = alloc.PendingOwner
+	alloc.OwnerPublicKey = alloc.PendingOwnerPublicKey
+	alloc.clearPendingOwner()
+	ownerWritePool, err := alloc.moveWritePoolLocks(prevOwner)
+	if err != nil {
+		return "", common.NewError("accept_allocation_ownership_failed", err.Error())
+	}
+
+	if err := alloc.save(balances, sc.ID); err != nil {
+		return "", common.NewError("accept_allocation_ownership_failed",
+			"saving allocation: "+err.Error())
+	}
+	if err := sc.deleteAllocationACL(alloc.ID, balances); err != nil {
+		return "", common.NewError("accept_allocation_ownership_failed",
+			"removing allocation collaborators: "+err.Error())
+	}
+	balances.EmitEvent(event.TypeStats, event.TagUpdateAllocation, alloc.ID, alloc.buildDbUpdates())
+
+	// move the write pool accounting of the previous owner to the new one
+	if wp, _ := ownerWritePool.Int64(); wp > 0 {
+		balances.EmitEvent(event.TypeStats, event.TagUnlockWritePool, alloc.ID, event.WritePoolLock{
+			Client:       prevOwner,
+			AllocationId: alloc.ID,
+			Amount:       wp,
+		})
+		balances.EmitEvent(event.TypeStats, event.TagLockWritePool, alloc.ID, event.WritePoolLock{
+			Client:       alloc.Owner,
+			AllocationId: alloc.ID,
+			Amount:       wp,
+		})
+	}
+
+	return string(alloc.Encode()), nil
+}
@@ -0,0 +1,176 @@
+package storagesc
+
+import (
+	"testing"
+	"time"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/encryption"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageSmartContract_allocationOwnershipTransfer(t *testing.T) {
+	const (
+		allocID  = "alloc_hex"
+		owner    = "owner_hex"
+		newOwner = "new_owner_hex"
+	)
+
+	var (
+		ssc      = newTestStorageSC()
+		balances = newTestBalances(t, false)
+		now      = toSeconds(2 * time.Hour)
+	)
+
+	conf := setConfig(t, balances)
+	conf.OwnershipTransferTimeout = time.Minute
+	_, err := balances.InsertTrieNode(scConfigKey(ADDRESS), conf)
+	require.NoError(t, err)
+
+	alloc := &StorageAllocation{
+		ID:             allocID,
+		Owner:          owner,
+		OwnerPublicKey: "owner_pub_key",
+		Expiration:     now + toSeconds(time.Hour),
+		WritePool:      100,
+	}
+	require.NoError(t, alloc.save(balances, ssc.ID))
+
+	call := func(
+		f func(*transaction.Transaction, []byte, cstate.StateContextI) (string, error),
+		clientID string, at common.Timestamp, input []byte,
+	) error {
+		tx := transaction.Transaction{
+			ClientID:     clientID,
+			ToClientID:   ADDRESS,
+			CreationDate: now + at,
+		}
+		tx.Hash = encryption.Hash(clientID + string(input))
+		balances.setTransaction(t, &tx)
+		_, err := f(&tx, input, balances)
+		return err
+	}
+	transfer := func(clientID string, at common.Timestamp, req *transferOwnershipRequest) error {
+		return call(ssc.transferAllocationOwnership, clientID, at, mustEncode(t, req))
+	}
+	accept := func(clientID string, at common.Timestamp) error {
+		return call(ssc.acceptAllocationOwnership, clientID, at,
+			mustEncode(t, &acceptOwnershipRequest{AllocationID: allocID}))
+	}
+
+	req := &transferOwnershipRequest{
+		AllocationID:      allocID,
+		NewOwnerID:        newOwner,
+		NewOwnerPublicKey: "new_owner_pub_key",
+	}
+
+	requireErrMsg(t, transfer(newOwner, 0, req),
+		"transfer_allocation_ownership_failed: only owner can transfer the allocation")
+	requireErrMsg(t, transfer(owner, 0, &transferOwnershipRequest{AllocationID: allocID}),
+		"transfer_allocation_ownership_failed: no pending ownership transfer to cancel")
+	requireErrMsg(t, transfer(owner, 0, &transferOwnershipRequest{AllocationID: allocID,
+		NewOwnerID: newOwner}),
+		"transfer_allocation_ownership_failed: missing new owner public key")
+	requireErrMsg(t, accept(newOwner, 0),
+		"accept_allocation_ownership_failed: no pending ownership transfer to the client")
+
+	// the transfer expires if not accepted in time
+	require.NoError(t, transfer(owner, 0, req))
+	requireErrMsg(t, accept(owner, 1),
+		"accept_allocation_ownership_failed: no pending ownership transfer to the client")
+	requireErrMsg(t, accept(newOwner, 61),
+		"accept_allocation_ownership_failed: ownership transfer expired")
+
+	// the owner can cancel the pending transfer
+	require.NoError(t, transfer(owner, 100, req))
+	require.NoError(t, transfer(owner, 101, &transferOwnershipRequest{AllocationID: allocID}))
+	requireErrMsg(t, accept(newOwner, 102),
+		"accept_allocation_ownership_failed: no pending ownership transfer to the client")
+
+	require.NoError(t, transfer(owner, 200, req))
+	pending, err := ssc.getAllocation(allocID, balances)
+	require.NoError(t, err)
+	assert.Equal(t, owner, pending.Owner)
+	assert.Equal(t, newOwner, pending.PendingOwner)
+	assert.Equal(t, now+260, pending.PendingOwnerExpiration)
+
+	require.NoError(t, accept(newOwner, 230))
+	accepted, err := ssc.getAllocation(allocID, balances)
+	require.NoError(t, err)
+	assert.Equal(t, newOwner, accepted.Owner)
+	assert.Equal(t, "new_owner_pub_key", accepted.OwnerPublicKey)
+	assert.Empty(t, accepted.PendingOwner)
+	assert.Zero(t, accepted.PendingOwnerExpiration)
+	assert.EqualValues(t, 100, accepted.WritePool)
+
+	requireErrMsg(t, transfer(owner, 240, req),
+		"transfer_allocation_ownership_failed: only owner can transfer the allocation")
+
+	// zero timeout disables the transfers
+	conf.OwnershipTransferTimeout = 0
+	_, err = balances.InsertTrieNode(scConfigKey(ADDRESS), conf)
+	require.NoError(t, err)
+	requireErrMsg(t, transfer(newOwner, 250, &transferOwnershipRequest{
+		AllocationID:      allocID,
+		NewOwnerID:        owner,
+		NewOwnerPublicKey: "owner_pub_key",
+	}), "transfer_allocation_ownership_failed: ownership transfers are disabled")
+}
+
+func TestStorageSmartContract_updateAllocationOwner(t *testing.T) {
+	const (
+		allocID  = "alloc_hex"
+		newOwner = "new_owner_hex"
+	)
+
+	var (
+		ssc      = newTestStorageSC()
+		balances = newTestBalances(t, false)
+		now      = toSeconds(2 * time.Hour)
+	)
+
+	// the owner can be changed in one step even with the transfers disabled
+	conf := setConfig(t, balances)
+	conf.OwnershipTransferTimeout = 0
+	_, err := balances.InsertTrieNode(scConfigKey(ADDRESS), conf)
+	require.NoError(t, err)
+
+	alloc := newAutoRenewAllocation(t, ssc, balances, conf, allocID, now, Terms{})
+	alloc.Expiration = now + toSeconds(time.Hour)
+	alloc.PendingOwner = "pending_owner_hex"
+	alloc.PendingOwnerPublicKey = "pending_owner_pub_key"
+	alloc.PendingOwnerExpiration = now + 60
+	require.NoError(t, alloc.save(balances, ssc.ID))
+
+	update := func(clientID string, req *updateAllocationRequest) error {
+		tx := transaction.Transaction{
+			ClientID:     clientID,
+			ToClientID:   ADDRESS,
+			CreationDate: now,
+		}
+		tx.Hash = encryption.Hash(clientID + req.OwnerID)
+		balances.setTransaction(t, &tx)
+		_, err := ssc.updateAllocationRequest(&tx, mustEncode(t, req), balances)
+		return err
+	}
+
+	requireErrMsg(t, update(alloc.Owner, &updateAllocationRequest{
+		ID:      allocID,
+		OwnerID: newOwner,
+	}), "allocation_updating_failed: owner public key is required when updating owner id")
+
+	require.NoError(t, update(alloc.Owner, &updateAllocationRequest{
+		ID:             allocID,
+		OwnerID:        newOwner,
+		OwnerPublicKey: "new_owner_pub_key",
+	}))
+	updated, err := ssc.getAllocation(allocID, balances)
+	require.NoError(t, err)
+	assert.Equal(t, newOwner, updated.Owner)
+	assert.Equal(t, "new_owner_pub_key", updated.OwnerPublicKey)
+	assert.Empty(t, updated.PendingOwner)
+	assert.Zero(t, updated.PendingOwnerExpiration)
+}
@@ -0,0 +1,261 @@
+package storagesc
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+//go:generate msgp -io=false -tests=false -unexported=true -v
+
+const (
+	// placementRegionDegrees is the size in degrees of the latitude and
+	// longitude grid cells the blobbers regions are.
+	placementRegionDegrees = 10.0
+	// earthRadiusKm is the mean radius of the earth used for the distance
+	// between blobbers.
+	earthRadiusKm = 6371.0
+	// geoFixedShift is the number of fraction bits of the fixed-point
+	// numbers of the distance check. The float64 trigonometry isn't used
+	// there as its results may differ between architectures.
+	geoFixedShift = 30
+	// geoPi is π in geoFixedShift fixed-point.
+	geoPi = 3373259426
+	// microDegrees is the precision of the geolocations in the distance check.
+	microDegrees = 1e6
+)
+
+// GeoBoundingBox is a latitude and longitude rectangle, the zero value
+// is not a restriction.
+type GeoBoundingBox struct {
+	MinLatitude  float64 `json:"min_latitude"`
+	MaxLatitude  float64 `json:"max_latitude"`
+	MinLongitude float64 `json:"min_longitude"`
+	MaxLongitude float64 `json:"max_longitude"`
+}
+
+func (bb GeoBoundingBox) isSet() bool {
+	return bb != GeoBoundingBox{}
+}
+
+func (bb GeoBoundingBox) validate() error {
+	switch {
+	case bb.MinLatitude < -90 || bb.MaxLatitude > 90 || bb.MinLatitude > bb.MaxLatitude:
+		return errors.New("invalid bounding box latitude range")
+	case bb.MinLongitude < -180 || bb.MaxLongitude > 180 || bb.MinLongitude > bb.MaxLongitude:
+		return errors.New("invalid bounding box longitude range")
+	}
+	return nil
+}
+
+func (bb GeoBoundingBox) contains(g StorageNodeGeolocation) bool {
+	return g.Latitude >= bb.MinLatitude && g.Latitude <= bb.MaxLatitude &&
+		g.Longitude >= bb.MinLongitude && g.Longitude <= bb.MaxLongitude
+}
+
+// PlacementPolicy restricts the geolocation of the blobbers of an
+// allocation, the zero value is not a restriction.
+type PlacementPolicy struct {
+	// MinDistanceKm is the lowest distance between any two blobbers.
+	MinDistanceKm float64 `json:"min_distance_km,omitempty"`
+	// MinRegions is the lowest number of distinct regions of the blobbers,
+	// see placementRegionDegrees.
+	MinRegions int `json:"min_regions,omitempty"`
+	// BoundingBox the blobbers should be inside.
+	BoundingBox GeoBoundingBox `json:"bounding_box,omitempty"`
+}
+
+func (pp *PlacementPolicy) validate(numBlobbers int) error {
+	if pp.MinDistanceKm < 0 {
+		return errors.New("negative min_distance_km")
+	}
+	if pp.MinRegions < 0 || pp.MinRegions > numBlobbers {
+		return fmt.Errorf("min_regions should be in range [0, %d]", numBlobbers)
+	}
+	if pp.BoundingBox.isSet() {
+		return pp.BoundingBox.validate()
+	}
+	return nil
+}
+
+// isInside returns an error if the blobber is outside the bounding box.
+func (pp *PlacementPolicy) isInside(b *StorageNode) error {
+	if pp.BoundingBox.isSet() && !pp.BoundingBox.contains(b.Geolocation) {
+		return fmt.Errorf("blobber %s is outside the allocation bounding box", b.ID)
+	}
+	return nil
+}
+
+// fits returns an error if the blobber is closer than the min distance to
+// any of the blobbers.
+func (pp *PlacementPolicy) fits(blobbers []*StorageNode, b *StorageNode) error {
+	if pp.MinDistanceKm == 0 {
+		return nil
+	}
+	min := geoMinHaversine(pp.MinDistanceKm)
+	for _, o := range blobbers {
+		if geoHaversine(o.Geolocation, b.Geolocation) < min {
+			return fmt.Errorf("blobbers %s and %s are closer than %v km",
+				o.ID, b.ID, pp.MinDistanceKm)
+		}
+	}
+	return nil
+}
+
+// check returns an error if the blobbers of the allocation violate the
+// policy.
+func (pp *PlacementPolicy) check(blobbers []*StorageNode) error {
+	for i, b := range blobbers {
+		if err := pp.isInside(b); err != nil {
+			return err
+		}
+		if err := pp.fits(blobbers[:i], b); err != nil {
+			return err
+		}
+	}
+	if n := countRegions(blobbers); n < pp.MinRegions {
+		return fmt.Errorf("blobbers are in %d distinct regions, wanted at least %d",
+			n, pp.MinRegions)
+	}
+	return nil
+}
+
+// checkAdded returns an error if the blobber added to the blobbers of the
+// allocation violates the policy. Only the added blobber is checked, the
+// allocation ones were checked when they were added.
+func (pp *PlacementPolicy) checkAdded(blobbers []*StorageNode, b *StorageNode) error {
+	if err := pp.isInside(b); err != nil {
+		return err
+	}
+	if err := pp.fits(blobbers, b); err != nil {
+		return err
+	}
+	if pp.MinRegions == 0 {
+		return nil
+	}
+	placed := make([]*StorageNode, 0, len(blobbers)+1)
+	if n := countRegions(append(append(placed, blobbers...), b)); n < pp.MinRegions {
+		return fmt.Errorf("blobbers are in %d distinct regions, wanted at least %d",
+			n, pp.MinRegions)
+	}
+	return nil
+}
+
+// selectBlobbers picks the given number of blobbers satisfying the policy
+// keeping the order of the list. The blobbers of the regions not picked
+// yet come first until the min regions are reached.
+func (pp *PlacementPolicy) selectBlobbers(list []*StorageNode, size int) ([]*StorageNode, error) {
+	var (
+		selected = make([]*StorageNode, 0, size)
+		picked   = make(map[int]bool, size)
+		regions  = make(map[[2]int]bool, size)
+	)
+	pick := func(newRegionOnly bool) {
+		for i, b := range list {
+			if len(selected) == size {
+				return
+			}
+			if newRegionOnly && len(regions) >= pp.MinRegions {
+				return
+			}
+			if picked[i] || (newRegionOnly && regions[geoRegion(b.Geolocation)]) {
+				continue
+			}
+			if pp.isInside(b) != nil || pp.fits(selected, b) != nil {
+				continue
+			}
+			picked[i] = true
+			regions[geoRegion(b.Geolocation)] = true
+			selected = append(selected, b)
+		}
+	}
+	pick(true)
+	pick(false)
+
+	if len(selected) < size {
+		return nil, fmt.Errorf("only %d of %d blobbers fit the placement policy",
+			len(selected), size)
+	}
+	if err := pp.check(selected); err != nil {
+		return nil, err
+	}
+	return selected, nil
+}
+
+// geoRegion returns the grid cell of the geolocation.
+func geoRegion(g StorageNodeGeolocation) [2]int {
+	return [2]int{
+		int(math.Floor(g.Latitude / placementRegionDegrees)),
+		int(math.Floor(g.Longitude / placementRegionDegrees)),
+	}
+}
+
+func countRegions(blobbers []*StorageNode) int {
+	regions := make(map[[2]int]struct{}, len(blobbers))
+	for _, b := range blobbers {
+		regions[geoRegion(b.Geolocation)] = struct{}{}
+	}
+	return len(regions)
+}
+
+// geoHaversine returns the haversine of the central angle between the
+// geolocations in 2*geoFixedShift fixed-point, it grows with the distance.
+func geoHaversine(a, b StorageNodeGeolocation) uint64 {
+	var (
+		latA = geoMicroDegrees(a.Latitude)
+		latB = geoMicroDegrees(b.Latitude)
+		dLat = geoFixedSin(geoRadians(latB-latA) / 2)
+		dLon = geoFixedSin(geoRadians(geoMicroDegrees(b.Longitude)-geoMicroDegrees(a.Longitude)) / 2)
+		cos  = geoFixedSin(geoPi/2-geoRadians(latA)) * geoFixedSin(geoPi/2-geoRadians(latB)) >> geoFixedShift
+	)
+	hi, lo := bits.Mul64(cos, dLon*dLon)
+	return dLat*dLat + (hi<<(64-geoFixedShift) | lo>>geoFixedShift)
+}
+
+// geoMinHaversine returns the haversine of the central angle of the
+// distance, see geoHaversine. A distance of half the circumference or more
+// is above any haversine.
+func geoMinHaversine(km float64) uint64 {
+	if km >= math.Pi*earthRadiusKm {
+		return math.MaxUint64
+	}
+	s := geoFixedSin(int64(km / (2 * earthRadiusKm) * (1 << geoFixedShift)))
+	return s * s
+}
+
+func geoMicroDegrees(deg float64) int64 {
+	return int64(math.Round(deg * microDegrees))
+}
+
+// geoRadians returns the absolute value of the micro-degrees angle in
+// geoFixedShift fixed-point radians.
+func geoRadians(md int64) int64 {
+	if md < 0 {
+		md = -md
+	}
+	return md * geoPi / (180 * microDegrees)
+}
+
+// geoFixedSin returns the sine of the fixed-point angle in [0, π].
+func geoFixedSin(x int64) uint64 {
+	if x > geoPi/2 {
+		x = geoPi - x
+	}
+	var (
+		x2   = (x * x) >> geoFixedShift
+		term = x
+		sum  = x
+	)
+	for n := int64(3); n <= 17; n += 2 {
+		term = ((-term * x2) >> geoFixedShift) / (n * (n - 1))
+		sum += term
+	}
+	switch {
+	case sum < 0:
+		return 0
+	case sum > 1<<geoFixedShift:
+		return 1 << geoFixedShift
+	}
+	return uint64(sum)
+}
@@ -0,0 +1,160 @@
+package storagesc
+
+// Code generated by github.com/tinylib/msgp DO NOT EDIT.
+
+import (
+	"github.com/tinylib/msgp/msgp"
+)
+
+// MarshalMsg implements msgp.Marshaler
+func (z *GeoBoundingBox) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 4
+	// string "MinLatitude"
+	o = append(o, 0x84, 0xab, 0x4d, 0x69, 0x6e, 0x4c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65)
+	o = msgp.AppendFloat64(o, z.MinLatitude)
+	// string "MaxLatitude"
+	o = append(o, 0xab, 0x4d, 0x61, 0x78, 0x4c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65)
+	o = msgp.AppendFloat64(o, z.MaxLatitude)
+	// string "MinLongitude"
+	o = append(o, 0xac, 0x4d, 0x69, 0x6e, 0x4c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65)
+	o = msgp.AppendFloat64(o, z.MinLongitude)
+	// string "MaxLongitude"
+	o = append(o, 0xac, 0x4d, 0x61, 0x78, 0x4c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65)
+	o = msgp.AppendFloat64(o, z.MaxLongitude)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *GeoBoundingBox) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "MinLatitude":
+			z.MinLatitude, bts, err = msgp.ReadFloat64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MinLatitude")
+				return
+			}
+		case "MaxLatitude":
+			z.MaxLatitude, bts, err = msgp.ReadFloat64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MaxLatitude")
+				return
+			}
+		case "MinLongitude":
+			z.MinLongitude, bts, err = msgp.ReadFloat64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MinLongitude")
+				return
+			}
+		case "MaxLongitude":
+			z.MaxLongitude, bts, err = msgp.ReadFloat64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MaxLongitude")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *GeoBoundingBox) Msgsize() (s int) {
+	s = 1 + 12 + msgp.Float64Size + 12 + msgp.Float64Size + 13 + msgp.Float64Size + 13 + msgp.Float64Size
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *PlacementPolicy) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 3
+	// string "MinDistanceKm"
+	o = append(o, 0x83, 0xad, 0x4d, 0x69, 0x6e, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x4b, 0x6d)
+	o = msgp.AppendFloat64(o, z.MinDistanceKm)
+	// string "MinRegions"
+	o = append(o, 0xaa, 0x4d, 0x69, 0x6e, 0x52, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x73)
+	o = msgp.AppendInt(o, z.MinRegions)
+	// string "BoundingBox"
+	o = append(o, 0xab, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x6f, 0x78)
+	o, err = z.BoundingBox.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "BoundingBox")
+		return
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *PlacementPolicy) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "MinDistanceKm":
+			z.MinDistanceKm, bts, err = msgp.ReadFloat64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MinDistanceKm")
+				return
+			}
+		case "MinRegions":
+			z.MinRegions, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MinRegions")
+				return
+			}
+		case "BoundingBox":
+			bts, err = z.BoundingBox.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "BoundingBox")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *PlacementPolicy) Msgsize() (s int) {
+	s = 1 + 14 + msgp.Float64Size + 11 + msgp.IntSize + 12 + z.BoundingBox.Msgsize()
+	return
+}
@@ -0,0 +1,141 @@
+package storagesc
+
+import (
+	"math"
+	"testing"
+
+	"0chain.net/smartcontract/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_geoHaversine(t *testing.T) {
+	var (
+		london  = StorageNodeGeolocation{Latitude: 51.5074, Longitude: -0.1278}
+		paris   = StorageNodeGeolocation{Latitude: 48.8566, Longitude: 2.3522}
+		sydney  = StorageNodeGeolocation{Latitude: -33.8688, Longitude: 151.2093}
+		equator = StorageNodeGeolocation{}
+		east    = StorageNodeGeolocation{Longitude: 1}
+		micro   = StorageNodeGeolocation{Longitude: 1e-6}
+		// the float64 great-circle distance the fixed-point one is close to
+		distanceKm = func(a, b StorageNodeGeolocation) float64 {
+			const rad = math.Pi / 180
+			var (
+				sLat = math.Sin((b.Latitude - a.Latitude) * rad / 2)
+				sLon = math.Sin((b.Longitude - a.Longitude) * rad / 2)
+				h    = sLat*sLat + math.Cos(a.Latitude*rad)*math.Cos(b.Latitude*rad)*sLon*sLon
+			)
+			return 2 * earthRadiusKm * math.Asin(math.Sqrt(h))
+		}
+	)
+
+	assert.Zero(t, geoHaversine(london, london))
+	assert.Equal(t, geoHaversine(london, paris), geoHaversine(paris, london))
+	assert.InDelta(t, 343.5, distanceKm(london, paris), 1)
+
+	// the distance check is within a meter of the float64 one
+	for _, pair := range [][2]StorageNodeGeolocation{
+		{london, paris}, {london, sydney}, {equator, east}, {paris, sydney},
+	} {
+		var (
+			h = geoHaversine(pair[0], pair[1])
+			d = distanceKm(pair[0], pair[1])
+		)
+		assert.GreaterOrEqual(t, h, geoMinHaversine(d-0.001), pair)
+		assert.Less(t, h, geoMinHaversine(d+0.001), pair)
+	}
+
+	// one degree of the equator is 111.19493 km
+	pp := &PlacementPolicy{MinDistanceKm: 111.1949}
+	a := &StorageNode{Provider: provider.Provider{ID: "a"}, Geolocation: equator}
+	b := &StorageNode{Provider: provider.Provider{ID: "b"}, Geolocation: east}
+	require.NoError(t, pp.fits([]*StorageNode{a}, b))
+	pp.MinDistanceKm = 111.1950
+	requireErrMsg(t, pp.fits([]*StorageNode{a}, b),
+		"blobbers a and b are closer than 111.195 km")
+
+	// a micro-degree of the equator is 11 cm
+	assert.Less(t, geoHaversine(equator, micro), geoMinHaversine(0.00012))
+	assert.GreaterOrEqual(t, geoHaversine(equator, micro), geoMinHaversine(0.0001))
+
+	// half the circumference is the farthest
+	assert.Less(t, geoHaversine(equator, StorageNodeGeolocation{Longitude: 180}),
+		geoMinHaversine(math.Pi*earthRadiusKm))
+}
+
+func TestPlacementPolicy_validate(t *testing.T) {
+	require.NoError(t, (&PlacementPolicy{}).validate(4))
+	require.NoError(t, (&PlacementPolicy{MinDistanceKm: 100, MinRegions: 4}).validate(4))
+
+	requireErrMsg(t, (&PlacementPolicy{MinDistanceKm: -1}).validate(4),
+		"negative min_distance_km")
+	requireErrMsg(t, (&PlacementPolicy{MinRegions: 5}).validate(4),
+		"min_regions should be in range [0, 4]")
+	requireErrMsg(t, (&PlacementPolicy{BoundingBox: GeoBoundingBox{
+		MinLatitude: 10, MaxLatitude: -10, MaxLongitude: 10}}).validate(4),
+		"invalid bounding box latitude range")
+	requireErrMsg(t, (&PlacementPolicy{BoundingBox: GeoBoundingBox{
+		MaxLatitude: 10, MinLongitude: -200}}).validate(4),
+		"invalid bounding box longitude range")
+}
+
+func TestPlacementPolicy_selectBlobbers(t *testing.T) {
+	blobber := func(id string, lat, long float64) *StorageNode {
+		return &StorageNode{
+			Provider:    provider.Provider{ID: id},
+			Geolocation: StorageNodeGeolocation{Latitude: lat, Longitude: long},
+		}
+	}
+	var (
+		london = blobber("london", 51.5074, -0.1278)
+		nearby = blobber("nearby", 51.52, -0.1)
+		paris  = blobber("paris", 48.8566, 2.3522)
+		nyc    = blobber("nyc", 40.7128, -74.006)
+		tokyo  = blobber("tokyo", 35.6762, 139.6503)
+		list   = []*StorageNode{london, nearby, paris, nyc, tokyo}
+		ids    = func(bs []*StorageNode) (ids []string) {
+			for _, b := range bs {
+				ids = append(ids, b.ID)
+			}
+			return
+		}
+	)
+
+	selected, err := (&PlacementPolicy{}).selectBlobbers(list, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"london", "nearby"}, ids(selected))
+
+	selected, err = (&PlacementPolicy{MinDistanceKm: 100}).selectBlobbers(list, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"london", "paris", "nyc"}, ids(selected))
+
+	// nearby is in the region of london
+	selected, err = (&PlacementPolicy{MinRegions: 3}).selectBlobbers(list, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"london", "paris", "nyc"}, ids(selected))
+
+	europe := GeoBoundingBox{MinLatitude: 35, MaxLatitude: 70, MinLongitude: -10, MaxLongitude: 40}
+	selected, err = (&PlacementPolicy{BoundingBox: europe}).selectBlobbers(list, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"london", "nearby", "paris"}, ids(selected))
+
+	_, err = (&PlacementPolicy{BoundingBox: europe, MinDistanceKm: 100}).selectBlobbers(list, 3)
+	requireErrMsg(t, err, "only 2 of 3 blobbers fit the placement policy")
+
+	uk := GeoBoundingBox{MinLatitude: 50, MaxLatitude: 60, MinLongitude: -8, MaxLongitude: 2}
+	_, err = (&PlacementPolicy{BoundingBox: uk, MinRegions: 2}).selectBlobbers(list, 2)
+	requireErrMsg(t, err, "blobbers are in 1 distinct regions, wanted at least 2")
+
+	// adding a blobber to the allocation
+	pp := &PlacementPolicy{MinDistanceKm: 100}
+	require.NoError(t, pp.check([]*StorageNode{london, paris, tokyo}))
+	requireErrMsg(t, pp.check([]*StorageNode{london, paris, nearby}),
+		"blobbers london and nearby are closer than 100 km")
+	require.NoError(t, pp.checkAdded([]*StorageNode{london, paris}, tokyo))
+	requireErrMsg(t, pp.checkAdded([]*StorageNode{london, paris}, nearby),
+		"blobbers london and nearby are closer than 100 km")
+	// only the added blobber is checked
+	require.NoError(t, pp.checkAdded([]*StorageNode{london, nearby}, tokyo))
+	requireErrMsg(t, (&PlacementPolicy{MinRegions: 3}).checkAdded([]*StorageNode{london}, nearby),
+		"blobbers are in 1 distinct regions, wanted at least 3")
+}
@@ -0,0 +1,347 @@
+package storagesc
+
+import (
+	"fmt"
+	"sort"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/datastore"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/stakepool"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/util"
+)
+
+//msgp:ignore allocationReadPoolStat
+//go:generate msgp -io=false -tests=false -unexported=true -v
+
+//
+// allocation read pool (sponsored reads)
+//
+
+func allocationReadPoolKey(scKey, allocID string) datastore.Key {
+	return scKey + ":allocreadpool:" + allocID
+}
+
+// allocationReaderSpentKey is the key of the tokens of the allocation read
+// pool spent for the reader.
+func allocationReaderSpentKey(scKey, allocID, readerID string) datastore.Key {
+	return allocationReadPoolKey(scKey, allocID) + ":spent:" + readerID
+}
+
+// allocationReadPool is a read pool of an allocation funded by its owner
+// or any other sponsor. It pays for the downloads of the allocation readers
+// having not enough tokens in their own read pools.
+// swagger:model allocationReadPool
+type allocationReadPool struct {
+	AllocationID string `json:"allocation_id"`
+	// Sponsors are the tokens left of each sponsor.
+	Sponsors map[string]currency.Coin `json:"sponsors"`
+	// ReaderLimit caps the tokens spent for a reader, zero means no limit.
+	// Only the allocation owner can set it.
+	ReaderLimit currency.Coin `json:"reader_limit"`
+	TotalSpent  currency.Coin `json:"total_spent"`
+}
+
+func newAllocationReadPool(allocID string) *allocationReadPool {
+	return &allocationReadPool{
+		AllocationID: allocID,
+		Sponsors:     make(map[string]currency.Coin),
+	}
+}
+
+// allocationReaderSpent are the tokens of an allocation read pool spent for
+// a reader. They are kept apart from the pool, so the pool doesn't grow with
+// the number of the readers.
+type allocationReaderSpent struct {
+	AllocationID string        `json:"allocation_id"`
+	ReaderID     string        `json:"reader_id"`
+	Spent        currency.Coin `json:"spent"`
+}
+
+func (rs *allocationReaderSpent) save(sscKey string, balances cstate.StateContextI) (err error) {
+	_, err = balances.InsertTrieNode(allocationReaderSpentKey(sscKey, rs.AllocationID, rs.ReaderID), rs)
+	return
+}
+
+// getAllocationReaderSpent returns the tokens of the allocation read pool
+// spent for the reader, nothing spent if the reader has no reads paid yet.
+func (ssc *StorageSmartContract) getAllocationReaderSpent(allocID, readerID string,
+	balances cstate.CommonStateContextI) (*allocationReaderSpent, error) {
+
+	rs := &allocationReaderSpent{AllocationID: allocID, ReaderID: readerID}
+	err := balances.GetTrieNode(allocationReaderSpentKey(ssc.ID, allocID, readerID), rs)
+	if err != nil && err != util.ErrValueNotPresent {
+		return nil, err
+	}
+	return rs, nil
+}
+
+func (arp *allocationReadPool) balance() (sum currency.Coin, err error) {
+	for _, b := range arp.Sponsors {
+		if sum, err = currency.AddCoin(sum, b); err != nil {
+			return 0, err
+		}
+	}
+	return sum, nil
+}
+
+func (arp *allocationReadPool) add(sponsorID string, coin currency.Coin) error {
+	sum, err := currency.AddCoin(arp.Sponsors[sponsorID], coin)
+	if err != nil {
+		return err
+	}
+	arp.Sponsors[sponsorID] = sum
+	return nil
+}
+
+// canSpend checks the pool can pay the value for the reader who has spent
+// the given tokens of the pool already.
+func (arp *allocationReadPool) canSpend(spent, value currency.Coin) (bool, error) {
+	if arp.ReaderLimit > 0 {
+		spent, err := currency.AddCoin(spent, value)
+		if err != nil {
+			return false, err
+		}
+		if spent > arp.ReaderLimit {
+			return false, nil
+		}
+	}
+	balance, err := arp.balance()
+	if err != nil {
+		return false, err
+	}
+	return value <= balance, nil
+}
+
+// spend takes the value for the reader from the sponsors in order of their
+// IDs.
+func (arp *allocationReadPool) spend(rs *allocationReaderSpent, value currency.Coin) error {
+	ok, err := arp.canSpend(rs.Spent, value)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("not enough tokens in sponsored read pool of "+
+			"allocation %s for reader %s", arp.AllocationID, rs.ReaderID)
+	}
+
+	ids := make([]string, 0, len(arp.Sponsors))
+	for id := range arp.Sponsors {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	left := value
+	for _, id := range ids {
+		if left == 0 {
+			break
+		}
+		take := arp.Sponsors[id]
+		if take > left {
+			take = left
+		}
+		arp.Sponsors[id] -= take
+		if arp.Sponsors[id] == 0 {
+			delete(arp.Sponsors, id)
+		}
+		left -= take
+	}
+
+	if rs.Spent, err = currency.AddCoin(rs.Spent, value); err != nil {
+		return err
+	}
+	if arp.TotalSpent, err = currency.AddCoin(arp.TotalSpent, value); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (arp *allocationReadPool) moveToBlobber(rs *allocationReaderSpent, blobID string,
+	sp *stakePool, value currency.Coin, balances cstate.StateContextI) (resp string, err error) {
+
+	if err = arp.spend(rs, value); err != nil {
+		return "", err
+	}
+
+	err = sp.DistributeRewards(value, blobID, spenum.Blobber, spenum.FileDownloadReward, balances)
+	if err != nil {
+		return "", fmt.Errorf("can't move tokens to blobber: %v", err)
+	}
+
+	return toJson([]readPoolRedeem{{PoolID: blobID, Balance: value}}), nil
+}
+
+func (arp *allocationReadPool) save(sscKey string, balances cstate.StateContextI) (err error) {
+	_, err = balances.InsertTrieNode(allocationReadPoolKey(sscKey, arp.AllocationID), arp)
+	return
+}
+
+func (ssc *StorageSmartContract) getAllocationReadPool(allocID datastore.Key,
+	balances cstate.CommonStateContextI) (arp *allocationReadPool, err error) {
+
+	arp = newAllocationReadPool(allocID)
+	err = balances.GetTrieNode(allocationReadPoolKey(ssc.ID, allocID), arp)
+	return
+}
+
+// sponsoredReadPool returns the allocation read pool paying the value for
+// the payer, and the tokens of it spent for the payer, if the payer's own
+// read pool rp can't pay it, or nil.
+func (ssc *StorageSmartContract) sponsoredReadPool(allocID, payerID string,
+	rp *readPool, value currency.Coin, balances cstate.StateContextI) (*allocationReadPool, *allocationReaderSpent, error) {
+
+	if value == 0 || rp.Balance >= value {
+		return nil, nil, nil
+	}
+	arp, err := ssc.getAllocationReadPool(allocID, balances)
+	switch err {
+	case nil:
+	case util.ErrValueNotPresent:
+		return nil, nil, nil
+	default:
+		return nil, nil, err
+	}
+	rs, err := ssc.getAllocationReaderSpent(allocID, payerID, balances)
+	if err != nil {
+		return nil, nil, err
+	}
+	ok, err := arp.canSpend(rs.Spent, value)
+	if err != nil || !ok {
+		return nil, nil, err
+	}
+	return arp, rs, nil
+}
+
+//
+// smart contract methods
+//
+
+// allocationReadPoolLock locks tokens to the allocation read pool, the
+// allocation owner can also set the per-reader spending limit.
+func (ssc *StorageSmartContract) allocationReadPoolLock(txn *transaction.Transaction,
+	req *readPoolLockRequest, conf *readPoolConfig, balances cstate.StateContextI) (string, error) {
+
+	alloc, err := ssc.getAllocation(req.AllocationID, balances)
+	if err != nil {
+		return "", common.NewErrorf("read_pool_lock_failed",
+			"can't get allocation: %v", err)
+	}
+	if alloc.Finalized || alloc.Canceled {
+		return "", common.NewError("read_pool_lock_failed",
+			"allocation is finalized or canceled")
+	}
+
+	if req.ReaderLimit != nil && txn.ClientID != alloc.Owner {
+		return "", common.NewError("read_pool_lock_failed",
+			"only the allocation owner can set the reader limit")
+	}
+
+	// the owner can set the reader limit without locking tokens
+	if (txn.Value > 0 || req.ReaderLimit == nil) && (txn.Value < conf.MinLock || txn.Value <= 0) {
+		return "", common.NewError("read_pool_lock_failed",
+			"insufficient amount to lock")
+	}
+
+	arp, err := ssc.getAllocationReadPool(alloc.ID, balances)
+	if err != nil && err != util.ErrValueNotPresent {
+		return "", common.NewError("read_pool_lock_failed", err.Error())
+	}
+	if err == util.ErrValueNotPresent {
+		arp = newAllocationReadPool(alloc.ID)
+	}
+
+	if req.ReaderLimit != nil {
+		arp.ReaderLimit = *req.ReaderLimit
+	}
+
+	if txn.Value > 0 {
+		if err := stakepool.CheckClientBalance(txn.ClientID, txn.Value, balances); err != nil {
+			return "", common.NewError("read_pool_lock_failed", err.Error())
+		}
+		transfer := state.NewTransfer(txn.ClientID, txn.ToClientID, txn.Value)
+		if err := balances.AddTransfer(transfer); err != nil {
+			return "", common.NewError("read_pool_lock_failed", err.Error())
+		}
+		if err := arp.add(txn.ClientID, txn.Value); err != nil {
+			return "", common.NewError("read_pool_lock_failed", err.Error())
+		}
+	}
+
+	if err := arp.save(ssc.ID, balances); err != nil {
+		return "", common.NewError("read_pool_lock_failed", err.Error())
+	}
+
+	i, _ := txn.Value.Int64()
+	balances.EmitEvent(event.TypeStats, event.TagLockReadPool, txn.ClientID, event.ReadPoolLock{
+		Client: txn.ClientID,
+		PoolId: allocationReadPoolKey(ssc.ID, alloc.ID),
+		Amount: i,
+	})
+
+	return "", nil
+}
+
+// allocationReadPoolUnlock returns the tokens left of the sponsor.
+func (ssc *StorageSmartContract) allocationReadPoolUnlock(txn *transaction.Transaction,
+	allocID string, balances cstate.StateContextI) (string, error) {
+
+	arp, err := ssc.getAllocationReadPool(allocID, balances)
+	if err != nil {
+		return "", common.NewErrorf("read_pool_unlock_failed",
+			"no allocation read pool found to unlock token: %v", err)
+	}
+
+	balance, ok := arp.Sponsors[txn.ClientID]
+	if !ok {
+		return "", common.NewError("read_pool_unlock_failed",
+			"no tokens of the client in the allocation read pool")
+	}
+	delete(arp.Sponsors, txn.ClientID)
+
+	transfer := state.NewTransfer(ssc.ID, txn.ClientID, balance)
+	if err = balances.AddTransfer(transfer); err != nil {
+		return "", common.NewError("read_pool_unlock_failed", err.Error())
+	}
+
+	if err = arp.save(ssc.ID, balances); err != nil {
+		return "", common.NewError("read_pool_unlock_failed", err.Error())
+	}
+
+	i, _ := balance.Int64()
+	key := allocationReadPoolKey(ssc.ID, allocID)
+	balances.EmitEvent(event.TypeStats, event.TagUnlockReadPool, key, event.ReadPoolLock{
+		Client: txn.ClientID,
+		PoolId: key,
+		Amount: i,
+	})
+
+	return "", nil
+}
+
+// allocationReadPoolStat is the sponsored usage of an allocation read pool.
+// swagger:model allocationReadPoolStat
+type allocationReadPoolStat struct {
+	*allocationReadPool
+	Balance currency.Coin `json:"balance"`
+	// ReaderSpent are the tokens spent for the reader, if requested.
+	ReaderSpent *currency.Coin `json:"reader_spent,omitempty"`
+}
+
+// newAllocationReadPoolStat returns the pool statistic with the spending
+// for the reader, if given.
+func newAllocationReadPoolStat(arp *allocationReadPool, rs *allocationReaderSpent) (*allocationReadPoolStat, error) {
+	balance, err := arp.balance()
+	if err != nil {
+		return nil, err
+	}
+	stat := &allocationReadPoolStat{allocationReadPool: arp, Balance: balance}
+	if rs != nil {
+		stat.ReaderSpent = &rs.Spent
+	}
+	return stat, nil
+}
@@ -0,0 +1,214 @@
+package storagesc
+
+// Code generated by github.com/tinylib/msgp DO NOT EDIT.
+
+import (
+	"github.com/0chain/common/core/currency"
+	"github.com/tinylib/msgp/msgp"
+)
+
+// MarshalMsg implements msgp.Marshaler
+func (z *allocationReadPool) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 4
+	// string "AllocationID"
+	o = append(o, 0x84, 0xac, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44)
+	o = msgp.AppendString(o, z.AllocationID)
+	// string "Sponsors"
+	o = append(o, 0xa8, 0x53, 0x70, 0x6f, 0x6e, 0x73, 0x6f, 0x72, 0x73)
+	o = msgp.AppendMapHeader(o, uint32(len(z.Sponsors)))
+	keys_za0001 := make([]string, 0, len(z.Sponsors))
+	for k := range z.Sponsors {
+		keys_za0001 = append(keys_za0001, k)
+	}
+	msgp.Sort(keys_za0001)
+	for _, k := range keys_za0001 {
+		za0002 := z.Sponsors[k]
+		o = msgp.AppendString(o, k)
+		o, err = za0002.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "Sponsors", k)
+			return
+		}
+	}
+	// string "ReaderLimit"
+	o = append(o, 0xab, 0x52, 0x65, 0x61, 0x64, 0x65, 0x72, 0x4c, 0x69, 0x6d, 0x69, 0x74)
+	o, err = z.ReaderLimit.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "ReaderLimit")
+		return
+	}
+	// string "TotalSpent"
+	o = append(o, 0xaa, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x70, 0x65, 0x6e, 0x74)
+	o, err = z.TotalSpent.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "TotalSpent")
+		return
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *allocationReadPool) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "AllocationID":
+			z.AllocationID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "AllocationID")
+				return
+			}
+		case "Sponsors":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Sponsors")
+				return
+			}
+			if z.Sponsors == nil {
+				z.Sponsors = make(map[string]currency.Coin, zb0002)
+			} else if len(z.Sponsors) > 0 {
+				for key := range z.Sponsors {
+					delete(z.Sponsors, key)
+				}
+			}
+			for zb0002 > 0 {
+				var za0001 string
+				var za0002 currency.Coin
+				zb0002--
+				za0001, bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Sponsors")
+					return
+				}
+				bts, err = za0002.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Sponsors", za0001)
+					return
+				}
+				z.Sponsors[za0001] = za0002
+			}
+		case "ReaderLimit":
+			bts, err = z.ReaderLimit.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ReaderLimit")
+				return
+			}
+		case "TotalSpent":
+			bts, err = z.TotalSpent.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "TotalSpent")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *allocationReadPool) Msgsize() (s int) {
+	s = 1 + 13 + msgp.StringPrefixSize + len(z.AllocationID) + 9 + msgp.MapHeaderSize
+	if z.Sponsors != nil {
+		for za0001, za0002 := range z.Sponsors {
+			_ = za0002
+			s += msgp.StringPrefixSize + len(za0001) + za0002.Msgsize()
+		}
+	}
+	s += 12 + z.ReaderLimit.Msgsize() + 11 + z.TotalSpent.Msgsize()
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *allocationReaderSpent) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 3
+	// string "AllocationID"
+	o = append(o, 0x83, 0xac, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44)
+	o = msgp.AppendString(o, z.AllocationID)
+	// string "ReaderID"
+	o = append(o, 0xa8, 0x52, 0x65, 0x61, 0x64, 0x65, 0x72, 0x49, 0x44)
+	o = msgp.AppendString(o, z.ReaderID)
+	// string "Spent"
+	o = append(o, 0xa5, 0x53, 0x70, 0x65, 0x6e, 0x74)
+	o, err = z.Spent.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Spent")
+		return
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *allocationReaderSpent) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "AllocationID":
+			z.AllocationID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "AllocationID")
+				return
+			}
+		case "ReaderID":
+			z.ReaderID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ReaderID")
+				return
+			}
+		case "Spent":
+			bts, err = z.Spent.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Spent")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *allocationReaderSpent) Msgsize() (s int) {
+	s = 1 + 13 + msgp.StringPrefixSize + len(z.AllocationID) + 9 + msgp.StringPrefixSize + len(z.ReaderID) + 6 + z.Spent.Msgsize()
+	return
+}
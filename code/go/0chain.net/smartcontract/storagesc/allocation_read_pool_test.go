@@ -0,0 +1,112 @@
+package storagesc
+
+import (
+	"testing"
+
+	"0chain.net/chaincore/transaction"
+	"github.com/0chain/common/core/currency"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_allocationReadPool_spend(t *testing.T) {
+	arp := newAllocationReadPool("alloc")
+	require.NoError(t, arp.add("sponsor_b", 30))
+	require.NoError(t, arp.add("sponsor_a", 20))
+	arp.ReaderLimit = 40
+
+	var (
+		reader = &allocationReaderSpent{AllocationID: "alloc", ReaderID: "reader"}
+		other  = &allocationReaderSpent{AllocationID: "alloc", ReaderID: "other"}
+	)
+
+	// the sponsors are charged in order of their IDs
+	require.NoError(t, arp.spend(reader, 25))
+	assert.EqualValues(t, map[string]currency.Coin{"sponsor_b": 25}, arp.Sponsors)
+	assert.EqualValues(t, 25, reader.Spent)
+
+	// over the reader limit
+	ok, err := arp.canSpend(reader.Spent, 20)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	require.Error(t, arp.spend(reader, 20))
+
+	// other reader, over the balance
+	ok, err = arp.canSpend(other.Spent, 26)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, arp.spend(other, 25))
+	assert.Empty(t, arp.Sponsors)
+	assert.EqualValues(t, 25, reader.Spent)
+	assert.EqualValues(t, 25, other.Spent)
+	assert.EqualValues(t, 50, arp.TotalSpent)
+}
+
+func TestStorageSmartContract_allocationReadPoolLock(t *testing.T) {
+	var (
+		ssc      = newTestStorageSC()
+		balances = newTestBalances(t, false)
+		owner    = "owner"
+		sponsor  = "sponsor"
+		alloc    = &StorageAllocation{ID: "alloc", Owner: owner}
+		limit    = currency.Coin(10)
+	)
+	_, err := balances.InsertTrieNode(alloc.GetKey(ssc.ID), alloc)
+	require.NoError(t, err)
+	testSetReadPoolConfig(t, &readPoolConfig{MinLock: 10}, balances, ssc.ID)
+
+	lock := func(clientID string, value currency.Coin, req *readPoolLockRequest) error {
+		tx := transaction.Transaction{ClientID: clientID, ToClientID: ssc.ID, Value: value}
+		balances.setTransaction(t, &tx)
+		_, err := ssc.readPoolLock(&tx, mustEncode(t, req), balances)
+		return err
+	}
+
+	balances.balances[sponsor] = 100
+	require.NoError(t, lock(sponsor, 50, &readPoolLockRequest{AllocationID: alloc.ID}))
+	requireErrMsg(t, lock(sponsor, 5, &readPoolLockRequest{AllocationID: alloc.ID}),
+		"read_pool_lock_failed: insufficient amount to lock")
+
+	// only the owner sets the reader limit, with no tokens locked
+	requireErrMsg(t, lock(sponsor, 0, &readPoolLockRequest{AllocationID: alloc.ID, ReaderLimit: &limit}),
+		"read_pool_lock_failed: only the allocation owner can set the reader limit")
+	require.NoError(t, lock(owner, 0, &readPoolLockRequest{AllocationID: alloc.ID, ReaderLimit: &limit}))
+
+	arp, err := ssc.getAllocationReadPool(alloc.ID, balances)
+	require.NoError(t, err)
+	assert.EqualValues(t, map[string]currency.Coin{sponsor: 50}, arp.Sponsors)
+	assert.EqualValues(t, limit, arp.ReaderLimit)
+
+	// the reader's own read pool pays first
+	rp := &readPool{Balance: 5}
+	sponsored, _, err := ssc.sponsoredReadPool(alloc.ID, "reader", rp, 5, balances)
+	require.NoError(t, err)
+	assert.Nil(t, sponsored)
+	sponsored, rs, err := ssc.sponsoredReadPool(alloc.ID, "reader", rp, 10, balances)
+	require.NoError(t, err)
+	assert.NotNil(t, sponsored)
+	assert.Equal(t, "reader", rs.ReaderID)
+	sponsored, _, err = ssc.sponsoredReadPool(alloc.ID, "reader", rp, 11, balances)
+	require.NoError(t, err)
+	assert.Nil(t, sponsored)
+
+	// the spending of the reader is kept apart from the pool
+	rs.Spent = limit
+	require.NoError(t, rs.save(ssc.ID, balances))
+	sponsored, _, err = ssc.sponsoredReadPool(alloc.ID, "reader", rp, 10, balances)
+	require.NoError(t, err)
+	assert.Nil(t, sponsored)
+
+	// the sponsor unlocks the tokens left
+	tx := transaction.Transaction{ClientID: sponsor, ToClientID: ssc.ID}
+	balances.setTransaction(t, &tx)
+	_, err = ssc.readPoolUnlock(&tx, mustEncode(t, &readPoolUnlockRequest{AllocationID: alloc.ID}), balances)
+	require.NoError(t, err)
+	arp, err = ssc.getAllocationReadPool(alloc.ID, balances)
+	require.NoError(t, err)
+	assert.Empty(t, arp.Sponsors)
+
+	_, err = ssc.readPoolUnlock(&tx, mustEncode(t, &readPoolUnlockRequest{AllocationID: alloc.ID}), balances)
+	require.Error(t, err)
+}
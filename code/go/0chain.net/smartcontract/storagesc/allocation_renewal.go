@@ -0,0 +1,293 @@
+package storagesc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/encryption"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/partitions"
+	"github.com/0chain/common/core/currency"
+	"github.com/0chain/common/core/logging"
+	"github.com/0chain/common/core/util"
+	"go.uber.org/zap"
+)
+
+//go:generate msgp -io=false -tests=false -unexported=true -v
+
+const autoRenewAllocationsPartitionSize = 50
+
+var (
+	ALL_AUTO_RENEW_ALLOCATIONS_KEY    = ADDRESS + encryption.Hash("all_auto_renew_allocations")
+	AUTO_RENEW_ALLOCATIONS_CURSOR_KEY = ADDRESS + encryption.Hash("auto_renew_allocations_cursor")
+)
+
+// AutoRenewAllocation is an allocation opted in the auto-renewal, it will be
+// saved in the auto-renew allocations partitions.
+type AutoRenewAllocation struct {
+	ID string `json:"id"`
+}
+
+func (ar *AutoRenewAllocation) GetID() string {
+	return ar.ID
+}
+
+// AutoRenewAllocationsCursor is the number of the auto-renew allocations
+// partitions left to check in the current pass, the renew_allocations
+// transaction checks them one per call from the last to the first one.
+type AutoRenewAllocationsCursor struct {
+	PartsLeft int `json:"parts_left"`
+}
+
+func partitionsAutoRenewAllocations(balances cstate.StateContextI) (*partitions.Partitions, error) {
+	return partitions.CreateIfNotExists(balances, ALL_AUTO_RENEW_ALLOCATIONS_KEY, autoRenewAllocationsPartitionSize)
+}
+
+// partitionsAutoRenewAllocationsSet adds the allocation to or removes it from
+// the auto-renew allocations partitions.
+func partitionsAutoRenewAllocationsSet(balances cstate.StateContextI, allocID string, autoRenew bool) error {
+	parts, err := partitionsAutoRenewAllocations(balances)
+	if err != nil {
+		return fmt.Errorf("could not get auto-renew allocations partitions: %v", err)
+	}
+
+	if autoRenew {
+		err = parts.Add(balances, &AutoRenewAllocation{ID: allocID})
+		if err != nil && !partitions.ErrItemExist(err) {
+			return err
+		}
+	} else {
+		err = parts.Remove(balances, allocID)
+		if err != nil && !partitions.ErrItemNotFound(err) {
+			return err
+		}
+	}
+
+	return parts.Save(balances)
+}
+
+func init() {
+	regInitPartsFunc(func(state cstate.StateContextI) error {
+		_, err := partitions.CreateIfNotExists(state, ALL_AUTO_RENEW_ALLOCATIONS_KEY, autoRenewAllocationsPartitionSize)
+		return err
+	})
+}
+
+type renewAllocationsInput struct {
+	Round int64 `json:"round,omitempty"`
+}
+
+// renewAllocations is the scheduled system transaction extending the
+// auto-renew allocations of the next partition expiring within the renewal
+// window by the renewal period. The auto-renewal of an allocation stops
+// with a warning event once its renewal budget or write pool can't cover
+// the next period, or it can't be extended.
+func (sc *StorageSmartContract) renewAllocations(
+	txn *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	var in renewAllocationsInput
+	if err := json.Unmarshal(input, &in); err != nil {
+		return "", common.NewError("renew_allocations_failed", err.Error())
+	}
+	if b := balances.GetBlock(); in.Round != b.Round {
+		return "", common.NewErrorf("renew_allocations_failed",
+			"bad round, block %v but input %v", b.Round, in.Round)
+	}
+
+	conf, err := sc.getConfig(balances, true)
+	if err != nil {
+		return "", common.NewError("renew_allocations_failed",
+			"can't get SC configurations: "+err.Error())
+	}
+
+	if conf.AutoRenewal.Period == 0 {
+		return "auto-renewal is disabled", nil
+	}
+
+	parts, err := partitionsAutoRenewAllocations(balances)
+	if err != nil {
+		return "", common.NewError("renew_allocations_failed", err.Error())
+	}
+
+	var cursor AutoRenewAllocationsCursor
+	err = balances.GetTrieNode(AUTO_RENEW_ALLOCATIONS_CURSOR_KEY, &cursor)
+	if err != nil && err != util.ErrValueNotPresent {
+		return "", common.NewError("renew_allocations_failed",
+			"can't get cursor: "+err.Error())
+	}
+
+	// the next pass starts from the last partition, the items moved on
+	// removal from the tail partitions are checked again, which is a no-op
+	// as they are extended out of the window already
+	if cursor.PartsLeft == 0 || cursor.PartsLeft > parts.NumPartitions {
+		cursor.PartsLeft = parts.NumPartitions
+	}
+	if cursor.PartsLeft == 0 {
+		return "0 allocations renewed", nil
+	}
+
+	ids, err := parts.PartitionIDs(balances, cursor.PartsLeft-1)
+	if err != nil {
+		return "", common.NewError("renew_allocations_failed", err.Error())
+	}
+	cursor.PartsLeft--
+
+	var (
+		window  = common.ToSeconds(conf.AutoRenewal.Window)
+		renewed int
+	)
+	for _, id := range ids {
+		alloc, err := sc.getAllocation(id, balances)
+		if err != nil {
+			return "", common.NewErrorf("renew_allocations_failed",
+				"can't get allocation %s: %v", id, err)
+		}
+
+		if !alloc.AutoRenew || alloc.Finalized || alloc.Canceled ||
+			alloc.Expiration < txn.CreationDate {
+			if err := parts.Remove(balances, id); err != nil {
+				return "", common.NewError("renew_allocations_failed", err.Error())
+			}
+			continue
+		}
+		if alloc.Expiration-txn.CreationDate > window {
+			continue // not near the expiration yet
+		}
+
+		ok, err := sc.renewAllocation(txn, conf, alloc, balances)
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			if err := parts.Remove(balances, id); err != nil {
+				return "", common.NewError("renew_allocations_failed", err.Error())
+			}
+			continue
+		}
+		renewed++
+	}
+
+	if err := parts.Save(balances); err != nil {
+		return "", common.NewError("renew_allocations_failed", err.Error())
+	}
+	if _, err := balances.InsertTrieNode(AUTO_RENEW_ALLOCATIONS_CURSOR_KEY, &cursor); err != nil {
+		return "", common.NewError("renew_allocations_failed",
+			"saving cursor: "+err.Error())
+	}
+
+	return fmt.Sprintf("%d allocations renewed", renewed), nil
+}
+
+// renewAllocation extends the allocation by the renewal period paying from
+// its write pool, or stops its auto-renewal if the renewal budget or the
+// write pool can't cover the period, or the allocation can't be extended.
+// It returns false if stopped.
+func (sc *StorageSmartContract) renewAllocation(
+	txn *transaction.Transaction,
+	conf *Config,
+	alloc *StorageAllocation,
+	balances cstate.StateContextI,
+) (bool, error) {
+	blobbers, err := sc.getAllocationBlobbers(alloc, balances)
+	if err != nil {
+		return false, common.NewError("renew_allocations_failed", err.Error())
+	}
+
+	for _, b := range blobbers {
+		if b.Capacity == 0 {
+			return false, sc.stopAutoRenewal(alloc.ID,
+				fmt.Sprintf("blobber %s no longer provides its service", b.ID), 0, balances)
+		}
+	}
+
+	// the cost of the renewal is what the extension moves from the write
+	// pool to the challenge pool, the write pool is limited to the renewal
+	// budget for the extension to fail if it costs more
+	var (
+		writePool = alloc.WritePool
+		limit     = alloc.WritePool
+	)
+	if alloc.RenewalBudget < limit {
+		limit = alloc.RenewalBudget
+	}
+	alloc.WritePool = limit
+
+	update := updateAllocationRequest{
+		ID:         alloc.ID,
+		Expiration: common.ToSeconds(conf.AutoRenewal.Period),
+	}
+	if err := sc.extendAllocation(txn, conf, alloc, blobbers, &update, balances); err != nil {
+		if cstate.ErrInvalidState(err) {
+			return false, err
+		}
+		var (
+			short  *writePoolShortError
+			reason = "can't extend the allocation: " + err.Error()
+			cost   currency.Coin
+		)
+		if errors.As(err, &short) {
+			cost = short.Cost
+			reason = "write pool can't cover the next period"
+			if limit < writePool {
+				reason = "renewal budget can't cover the next period"
+			}
+		}
+		return false, sc.stopAutoRenewal(alloc.ID, reason, cost, balances)
+	}
+
+	cost, err := currency.MinusCoin(limit, alloc.WritePool)
+	if err != nil {
+		return false, common.NewError("renew_allocations_failed", err.Error())
+	}
+	if alloc.WritePool, err = currency.MinusCoin(writePool, cost); err != nil {
+		return false, common.NewError("renew_allocations_failed", err.Error())
+	}
+	if alloc.RenewalBudget, err = currency.MinusCoin(alloc.RenewalBudget, cost); err != nil {
+		return false, common.NewError("renew_allocations_failed", err.Error())
+	}
+
+	if err := alloc.saveUpdatedAllocation(blobbers, balances); err != nil {
+		return false, common.NewError("renew_allocations_failed", err.Error())
+	}
+	emitAddOrOverwriteAllocationBlobberTerms(alloc, balances, txn)
+
+	return true, nil
+}
+
+// stopAutoRenewal turns off the auto-renewal of the allocation with a
+// warning event, the cost is the one of the next period if known. The
+// allocation is reloaded, dropping the changes of a failed extension.
+func (sc *StorageSmartContract) stopAutoRenewal(
+	allocID, reason string,
+	cost currency.Coin,
+	balances cstate.StateContextI,
+) error {
+	alloc, err := sc.getAllocation(allocID, balances)
+	if err != nil {
+		return common.NewError("renew_allocations_failed", err.Error())
+	}
+
+	logging.Logger.Info("renew_allocations: auto-renewal stopped",
+		zap.String("allocation", alloc.ID),
+		zap.String("reason", reason))
+	alloc.AutoRenew = false
+	if err := alloc.save(balances, sc.ID); err != nil {
+		return common.NewError("renew_allocations_failed", err.Error())
+	}
+	balances.EmitEvent(event.TypeStats, event.TagUpdateAllocation, alloc.ID, alloc.buildDbUpdates())
+	balances.EmitEvent(event.TypeStats, event.TagAddAllocationRenewalWarning, alloc.ID, event.AllocationRenewalWarning{
+		AllocationID: alloc.ID,
+		Owner:        alloc.Owner,
+		Budget:       alloc.RenewalBudget,
+		WritePool:    alloc.WritePool,
+		Cost:         cost,
+		Reason:       reason,
+	})
+	return nil
+}
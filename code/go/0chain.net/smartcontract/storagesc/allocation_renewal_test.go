@@ -0,0 +1,225 @@
+package storagesc
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/smartcontract/stakepool"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newAutoRenewAllocation saves an auto-renew allocation of the test blobbers
+// with 1 GB used of each one, the blobber terms are the given ones.
+func newAutoRenewAllocation(t *testing.T, ssc *StorageSmartContract, balances *testBalances,
+	conf *Config, allocID string, now common.Timestamp, terms Terms) *StorageAllocation {
+
+	alloc := &StorageAllocation{
+		ID:               allocID,
+		Owner:            "owner_hex",
+		DataShards:       1,
+		ParityShards:     1,
+		Size:             2 * GB,
+		StartTime:        now,
+		Expiration:       now + 100,
+		TimeUnit:         conf.TimeUnit,
+		WritePool:        20000,
+		AutoRenew:        true,
+		RenewalBudget:    10000,
+		BlobberAllocsMap: make(map[string]*BlobberAllocation),
+		Stats:            &StorageAllocationStats{},
+	}
+	for _, b := range newTestAllBlobbers().Nodes {
+		if err := balances.GetTrieNode(b.GetKey(), &StorageNode{}); err != nil {
+			_, err = balances.InsertTrieNode(b.GetKey(), b)
+			require.NoError(t, err)
+
+			sp := newStakePool()
+			sp.Pools["hash "+b.ID] = &stakepool.DelegatePool{Balance: 20e10}
+			require.NoError(t, sp.Save(spenum.Blobber, b.ID, balances))
+		}
+
+		if terms == (Terms{}) {
+			terms = b.Terms
+		}
+		ba := &BlobberAllocation{
+			BlobberID:    b.ID,
+			AllocationID: allocID,
+			Size:         GB,
+			Terms:        terms,
+			Stats:        &StorageAllocationStats{UsedSize: GB},
+		}
+		alloc.BlobberAllocs = append(alloc.BlobberAllocs, ba)
+		alloc.BlobberAllocsMap[b.ID] = ba
+		terms = Terms{}
+	}
+	require.NoError(t, alloc.save(balances, ssc.ID))
+	cp, err := ssc.newChallengePool(allocID, now, alloc.Expiration, balances)
+	require.NoError(t, err)
+	require.NoError(t, cp.save(ssc.ID, alloc, balances))
+	require.NoError(t, partitionsAutoRenewAllocationsSet(balances, allocID, true))
+	return alloc
+}
+
+func TestStorageSmartContract_renewAllocations(t *testing.T) {
+	const allocID = "alloc_hex"
+
+	var (
+		ssc      = newTestStorageSC()
+		balances = newTestBalances(t, false)
+		now      = toSeconds(2 * time.Hour)
+	)
+
+	conf := setConfig(t, balances)
+	conf.AutoRenewal.Period = 10 * time.Minute
+	conf.AutoRenewal.Window = 5 * time.Minute
+	_, err := balances.InsertTrieNode(scConfigKey(ADDRESS), conf)
+	require.NoError(t, err)
+
+	newAutoRenewAllocation(t, ssc, balances, conf, allocID, now, Terms{})
+
+	renew := func(at common.Timestamp) *StorageAllocation {
+		tx := transaction.Transaction{CreationDate: now + at}
+		balances.setTransaction(t, &tx)
+		_, err := ssc.renewAllocations(&tx,
+			mustEncode(t, &renewAllocationsInput{Round: balances.GetBlock().Round}), balances)
+		require.NoError(t, err)
+		alloc, err := ssc.getAllocation(allocID, balances)
+		require.NoError(t, err)
+		return alloc
+	}
+
+	// (200 + 250) write price * 1 GB used * 10 time units are moved to the
+	// challenge pool, truncated
+	renewed := renew(0)
+	assert.Equal(t, now+100+600, renewed.Expiration)
+	assert.EqualValues(t, 10000-4499, renewed.RenewalBudget)
+	assert.EqualValues(t, 20000-4499, renewed.WritePool)
+	cp, err := ssc.getChallengePool(allocID, balances)
+	require.NoError(t, err)
+	assert.EqualValues(t, 4499, cp.Balance)
+
+	// not in the renewal window yet
+	renewed = renew(300)
+	assert.Equal(t, now+700, renewed.Expiration)
+
+	renewed = renew(650)
+	assert.Equal(t, now+1300, renewed.Expiration)
+	assert.EqualValues(t, 1001, renewed.RenewalBudget)
+	assert.EqualValues(t, 11001, renewed.WritePool)
+
+	// the budget can't cover the next period, nothing is moved
+	stopped := renew(1250)
+	assert.Equal(t, now+1300, stopped.Expiration)
+	assert.False(t, stopped.AutoRenew)
+	assert.EqualValues(t, 1001, stopped.RenewalBudget)
+	assert.EqualValues(t, 11001, stopped.WritePool)
+	cp, err = ssc.getChallengePool(allocID, balances)
+	require.NoError(t, err)
+	assert.EqualValues(t, 8999, cp.Balance)
+
+	parts, err := partitionsAutoRenewAllocations(balances)
+	require.NoError(t, err)
+	ok, err := parts.Exist(balances, allocID)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestStorageSmartContract_renewAllocationsExtendFailure(t *testing.T) {
+	var (
+		ssc      = newTestStorageSC()
+		balances = newTestBalances(t, false)
+		now      = toSeconds(2 * time.Hour)
+	)
+
+	conf := setConfig(t, balances)
+	conf.AutoRenewal.Period = 10 * time.Minute
+	conf.AutoRenewal.Window = 5 * time.Minute
+	_, err := balances.InsertTrieNode(scConfigKey(ADDRESS), conf)
+	require.NoError(t, err)
+
+	// the blobber lowered its price, the offer reduction of the broken
+	// allocation fails as its stake pool has no offers
+	broken := newAutoRenewAllocation(t, ssc, balances, conf, "broken_alloc", now,
+		Terms{WritePrice: 1e10, ReadPrice: 1})
+	newAutoRenewAllocation(t, ssc, balances, conf, "alloc", now, Terms{})
+
+	tx := transaction.Transaction{CreationDate: now}
+	balances.setTransaction(t, &tx)
+	resp, err := ssc.renewAllocations(&tx,
+		mustEncode(t, &renewAllocationsInput{Round: balances.GetBlock().Round}), balances)
+	require.NoError(t, err)
+	assert.Equal(t, "1 allocations renewed", resp)
+
+	stopped, err := ssc.getAllocation(broken.ID, balances)
+	require.NoError(t, err)
+	assert.False(t, stopped.AutoRenew)
+	assert.Equal(t, broken.Expiration, stopped.Expiration)
+	assert.Equal(t, broken.WritePool, stopped.WritePool)
+	assert.Equal(t, broken.RenewalBudget, stopped.RenewalBudget)
+
+	renewed, err := ssc.getAllocation("alloc", balances)
+	require.NoError(t, err)
+	assert.True(t, renewed.AutoRenew)
+	assert.Equal(t, now+700, renewed.Expiration)
+
+	// the cursor is saved for the next call
+	var cursor AutoRenewAllocationsCursor
+	require.NoError(t, balances.GetTrieNode(AUTO_RENEW_ALLOCATIONS_CURSOR_KEY, &cursor))
+	assert.Equal(t, 0, cursor.PartsLeft)
+}
+
+func TestStorageSmartContract_renewAllocationsPaging(t *testing.T) {
+	const allocsNum = 2*autoRenewAllocationsPartitionSize + 1
+
+	var (
+		ssc      = newTestStorageSC()
+		balances = newTestBalances(t, false)
+		now      = toSeconds(2 * time.Hour)
+	)
+
+	conf := setConfig(t, balances)
+	conf.AutoRenewal.Period = 0
+	_, err := balances.InsertTrieNode(scConfigKey(ADDRESS), conf)
+	require.NoError(t, err)
+
+	// the allocations are not in the renewal window
+	for i := 0; i < allocsNum; i++ {
+		alloc := &StorageAllocation{
+			ID:         "alloc_" + strconv.Itoa(i),
+			Expiration: now + toSeconds(time.Hour),
+			AutoRenew:  true,
+		}
+		require.NoError(t, alloc.save(balances, ssc.ID))
+		require.NoError(t, partitionsAutoRenewAllocationsSet(balances, alloc.ID, true))
+	}
+
+	renew := func() (string, error) {
+		tx := transaction.Transaction{CreationDate: now}
+		balances.setTransaction(t, &tx)
+		return ssc.renewAllocations(&tx,
+			mustEncode(t, &renewAllocationsInput{Round: balances.GetBlock().Round}), balances)
+	}
+
+	resp, err := renew()
+	require.NoError(t, err)
+	assert.Equal(t, "auto-renewal is disabled", resp)
+
+	conf.AutoRenewal.Period = 10 * time.Minute
+	conf.AutoRenewal.Window = 5 * time.Minute
+	_, err = balances.InsertTrieNode(scConfigKey(ADDRESS), conf)
+	require.NoError(t, err)
+
+	// a partition per call, from the last one
+	for _, left := range []int{2, 1, 0, 2} {
+		_, err := renew()
+		require.NoError(t, err)
+		var cursor AutoRenewAllocationsCursor
+		require.NoError(t, balances.GetTrieNode(AUTO_RENEW_ALLOCATIONS_CURSOR_KEY, &cursor))
+		assert.Equal(t, left, cursor.PartsLeft)
+	}
+}
@@ -0,0 +1,167 @@
+package storagesc
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/core/common"
+	"0chain.net/smartcontract/dbs/event"
+	"github.com/0chain/common/core/currency"
+)
+
+//
+// allocation billing statements
+//
+
+// billingStatementCSVBatchSize is the number of charges the csv statement
+// reads from the events database at once.
+const billingStatementCSVBatchSize = 1000
+
+// emitAllocationCharge records a token movement paid by the client for the
+// allocation in the events database, see event.AllocationChargeType. The
+// refunds are the owner's as the rest of the write pool, the challenge pool
+// rewards have no client.
+func emitAllocationCharge(
+	alloc *StorageAllocation,
+	clientID, blobberID string,
+	typ event.AllocationChargeType,
+	amount currency.Coin,
+	at common.Timestamp,
+	balances cstate.StateContextI,
+) {
+	if amount == 0 {
+		return
+	}
+	balances.EmitEvent(event.TypeStats, event.TagAddAllocationCharge, alloc.ID, event.AllocationCharge{
+		AllocationID: alloc.ID,
+		ClientID:     clientID,
+		BlobberID:    blobberID,
+		Type:         typ,
+		Amount:       amount,
+		Timestamp:    int64(at),
+	})
+}
+
+// emitWritePoolCharges records the parts of a write pool payment as the
+// charges of the clients that paid them, see payFromWritePool.
+func emitWritePoolCharges(
+	alloc *StorageAllocation,
+	paid []*WritePoolLock,
+	blobberID string,
+	typ event.AllocationChargeType,
+	at common.Timestamp,
+	balances cstate.StateContextI,
+) {
+	for _, p := range paid {
+		emitAllocationCharge(alloc, p.ClientID, blobberID, typ, p.Amount, at, balances)
+	}
+}
+
+// billingStatement is the itemized charges of an allocation or a client in
+// a time range.
+// swagger:model billingStatement
+type billingStatement struct {
+	AllocationID string `json:"allocation_id,omitempty"`
+	ClientID     string `json:"client_id,omitempty"`
+	From         int64  `json:"from"`
+	To           int64  `json:"to"`
+	// Total is the tokens the client paid in the time range: the write
+	// charges less the refunds, the reads, the min lock demand and the
+	// cancellation charges. The challenge and validator rewards are paid
+	// out of the write charges and the unlocked tokens are the client's.
+	Total   currency.Coin                 `json:"total"`
+	Totals  []event.AllocationChargeTotal `json:"totals"`
+	Charges []event.AllocationCharge      `json:"charges"`
+}
+
+// setTotal sums the Totals of the statement to its Total.
+func (bs *billingStatement) setTotal() (err error) {
+	var paid, refunded currency.Coin
+	for _, t := range bs.Totals {
+		switch t.Type {
+		case event.ChargeWrite, event.ChargeRead, event.ChargeMinLockDemand, event.ChargeCancellation:
+			paid, err = currency.AddCoin(paid, t.Amount)
+		case event.ChargeRefund:
+			refunded, err = currency.AddCoin(refunded, t.Amount)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	// the refunds of the write charges made before the time range
+	if refunded > paid {
+		bs.Total = 0
+		return nil
+	}
+	bs.Total, err = currency.MinusCoin(paid, refunded)
+	return err
+}
+
+var (
+	billingStatementCSVHeader = []string{
+		"timestamp", "round", "txn_hash", "allocation_id", "client_id", "blobber_id", "type", "amount",
+	}
+	billingStatementTotalsCSVHeader = []string{
+		"type", "blobber_id", "count", "amount",
+	}
+)
+
+// writeCSV writes all the charges of the statement, one per line, the
+// each function calls the given one with them batch by batch. The totals
+// and the total of the statement follow the charges after an empty line.
+func (bs *billingStatement) writeCSV(
+	w io.Writer,
+	each func(func([]event.AllocationCharge) error) error,
+) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(billingStatementCSVHeader); err != nil {
+		return err
+	}
+	err := each(func(charges []event.AllocationCharge) error {
+		for _, c := range charges {
+			err := cw.Write([]string{
+				strconv.FormatInt(c.Timestamp, 10),
+				strconv.FormatInt(c.Round, 10),
+				c.TxnHash,
+				c.AllocationID,
+				c.ClientID,
+				c.BlobberID,
+				string(c.Type),
+				strconv.FormatUint(uint64(c.Amount), 10),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := cw.Write(nil); err != nil {
+		return err
+	}
+	if err := cw.Write(billingStatementTotalsCSVHeader); err != nil {
+		return err
+	}
+	for _, t := range bs.Totals {
+		err := cw.Write([]string{
+			string(t.Type),
+			t.BlobberID,
+			strconv.FormatInt(t.Count, 10),
+			strconv.FormatUint(uint64(t.Amount), 10),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if err := cw.Write([]string{"total", "", "", strconv.FormatUint(uint64(bs.Total), 10)}); err != nil {
+		return err
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,167 @@
+package storagesc
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"0chain.net/chaincore/chain/state/mocks"
+	"0chain.net/chaincore/config"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/rest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBillingStatement_writeCSV(t *testing.T) {
+	bs := &billingStatement{
+		AllocationID: "alloc",
+		Totals: []event.AllocationChargeTotal{
+			{Type: event.ChargeChallengeReward, BlobberID: "b1", Amount: 800, Count: 1},
+			{Type: event.ChargeRefund, BlobberID: "b1", Amount: 200, Count: 1},
+			{Type: event.ChargeUnlock, Amount: 5, Count: 1},
+			{Type: event.ChargeWrite, BlobberID: "b1", Amount: 1000, Count: 1},
+		},
+	}
+	require.NoError(t, bs.setTotal())
+	// the challenge rewards are paid out of the write charges
+	assert.EqualValues(t, 800, bs.Total)
+
+	batches := [][]event.AllocationCharge{
+		{
+			{
+				AllocationID: "alloc",
+				ClientID:     "owner",
+				BlobberID:    "b1",
+				Type:         event.ChargeWrite,
+				Amount:       1000,
+				Timestamp:    100,
+				TxnHash:      "hash1",
+				Round:        10,
+			},
+		},
+		{
+			{
+				AllocationID: "alloc",
+				ClientID:     "owner",
+				Type:         event.ChargeUnlock,
+				Amount:       5,
+				Timestamp:    200,
+				TxnHash:      "hash2",
+				Round:        20,
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, bs.writeCSV(&buf, func(fn func([]event.AllocationCharge) error) error {
+		for _, charges := range batches {
+			if err := fn(charges); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+	assert.Equal(t,
+		"timestamp,round,txn_hash,allocation_id,client_id,blobber_id,type,amount\n"+
+			"100,10,hash1,alloc,owner,b1,write,1000\n"+
+			"200,20,hash2,alloc,owner,,unlock,5\n"+
+			"\n"+
+			"type,blobber_id,count,amount\n"+
+			"challenge_reward,b1,1,800\n"+
+			"refund,b1,1,200\n"+
+			"unlock,,1,5\n"+
+			"write,b1,1,1000\n"+
+			"total,,,800\n",
+		buf.String())
+}
+
+func TestStorageRestHandler_getBillingStatement(t *testing.T) {
+	const chargesNum = 25 // more than the default limit
+
+	eventDb, err := event.NewInMemoryEventDb(config.DbAccess{}, config.DbSettings{
+		PartitionChangePeriod: 1,
+	})
+	require.NoError(t, err)
+	defer eventDb.Close()
+	require.NoError(t, eventDb.Drop())
+	require.NoError(t, eventDb.AutoMigrate())
+
+	charges := make([]event.AllocationCharge, 0, chargesNum)
+	for i := 0; i < chargesNum; i++ {
+		charges = append(charges, event.AllocationCharge{
+			AllocationID: "alloc",
+			ClientID:     "owner",
+			BlobberID:    "b1",
+			Type:         event.ChargeWrite,
+			Amount:       10,
+			Timestamp:    int64(100 + i),
+		})
+	}
+	charges = append(charges, event.AllocationCharge{
+		AllocationID: "alloc",
+		ClientID:     "owner",
+		BlobberID:    "b1",
+		Type:         event.ChargeChallengeReward,
+		Amount:       100,
+		Timestamp:    200,
+	})
+	require.NoError(t, eventDb.Get().Create(&charges).Error)
+
+	sctx := &mocks.TimedQueryStateContextI{}
+	sctx.On("GetEventDB").Return(eventDb)
+	srh := NewStorageRestHandler(rest.NewRestHandler(&rest.TestQueryChainer{}))
+	srh.SetQueryStateContext(sctx)
+
+	get := func(params map[string]string) *httptest.ResponseRecorder {
+		query := url.Values{}
+		for k, v := range params {
+			query.Set(k, v)
+		}
+		target := url.URL{Path: "/billing-statement", RawQuery: query.Encode()}
+		req, err := http.NewRequest(http.MethodGet, target.String(), nil)
+		require.NoError(t, err)
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(srh.getBillingStatement).ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := get(map[string]string{"from": "0"})
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	rr = get(map[string]string{"allocation_id": "alloc", "format": "xml"})
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	rr = get(map[string]string{"allocation_id": "alloc", "from": "10", "to": "5"})
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	// the json charges are paginated, the totals are of the whole range
+	rr = get(map[string]string{"allocation_id": "alloc"})
+	require.Equal(t, http.StatusOK, rr.Code)
+	var statement billingStatement
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &statement))
+	assert.Len(t, statement.Charges, 20)
+	assert.EqualValues(t, chargesNum*10, statement.Total)
+	assert.Equal(t, []event.AllocationChargeTotal{
+		{Type: event.ChargeChallengeReward, BlobberID: "b1", Amount: 100, Count: 1},
+		{Type: event.ChargeWrite, BlobberID: "b1", Amount: chargesNum * 10, Count: chargesNum},
+	}, statement.Totals)
+
+	// the csv has all the charges and the totals
+	rr = get(map[string]string{"client_id": "owner", "format": "csv"})
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "text/csv", rr.Header().Get("Content-Type"))
+	r := csv.NewReader(rr.Body)
+	r.FieldsPerRecord = -1
+	records, err := r.ReadAll()
+	require.NoError(t, err)
+	// header, charges with the reward, totals header, totals and total,
+	// the empty line is skipped by the reader
+	require.Len(t, records, 1+(chargesNum+1)+1+2+1)
+	assert.Equal(t, billingStatementCSVHeader, records[0])
+	assert.Equal(t, billingStatementTotalsCSVHeader, records[1+chargesNum+1])
+	assert.Equal(t, []string{"total", "", "", strconv.Itoa(chargesNum * 10)}, records[len(records)-1])
+}
@@ -718,6 +718,8 @@ func TestExtendAllocation(t *testing.T) {
 					event.TagAddOrUpdateChallengePool, mock.Anything, mock.Anything).Return().Maybe()
 				balances.On("EmitEvent", event.TypeStats,
 					event.TagUpdateBlobberTotalOffers, mock.Anything, mock.Anything).Return().Maybe()
+				balances.On("EmitEvent", event.TypeStats,
+					event.TagAddAllocationCharge, mock.Anything, mock.Anything).Return().Maybe()
 			}
 		}
 		balances.On(
@@ -793,7 +795,7 @@ func TestExtendAllocation(t *testing.T) {
 			},
 			want: want{
 				err:    true,
-				errMsg: "allocation_extending_failed: adjust_challenge_pool: insufficient funds 0 in write pool to pay 6287864",
+				errMsg: "allocation_extending_failed: adjust_challenge_pool: insufficient funds 0 in write pool to pay 25151456",
 			},
 		},
 	}
@@ -1262,6 +1264,8 @@ func TestStorageSmartContract_newAllocationRequest(t *testing.T) {
 		sb.Nodes[1].LastHealthCheck = tx.CreationDate
 		sb.Nodes[0].Allocated += 10 * GB
 		sb.Nodes[1].Allocated += 10 * GB
+		sb.Nodes[0].Reputation = initialReputation
+		sb.Nodes[1].Reputation = initialReputation
 
 		// blobbers saved in all blobbers list
 		var ab []*StorageNode
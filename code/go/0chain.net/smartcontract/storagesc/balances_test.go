@@ -85,6 +85,9 @@ func (tb *testBalances) GetTransfers() []*state.Transfer             { return ni
 func (tb *testBalances) GetMagicBlock(round int64) *block.MagicBlock { return nil }
 func (tb *testBalances) SetMagicBlock(block *block.MagicBlock)       {}
 func (tb *testBalances) AddSignedTransfer(st *state.SignedTransfer)  {}
+func (tb *testBalances) ExecuteOnBehalf(*transaction.Transaction, func(cstate.StateContextI) (string, error)) (string, error) {
+	return "", nil
+}
 func (tb *testBalances) GetSignedTransfers() []*state.SignedTransfer { return nil }
 func (tb *testBalances) GetEventDB() *event.EventDb                  { return nil }
 func (tb *testBalances) EmitEvent(event.EventType, event.EventTag, string, interface{}, ...cstate.Appender) {
@@ -131,6 +131,50 @@ func BenchmarkRestTests(
 				},
 				Endpoint: srh.getAllocations,
 			},
+			{
+				FuncName: "degraded-allocations",
+				Params: map[string]string{
+					"owner": data.Clients[0],
+					"limit": "20",
+				},
+				Endpoint: srh.getDegradedAllocations,
+			},
+			{
+				FuncName: "blobber-replacements",
+				Params: map[string]string{
+					"allocation_id": getMockAllocationId(0),
+				},
+				Endpoint: srh.getBlobberReplacements,
+			},
+			{
+				FuncName: "allocation-renewal-warnings",
+				Params: map[string]string{
+					"owner": data.Clients[0],
+				},
+				Endpoint: srh.getAllocationRenewalWarnings,
+			},
+			{
+				FuncName: "allocation-acl",
+				Params: map[string]string{
+					"allocation_id": getMockAllocationId(0),
+				},
+				Endpoint: srh.getAllocationACL,
+			},
+			{
+				FuncName: "allocation-acls",
+				Params: map[string]string{
+					"client_id": data.Clients[2],
+				},
+				Endpoint: srh.getAllocationACLs,
+			},
+			{
+				FuncName: "billing-statement",
+				Params: map[string]string{
+					"client_id": data.Clients[0],
+					"format":    "csv",
+				},
+				Endpoint: srh.getBillingStatement,
+			},
 			{
 				FuncName: "allocation_min_lock",
 				Params: map[string]string{
@@ -194,6 +238,13 @@ func BenchmarkRestTests(
 				},
 				Endpoint: srh.getReadPoolStat,
 			},
+			{
+				FuncName: "getAllocationReadPoolStat",
+				Params: map[string]string{
+					"allocation_id": getMockAllocationId(0),
+				},
+				Endpoint: srh.getAllocationReadPoolStat,
+			},
 			{
 				FuncName: "writemarkers",
 				Params: map[string]string{
@@ -225,6 +276,13 @@ func BenchmarkRestTests(
 				},
 				Endpoint: srh.getUserStakePoolStat,
 			},
+			{
+				FuncName: "getUserUnbondingEntries",
+				Params: map[string]string{
+					"client_id": data.Clients[0],
+				},
+				Endpoint: srh.getUserUnbondingEntries,
+			},
 			{
 				FuncName: "getChallengePoolStat",
 				Params: map[string]string{
@@ -42,11 +42,14 @@ func AddMockAllocations(
 ) {
 	for i := 0; i < viper.GetInt(sc.NumAllocations); i++ {
 		cIndex := getMockOwnerFromAllocationIndex(i, len(clients))
+		// the first allocation is being transferred to the next client
+		pIndex := (cIndex + 1) % len(clients)
 		addMockAllocation(
 			i,
 			clients,
 			cIndex,
 			publicKeys[cIndex],
+			publicKeys[pIndex],
 			eventDb,
 			balances,
 		)
@@ -62,6 +65,7 @@ func addMockAllocation(
 	clients []string,
 	cIndex int,
 	publicKey string,
+	pendingOwnerPublicKey string,
 	eventDb *event.EventDb,
 	balances cstate.StateContextI,
 ) {
@@ -88,12 +92,32 @@ func addMockAllocation(
 			FailedChallenges:          1,
 			LastestClosedChallengeTxn: "latest closed challenge transaction:" + id,
 		},
-		TimeUnit:  1 * time.Hour,
-		Finalized: i == mockFinalizedAllocationIndex,
-		WritePool: mockWriePoolSize,
+		TimeUnit:            1 * time.Hour,
+		Finalized:           i == mockFinalizedAllocationIndex,
+		WritePool:           mockWriePoolSize,
+		AutoReplaceBlobbers: true,
+		AutoRenew:           true,
+		RenewalBudget:       mockWriePoolSize,
+	}
+
+	if i == 0 {
+		// the ownership of the first allocation is transferred
+		sa.PendingOwner = clients[(cIndex+1)%len(clients)]
+		sa.PendingOwnerPublicKey = pendingOwnerPublicKey
+		sa.PendingOwnerExpiration = sa.Expiration
 	}
 
 	startBlobbers := getMockBlobberBlockFromAllocationIndex(i)
+	if i == 0 {
+		// the first blobber of the first allocation is killed, its other
+		// allocations are left to be marked degraded
+		sa.DegradedBlobbers = []string{getMockBlobberId(startBlobbers)}
+		_, err := balances.InsertTrieNode(getDegradedAllocationsCursorKey(getMockBlobberId(startBlobbers)),
+			&DegradedAllocationsCursor{PartsLeft: 1})
+		if err != nil {
+			log.Fatal("add degraded allocations cursor", err)
+		}
+	}
 	for j := 0; j < viper.GetInt(sc.NumBlobbersPerAllocation); j++ {
 		bIndex := startBlobbers + j
 		bId := getMockBlobberId(bIndex)
@@ -124,6 +148,22 @@ func addMockAllocation(
 	if _, err := balances.InsertTrieNode(sa.GetKey(ADDRESS), sa); err != nil {
 		log.Fatal(err)
 	}
+	if err := partitionsAutoRenewAllocationsSet(balances, sa.ID, sa.AutoRenew); err != nil {
+		log.Fatal("add auto-renew allocation", err)
+	}
+
+	arp := newAllocationReadPool(sa.ID)
+	arp.Sponsors[sa.Owner] = 10 * 1e10
+	if err := arp.save(ADDRESS, balances); err != nil {
+		log.Fatal(err)
+	}
+
+	acl := newAllocationACL(sa.ID)
+	collaborator := clients[(cIndex+2)%len(clients)]
+	acl.Permissions[collaborator] = aclAllPermissions
+	if err := acl.save(ADDRESS, balances); err != nil {
+		log.Fatal(err)
+	}
 
 	if viper.GetBool(sc.EventDbEnabled) {
 		allocationTerms := make([]event.AllocationBlobberTerm, 0)
@@ -157,6 +197,28 @@ func addMockAllocation(
 		if err := eventDb.Store.Get().Create(&allocationDb).Error; err != nil {
 			log.Fatal(err)
 		}
+		aclDb := event.AllocationACL{
+			AllocationID: sa.ID,
+			ClientID:     collaborator,
+			Permissions:  aclAllPermissions,
+		}
+		if err := eventDb.Store.Get().Create(&aclDb).Error; err != nil {
+			log.Fatal(err)
+		}
+		chargesDb := make([]event.AllocationCharge, 0, len(sa.BlobberAllocs))
+		for _, b := range sa.BlobberAllocs {
+			chargesDb = append(chargesDb, event.AllocationCharge{
+				AllocationID: sa.ID,
+				ClientID:     sa.Owner,
+				BlobberID:    b.BlobberID,
+				Type:         event.ChargeWrite,
+				Amount:       b.MinLockDemand,
+				Timestamp:    int64(sa.StartTime),
+			})
+		}
+		if err := eventDb.Store.Get().Create(&chargesDb).Error; err != nil {
+			log.Fatal(err)
+		}
 	}
 }
 
@@ -373,9 +435,11 @@ func AddMockBlobbers(
 			Terms:             getMockBlobberTerms(),
 			Capacity:          viper.GetInt64(sc.StorageMinBlobberCapacity) * 10000,
 			Allocated:         mockUsedData,
+			SavedData:         mockUsedData / int64(i%4+1),
 			PublicKey:         "",
 			StakePoolSettings: getMockStakePoolSettings(id),
 			IsAvailable:       true,
+			Reputation:        initialReputation,
 		}
 		blobbers.Nodes.add(blobber)
 		rtvBlobbers = append(rtvBlobbers, blobber)
@@ -408,6 +472,7 @@ func AddMockBlobbers(
 				ChallengesPassed:    uint64(i),
 				ChallengesCompleted: uint64(i + 1),
 				RankMetric:          float64(i) / (float64(i) + 1),
+				Reputation:          blobber.Reputation,
 				IsAvailable:         blobber.IsAvailable,
 			}
 			blobberDb.TotalStake, err = currency.ParseZCN(viper.GetFloat64(sc.StorageMaxStake) / 2)
@@ -611,6 +676,10 @@ func GetMockBlobberStakePools(
 				}
 			}
 		}
+		sp.Unbonding = []*stakepool.UnbondingEntry{{
+			DelegateID: getMockBlobberStakePoolId(i, 0, clients),
+			Amount:     currency.Coin(viper.GetInt64(sc.StorageMaxStake) * 1e10 / 2),
+		}}
 		sps = append(sps, sp)
 	}
 	return sps
@@ -818,6 +887,11 @@ func SetMockConfig(
 	conf.MaxWritePrice = 100e10 // 100 tokens per GB max allowed
 	conf.MinWritePrice = 0
 	conf.MaxDelegates = viper.GetInt(sc.StorageMaxDelegates)
+	conf.OwnershipTransferTimeout = time.Hour
+	conf.ChallengeBlobberSelection = int(randomSelection)
+	conf.ChallengeRiskWeight = 4
+	conf.AutoRenewal.Period = viper.GetDuration(sc.TimeUnit)
+	conf.AutoRenewal.Window = viper.GetDuration(sc.TimeUnit) / 2
 	conf.MaxChallengeCompletionTime = viper.GetDuration(sc.StorageMaxChallengeCompletionTime)
 	conf.MaxCharge = viper.GetFloat64(sc.StorageMaxCharge)
 	conf.MinStake = currency.Coin(viper.GetInt64(sc.StorageMinStake) * 1e10)
@@ -868,39 +942,48 @@ func SetMockConfig(
 	}
 	var mockCost = 100
 	conf.Cost = map[string]int{
-		"cost.update_settings":           mockCost,
-		"cost.read_redeem":               mockCost,
-		"cost.commit_connection":         mockCost,
-		"cost.new_allocation_request":    mockCost,
-		"cost.update_allocation_request": mockCost,
-		"cost.finalize_allocation":       mockCost,
-		"cost.cancel_allocation":         mockCost,
-		"cost.add_free_storage_assigner": mockCost,
-		"cost.free_allocation_request":   mockCost,
-		"cost.free_update_allocation":    mockCost,
-		"cost.blobber_health_check":      mockCost,
-		"cost.update_blobber_settings":   mockCost,
-		"cost.pay_blobber_block_rewards": mockCost,
-		"cost.challenge_request":         mockCost,
-		"cost.challenge_response":        mockCost,
-		"cost.generate_challenge":        mockCost,
-		"cost.add_validator":             mockCost,
-		"cost.update_validator_settings": mockCost,
-		"cost.add_blobber":               mockCost,
-		"cost.new_read_pool":             mockCost,
-		"cost.read_pool_lock":            mockCost,
-		"cost.read_pool_unlock":          mockCost,
-		"cost.write_pool_lock":           mockCost,
-		"cost.write_pool_unlock":         mockCost,
-		"cost.stake_pool_lock":           mockCost,
-		"cost.stake_pool_unlock":         mockCost,
-		"cost.stake_pool_pay_interests":  mockCost,
-		"cost.commit_settings_changes":   mockCost,
-		"cost.collect_reward":            mockCost,
-		"cost.kill_blobber":              mockCost,
-		"cost.kill_validator":            mockCost,
-		"cost.shutdown_blobber":          mockCost,
-		"cost.shutdown_validator":        mockCost,
+		"cost.update_settings":               mockCost,
+		"cost.read_redeem":                   mockCost,
+		"cost.commit_connection":             mockCost,
+		"cost.new_allocation_request":        mockCost,
+		"cost.update_allocation_request":     mockCost,
+		"cost.replace_degraded_blobber":      mockCost,
+		"cost.mark_degraded_allocations":     mockCost,
+		"cost.transfer_allocation_ownership": mockCost,
+		"cost.accept_allocation_ownership":   mockCost,
+		"cost.renew_allocations":             mockCost,
+		"cost.update_allocation_acl":         mockCost,
+		"cost.finalize_allocation":           mockCost,
+		"cost.cancel_allocation":             mockCost,
+		"cost.add_free_storage_assigner":     mockCost,
+		"cost.free_allocation_request":       mockCost,
+		"cost.free_update_allocation":        mockCost,
+		"cost.blobber_health_check":          mockCost,
+		"cost.update_blobber_settings":       mockCost,
+		"cost.pay_blobber_block_rewards":     mockCost,
+		"cost.challenge_request":             mockCost,
+		"cost.challenge_response":            mockCost,
+		"cost.generate_challenge":            mockCost,
+		"cost.add_validator":                 mockCost,
+		"cost.update_validator_settings":     mockCost,
+		"cost.add_blobber":                   mockCost,
+		"cost.new_read_pool":                 mockCost,
+		"cost.read_pool_lock":                mockCost,
+		"cost.read_pool_unlock":              mockCost,
+		"cost.write_pool_lock":               mockCost,
+		"cost.write_pool_unlock":             mockCost,
+		"cost.stake_pool_lock":               mockCost,
+		"cost.stake_pool_unlock":             mockCost,
+		"cost.stake_pool_pay_interests":      mockCost,
+		"cost.commit_settings_changes":       mockCost,
+		"cost.collect_reward":                mockCost,
+		"cost.stake_pool_auto_compound":      mockCost,
+		"cost.stake_pool_redelegate":         mockCost,
+		"cost.stake_pool_withdraw_unbonded":  mockCost,
+		"cost.kill_blobber":                  mockCost,
+		"cost.kill_validator":                mockCost,
+		"cost.shutdown_blobber":              mockCost,
+		"cost.shutdown_validator":            mockCost,
 	}
 	return
 }
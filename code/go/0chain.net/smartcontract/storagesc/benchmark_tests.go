@@ -213,6 +213,97 @@ func BenchmarkTests(
 			}(),
 			timings: timings,
 		},
+		{
+			name:     "storage.replace_degraded_blobber",
+			endpoint: ssc.replaceDegradedBlobber,
+			txn: &transaction.Transaction{
+				HashIDField: datastore.HashIDField{
+					Hash: encryption.Hash("mock transaction hash"),
+				},
+				ClientID:     data.Clients[0],
+				CreationDate: creationTime - 1,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&replaceBlobberRequest{
+					AllocationID: getMockAllocationId(0),
+					BlobberID:    getMockBlobberId(0),
+					Candidates:   []string{getMockBlobberId(viper.GetInt(bk.NumBlobbers) - 1)},
+				})
+				return bytes
+			}(),
+		},
+		{
+			name:     "storage.mark_degraded_allocations",
+			endpoint: ssc.markDegradedAllocations,
+			txn: &transaction.Transaction{
+				HashIDField: datastore.HashIDField{
+					Hash: encryption.Hash("mock transaction hash"),
+				},
+				ClientID:     data.Clients[0],
+				CreationDate: creationTime - 1,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&markDegradedAllocationsRequest{
+					BlobberID: getMockBlobberId(0),
+				})
+				return bytes
+			}(),
+		},
+		{
+			name:     "storage.transfer_allocation_ownership",
+			endpoint: ssc.transferAllocationOwnership,
+			txn: &transaction.Transaction{
+				HashIDField: datastore.HashIDField{
+					Hash: encryption.Hash("mock transaction hash"),
+				},
+				ClientID:     data.Clients[0],
+				CreationDate: creationTime - 1,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&transferOwnershipRequest{
+					AllocationID:      getMockAllocationId(0),
+					NewOwnerID:        data.Clients[1],
+					NewOwnerPublicKey: data.PublicKeys[1],
+				})
+				return bytes
+			}(),
+		},
+		{
+			name:     "storage.accept_allocation_ownership",
+			endpoint: ssc.acceptAllocationOwnership,
+			txn: &transaction.Transaction{
+				HashIDField: datastore.HashIDField{
+					Hash: encryption.Hash("mock transaction hash"),
+				},
+				ClientID:     data.Clients[1],
+				CreationDate: creationTime - 1,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&acceptOwnershipRequest{
+					AllocationID: getMockAllocationId(0),
+				})
+				return bytes
+			}(),
+		},
+		{
+			name:     "storage.update_allocation_acl",
+			endpoint: ssc.updateAllocationACL,
+			txn: &transaction.Transaction{
+				HashIDField: datastore.HashIDField{
+					Hash: encryption.Hash("mock transaction hash"),
+				},
+				ClientID:     data.Clients[0],
+				CreationDate: creationTime - 1,
+			},
+			input: func() []byte {
+				bytes, _ := json.Marshal(&updateAllocationACLRequest{
+					AllocationID: getMockAllocationId(0),
+					ClientID:     data.Clients[3],
+					Permissions:  aclPermissionUpload | aclPermissionReadWithoutPayment,
+				})
+				return bytes
+			}(),
+		},
 		{
 			name:     "storage.update_allocation_request",
 			endpoint: ssc.updateAllocationRequest,
@@ -619,6 +710,47 @@ func BenchmarkTests(
 				return bytes
 			}(),
 		},
+		{
+			name:     "storage.stake_pool_auto_compound",
+			endpoint: ssc.stakePoolAutoCompound,
+			txn: &transaction.Transaction{
+				ClientID:     getMockBlobberStakePoolId(0, 0, data.Clients),
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: (&stakepool.AutoCompoundRequest{
+				ProviderType: spenum.Blobber,
+				ProviderID:   getMockBlobberId(0),
+				AutoCompound: true,
+			}).Encode(),
+		},
+		{
+			name:     "storage.stake_pool_redelegate",
+			endpoint: ssc.stakePoolRedelegate,
+			txn: &transaction.Transaction{
+				ClientID:     getMockBlobberStakePoolId(0, 0, data.Clients),
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: (&stakepool.RedelegateRequest{
+				ProviderType:   spenum.Blobber,
+				FromProviderID: getMockBlobberId(0),
+				ToProviderID:   getMockBlobberId(1),
+			}).Encode(),
+		},
+		{
+			name:     "storage.stake_pool_withdraw_unbonded",
+			endpoint: ssc.stakePoolWithdrawUnbonded,
+			txn: &transaction.Transaction{
+				ClientID:     getMockBlobberStakePoolId(0, 0, data.Clients),
+				ToClientID:   ADDRESS,
+				CreationDate: creationTime,
+			},
+			input: (&stakepool.StakePoolRequest{
+				ProviderType: spenum.Blobber,
+				ProviderID:   getMockBlobberId(0),
+			}).Encode(),
+		},
 		{
 			name:     "storage.collect_reward",
 			endpoint: ssc.collectReward,
@@ -765,13 +897,18 @@ func BenchmarkTests(
 					"free_allocation_settings.write_price_range.max": "0.1",
 					"free_allocation_settings.read_pool_fraction":    "0.2",
 
-					"validator_reward":         "0.025",
-					"blobber_slash":            "0.1",
-					"max_read_price":           "100",
-					"max_write_price":          "100",
-					"challenge_enabled":        "true",
-					"validators_per_challenge": "2",
-					"max_delegates":            "100",
+					"validator_reward":            "0.025",
+					"blobber_slash":               "0.1",
+					"max_read_price":              "100",
+					"max_write_price":             "100",
+					"challenge_enabled":           "true",
+					"validators_per_challenge":    "2",
+					"challenge_blobber_selection": "2",
+					"challenge_risk_weight":       "4",
+					"max_delegates":               "100",
+					"ownership_transfer_timeout":  "1h",
+					"auto_renewal.period":         "720h",
+					"auto_renewal.window":         "24h",
 
 					"block_reward.block_reward":     "1000",
 					"block_reward.qualifying_stake": "1",
@@ -782,35 +919,44 @@ func BenchmarkTests(
 					"block_reward.zeta.k":           "0.9",
 					"block_reward.zeta.mu":          "0.2",
 
-					"cost.update_settings":           "105",
-					"cost.read_redeem":               "105",
-					"cost.commit_connection":         "105",
-					"cost.new_allocation_request":    "105",
-					"cost.update_allocation_request": "105",
-					"cost.finalize_allocation":       "105",
-					"cost.cancel_allocation":         "105",
-					"cost.add_free_storage_assigner": "105",
-					"cost.free_allocation_request":   "105",
-					"cost.free_update_allocation":    "105",
-					"cost.blobber_health_check":      "105",
-					"cost.update_blobber_settings":   "105",
-					"cost.pay_blobber_block_rewards": "105",
-					"cost.challenge_request":         "105",
-					"cost.challenge_response":        "105",
-					"cost.generate_challenge":        "105",
-					"cost.add_validator":             "105",
-					"cost.update_validator_settings": "105",
-					"cost.add_blobber":               "105",
-					"cost.new_read_pool":             "105",
-					"cost.read_pool_lock":            "105",
-					"cost.read_pool_unlock":          "105",
-					"cost.write_pool_lock":           "105",
-					"cost.write_pool_unlock":         "105",
-					"cost.stake_pool_lock":           "105",
-					"cost.stake_pool_unlock":         "105",
-					"cost.stake_pool_pay_interests":  "105",
-					"cost.commit_settings_changes":   "105",
-					"cost.collect_reward":            "105",
+					"cost.update_settings":               "105",
+					"cost.read_redeem":                   "105",
+					"cost.commit_connection":             "105",
+					"cost.new_allocation_request":        "105",
+					"cost.update_allocation_request":     "105",
+					"cost.replace_degraded_blobber":      "105",
+					"cost.mark_degraded_allocations":     "105",
+					"cost.transfer_allocation_ownership": "105",
+					"cost.accept_allocation_ownership":   "105",
+					"cost.renew_allocations":             "105",
+					"cost.update_allocation_acl":         "105",
+					"cost.finalize_allocation":           "105",
+					"cost.cancel_allocation":             "105",
+					"cost.add_free_storage_assigner":     "105",
+					"cost.free_allocation_request":       "105",
+					"cost.free_update_allocation":        "105",
+					"cost.blobber_health_check":          "105",
+					"cost.update_blobber_settings":       "105",
+					"cost.pay_blobber_block_rewards":     "105",
+					"cost.challenge_request":             "105",
+					"cost.challenge_response":            "105",
+					"cost.generate_challenge":            "105",
+					"cost.add_validator":                 "105",
+					"cost.update_validator_settings":     "105",
+					"cost.add_blobber":                   "105",
+					"cost.new_read_pool":                 "105",
+					"cost.read_pool_lock":                "105",
+					"cost.read_pool_unlock":              "105",
+					"cost.write_pool_lock":               "105",
+					"cost.write_pool_unlock":             "105",
+					"cost.stake_pool_lock":               "105",
+					"cost.stake_pool_unlock":             "105",
+					"cost.stake_pool_pay_interests":      "105",
+					"cost.commit_settings_changes":       "105",
+					"cost.collect_reward":                "105",
+					"cost.stake_pool_auto_compound":      "105",
+					"cost.stake_pool_redelegate":         "105",
+					"cost.stake_pool_withdraw_unbonded":  "105",
 				},
 			}).Encode(),
 		},
@@ -842,6 +988,23 @@ func BenchmarkTests(
 			},
 			input: nil,
 		},
+		{
+			name: "storage.renew_allocations",
+			endpoint: func(
+				txn *transaction.Transaction,
+				_ []byte,
+				balances cstate.StateContextI,
+			) (string, error) {
+				input, err := json.Marshal(&renewAllocationsInput{Round: balances.GetBlock().Round})
+				if err != nil {
+					return "", err
+				}
+				return ssc.renewAllocations(txn, input, balances)
+			},
+			txn: &transaction.Transaction{
+				CreationDate: creationTime,
+			},
+		},
 		// todo "update_config" waiting for PR489
 	}
 	var testsI []bk.BenchTestI
@@ -91,6 +91,15 @@ func (sc *StorageSmartContract) updateBlobber(t *transaction.Transaction,
 		return fmt.Errorf("invalid blobber params: %v", err)
 	}
 
+	// the placement of the allocations was checked against the geolocation,
+	// a missing one keeps the saved geolocation
+	if blobber.Geolocation == (StorageNodeGeolocation{}) {
+		blobber.Geolocation = savedBlobber.Geolocation
+	}
+	if blobber.Geolocation != savedBlobber.Geolocation && savedBlobber.Allocated > 0 {
+		return fmt.Errorf("blobber %s geolocation can't be changed while it has allocations", blobber.ID)
+	}
+
 	if savedBlobber.BaseURL != blobber.BaseURL {
 		//if updating url
 		has, err := sc.hasBlobberUrl(blobber.BaseURL, balances)
@@ -130,6 +139,7 @@ func (sc *StorageSmartContract) updateBlobber(t *transaction.Transaction,
 	}
 	blobber.Allocated = savedBlobber.Allocated
 	blobber.SavedData = savedBlobber.SavedData
+	blobber.Reputation = savedBlobber.Reputation
 
 	// update statistics
 	sc.statIncr(statUpdateBlobber)
@@ -172,6 +182,7 @@ func (sc *StorageSmartContract) updateBlobber(t *transaction.Transaction,
 	if err := emitUpdateBlobber(blobber, sp, balances); err != nil {
 		return fmt.Errorf("emmiting blobber %v: %v", blobber, err)
 	}
+	emitBlobberTiers(blobber, balances)
 	return
 }
 
@@ -324,6 +335,7 @@ func (sc *StorageSmartContract) updateBlobberSettings(t *transaction.Transaction
 	})
 	blobber.Capacity = updatedBlobber.Capacity
 	blobber.Terms = updatedBlobber.Terms
+	blobber.Tiers = updatedBlobber.Tiers
 	blobber.StakePoolSettings = updatedBlobber.StakePoolSettings
 
 	return string(blobber.Encode()), nil
@@ -446,15 +458,27 @@ func (sc *StorageSmartContract) commitBlobberRead(t *transaction.Transaction,
 
 	commitRead.ReadMarker.ReadSize = sizeRead
 
+	// the owner pays the reads of the collaborators permitted to read
+	// without payment
+	payerID := commitRead.ReadMarker.ClientID
+	ok, err := sc.canReadWithoutPayment(alloc, payerID, balances)
+	if err != nil {
+		return "", common.NewErrorf("commit_blobber_read",
+			"can't get allocation collaborators: %v", err)
+	}
+	if ok {
+		payerID = alloc.Owner
+	}
+
 	// move tokens from read pool to blobber
-	rp, err := sc.getReadPool(commitRead.ReadMarker.ClientID, balances)
+	rp, err := sc.getReadPool(payerID, balances)
 	if err != nil && err != util.ErrValueNotPresent {
 		return "", common.NewErrorf("commit_blobber_read",
 			"can't get related read pool: %v", err)
 	}
 	if err == util.ErrValueNotPresent || rp == nil {
 		rp = new(readPool)
-		if err = rp.save(sc.ID, commitRead.ReadMarker.ClientID, balances); err != nil {
+		if err = rp.save(sc.ID, payerID, balances); err != nil {
 			return "", common.NewError("new_read_pool_failed", err.Error())
 		}
 	}
@@ -466,15 +490,29 @@ func (sc *StorageSmartContract) commitBlobberRead(t *transaction.Transaction,
 			"can't get related stake pool: %v", err)
 	}
 
+	// charge the sponsored read pool of the allocation if the payer's
+	// own read pool is short of tokens
+	arp, rs, err := sc.sponsoredReadPool(alloc.ID, payerID, rp, value, balances)
+	if err != nil {
+		return "", common.NewErrorf("commit_blobber_read",
+			"can't get sponsored read pool: %v", err)
+	}
+
 	details.Stats.NumReads++
 	alloc.Stats.NumReads++
 
-	resp, err = rp.moveToBlobber(commitRead.ReadMarker.AllocationID,
-		commitRead.ReadMarker.BlobberID, sp, value, balances)
+	if arp != nil {
+		resp, err = arp.moveToBlobber(rs, commitRead.ReadMarker.BlobberID, sp, value, balances)
+	} else {
+		resp, err = rp.moveToBlobber(commitRead.ReadMarker.AllocationID,
+			commitRead.ReadMarker.BlobberID, sp, value, balances)
+	}
 	if err != nil {
 		return "", common.NewErrorf("commit_blobber_read",
 			"can't transfer tokens from read pool to stake pool: %v", err)
 	}
+	emitAllocationCharge(alloc, payerID, commitRead.ReadMarker.BlobberID,
+		event.ChargeRead, value, t.CreationDate, balances)
 	readReward, err := currency.AddCoin(details.ReadReward, value) // stat
 	if err != nil {
 		return "", err
@@ -531,7 +569,14 @@ func (sc *StorageSmartContract) commitBlobberRead(t *transaction.Transaction,
 			"can't save stake pool: %v", err)
 	}
 
-	if err = rp.save(sc.ID, commitRead.ReadMarker.ClientID, balances); err != nil {
+	if arp != nil {
+		if err = arp.save(sc.ID, balances); err == nil {
+			err = rs.save(sc.ID, balances)
+		}
+	} else {
+		err = rp.save(sc.ID, payerID, balances)
+	}
+	if err != nil {
 		return "", common.NewErrorf("commit_blobber_read",
 			"can't Save read pool: %v", err)
 	}
@@ -593,7 +638,7 @@ func (sc *StorageSmartContract) commitMoveTokens(conf *Config, alloc *StorageAll
 			return 0, fmt.Errorf("can't move tokens to challenge pool: %v", err)
 		}
 		logging.Logger.Info("commitMoveTokens", zap.Any("move", move), zap.Any("size", size), zap.Any("rdtu", rdtu), zap.Any("alloc_write_pool", alloc.WritePool))
-		err = alloc.moveToChallengePool(cp, move)
+		paid, err := alloc.moveToChallengePool(cp, move)
 		coin, _ := move.Int64()
 		balances.EmitEvent(event.TypeStats, event.TagToChallengePool, cp.ID, event.ChallengePoolLock{
 			Client:       alloc.Owner,
@@ -603,6 +648,7 @@ func (sc *StorageSmartContract) commitMoveTokens(conf *Config, alloc *StorageAll
 		if err != nil {
 			return 0, fmt.Errorf("can't move tokens to challenge pool: %v", err)
 		}
+		emitWritePoolCharges(alloc, paid, details.BlobberID, event.ChargeWrite, now, balances)
 
 		movedToChallenge, err := currency.AddCoin(alloc.MovedToChallenge, move)
 		if err != nil {
@@ -632,6 +678,7 @@ func (sc *StorageSmartContract) commitMoveTokens(conf *Config, alloc *StorageAll
 		if err != nil {
 			return 0, fmt.Errorf("can't move tokens to write pool: %v", err)
 		}
+		emitAllocationCharge(alloc, alloc.Owner, details.BlobberID, event.ChargeRefund, move, now, balances)
 		movedBack, err := currency.AddCoin(alloc.MovedBack, move)
 		if err != nil {
 			return 0, err
@@ -871,7 +918,8 @@ func (sc *StorageSmartContract) updateBlobberChallengeReady(balances cstate.Stat
 		return fmt.Errorf("unable to total stake pool: %v", err)
 	}
 	weight := uint64(stakedAmount) * blobUsedCapacity
-	if err := partitionsChallengeReadyBlobberAddOrUpdate(balances, blobAlloc.BlobberID, weight); err != nil {
+	if err := partitionsChallengeReadyBlobberAddOrUpdate(balances, blobAlloc.BlobberID, weight,
+		int64(blobUsedCapacity)); err != nil {
 		return fmt.Errorf("could not add blobber to challenge ready partitions: %v", err)
 	}
 	return nil
@@ -907,6 +955,7 @@ func (sc *StorageSmartContract) insertBlobber(t *transaction.Transaction,
 	}
 
 	blobber.LastHealthCheck = t.CreationDate // set to now
+	blobber.Reputation = initialReputation
 
 	// create stake pool
 	var sp *stakePool
@@ -21,6 +21,13 @@ func (z *BlobberAllocationNode) GetID() string {
 	return z.ID
 }
 
+// DegradedAllocationsCursor is the number of the allocations partitions of
+// a killed or shut down blobber left to be marked degraded, they are marked
+// from the last one.
+type DegradedAllocationsCursor struct {
+	PartsLeft int `json:"parts_left"`
+}
+
 func partitionsBlobberAllocations(blobberID string, balances state.StateContextI) (*partitions.Partitions, error) {
 	return partitions.CreateIfNotExists(balances, getBlobberAllocationsKey(blobberID), blobberAllocationPartitionSize)
 }
@@ -57,3 +57,55 @@ func (z BlobberAllocationNode) Msgsize() (s int) {
 	s = 1 + 3 + msgp.StringPrefixSize + len(z.ID)
 	return
 }
+
+// MarshalMsg implements msgp.Marshaler
+func (z DegradedAllocationsCursor) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 1
+	// string "PartsLeft"
+	o = append(o, 0x81, 0xa9, 0x50, 0x61, 0x72, 0x74, 0x73, 0x4c, 0x65, 0x66, 0x74)
+	o = msgp.AppendInt(o, z.PartsLeft)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *DegradedAllocationsCursor) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "PartsLeft":
+			z.PartsLeft, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "PartsLeft")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z DegradedAllocationsCursor) Msgsize() (s int) {
+	s = 1 + 10 + msgp.IntSize
+	return
+}
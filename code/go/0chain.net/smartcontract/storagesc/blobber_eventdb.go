@@ -55,6 +55,7 @@ func emitAddBlobber(sn *StorageNode, sp *stakePool, balances cstate.StateContext
 		Allocated:   sn.Allocated,
 		SavedData:   sn.SavedData,
 		IsAvailable: true,
+		Reputation:  sn.reputation(),
 		Provider: event.Provider{
 			ID:              sn.ID,
 			DelegateWallet:  sn.StakePoolSettings.DelegateWallet,
@@ -75,9 +76,32 @@ func emitAddBlobber(sn *StorageNode, sp *stakePool, balances cstate.StateContext
 	}
 
 	balances.EmitEvent(event.TypeStats, event.TagAddBlobber, sn.ID, data)
+	if len(sn.Tiers) > 0 {
+		emitBlobberTiers(sn, balances)
+	}
 	return nil
 }
 
+// emitBlobberTiers replaces the storage tiers of the blobber in the event db
+func emitBlobberTiers(sn *StorageNode, balances cstate.StateContextI) {
+	tiers := make([]event.BlobberTier, 0, len(sn.Tiers))
+	for _, t := range sn.Tiers {
+		tiers = append(tiers, event.BlobberTier{
+			BlobberID:                  sn.ID,
+			Name:                       t.Name,
+			ReadPrice:                  t.Terms.ReadPrice,
+			WritePrice:                 t.Terms.WritePrice,
+			MinLockDemand:              t.Terms.MinLockDemand,
+			LatencyClass:               t.LatencyClass,
+			MaxChallengeCompletionTime: t.MaxChallengeCompletionTime,
+		})
+	}
+	balances.EmitEvent(event.TypeStats, event.TagSetBlobberTiers, sn.ID, event.BlobberTiers{
+		BlobberID: sn.ID,
+		Tiers:     tiers,
+	})
+}
+
 func emitUpdateBlobberAllocatedSavedHealth(sn *StorageNode, balances cstate.StateContextI) {
 	balances.EmitEvent(event.TypeStats, event.TagUpdateBlobberAllocatedSavedHealth, sn.ID, event.Blobber{
 		Provider: event.Provider{
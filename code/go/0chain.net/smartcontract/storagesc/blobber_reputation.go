@@ -0,0 +1,99 @@
+package storagesc
+
+import (
+	"fmt"
+	"sort"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/smartcontract/dbs/event"
+)
+
+const (
+	// initialReputation is the reputation of a newly added blobber, it
+	// loses it failing challenges.
+	initialReputation = 1.0
+	// reputationWeight is the weight of the latest challenge outcome in the
+	// rolling reputation, the older outcomes decay by 1 - reputationWeight
+	// on each challenge.
+	reputationWeight = 0.05
+	// reputationPrecision is the precision of the reputation, it's rounded
+	// up to the initial one closer than that to it and it's kept at least
+	// that above zero.
+	reputationPrecision = 1e-3
+)
+
+// reputation returns the reputation of the blobber. The blobbers added
+// before the reputation have none saved, they have the initial one.
+func (sn *StorageNode) reputation() float64 {
+	if sn.Reputation == 0 {
+		return initialReputation
+	}
+	return sn.Reputation
+}
+
+// updateReputation updates the rolling reputation of the blobber with the
+// outcome of a completed challenge, it returns true if the reputation has
+// changed. The reputation is an exponential moving average of the
+// challenges passed in (0, 1], so the blobbers passing all their
+// challenges keep the initial one.
+func (sn *StorageNode) updateReputation(passed bool) bool {
+	var outcome float64
+	if passed {
+		outcome = 1
+	}
+
+	prev := sn.reputation()
+	next := (1-reputationWeight)*prev + reputationWeight*outcome
+	switch {
+	case initialReputation-next < reputationPrecision:
+		next = initialReputation
+	case next < reputationPrecision:
+		next = reputationPrecision
+	}
+	if next == prev {
+		return false
+	}
+	sn.Reputation = next
+	return true
+}
+
+// updateBlobbersReputation records the failed challenges of the blobbers,
+// given as the number of failures by blobber ID, e.g. the expired ones.
+func (sc *StorageSmartContract) updateBlobbersReputation(
+	failed map[string]int,
+	balances cstate.StateContextI,
+) error {
+	ids := make([]string, 0, len(failed))
+	for id := range failed {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		blobber, err := sc.getBlobber(id, balances)
+		if err != nil {
+			return fmt.Errorf("can't get blobber %s: %v", id, err)
+		}
+		var changed bool
+		for i := 0; i < failed[id]; i++ {
+			if blobber.updateReputation(false) {
+				changed = true
+			}
+		}
+		if !changed {
+			continue
+		}
+		if _, err := balances.InsertTrieNode(blobber.GetKey(), blobber); err != nil {
+			return fmt.Errorf("saving blobber %s: %v", id, err)
+		}
+		emitUpdateBlobberReputation(blobber, balances)
+	}
+	return nil
+}
+
+func emitUpdateBlobberReputation(sn *StorageNode, balances cstate.StateContextI) {
+	balances.EmitEvent(event.TypeStats, event.TagUpdateBlobberReputation, sn.ID, event.Blobber{
+		Provider:   event.Provider{ID: sn.ID},
+		Reputation: sn.reputation(),
+	})
+}
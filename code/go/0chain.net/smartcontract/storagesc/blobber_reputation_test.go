@@ -0,0 +1,104 @@
+package storagesc
+
+import (
+	"testing"
+	"time"
+
+	"0chain.net/core/common"
+	"0chain.net/smartcontract/provider"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageNode_updateReputation(t *testing.T) {
+	sn := &StorageNode{Reputation: initialReputation}
+
+	assert.False(t, sn.updateReputation(true))
+	assert.Equal(t, 1.0, sn.Reputation)
+
+	assert.True(t, sn.updateReputation(false))
+	assert.InDelta(t, 0.95, sn.Reputation, 1e-9)
+	assert.True(t, sn.updateReputation(false))
+	assert.InDelta(t, 0.9025, sn.Reputation, 1e-9)
+
+	assert.True(t, sn.updateReputation(true))
+	assert.InDelta(t, 0.907375, sn.Reputation, 1e-9)
+
+	// it's rounded up to the initial reputation close to it
+	for sn.updateReputation(true) {
+	}
+	assert.Equal(t, initialReputation, sn.Reputation)
+
+	// and kept above zero
+	for sn.updateReputation(false) {
+	}
+	assert.Equal(t, reputationPrecision, sn.Reputation)
+}
+
+func TestStorageNode_reputation(t *testing.T) {
+	// the blobbers added before the reputation have the initial one
+	sn := &StorageNode{}
+	assert.Equal(t, initialReputation, sn.reputation())
+	assert.False(t, sn.updateReputation(true))
+	assert.Equal(t, initialReputation, sn.reputation())
+
+	assert.True(t, sn.updateReputation(false))
+	assert.InDelta(t, 0.95, sn.reputation(), 1e-9)
+}
+
+func TestStorageSmartContract_updateBlobbersReputation(t *testing.T) {
+	var (
+		ssc      = newTestStorageSC()
+		balances = newTestBalances(t, false)
+	)
+
+	for _, id := range []string{"b1", "b2"} {
+		b := &StorageNode{
+			Provider:   provider.Provider{ID: id, ProviderType: spenum.Blobber},
+			Reputation: initialReputation,
+		}
+		_, err := balances.InsertTrieNode(b.GetKey(), b)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, ssc.updateBlobbersReputation(map[string]int{"b1": 2}, balances))
+
+	b1, err := ssc.getBlobber("b1", balances)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.9025, b1.Reputation, 1e-9)
+	b2, err := ssc.getBlobber("b2", balances)
+	require.NoError(t, err)
+	assert.Equal(t, initialReputation, b2.Reputation)
+
+	requireErrMsg(t, ssc.updateBlobbersReputation(map[string]int{"b3": 1}, balances),
+		"can't get blobber b3: value not present")
+}
+
+func TestStorageAllocation_filterBlobbersMinReputation(t *testing.T) {
+	var (
+		now      = common.Timestamp(100)
+		blobbers = []*StorageNode{
+			{Provider: provider.Provider{ID: "b1"}, Capacity: 10 * GB, Reputation: 0.95},
+			{Provider: provider.Provider{ID: "b2"}, Capacity: 10 * GB, Reputation: 0.5},
+		}
+		alloc = &StorageAllocation{
+			ID:              "alloc",
+			Size:            GB,
+			DataShards:      1,
+			Expiration:      now + 1000,
+			WritePriceRange: PriceRange{Min: 0, Max: 50},
+			TimeUnit:        time.Minute,
+			MinReputation:   0.9,
+		}
+	)
+
+	filtered, err := alloc.filterBlobbers(blobbers, now, alloc.bSize())
+	require.NoError(t, err)
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "b1", filtered[0].ID)
+
+	nar := newAllocationRequest{DataShards: 1, Blobbers: []string{"b1"}, MinReputation: 1.5}
+	requireErrMsg(t, nar.validate(common.ToTime(now), &Config{}),
+		"invalid min_reputation, should be in range [0, 1]")
+}
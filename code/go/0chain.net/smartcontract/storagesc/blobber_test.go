@@ -72,6 +72,25 @@ func TestStorageSmartContract_addBlobber(t *testing.T) {
 	_, err = updateBlobber(t, b2, 0, tp, ssc, balances)
 	require.Error(t, err)
 
+	// the geolocation is kept while the blobber has allocations
+	london := StorageNodeGeolocation{Latitude: 51.5074, Longitude: -0.1278}
+	ab.Allocated = GB
+	ab.Geolocation = london
+	_, err = balances.InsertTrieNode(ab.GetKey(), ab)
+	require.NoError(t, err)
+
+	b.Geolocation = StorageNodeGeolocation{}
+	tp += 100
+	_, err = updateBlobber(t, b, 0, tp, ssc, balances)
+	require.NoError(t, err)
+	ab, err = ssc.getBlobber(b.ID, balances)
+	require.NoError(t, err)
+	require.Equal(t, london, ab.Geolocation)
+
+	b.Geolocation = StorageNodeGeolocation{Latitude: 48.8566, Longitude: 2.3522}
+	tp += 100
+	_, err = updateBlobber(t, b, 0, tp, ssc, balances)
+	require.ErrorContains(t, err, "geolocation can't be changed while it has allocations")
 }
 
 func TestStorageSmartContract_addBlobber_preventDuplicates(t *testing.T) {
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"math/rand"
 	"sort"
 	"strconv"
@@ -84,7 +85,7 @@ func (sc *StorageSmartContract) blobberReward(alloc *StorageAllocation, latestCo
 
 	// time of this challenge
 	challengeCompletedTime := blobAlloc.LatestCompletedChallenge.Created
-	if challengeCompletedTime > alloc.Expiration+toSeconds(getMaxChallengeCompletionTime()) {
+	if challengeCompletedTime > alloc.Expiration+toSeconds(blobAlloc.challengeCompletionTime()) {
 		return errors.New("late challenge response")
 	}
 
@@ -153,6 +154,8 @@ func (sc *StorageSmartContract) blobberReward(alloc *StorageAllocation, latestCo
 		if err != nil {
 			return fmt.Errorf("moving partial challenge to write pool: %v", err)
 		}
+		emitAllocationCharge(alloc, alloc.Owner, blobAlloc.BlobberID,
+			event.ChargeRefund, back, challengeCompletedTime, balances)
 		newMoved, err := currency.AddCoin(alloc.MovedBack, back)
 		if err != nil {
 			return err
@@ -188,6 +191,8 @@ func (sc *StorageSmartContract) blobberReward(alloc *StorageAllocation, latestCo
 	if err != nil {
 		return fmt.Errorf("rewarding blobbers: %v", err)
 	}
+	emitAllocationCharge(alloc, "", blobAlloc.BlobberID,
+		event.ChargeChallengeReward, blobberReward, challengeCompletedTime, balances)
 
 	newChallengeReward, err := currency.AddCoin(blobAlloc.ChallengeReward, blobberReward)
 	if err != nil {
@@ -205,6 +210,8 @@ func (sc *StorageSmartContract) blobberReward(alloc *StorageAllocation, latestCo
 	if err != nil {
 		return fmt.Errorf("rewarding validators: %v", err)
 	}
+	emitAllocationCharge(alloc, "", blobAlloc.BlobberID,
+		event.ChargeValidatorReward, validatorsReward, challengeCompletedTime, balances)
 
 	moveToValidators, err := currency.AddCoin(alloc.MovedToValidators, validatorsReward)
 	if err != nil {
@@ -293,7 +300,7 @@ func (sc *StorageSmartContract) blobberPenalty(alloc *StorageAllocation, prev co
 
 	// time of this challenge
 	challengeCompleteTime := blobAlloc.LatestCompletedChallenge.Created
-	if challengeCompleteTime > alloc.Expiration+toSeconds(getMaxChallengeCompletionTime()) {
+	if challengeCompleteTime > alloc.Expiration+toSeconds(blobAlloc.challengeCompletionTime()) {
 		return errors.New("late challenge response")
 	}
 
@@ -356,6 +363,8 @@ func (sc *StorageSmartContract) blobberPenalty(alloc *StorageAllocation, prev co
 	if err != nil {
 		return fmt.Errorf("rewarding validators: %v", err)
 	}
+	emitAllocationCharge(alloc, "", blobAlloc.BlobberID,
+		event.ChargeValidatorReward, validatorsReward, challengeCompleteTime, balances)
 
 	moveToValidators, err := currency.AddCoin(alloc.MovedToValidators, validatorsReward)
 	if err != nil {
@@ -379,6 +388,8 @@ func (sc *StorageSmartContract) blobberPenalty(alloc *StorageAllocation, prev co
 	if err != nil {
 		return fmt.Errorf("moving challenge pool rest back to write pool: %v", err)
 	}
+	emitAllocationCharge(alloc, alloc.Owner, blobAlloc.BlobberID,
+		event.ChargeRefund, move, challengeCompleteTime, balances)
 
 	moveBack, err := currency.AddCoin(alloc.MovedBack, move)
 	if err != nil {
@@ -528,6 +539,12 @@ func (sc *StorageSmartContract) verifyChallenge(t *transaction.Transaction,
 		latestCompletedChallTime = lcc.Created
 	}
 
+	// the storage tier of the blobber can shorten the completion time
+	if blobAlloc.MaxChallengeCompletionTime > 0 &&
+		isChallengeExpired(t.CreationDate, challenge.Created, blobAlloc.MaxChallengeCompletionTime) {
+		result.fresh = false
+	}
+
 	challenge.Responded = 1
 	cab := &challengeAllocBlobberPassResult{
 		verifyTicketsResult:      result,
@@ -645,6 +662,10 @@ func (sc *StorageSmartContract) challengePassed(
 			"can't get blobber"+err.Error())
 	}
 
+	// the blobber is saved only if its reward round or reputation changes
+	reputationChanged := blobber.updateReputation(true)
+	saveBlobber := reputationChanged
+
 	rewardRound := GetCurrentRewardRound(balances.GetBlock().Round, triggerPeriod)
 	// this expiry of blobber needs to be corrected once logic is finalized
 	if blobber.RewardRound.StartRound != rewardRound ||
@@ -674,13 +695,19 @@ func (sc *StorageSmartContract) challengePassed(
 			StartRound: rewardRound,
 			Timestamp:  t.CreationDate,
 		}
+		saveBlobber = true
+	}
 
+	if saveBlobber {
 		_, err = balances.InsertTrieNode(blobber.GetKey(), blobber)
 		if err != nil {
 			return "", common.NewError("verify_challenge",
 				"error inserting blobber to chain"+err.Error())
 		}
 	}
+	if reputationChanged {
+		emitUpdateBlobberReputation(blobber, balances)
+	}
 
 	var brStats BlobberRewardNode
 	if err := ongoingParts.Get(balances, blobber.ID, &brStats); err != nil {
@@ -770,6 +797,10 @@ func (sc *StorageSmartContract) challengeFailed(
 		return "", common.NewError("challenge_penalty_error", err.Error())
 	}
 
+	if err := sc.updateBlobbersReputation(map[string]int{cab.challenge.BlobberID: 1}, balances); err != nil {
+		return "", common.NewError("challenge_penalty_error", err.Error())
+	}
+
 	logging.Logger.Info("Challenge failed", zap.String("challenge", cab.challenge.ID))
 
 	err := sc.blobberPenalty(cab.alloc, cab.latestCompletedChallTime, cab.blobAlloc,
@@ -834,16 +865,26 @@ type challengeOutput struct {
 
 type challengeBlobberSelection int
 
-// randomWeightSelection select n blobbers from blobberChallenge partition and then select a blobber with the highest weight
 // randomSelection select a blobber randomly from partition
+// randomWeightSelection select n blobbers from blobberChallenge partition and then select a blobber with the highest weight
+// savedDataRiskSelection select a blobber with chance proportional to its saved data and failure rate
 const (
-	randomWeightSelection challengeBlobberSelection = iota
-	randomSelection
+	randomSelection challengeBlobberSelection = iota
+	randomWeightSelection
+	savedDataRiskSelection
 )
 
+func (s challengeBlobberSelection) isValid() bool {
+	return s >= randomSelection && s <= savedDataRiskSelection
+}
+
 // selectBlobberForChallenge select blobber for challenge in random manner
 func selectBlobberForChallenge(selection challengeBlobberSelection, challengeBlobbersPartition *partitions.Partitions,
-	r *rand.Rand, balances cstate.StateContextI) (string, error) {
+	r *rand.Rand, riskWeight float64, balances cstate.StateContextI) (string, error) {
+
+	if selection == savedDataRiskSelection {
+		return selectBlobberBySavedDataRisk(challengeBlobbersPartition, r, riskWeight, balances)
+	}
 
 	var challengeBlobbers []ChallengeReadyBlobber
 	err := challengeBlobbersPartition.GetRandomItems(balances, r, &challengeBlobbers)
@@ -884,6 +925,58 @@ func selectBlobberForChallenge(selection challengeBlobberSelection, challengeBlo
 	}
 }
 
+// maxSavedDataRiskSelectionTries bounds the partitions and blobbers the saved
+// data risk selection reads for one challenge
+const maxSavedDataRiskSelectionTries = 50
+
+// selectBlobberBySavedDataRisk selects a challenge ready blobber with the
+// chance proportional to its saved data raised by its failure rate, i.e.
+// saved_data * (1 + risk_weight * (1 - reputation)), among all the challenge
+// ready blobbers. It samples a random blobber and accepts it with the chance
+// of its weight to the max weight, so only the sampled partitions and the
+// blobbers passing the saved data step are read. If no blobber is accepted
+// in maxSavedDataRiskSelectionTries the sampled one with the most saved data
+// is returned. The random source is seeded by the challenge so all miners
+// agree.
+func selectBlobberBySavedDataRisk(challengeBlobbersPartition *partitions.Partitions, r *rand.Rand,
+	riskWeight float64, balances cstate.StateContextI) (string, error) {
+	maxSaved, err := getChallengeReadyMaxSavedData(balances)
+	if err != nil {
+		return "", fmt.Errorf("could not get challenge ready max saved data: %v", err)
+	}
+
+	var fallback *ChallengeReadyBlobber
+	for i := 0; i < maxSavedDataRiskSelectionTries; i++ {
+		var challengeBlobbers []ChallengeReadyBlobber
+		err := challengeBlobbersPartition.GetRandomItems(balances, r, &challengeBlobbers)
+		if err != nil {
+			return "", fmt.Errorf("error getting random slice from blobber challenge partition: %v", err)
+		}
+
+		bc := challengeBlobbers[r.Intn(len(challengeBlobbers))]
+		if fallback == nil || bc.SavedData > fallback.SavedData {
+			fallback = &bc
+		}
+		if maxSaved <= 0 {
+			break
+		}
+		if r.Float64()*float64(maxSaved) >= float64(bc.SavedData) {
+			continue
+		}
+
+		b, err := getBlobber(bc.BlobberID, balances)
+		if err != nil {
+			return "", fmt.Errorf("could not get blobber %s: %v", bc.BlobberID, err)
+		}
+		failureRate := math.Max(0, 1-b.reputation())
+		if r.Float64()*(1+riskWeight) < 1+riskWeight*failureRate {
+			return bc.BlobberID, nil
+		}
+	}
+
+	return fallback.BlobberID, nil
+}
+
 func (sc *StorageSmartContract) populateGenerateChallenge(
 	challengeBlobbersPartition *partitions.Partitions,
 	seed int64,
@@ -895,8 +988,9 @@ func (sc *StorageSmartContract) populateGenerateChallenge(
 	conf *Config,
 ) (*challengeOutput, error) {
 	r := rand.New(rand.NewSource(seed))
-	blobberSelection := challengeBlobberSelection(1) // challengeBlobberSelection(r.Intn(2))
-	blobberID, err := selectBlobberForChallenge(blobberSelection, challengeBlobbersPartition, r, balances)
+	blobberSelection := challengeBlobberSelection(conf.ChallengeBlobberSelection)
+	blobberID, err := selectBlobberForChallenge(blobberSelection, challengeBlobbersPartition, r,
+		conf.ChallengeRiskWeight, balances)
 	if err != nil {
 		return nil, common.NewError("add_challenge", err.Error())
 	}
@@ -1264,6 +1358,11 @@ func (sc *StorageSmartContract) addChallenge(alloc *StorageAllocation,
 		expiredCountMap[blobberID]++
 	}
 
+	// the expired challenges count as failed for the blobbers reputation
+	if err := sc.updateBlobbersReputation(expiredCountMap, balances); err != nil {
+		return common.NewErrorf("add_challenge", "update blobbers reputation: %v", err)
+	}
+
 	// add the generated challenge to the open challenges list in the allocation
 	if !allocChallenges.addChallenge(challenge) {
 		return common.NewError("add_challenge", "challenge already exist in allocation")
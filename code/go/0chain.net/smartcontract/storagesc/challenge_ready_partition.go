@@ -5,6 +5,7 @@ import (
 
 	"0chain.net/chaincore/chain/state"
 	"0chain.net/smartcontract/partitions"
+	"github.com/0chain/common/core/util"
 )
 
 const allChallengeReadyBlobbersPartitionSize = 50
@@ -22,19 +23,21 @@ func partitionsChallengeReadyBlobbers(balances state.StateContextI) (*partitions
 type ChallengeReadyBlobber struct {
 	BlobberID string `json:"blobber_id"`
 	Weight    uint64 `json:"weight"`
+	SavedData int64  `json:"saved_data"`
 }
 
 func (bc *ChallengeReadyBlobber) GetID() string {
 	return bc.BlobberID
 }
 
-func partitionsChallengeReadyBlobberAddOrUpdate(state state.StateContextI, blobberID string, weight uint64) error {
+func partitionsChallengeReadyBlobberAddOrUpdate(state state.StateContextI, blobberID string, weight uint64,
+	savedData int64) error {
 	parts, err := partitionsChallengeReadyBlobbers(state)
 	if err != nil {
 		return fmt.Errorf("could not get challenge ready partitions, %v", err)
 	}
 
-	crb := &ChallengeReadyBlobber{BlobberID: blobberID, Weight: weight}
+	crb := &ChallengeReadyBlobber{BlobberID: blobberID, Weight: weight, SavedData: savedData}
 	if err := parts.Add(state, crb); err != nil {
 		if !partitions.ErrItemExist(err) {
 			return err
@@ -50,6 +53,42 @@ func partitionsChallengeReadyBlobberAddOrUpdate(state state.StateContextI, blobb
 		return fmt.Errorf("could not add or update challenge ready partitions: %v", err)
 	}
 
+	return updateChallengeReadyMaxSavedData(state, savedData)
+}
+
+// challengeReadyMaxSavedData is the largest saved data a challenge ready
+// blobber ever had, it bounds the saved data risk selection. It never
+// decreases, a stale maximum only makes the selection do more tries.
+type challengeReadyMaxSavedData struct {
+	SavedData int64 `json:"saved_data"`
+}
+
+func getChallengeReadyMaxSavedData(state state.CommonStateContextI) (int64, error) {
+	var m challengeReadyMaxSavedData
+	err := state.GetTrieNode(CHALLENGE_READY_MAX_SAVED_KEY, &m)
+	switch err {
+	case nil:
+		return m.SavedData, nil
+	case util.ErrValueNotPresent:
+		return 0, nil
+	default:
+		return 0, err
+	}
+}
+
+func updateChallengeReadyMaxSavedData(state state.StateContextI, savedData int64) error {
+	maxSaved, err := getChallengeReadyMaxSavedData(state)
+	if err != nil {
+		return fmt.Errorf("could not get challenge ready max saved data: %v", err)
+	}
+	if savedData <= maxSaved {
+		return nil
+	}
+
+	_, err = state.InsertTrieNode(CHALLENGE_READY_MAX_SAVED_KEY, &challengeReadyMaxSavedData{SavedData: savedData})
+	if err != nil {
+		return fmt.Errorf("could not save challenge ready max saved data: %v", err)
+	}
 	return nil
 }
 
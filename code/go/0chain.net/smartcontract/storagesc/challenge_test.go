@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"math/rand"
 	"strconv"
 	"strings"
 	"testing"
@@ -12,6 +13,7 @@ import (
 	"0chain.net/chaincore/block"
 	"0chain.net/chaincore/config"
 	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/provider"
 	"0chain.net/smartcontract/stakepool/spenum"
 
 	"github.com/0chain/common/core/currency"
@@ -1288,3 +1290,79 @@ func confirmBlobberReward(
 		}
 	}
 }
+
+func TestSelectBlobberBySavedDataRisk(t *testing.T) {
+	balances := newTestBalances(t, false)
+	// small partitions, so the blobbers are selected across them
+	parts, err := partitions.CreateIfNotExists(balances, "challenge_ready_test", 2)
+	require.NoError(t, err)
+	for _, b := range []*StorageNode{
+		{Provider: provider.Provider{ID: "empty", ProviderType: spenum.Blobber}, Reputation: 1},
+		{Provider: provider.Provider{ID: "small", ProviderType: spenum.Blobber}, SavedData: GB, Reputation: 1},
+		{Provider: provider.Provider{ID: "large", ProviderType: spenum.Blobber}, SavedData: 8 * GB, Reputation: 1},
+		{Provider: provider.Provider{ID: "risky", ProviderType: spenum.Blobber}, SavedData: GB, Reputation: 0.5},
+	} {
+		_, err := balances.InsertTrieNode(b.GetKey(), b)
+		require.NoError(t, err)
+		require.NoError(t, parts.Add(balances, &ChallengeReadyBlobber{BlobberID: b.ID, SavedData: b.SavedData}))
+		require.NoError(t, updateChallengeReadyMaxSavedData(balances, b.SavedData))
+	}
+	require.NoError(t, parts.Save(balances))
+
+	// weights 0, 1, 8 and 1 * (1 + 4 * 0.5) = 3 GB
+	counts := make(map[string]int)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 12000; i++ {
+		id, err := selectBlobberBySavedDataRisk(parts, r, 4, balances)
+		require.NoError(t, err)
+		counts[id]++
+	}
+	require.Zero(t, counts["empty"])
+	require.InDelta(t, 1000, counts["small"], 150)
+	require.InDelta(t, 8000, counts["large"], 300)
+	require.InDelta(t, 3000, counts["risky"], 250)
+
+	// all miners select the same blobber for the same seed
+	first, err := selectBlobberBySavedDataRisk(parts, rand.New(rand.NewSource(42)), 4, balances)
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		id, err := selectBlobberBySavedDataRisk(parts, rand.New(rand.NewSource(42)), 4, balances)
+		require.NoError(t, err)
+		require.Equal(t, first, id)
+	}
+
+	unknown, err := partitions.CreateIfNotExists(balances, "challenge_ready_unknown_test", 2)
+	require.NoError(t, err)
+	require.NoError(t, unknown.Add(balances, &ChallengeReadyBlobber{BlobberID: "unknown", SavedData: 8 * GB}))
+	_, err = selectBlobberBySavedDataRisk(unknown, r, 4, balances)
+	require.EqualError(t, err, "could not get blobber unknown: value not present")
+}
+
+func BenchmarkSelectBlobberForChallenge(b *testing.B) {
+	balances := newTestBalances(b, false)
+	for i := 0; i < 1000; i++ {
+		blobber := &StorageNode{
+			Provider:   provider.Provider{ID: fmt.Sprintf("blobber_%d", i), ProviderType: spenum.Blobber},
+			SavedData:  int64(i%10+1) * GB,
+			Reputation: float64(i%5+1) / 5,
+		}
+		_, err := balances.InsertTrieNode(blobber.GetKey(), blobber)
+		require.NoError(b, err)
+		require.NoError(b, partitionsChallengeReadyBlobberAddOrUpdate(balances, blobber.ID,
+			uint64(blobber.SavedData), blobber.SavedData))
+	}
+
+	for _, selection := range []challengeBlobberSelection{
+		randomSelection, randomWeightSelection, savedDataRiskSelection,
+	} {
+		b.Run(fmt.Sprintf("selection_%d", selection), func(b *testing.B) {
+			r := rand.New(rand.NewSource(1))
+			for i := 0; i < b.N; i++ {
+				parts, err := partitionsChallengeReadyBlobbers(balances)
+				require.NoError(b, err)
+				_, err = selectBlobberForChallenge(selection, parts, r, 4, balances)
+				require.NoError(b, err)
+			}
+		})
+	}
+}
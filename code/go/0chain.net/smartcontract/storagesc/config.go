@@ -30,8 +30,9 @@ type freeAllocationSettings struct {
 }
 
 type stakePoolConfig struct {
-	MinLockPeriod time.Duration `json:"min_lock_period"`
-	KillSlash     float64       `json:"kill_slash"`
+	MinLockPeriod   time.Duration `json:"min_lock_period"`
+	KillSlash       float64       `json:"kill_slash"`
+	UnbondingRounds int64         `json:"unbonding_rounds"` // rounds before unlocked stake can be withdrawn
 }
 
 type readPoolConfig struct {
@@ -42,6 +43,18 @@ type writePoolConfig struct {
 	MinLock currency.Coin `json:"min_lock"`
 }
 
+type autoRenewalConfig struct {
+	// Period the auto-renew allocations are extended by, zero disables
+	// the auto-renewal.
+	Period time.Duration `json:"period"`
+	// Window before the expiration the auto-renew allocations are extended
+	// in, it must be less than the period to extend them once per period.
+	Window time.Duration `json:"window"`
+	// TriggerPeriod is the number of rounds the renew_allocations
+	// transaction is generated each.
+	TriggerPeriod int64 `json:"trigger_period"`
+}
+
 type blockReward struct {
 	BlockReward             currency.Coin    `json:"block_reward"`
 	BlockRewardChangePeriod int64            `json:"block_reward_change_period"`
@@ -133,6 +146,12 @@ type Config struct {
 	// ValidatorsPerChallenge is the number of validators to select per
 	// challenges.
 	ValidatorsPerChallenge int `json:"validators_per_challenge"`
+	// ChallengeBlobberSelection is the mode selecting the blobber to
+	// challenge, see challengeBlobberSelection.
+	ChallengeBlobberSelection int `json:"challenge_blobber_selection"`
+	// ChallengeRiskWeight is how much the failure rate of a blobber raises
+	// its chance to be challenged in the saved data and risk selection.
+	ChallengeRiskWeight float64 `json:"challenge_risk_weight"`
 
 	// MinStake allowed by a blobber/validator (entire SC boundary).
 	MinStake currency.Coin `json:"min_stake"`
@@ -141,6 +160,11 @@ type Config struct {
 
 	// MaxDelegates per stake pool
 	MaxDelegates int `json:"max_delegates"`
+	// OwnershipTransferTimeout is the time the new owner of an allocation
+	// has to accept the ownership transfer, zero disables the transfers.
+	OwnershipTransferTimeout time.Duration `json:"ownership_transfer_timeout"`
+	// AutoRenewal related configurations.
+	AutoRenewal autoRenewalConfig `json:"auto_renewal"`
 
 	// MaxCharge that blobber gets from rewards to its delegate_wallet.
 	MaxCharge float64 `json:"max_charge"`
@@ -193,6 +217,9 @@ func (conf *Config) validate() (err error) {
 	if conf.StakePool.KillSlash < 0 || conf.StakePool.KillSlash > 1 {
 		return fmt.Errorf("stakepool.kill_slash, %v must be in interval [0.1]", conf.StakePool.KillSlash)
 	}
+	if conf.StakePool.UnbondingRounds < 0 {
+		return fmt.Errorf("negative stakepool.unbonding_rounds: %v", conf.StakePool.UnbondingRounds)
+	}
 
 	if conf.FreeAllocationSettings.DataShards < 0 {
 		return fmt.Errorf("negative free_allocation_settings.data_shards: %v",
@@ -222,6 +249,14 @@ func (conf *Config) validate() (err error) {
 		return fmt.Errorf("invalid validators_per_challenge <= 0: %v",
 			conf.ValidatorsPerChallenge)
 	}
+	if !challengeBlobberSelection(conf.ChallengeBlobberSelection).isValid() {
+		return fmt.Errorf("invalid challenge_blobber_selection: %v",
+			conf.ChallengeBlobberSelection)
+	}
+	if conf.ChallengeRiskWeight < 0 {
+		return fmt.Errorf("negative challenge_risk_weight: %v",
+			conf.ChallengeRiskWeight)
+	}
 	if conf.MaxStake < conf.MinStake {
 		return fmt.Errorf("max_stake less than min_stake: %v < %v", conf.MinStake,
 			conf.MaxStake)
@@ -229,6 +264,22 @@ func (conf *Config) validate() (err error) {
 	if conf.MaxDelegates < 1 {
 		return fmt.Errorf("max_delegates is too small %v", conf.MaxDelegates)
 	}
+	if conf.OwnershipTransferTimeout < 0 {
+		return fmt.Errorf("negative ownership_transfer_timeout: %v",
+			conf.OwnershipTransferTimeout)
+	}
+	if conf.AutoRenewal.Period < 0 {
+		return fmt.Errorf("negative auto_renewal.period: %v",
+			conf.AutoRenewal.Period)
+	}
+	if conf.AutoRenewal.Window < 0 {
+		return fmt.Errorf("negative auto_renewal.window: %v",
+			conf.AutoRenewal.Window)
+	}
+	if conf.AutoRenewal.Period > 0 && conf.AutoRenewal.Period <= conf.AutoRenewal.Window {
+		return fmt.Errorf("auto_renewal.period is not greater than auto_renewal.window: %v <= %v",
+			conf.AutoRenewal.Period, conf.AutoRenewal.Window)
+	}
 	if conf.MaxCharge < 0 {
 		return fmt.Errorf("negative max_charge: %v", conf.MaxCharge)
 	}
@@ -377,6 +428,7 @@ func getConfiguredConfig() (conf *Config, err error) {
 	conf.StakePool = new(stakePoolConfig)
 	conf.StakePool.MinLockPeriod = scc.GetDuration(pfx + "stakepool.min_lock_period")
 	conf.StakePool.KillSlash = scc.GetFloat64(pfx + "stakepool.kill_slash")
+	conf.StakePool.UnbondingRounds = scc.GetInt64(pfx + "stakepool.unbonding_rounds")
 
 	conf.MaxTotalFreeAllocation, err = currency.MultFloat64(1e10, scc.GetFloat64(pfx+"max_total_free_allocation"))
 	if err != nil {
@@ -428,8 +480,15 @@ func getConfiguredConfig() (conf *Config, err error) {
 	conf.ChallengeEnabled = scc.GetBool(pfx + "challenge_enabled")
 	conf.ValidatorsPerChallenge = scc.GetInt(
 		pfx + "validators_per_challenge")
+	conf.ChallengeBlobberSelection = scc.GetInt(
+		pfx + "challenge_blobber_selection")
+	conf.ChallengeRiskWeight = scc.GetFloat64(pfx + "challenge_risk_weight")
 
 	conf.MaxDelegates = scc.GetInt(pfx + "max_delegates")
+	conf.OwnershipTransferTimeout = scc.GetDuration(pfx + "ownership_transfer_timeout")
+	conf.AutoRenewal.Period = scc.GetDuration(pfx + "auto_renewal.period")
+	conf.AutoRenewal.Window = scc.GetDuration(pfx + "auto_renewal.window")
+	conf.AutoRenewal.TriggerPeriod = scc.GetInt64(pfx + "auto_renewal.trigger_period")
 	conf.MaxCharge = scc.GetFloat64(pfx + "max_charge")
 
 	conf.BlockReward = new(blockReward)
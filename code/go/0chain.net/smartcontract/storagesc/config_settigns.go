@@ -44,6 +44,7 @@ const (
 
 	StakePoolMinLockPeriod
 	StakePoolKillSlash
+	StakePoolUnbondingRounds
 	MaxTotalFreeAllocation
 	MaxIndividualFreeAllocation
 	CancellationCharge
@@ -67,7 +68,12 @@ const (
 	MinWritePrice
 	ChallengeEnabled
 	ValidatorsPerChallenge
+	ChallengeBlobberSelection
+	ChallengeRiskWeight
 	MaxDelegates
+	OwnershipTransferTimeout
+	AutoRenewalPeriod
+	AutoRenewalWindow
 
 	BlockRewardBlockReward
 	BlockRewardQualifyingStake
@@ -85,6 +91,12 @@ const (
 	CostCommitConnection
 	CostNewAllocationRequest
 	CostUpdateAllocationRequest
+	CostReplaceDegradedBlobber
+	CostMarkDegradedAllocations
+	CostTransferAllocationOwnership
+	CostAcceptAllocationOwnership
+	CostRenewAllocations
+	CostUpdateAllocationACL
 	CostFinalizeAllocation
 	CostCancelAllocation
 	CostAddFreeStorageAssigner
@@ -109,6 +121,9 @@ const (
 	CostStakePoolPayInterests
 	CostCommitSettingsChanges
 	CostCollectReward
+	CostStakePoolAutoCompound
+	CostStakePoolRedelegate
+	CostWithdrawUnbonded
 	CostKillBlobber
 	CostKillValidator
 	CostShutdownBlobber
@@ -148,6 +163,7 @@ func initSettingName() {
 	SettingName[WritePoolMinLock] = "writepool.min_lock"
 	SettingName[StakePoolKillSlash] = "stakepool.kill_slash"
 	SettingName[StakePoolMinLockPeriod] = "stakepool.min_lock_period"
+	SettingName[StakePoolUnbondingRounds] = "stakepool.unbonding_rounds"
 	SettingName[MaxTotalFreeAllocation] = "max_total_free_allocation"
 	SettingName[MaxIndividualFreeAllocation] = "max_individual_free_allocation"
 	SettingName[CancellationCharge] = "cancellation_charge"
@@ -168,7 +184,12 @@ func initSettingName() {
 	SettingName[MinWritePrice] = "min_write_price"
 	SettingName[ChallengeEnabled] = "challenge_enabled"
 	SettingName[ValidatorsPerChallenge] = "validators_per_challenge"
+	SettingName[ChallengeBlobberSelection] = "challenge_blobber_selection"
+	SettingName[ChallengeRiskWeight] = "challenge_risk_weight"
 	SettingName[MaxDelegates] = "max_delegates"
+	SettingName[OwnershipTransferTimeout] = "ownership_transfer_timeout"
+	SettingName[AutoRenewalPeriod] = "auto_renewal.period"
+	SettingName[AutoRenewalWindow] = "auto_renewal.window"
 	SettingName[BlockRewardBlockReward] = "block_reward.block_reward"
 	SettingName[BlockRewardQualifyingStake] = "block_reward.qualifying_stake"
 	SettingName[BlockRewardGammaAlpha] = "block_reward.gamma.alpha"
@@ -183,6 +204,12 @@ func initSettingName() {
 	SettingName[CostCommitConnection] = "cost.commit_connection"
 	SettingName[CostNewAllocationRequest] = "cost.new_allocation_request"
 	SettingName[CostUpdateAllocationRequest] = "cost.update_allocation_request"
+	SettingName[CostReplaceDegradedBlobber] = "cost.replace_degraded_blobber"
+	SettingName[CostMarkDegradedAllocations] = "cost.mark_degraded_allocations"
+	SettingName[CostTransferAllocationOwnership] = "cost.transfer_allocation_ownership"
+	SettingName[CostAcceptAllocationOwnership] = "cost.accept_allocation_ownership"
+	SettingName[CostRenewAllocations] = "cost.renew_allocations"
+	SettingName[CostUpdateAllocationACL] = "cost.update_allocation_acl"
 	SettingName[CostFinalizeAllocation] = "cost.finalize_allocation"
 	SettingName[CostCancelAllocation] = "cost.cancel_allocation"
 	SettingName[CostAddFreeStorageAssigner] = "cost.add_free_storage_assigner"
@@ -207,6 +234,9 @@ func initSettingName() {
 	SettingName[CostStakePoolPayInterests] = "cost.stake_pool_pay_interests"
 	SettingName[CostCommitSettingsChanges] = "cost.commit_settings_changes"
 	SettingName[CostCollectReward] = "cost.collect_reward"
+	SettingName[CostStakePoolAutoCompound] = "cost.stake_pool_auto_compound"
+	SettingName[CostStakePoolRedelegate] = "cost.stake_pool_redelegate"
+	SettingName[CostWithdrawUnbonded] = "cost.stake_pool_withdraw_unbonded"
 	SettingName[CostKillBlobber] = "cost.kill_blobber"
 	SettingName[CostKillValidator] = "cost.kill_validator"
 	SettingName[CostShutdownBlobber] = "cost.shutdown_blobber"
@@ -229,6 +259,7 @@ func initSettings() {
 		WritePoolMinLock.String():                 {WritePoolMinLock, smartcontract.CurrencyCoin},
 		StakePoolMinLockPeriod.String():           {StakePoolMinLockPeriod, smartcontract.Duration},
 		StakePoolKillSlash.String():               {StakePoolKillSlash, smartcontract.Float64},
+		StakePoolUnbondingRounds.String():         {StakePoolUnbondingRounds, smartcontract.Int64},
 		MaxTotalFreeAllocation.String():           {MaxTotalFreeAllocation, smartcontract.CurrencyCoin},
 		MaxIndividualFreeAllocation.String():      {MaxIndividualFreeAllocation, smartcontract.CurrencyCoin},
 		CancellationCharge.String():               {CancellationCharge, smartcontract.Float64},
@@ -249,7 +280,12 @@ func initSettings() {
 		MinWritePrice.String():                    {MinWritePrice, smartcontract.CurrencyCoin},
 		ChallengeEnabled.String():                 {ChallengeEnabled, smartcontract.Boolean},
 		ValidatorsPerChallenge.String():           {ValidatorsPerChallenge, smartcontract.Int},
+		ChallengeBlobberSelection.String():        {ChallengeBlobberSelection, smartcontract.Int},
+		ChallengeRiskWeight.String():              {ChallengeRiskWeight, smartcontract.Float64},
 		MaxDelegates.String():                     {MaxDelegates, smartcontract.Int},
+		OwnershipTransferTimeout.String():         {OwnershipTransferTimeout, smartcontract.Duration},
+		AutoRenewalPeriod.String():                {AutoRenewalPeriod, smartcontract.Duration},
+		AutoRenewalWindow.String():                {AutoRenewalWindow, smartcontract.Duration},
 		BlockRewardBlockReward.String():           {BlockRewardBlockReward, smartcontract.CurrencyCoin},
 		BlockRewardQualifyingStake.String():       {BlockRewardQualifyingStake, smartcontract.CurrencyCoin},
 		BlockRewardGammaAlpha.String():            {BlockRewardGammaAlpha, smartcontract.Float64},
@@ -264,6 +300,12 @@ func initSettings() {
 		CostCommitConnection.String():             {CostCommitConnection, smartcontract.Cost},
 		CostNewAllocationRequest.String():         {CostNewAllocationRequest, smartcontract.Cost},
 		CostUpdateAllocationRequest.String():      {CostUpdateAllocationRequest, smartcontract.Cost},
+		CostReplaceDegradedBlobber.String():       {CostReplaceDegradedBlobber, smartcontract.Cost},
+		CostMarkDegradedAllocations.String():      {CostMarkDegradedAllocations, smartcontract.Cost},
+		CostTransferAllocationOwnership.String():  {CostTransferAllocationOwnership, smartcontract.Cost},
+		CostAcceptAllocationOwnership.String():    {CostAcceptAllocationOwnership, smartcontract.Cost},
+		CostRenewAllocations.String():             {CostRenewAllocations, smartcontract.Cost},
+		CostUpdateAllocationACL.String():          {CostUpdateAllocationACL, smartcontract.Cost},
 		CostFinalizeAllocation.String():           {CostFinalizeAllocation, smartcontract.Cost},
 		CostCancelAllocation.String():             {CostCancelAllocation, smartcontract.Cost},
 		CostAddFreeStorageAssigner.String():       {CostAddFreeStorageAssigner, smartcontract.Cost},
@@ -288,6 +330,9 @@ func initSettings() {
 		CostStakePoolPayInterests.String():        {CostStakePoolPayInterests, smartcontract.Cost},
 		CostCommitSettingsChanges.String():        {CostCommitSettingsChanges, smartcontract.Cost},
 		CostCollectReward.String():                {CostCollectReward, smartcontract.Cost},
+		CostStakePoolAutoCompound.String():        {CostStakePoolAutoCompound, smartcontract.Cost},
+		CostStakePoolRedelegate.String():          {CostStakePoolRedelegate, smartcontract.Cost},
+		CostWithdrawUnbonded.String():             {CostWithdrawUnbonded, smartcontract.Cost},
 		CostKillBlobber.String():                  {CostKillBlobber, smartcontract.Cost},
 		CostKillValidator.String():                {CostKillValidator, smartcontract.Cost},
 		CostShutdownBlobber.String():              {CostShutdownBlobber, smartcontract.Cost},
@@ -360,6 +405,8 @@ func (conf *Config) setInt(key string, change int) error {
 		conf.MaxBlobbersPerAllocation = change
 	case ValidatorsPerChallenge:
 		conf.ValidatorsPerChallenge = change
+	case ChallengeBlobberSelection:
+		conf.ChallengeBlobberSelection = change
 	case MaxDelegates:
 		conf.MaxDelegates = change
 	default:
@@ -430,6 +477,11 @@ func (conf *Config) setInt64(key string, change int64) error {
 		conf.MinBlobberCapacity = change
 	case FreeAllocationSize:
 		conf.FreeAllocationSettings.Size = change
+	case StakePoolUnbondingRounds:
+		if conf.StakePool == nil {
+			conf.StakePool = &stakePoolConfig{}
+		}
+		conf.StakePool.UnbondingRounds = change
 	default:
 		return fmt.Errorf("key: %v not implemented as int64", key)
 	}
@@ -449,6 +501,8 @@ func (conf *Config) setFloat64(key string, change float64) error {
 		conf.StakePool.KillSlash = change
 	case BlobberSlash:
 		conf.BlobberSlash = change
+	case ChallengeRiskWeight:
+		conf.ChallengeRiskWeight = change
 	case BlockRewardGammaAlpha:
 		if conf.BlockReward == nil {
 			conf.BlockReward = &blockReward{}
@@ -498,6 +552,12 @@ func (conf *Config) setDuration(key string, change time.Duration) error {
 		conf.StakePool.MinLockPeriod = change
 	case HealthCheckPeriod:
 		conf.HealthCheckPeriod = change
+	case OwnershipTransferTimeout:
+		conf.OwnershipTransferTimeout = change
+	case AutoRenewalPeriod:
+		conf.AutoRenewal.Period = change
+	case AutoRenewalWindow:
+		conf.AutoRenewal.Window = change
 	default:
 		return fmt.Errorf("key: %v not implemented as duration", key)
 	}
@@ -656,6 +716,8 @@ func (conf *Config) get(key Setting) interface{} {
 		return conf.ValidatorReward
 	case StakePoolKillSlash:
 		return conf.StakePool.KillSlash
+	case StakePoolUnbondingRounds:
+		return conf.StakePool.UnbondingRounds
 	case BlobberSlash:
 		return conf.BlobberSlash
 	case MaxBlobbersPerAllocation:
@@ -670,8 +732,18 @@ func (conf *Config) get(key Setting) interface{} {
 		return conf.ChallengeEnabled
 	case ValidatorsPerChallenge:
 		return conf.ValidatorsPerChallenge
+	case ChallengeBlobberSelection:
+		return conf.ChallengeBlobberSelection
+	case ChallengeRiskWeight:
+		return conf.ChallengeRiskWeight
 	case MaxDelegates:
 		return conf.MaxDelegates
+	case OwnershipTransferTimeout:
+		return conf.OwnershipTransferTimeout
+	case AutoRenewalPeriod:
+		return conf.AutoRenewal.Period
+	case AutoRenewalWindow:
+		return conf.AutoRenewal.Window
 	case BlockRewardBlockReward:
 		return conf.BlockReward.BlockReward
 	case BlockRewardQualifyingStake:
@@ -144,51 +144,63 @@ func TestUpdateSettings(t *testing.T) {
 					"free_allocation_settings.write_price_range.max": "0.1",
 					"free_allocation_settings.read_pool_fraction":    "0.2",
 
-					"validator_reward":               "0.025",
-					"blobber_slash":                  "0.1",
-					"max_read_price":                 "100",
-					"max_write_price":                "100",
-					"challenge_enabled":              "true",
-					"validators_per_challenge":       "2",
-					"max_delegates":                  "100",
-					"owner_id":                       "f769ccdf8587b8cab6a0f6a8a5a0a91d3405392768f283c80a45d6023a1bfa1f",
-					"block_reward.block_reward":      "1000",
-					"block_reward.qualifying_stake":  "1",
-					"block_reward.gamma.alpha":       "0.2",
-					"block_reward.gamma.a":           "10",
-					"block_reward.gamma.b":           "9",
-					"block_reward.zeta.i":            "1",
-					"block_reward.zeta.k":            "0.9",
-					"block_reward.zeta.mu":           "0.2",
-					"cost.update_settings":           "105",
-					"cost.read_redeem":               "105",
-					"cost.commit_connection":         "105",
-					"cost.new_allocation_request":    "105",
-					"cost.update_allocation_request": "105",
-					"cost.finalize_allocation":       "105",
-					"cost.cancel_allocation":         "105",
-					"cost.add_free_storage_assigner": "105",
-					"cost.free_allocation_request":   "105",
-					"cost.free_update_allocation":    "105",
-					"cost.blobber_health_check":      "105",
-					"cost.update_blobber_settings":   "105",
-					"cost.pay_blobber_block_rewards": "105",
-					"cost.challenge_request":         "105",
-					"cost.challenge_response":        "105",
-					"cost.generate_challenge":        "105",
-					"cost.add_validator":             "105",
-					"cost.update_validator_settings": "105",
-					"cost.add_blobber":               "105",
-					"cost.new_read_pool":             "105",
-					"cost.read_pool_lock":            "105",
-					"cost.read_pool_unlock":          "105",
-					"cost.write_pool_lock":           "105",
-					"cost.write_pool_unlock":         "105",
-					"cost.stake_pool_lock":           "105",
-					"cost.stake_pool_unlock":         "105",
-					"cost.stake_pool_pay_interests":  "105",
-					"cost.commit_settings_changes":   "105",
-					"cost.collect_reward":            "105",
+					"validator_reward":                   "0.025",
+					"blobber_slash":                      "0.1",
+					"max_read_price":                     "100",
+					"max_write_price":                    "100",
+					"challenge_enabled":                  "true",
+					"validators_per_challenge":           "2",
+					"challenge_blobber_selection":        "2",
+					"challenge_risk_weight":              "4",
+					"max_delegates":                      "100",
+					"ownership_transfer_timeout":         "1h",
+					"owner_id":                           "f769ccdf8587b8cab6a0f6a8a5a0a91d3405392768f283c80a45d6023a1bfa1f",
+					"block_reward.block_reward":          "1000",
+					"block_reward.qualifying_stake":      "1",
+					"block_reward.gamma.alpha":           "0.2",
+					"block_reward.gamma.a":               "10",
+					"block_reward.gamma.b":               "9",
+					"block_reward.zeta.i":                "1",
+					"block_reward.zeta.k":                "0.9",
+					"block_reward.zeta.mu":               "0.2",
+					"cost.update_settings":               "105",
+					"cost.read_redeem":                   "105",
+					"cost.commit_connection":             "105",
+					"cost.new_allocation_request":        "105",
+					"cost.update_allocation_request":     "105",
+					"cost.replace_degraded_blobber":      "105",
+					"cost.mark_degraded_allocations":     "105",
+					"cost.transfer_allocation_ownership": "105",
+					"cost.accept_allocation_ownership":   "105",
+					"cost.renew_allocations":             "105",
+					"cost.update_allocation_acl":         "105",
+					"cost.finalize_allocation":           "105",
+					"cost.cancel_allocation":             "105",
+					"cost.add_free_storage_assigner":     "105",
+					"cost.free_allocation_request":       "105",
+					"cost.free_update_allocation":        "105",
+					"cost.blobber_health_check":          "105",
+					"cost.update_blobber_settings":       "105",
+					"cost.pay_blobber_block_rewards":     "105",
+					"cost.challenge_request":             "105",
+					"cost.challenge_response":            "105",
+					"cost.generate_challenge":            "105",
+					"cost.add_validator":                 "105",
+					"cost.update_validator_settings":     "105",
+					"cost.add_blobber":                   "105",
+					"cost.new_read_pool":                 "105",
+					"cost.read_pool_lock":                "105",
+					"cost.read_pool_unlock":              "105",
+					"cost.write_pool_lock":               "105",
+					"cost.write_pool_unlock":             "105",
+					"cost.stake_pool_lock":               "105",
+					"cost.stake_pool_unlock":             "105",
+					"cost.stake_pool_pay_interests":      "105",
+					"cost.commit_settings_changes":       "105",
+					"cost.collect_reward":                "105",
+					"cost.stake_pool_auto_compound":      "105",
+					"cost.stake_pool_redelegate":         "105",
+					"cost.stake_pool_withdraw_unbonded":  "105",
 				},
 			},
 		},
@@ -392,7 +404,12 @@ func TestCommitSettingChanges(t *testing.T) {
 					"max_write_price":                                "100",
 					"challenge_enabled":                              "true",
 					"validators_per_challenge":                       "2",
+					"challenge_blobber_selection":                    "2",
+					"challenge_risk_weight":                          "4",
 					"max_delegates":                                  "100",
+					"ownership_transfer_timeout":                     "1h",
+					"auto_renewal.period":                            "720h",
+					"auto_renewal.window":                            "24h",
 					"owner_id":                                       "f769ccdf8587b8cab6a0f6a8a5a0a91d3405392768f283c80a45d6023a1bfa1f",
 					"block_reward.block_reward":                      "1000",
 					"block_reward.qualifying_stake":                  "1",
@@ -407,6 +424,12 @@ func TestCommitSettingChanges(t *testing.T) {
 					"cost.commit_connection":                         "105",
 					"cost.new_allocation_request":                    "105",
 					"cost.update_allocation_request":                 "105",
+					"cost.replace_degraded_blobber":                  "105",
+					"cost.mark_degraded_allocations":                 "105",
+					"cost.transfer_allocation_ownership":             "105",
+					"cost.accept_allocation_ownership":               "105",
+					"cost.renew_allocations":                         "105",
+					"cost.update_allocation_acl":                     "105",
 					"cost.finalize_allocation":                       "105",
 					"cost.cancel_allocation":                         "105",
 					"cost.add_free_storage_assigner":                 "105",
@@ -431,6 +454,9 @@ func TestCommitSettingChanges(t *testing.T) {
 					"cost.stake_pool_pay_interests":                  "105",
 					"cost.commit_settings_changes":                   "105",
 					"cost.collect_reward":                            "105",
+					"cost.stake_pool_auto_compound":                  "105",
+					"cost.stake_pool_redelegate":                     "105",
+					"cost.stake_pool_withdraw_unbonded":              "105",
 				},
 			},
 		},
@@ -477,6 +503,12 @@ func getConfField(conf Config, field string) interface{} {
 
 	case HealthCheckPeriod:
 		return conf.HealthCheckPeriod
+	case OwnershipTransferTimeout:
+		return conf.OwnershipTransferTimeout
+	case AutoRenewalPeriod:
+		return conf.AutoRenewal.Period
+	case AutoRenewalWindow:
+		return conf.AutoRenewal.Window
 	case MaxTotalFreeAllocation:
 		return conf.MaxTotalFreeAllocation
 	case MaxIndividualFreeAllocation:
@@ -515,6 +547,10 @@ func getConfField(conf Config, field string) interface{} {
 		return conf.ChallengeEnabled
 	case ValidatorsPerChallenge:
 		return conf.ValidatorsPerChallenge
+	case ChallengeBlobberSelection:
+		return conf.ChallengeBlobberSelection
+	case ChallengeRiskWeight:
+		return conf.ChallengeRiskWeight
 	case MaxDelegates:
 		return conf.MaxDelegates
 	case BlockRewardBlockReward:
@@ -63,6 +63,12 @@ func GetEndpoints(rh rest.RestHandlerI) []rest.Endpoint {
 		rest.MakeEndpoint(storage+"/writemarkers", common.UserRateLimit(srh.getWriteMarker)),
 		rest.MakeEndpoint(storage+"/errors", common.UserRateLimit(srh.getErrors)),
 		rest.MakeEndpoint(storage+"/allocations", common.UserRateLimit(srh.getAllocations)),
+		rest.MakeEndpoint(storage+"/degraded-allocations", common.UserRateLimit(srh.getDegradedAllocations)),
+		rest.MakeEndpoint(storage+"/blobber-replacements", common.UserRateLimit(srh.getBlobberReplacements)),
+		rest.MakeEndpoint(storage+"/allocation-renewal-warnings", common.UserRateLimit(srh.getAllocationRenewalWarnings)),
+		rest.MakeEndpoint(storage+"/allocation-acl", common.UserRateLimit(srh.getAllocationACL)),
+		rest.MakeEndpoint(storage+"/allocation-acls", common.UserRateLimit(srh.getAllocationACLs)),
+		rest.MakeEndpoint(storage+"/billing-statement", common.UserRateLimit(srh.getBillingStatement)),
 		rest.MakeEndpoint(storage+"/allocation_min_lock", common.UserRateLimit(srh.getAllocationMinLock)),
 		rest.MakeEndpoint(storage+"/allocation", common.UserRateLimit(srh.getAllocation)),
 		rest.MakeEndpoint(storage+"/latestreadmarker", common.UserRateLimit(srh.getLatestReadMarker)),
@@ -77,10 +83,12 @@ func GetEndpoints(rh rest.RestHandlerI) []rest.Endpoint {
 		rest.MakeEndpoint(storage+"/getStakePoolStat", common.UserRateLimit(srh.getStakePoolStat)),
 		rest.MakeEndpoint(storage+"/getUserStakePoolStat", common.UserRateLimit(srh.getUserStakePoolStat)),
 		rest.MakeEndpoint(storage+"/getUserLockedTotal", common.UserRateLimit(srh.getUserLockedTotal)),
+		rest.MakeEndpoint(storage+"/getUserUnbondingEntries", common.UserRateLimit(srh.getUserUnbondingEntries)),
 		rest.MakeEndpoint(storage+"/block", common.UserRateLimit(srh.getBlock)),
 		rest.MakeEndpoint(storage+"/get_blocks", common.UserRateLimit(srh.getBlocks)),
 		rest.MakeEndpoint(storage+"/storage-config", common.UserRateLimit(srh.getConfig)),
 		rest.MakeEndpoint(storage+"/getReadPoolStat", common.UserRateLimit(srh.getReadPoolStat)),
+		rest.MakeEndpoint(storage+"/getAllocationReadPoolStat", common.UserRateLimit(srh.getAllocationReadPoolStat)),
 		rest.MakeEndpoint(storage+"/getChallengePoolStat", common.UserRateLimit(srh.getChallengePoolStat)),
 		rest.MakeEndpoint(storage+"/alloc_write_marker_count", common.UserRateLimit(srh.getWriteMarkerCount)),
 		rest.MakeEndpoint(storage+"/collected_reward", common.UserRateLimit(srh.getCollectedReward)),
@@ -275,6 +283,7 @@ type allocationBlobbersRequest struct {
 	ReadPriceRange  PriceRange       `json:"read_price_range"`
 	WritePriceRange PriceRange       `json:"write_price_range"`
 	Size            int64            `json:"size"`
+	Tier            string           `json:"tier"`
 }
 
 func (nar *allocationBlobbersRequest) decode(b []byte) error {
@@ -378,6 +387,7 @@ func getBlobbersForRequest(request allocationBlobbersRequest, edb *event.EventDb
 		AllocationSize:     allocationSize,
 		AllocationSizeInGB: sizeInGB(allocationSize),
 		NumberOfDataShards: request.DataShards,
+		Tier:               request.Tier,
 	}
 
 	logging.Logger.Debug("alloc_blobbers", zap.Int64("ReadPriceRange.Min", allocation.ReadPriceRange.Min),
@@ -638,6 +648,59 @@ func (srh *StorageRestHandler) getReadPoolStat(w http.ResponseWriter, r *http.Re
 	common.Respond(w, r, &rp, nil)
 }
 
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/getAllocationReadPoolStat getAllocationReadPoolStat
+// Gets the sponsored read pool of an allocation and its usage by the readers
+//
+// parameters:
+//
+//	+name: allocation_id
+//	 description: allocation of the sponsored read pool
+//	 required: true
+//	 in: query
+//	 type: string
+//	+name: reader_id
+//	 description: reader to get the sponsored usage of
+//	 required: false
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200: allocationReadPoolStat
+//	400:
+func (srh *StorageRestHandler) getAllocationReadPoolStat(w http.ResponseWriter, r *http.Request) {
+	allocationID := r.URL.Query().Get("allocation_id")
+	if allocationID == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("missing allocation_id"))
+		return
+	}
+
+	arp := newAllocationReadPool(allocationID)
+	err := srh.GetQueryStateContext().GetTrieNode(allocationReadPoolKey(ADDRESS, allocationID), arp)
+	if err != nil {
+		common.Respond(w, r, nil, smartcontract.NewErrNoResourceOrErrInternal(err, true, "can't get allocation read pool"))
+		return
+	}
+
+	var rs *allocationReaderSpent
+	if readerID := r.URL.Query().Get("reader_id"); readerID != "" {
+		rs = &allocationReaderSpent{AllocationID: allocationID, ReaderID: readerID}
+		err = srh.GetQueryStateContext().GetTrieNode(allocationReaderSpentKey(ADDRESS, allocationID, readerID), rs)
+		if err != nil && err != util.ErrValueNotPresent {
+			common.Respond(w, r, nil, common.NewErrInternal(err.Error()))
+			return
+		}
+	}
+
+	stat, err := newAllocationReadPoolStat(arp, rs)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal(err.Error()))
+		return
+	}
+
+	common.Respond(w, r, stat, nil)
+}
+
 const cantGetConfigErrMsg = "can't get config"
 
 func getConfig(balances cstate.CommonStateContextI) (*Config, error) {
@@ -942,6 +1005,63 @@ func (srh *StorageRestHandler) getUserLockedTotal(w http.ResponseWriter, r *http
 
 }
 
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/getUserUnbondingEntries getUserUnbondingEntries
+// Gets a user's stake unlocked from blobbers and validators, waiting for the unbonding period to be over
+//
+// parameters:
+//
+//	+name: client_id
+//	 description: client for which to get unbonding entries
+//	 required: true
+//	 in: query
+//	 type: string
+//	+name: offset
+//	 description: offset
+//	 in: query
+//	 type: string
+//	+name: limit
+//	 description: limit
+//	 in: query
+//	 type: string
+//	+name: sort
+//	 description: desc or asc
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200: []UnbondingEntry
+//	400:
+//	500:
+func (srh *StorageRestHandler) getUserUnbondingEntries(w http.ResponseWriter, r *http.Request) {
+	clientID := r.URL.Query().Get("client_id")
+	if clientID == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("no client_id"))
+		return
+	}
+
+	pagination, err := common2.GetOffsetLimitOrderParam(r.URL.Query())
+	if err != nil {
+		common.Respond(w, r, nil, err)
+		return
+	}
+
+	edb := srh.GetQueryStateContext().GetEventDB()
+	if edb == nil {
+		common.Respond(w, r, nil, common.NewErrInternal("no db connection"))
+		return
+	}
+
+	entries, err := edb.GetUserUnbondingEntries(clientID,
+		[]spenum.Provider{spenum.Blobber, spenum.Validator}, pagination)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("getting unbonding entries: "+err.Error()))
+		return
+	}
+
+	common.Respond(w, r, entries, nil)
+}
+
 // swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/getStakePoolStat getStakePoolStat
 // Gets statistic for all locked tokens of a stake pool
 //
@@ -1749,6 +1869,383 @@ func (srh *StorageRestHandler) getAllocations(w http.ResponseWriter, r *http.Req
 	common.Respond(w, r, allocations, nil)
 }
 
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/degraded-allocations degraded-allocations
+// Gets a list of active allocations having killed or shut down blobbers not replaced yet
+//
+// parameters:
+//
+//	+name: owner
+//	 description: owner of allocations we wish to list, all owners if omitted
+//	 in: query
+//	 type: string
+//	+name: offset
+//	 description: offset
+//	 in: query
+//	 type: string
+//	+name: limit
+//	 description: limit
+//	 in: query
+//	 type: string
+//	+name: sort
+//	 description: desc or asc
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200: []StorageAllocation
+//	400:
+//	500:
+func (srh *StorageRestHandler) getDegradedAllocations(w http.ResponseWriter, r *http.Request) {
+	owner := r.URL.Query().Get("owner")
+
+	limit, err := common2.GetOffsetLimitOrderParam(r.URL.Query())
+	if err != nil {
+		common.Respond(w, r, nil, err)
+		return
+	}
+
+	edb := srh.GetQueryStateContext().GetEventDB()
+	if edb == nil {
+		common.Respond(w, r, nil, common.NewErrInternal("no db connection"))
+		return
+	}
+	allocations, err := getDegradedAllocationsFromDb(owner, edb, limit)
+	if err != nil {
+		common.Respond(w, r, nil, smartcontract.NewErrNoResourceOrErrInternal(err, true, "can't get degraded allocations"))
+		return
+	}
+	common.Respond(w, r, allocations, nil)
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/blobber-replacements blobber-replacements
+// Gets the replacements of killed or shut down blobbers of allocations
+//
+// parameters:
+//
+//	+name: allocation_id
+//	 description: allocation of the replacements, all allocations if omitted
+//	 in: query
+//	 type: string
+//	+name: blobber_id
+//	 description: replaced or replacing blobber, all blobbers if omitted
+//	 in: query
+//	 type: string
+//	+name: offset
+//	 description: offset
+//	 in: query
+//	 type: string
+//	+name: limit
+//	 description: limit
+//	 in: query
+//	 type: string
+//	+name: sort
+//	 description: desc or asc
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200: []BlobberReplacement
+//	400:
+//	500:
+func (srh *StorageRestHandler) getBlobberReplacements(w http.ResponseWriter, r *http.Request) {
+	var (
+		allocationID = r.URL.Query().Get("allocation_id")
+		blobberID    = r.URL.Query().Get("blobber_id")
+	)
+
+	limit, err := common2.GetOffsetLimitOrderParam(r.URL.Query())
+	if err != nil {
+		common.Respond(w, r, nil, err)
+		return
+	}
+
+	edb := srh.GetQueryStateContext().GetEventDB()
+	if edb == nil {
+		common.Respond(w, r, nil, common.NewErrInternal("no db connection"))
+		return
+	}
+	replacements, err := edb.GetBlobberReplacements(allocationID, blobberID, limit)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("can't get blobber replacements", err.Error()))
+		return
+	}
+	common.Respond(w, r, replacements, nil)
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/allocation-renewal-warnings allocation-renewal-warnings
+// Gets the warnings of the stopped allocations auto-renewals
+//
+// parameters:
+//
+//	+name: allocation_id
+//	 description: allocation of the warnings, all allocations if omitted
+//	 in: query
+//	 type: string
+//	+name: owner
+//	 description: owner of the allocations, all owners if omitted
+//	 in: query
+//	 type: string
+//	+name: offset
+//	 description: offset
+//	 in: query
+//	 type: string
+//	+name: limit
+//	 description: limit
+//	 in: query
+//	 type: string
+//	+name: sort
+//	 description: desc or asc
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200: []AllocationRenewalWarning
+//	400:
+//	500:
+func (srh *StorageRestHandler) getAllocationRenewalWarnings(w http.ResponseWriter, r *http.Request) {
+	var (
+		allocationID = r.URL.Query().Get("allocation_id")
+		owner        = r.URL.Query().Get("owner")
+	)
+
+	limit, err := common2.GetOffsetLimitOrderParam(r.URL.Query())
+	if err != nil {
+		common.Respond(w, r, nil, err)
+		return
+	}
+
+	edb := srh.GetQueryStateContext().GetEventDB()
+	if edb == nil {
+		common.Respond(w, r, nil, common.NewErrInternal("no db connection"))
+		return
+	}
+	warnings, err := edb.GetAllocationRenewalWarnings(allocationID, owner, limit)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("can't get allocation renewal warnings", err.Error()))
+		return
+	}
+	common.Respond(w, r, warnings, nil)
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/allocation-acl allocation-acl
+// Gets the collaborators of an allocation and their permissions from the chain state
+//
+// parameters:
+//
+//	+name: allocation_id
+//	 description: allocation of the collaborators
+//	 required: true
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200: AllocationACL
+//	400:
+//	404:
+func (srh *StorageRestHandler) getAllocationACL(w http.ResponseWriter, r *http.Request) {
+	allocationID := r.URL.Query().Get("allocation_id")
+	if allocationID == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("missing allocation_id"))
+		return
+	}
+
+	acl := newAllocationACL(allocationID)
+	err := srh.GetQueryStateContext().GetTrieNode(allocationACLKey(ADDRESS, allocationID), acl)
+	if err != nil {
+		common.Respond(w, r, nil, smartcontract.NewErrNoResourceOrErrInternal(err, true, "can't get allocation collaborators"))
+		return
+	}
+
+	common.Respond(w, r, acl, nil)
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/allocation-acls allocation-acls
+// Gets the collaborators of the allocations
+//
+// parameters:
+//
+//	+name: allocation_id
+//	 description: allocation of the collaborators, all allocations if omitted
+//	 in: query
+//	 type: string
+//	+name: client_id
+//	 description: collaborator, all collaborators if omitted
+//	 in: query
+//	 type: string
+//	+name: offset
+//	 description: offset
+//	 in: query
+//	 type: string
+//	+name: limit
+//	 description: limit
+//	 in: query
+//	 type: string
+//	+name: sort
+//	 description: desc or asc
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200: []AllocationACL
+//	400:
+//	500:
+func (srh *StorageRestHandler) getAllocationACLs(w http.ResponseWriter, r *http.Request) {
+	var (
+		allocationID = r.URL.Query().Get("allocation_id")
+		clientID     = r.URL.Query().Get("client_id")
+	)
+
+	limit, err := common2.GetOffsetLimitOrderParam(r.URL.Query())
+	if err != nil {
+		common.Respond(w, r, nil, err)
+		return
+	}
+
+	edb := srh.GetQueryStateContext().GetEventDB()
+	if edb == nil {
+		common.Respond(w, r, nil, common.NewErrInternal("no db connection"))
+		return
+	}
+	acls, err := edb.GetAllocationACLs(allocationID, clientID, limit)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("can't get allocation collaborators", err.Error()))
+		return
+	}
+	common.Respond(w, r, acls, nil)
+}
+
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/billing-statement billing-statement
+// Gets the itemized write, read, challenge reward, cancellation charges and refunds of an allocation or a client
+//
+// parameters:
+//
+//	+name: allocation_id
+//	 description: allocation of the statement, either it or the client_id is required
+//	 in: query
+//	 type: string
+//	+name: client_id
+//	 description: client of the statement, either it or the allocation_id is required
+//	 in: query
+//	 type: string
+//	+name: from
+//	 description: start of the statement as a unix timestamp, zero if omitted
+//	 in: query
+//	 type: string
+//	+name: to
+//	 description: end of the statement as a unix timestamp, now if omitted
+//	 in: query
+//	 type: string
+//	+name: format
+//	 description: json or csv, json if omitted
+//	 in: query
+//	 type: string
+//	+name: offset
+//	 description: offset of the json charges, the csv has all of them
+//	 in: query
+//	 type: string
+//	+name: limit
+//	 description: limit of the json charges, the csv has all of them
+//	 in: query
+//	 type: string
+//	+name: sort
+//	 description: desc or asc
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200: billingStatement
+//	400:
+//	500:
+func (srh *StorageRestHandler) getBillingStatement(w http.ResponseWriter, r *http.Request) {
+	var (
+		query  = r.URL.Query()
+		format = query.Get("format")
+		filter = event.AllocationChargesFilter{
+			AllocationID: query.Get("allocation_id"),
+			ClientID:     query.Get("client_id"),
+			To:           time.Now().Unix(),
+		}
+	)
+	if filter.AllocationID == "" && filter.ClientID == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("missing allocation_id or client_id"))
+		return
+	}
+	if format != "" && format != "json" && format != "csv" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("format should be json or csv"))
+		return
+	}
+
+	var err error
+	if from := query.Get("from"); from != "" {
+		if filter.From, err = strconv.ParseInt(from, 10, 64); err != nil {
+			common.Respond(w, r, nil, common.NewErrBadRequest("from parameter is not valid"))
+			return
+		}
+	}
+	if to := query.Get("to"); to != "" {
+		if filter.To, err = strconv.ParseInt(to, 10, 64); err != nil {
+			common.Respond(w, r, nil, common.NewErrBadRequest("to parameter is not valid"))
+			return
+		}
+	}
+	if filter.To < filter.From {
+		common.Respond(w, r, nil, common.NewErrBadRequest("to is less than from"))
+		return
+	}
+
+	limit, err := common2.GetOffsetLimitOrderParam(query)
+	if err != nil {
+		common.Respond(w, r, nil, err)
+		return
+	}
+
+	edb := srh.GetQueryStateContext().GetEventDB()
+	if edb == nil {
+		common.Respond(w, r, nil, common.NewErrInternal("no db connection"))
+		return
+	}
+
+	statement := billingStatement{
+		AllocationID: filter.AllocationID,
+		ClientID:     filter.ClientID,
+		From:         filter.From,
+		To:           filter.To,
+	}
+	if statement.Totals, err = edb.GetAllocationChargeTotals(filter); err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("can't get allocation charge totals", err.Error()))
+		return
+	}
+	if err := statement.setTotal(); err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("can't sum allocation charge totals", err.Error()))
+		return
+	}
+
+	// the csv statement has all the charges of the time range
+	if format == "csv" {
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", "attachment; filename=statement.csv")
+		err := statement.writeCSV(w, func(fn func([]event.AllocationCharge) error) error {
+			return edb.EachAllocationChargesBatch(filter, billingStatementCSVBatchSize, fn)
+		})
+		if err != nil {
+			logging.Logger.Error("writing billing statement", zap.Error(err))
+		}
+		return
+	}
+
+	if statement.Charges, err = edb.GetAllocationCharges(filter, limit); err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("can't get allocation charges", err.Error()))
+		return
+	}
+	common.Respond(w, r, statement, nil)
+}
+
 // getErrors swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/allocation allocation
 // Gets allocation object
 //
@@ -2105,6 +2602,8 @@ type storageNodeResponse struct {
 	StakePoolSettings       stakepool.Settings     `json:"stake_pool_settings"`
 	RewardRound             RewardRound            `json:"reward_round"`
 	IsAvailable             bool                   `json:"is_available"`
+	Tiers                   []StorageTier          `json:"tiers,omitempty"`
+	Reputation              float64                `json:"reputation"`
 
 	TotalStake               currency.Coin `json:"total_stake"`
 	CreationRound            int64         `json:"creation_round"`
@@ -2133,6 +2632,8 @@ func StoragNodeToStorageNodeResponse(sn StorageNode) storageNodeResponse {
 		IsKilled:                sn.IsKilled(),
 		IsShutdown:              sn.IsShutDown(),
 		IsAvailable:             sn.IsAvailable,
+		Tiers:                   sn.Tiers,
+		Reputation:              sn.reputation(),
 	}
 }
 
@@ -2157,6 +2658,8 @@ func StoragNodeResponseToStorageNode(snr storageNodeResponse) StorageNode {
 		StakePoolSettings:       snr.StakePoolSettings,
 		RewardRound:             snr.RewardRound,
 		IsAvailable:             snr.IsAvailable,
+		Tiers:                   snr.Tiers,
+		Reputation:              snr.Reputation,
 	}
 }
 
@@ -2192,7 +2695,29 @@ func blobberTableToStorageNode(blobber event.Blobber) storageNodeResponse {
 		IsShutdown:               blobber.IsShutdown,
 		SavedData:                blobber.SavedData,
 		IsAvailable:              blobber.IsAvailable,
+		Reputation:               blobber.Reputation,
+		Tiers:                    blobberTierTableToStorageTiers(blobber.Tiers),
+	}
+}
+
+func blobberTierTableToStorageTiers(tiers []event.BlobberTier) []StorageTier {
+	if len(tiers) == 0 {
+		return nil
+	}
+	sts := make([]StorageTier, 0, len(tiers))
+	for _, t := range tiers {
+		sts = append(sts, StorageTier{
+			Name: t.Name,
+			Terms: Terms{
+				ReadPrice:     t.ReadPrice,
+				WritePrice:    t.WritePrice,
+				MinLockDemand: t.MinLockDemand,
+			},
+			LatencyClass:               t.LatencyClass,
+			MaxChallengeCompletionTime: t.MaxChallengeCompletionTime,
+		})
 	}
+	return sts
 }
 
 // getBlobbers swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/getblobbers getblobbers
@@ -2258,7 +2783,7 @@ func (srh *StorageRestHandler) getBlobbers(w http.ResponseWriter, r *http.Reques
 }
 
 // getBlobbers swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d7/blobbers-by-rank blobbers-by-rank
-// Gets list of all blobbers ordered by rank
+// Gets list of all blobbers ordered by reputation, then by rank
 //
 // parameters:
 //
@@ -57,10 +57,13 @@ func (sc *mockStateContext) EmitEvent(eventType event.EventType, tag event.Event
 	}
 	sc.events = append(sc.events, e)
 }
-func (sc *mockStateContext) EmitError(error)                                       {}
-func (sc *mockStateContext) GetEvents() []event.Event                              { return sc.events }
-func (sc *mockStateContext) GetEventDB() *event.EventDb                            { return nil }
-func (sc *mockStateContext) AddSignedTransfer(_ *state.SignedTransfer)             {}
+func (sc *mockStateContext) EmitError(error)                           {}
+func (sc *mockStateContext) GetEvents() []event.Event                  { return sc.events }
+func (sc *mockStateContext) GetEventDB() *event.EventDb                { return nil }
+func (sc *mockStateContext) AddSignedTransfer(_ *state.SignedTransfer) {}
+func (sc *mockStateContext) ExecuteOnBehalf(*transaction.Transaction, func(cstate.StateContextI) (string, error)) (string, error) {
+	return "", nil
+}
 func (sc *mockStateContext) DeleteTrieNode(_ datastore.Key) (datastore.Key, error) { return "", nil }
 func (sc *mockStateContext) GetChainCurrentMagicBlock() *block.MagicBlock          { return nil }
 func (sc *mockStateContext) GetLatestFinalizedBlock() *block.Block                 { return nil }
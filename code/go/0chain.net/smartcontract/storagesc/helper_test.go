@@ -383,6 +383,7 @@ func setConfig(t testing.TB, balances chainState.StateContextI) (
 	conf.TimeUnit = 1 * time.Minute // use one hour as the time unit in the tests
 	conf.ChallengeEnabled = true
 	conf.ValidatorsPerChallenge = 10
+	conf.ChallengeBlobberSelection = int(randomSelection)
 	conf.MaxBlobbersPerAllocation = 10
 	conf.MinAllocSize = 1 * GB
 	conf.MinBlobberCapacity = 1 * GB
@@ -392,6 +393,9 @@ func setConfig(t testing.TB, balances chainState.StateContextI) (
 	conf.MaxWritePrice = 100e10 // 100 tokens per GB max allowed
 	conf.MinWritePrice = 0      // 100 tokens per GB max allowed
 	conf.MaxDelegates = 200
+	conf.OwnershipTransferTimeout = time.Hour
+	conf.AutoRenewal.Period = 720 * time.Hour
+	conf.AutoRenewal.Window = 24 * time.Hour
 	conf.MaxChallengeCompletionTime = 5 * time.Minute
 	config.SmartContractConfig.Set(confMaxChallengeCompletionTime, "5m")
 
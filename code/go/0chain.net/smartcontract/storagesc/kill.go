@@ -15,7 +15,7 @@ import (
 // killBlobber
 // punitively disables a blobber. it will no longer be used for new allocations
 // or receive further rewards. Stakeholders will have their stakes slashed.
-func (_ *StorageSmartContract) killBlobber(
+func (sc *StorageSmartContract) killBlobber(
 	tx *transaction.Transaction,
 	input []byte,
 	balances cstate.StateContextI,
@@ -61,6 +61,9 @@ func (_ *StorageSmartContract) killBlobber(
 	if err != nil {
 		return "", common.NewError("kill_blobber_failed", "saving blobber: "+err.Error())
 	}
+	if err := sc.markBlobberAllocationsDegraded(blobber.ID, balances); err != nil {
+		return "", common.NewError("kill_blobber_failed", "marking allocations degraded: "+err.Error())
+	}
 	return "", nil
 }
 
@@ -44,12 +44,17 @@ var (
 	ALL_VALIDATORS_KEY               = ADDRESS + encryption.Hash("all_validators")
 	ALL_CHALLENGE_READY_BLOBBERS_KEY = ADDRESS + encryption.Hash("all_challenge_ready_blobbers")
 	BLOBBER_REWARD_KEY               = ADDRESS + encryption.Hash("blobber_rewards")
+	CHALLENGE_READY_MAX_SAVED_KEY    = ADDRESS + encryption.Hash("challenge_ready_max_saved_data")
 )
 
 func getBlobberAllocationsKey(blobberID string) string {
 	return ADDRESS + encryption.Hash("blobber_allocations_"+blobberID)
 }
 
+func getDegradedAllocationsCursorKey(blobberID string) string {
+	return ADDRESS + encryption.Hash("degraded_allocations_cursor_"+blobberID)
+}
+
 type Allocations struct {
 	List SortedList
 }
@@ -339,6 +344,12 @@ type StorageNode struct {
 	StakePoolSettings stakepool.Settings `json:"stake_pool_settings"`
 	RewardRound       RewardRound        `json:"reward_round"`
 	IsAvailable       bool               `json:"is_available"`
+	// Tiers are the storage tiers the blobber offers in addition to its
+	// default terms.
+	Tiers []StorageTier `json:"tiers,omitempty"`
+	// Reputation is the rolling rate of the challenges passed by the
+	// blobber, see updateReputation.
+	Reputation float64 `json:"reputation"`
 }
 
 // validate the blobber configurations
@@ -346,6 +357,9 @@ func (sn *StorageNode) validate(conf *Config) (err error) {
 	if err = sn.Terms.validate(conf); err != nil {
 		return
 	}
+	if err = validateStorageTiers(sn.Tiers, conf); err != nil {
+		return
+	}
 	if sn.Capacity <= conf.MinBlobberCapacity {
 		return errors.New("insufficient blobber capacity")
 	}
@@ -519,6 +533,9 @@ type BlobberAllocation struct {
 	// balance.
 	ChallengePoolIntegralValue currency.Coin     `json:"challenge_pool_integral_value"`
 	LatestCompletedChallenge   *StorageChallenge `json:"latest_completed_challenge"`
+	// MaxChallengeCompletionTime of the storage tier of the allocation,
+	// zero means the smart contract one.
+	MaxChallengeCompletionTime time.Duration `json:"max_challenge_completion_time,omitempty"`
 }
 
 func newBlobberAllocation(
@@ -531,7 +548,11 @@ func newBlobberAllocation(
 	ba := &BlobberAllocation{}
 	ba.Stats = &StorageAllocationStats{}
 	ba.Size = size
-	ba.Terms = blobber.Terms
+	var ok bool
+	if ba.Terms, ok = allocation.blobberTerms(blobber); !ok {
+		return nil, fmt.Errorf("blobber %s doesn't offer storage tier %s", blobber.ID, allocation.Tier)
+	}
+	ba.MaxChallengeCompletionTime = allocation.blobberChallengeCompletionTime(blobber)
 	ba.AllocationID = allocation.ID
 	ba.BlobberID = blobber.ID
 
@@ -540,7 +561,7 @@ func newBlobberAllocation(
 		return nil, fmt.Errorf("new blobber allocation failed: %v", err)
 	}
 
-	ba.MinLockDemand, err = blobber.Terms.minLockDemand(sizeInGB(size), rdtu)
+	ba.MinLockDemand, err = ba.Terms.minLockDemand(sizeInGB(size), rdtu)
 	return ba, err
 }
 
@@ -630,6 +651,19 @@ type StorageAllocation struct {
 	Stats             *StorageAllocationStats `json:"stats"`
 	DiverseBlobbers   bool                    `json:"diverse_blobbers"`
 	PreferredBlobbers []string                `json:"preferred_blobbers"`
+	// Tier is the storage tier of the blobbers, empty for their default terms.
+	Tier string `json:"tier,omitempty"`
+	// MinReputation is the lowest reputation of the blobbers the allocation
+	// accepts.
+	MinReputation float64 `json:"min_reputation,omitempty"`
+	// Placement restricts the geolocation of the blobbers.
+	Placement PlacementPolicy `json:"placement"`
+	// PendingOwner is the client the allocation ownership is transferred
+	// to, it becomes the owner accepting the transfer before the
+	// PendingOwnerExpiration.
+	PendingOwner           string           `json:"pending_owner,omitempty"`
+	PendingOwnerPublicKey  string           `json:"pending_owner_public_key,omitempty"`
+	PendingOwnerExpiration common.Timestamp `json:"pending_owner_expiration,omitempty"`
 	// Blobbers not to be used anywhere except /allocation and /allocations table
 	// if Blobbers are getting used in any smart-contract, we should avoid.
 	BlobberAllocs    []*BlobberAllocation          `json:"blobber_details"`
@@ -649,7 +683,26 @@ type StorageAllocation struct {
 	// 00100000 - 32 - rename
 	FileOptions uint16 `json:"file_options"`
 
+	// AutoReplaceBlobbers lets anyone replace the killed or shut down
+	// blobbers of the allocation with the replace_degraded_blobber
+	// transaction, not only the owner.
+	AutoReplaceBlobbers bool `json:"auto_replace_blobbers"`
+	// DegradedBlobbers are the killed or shut down blobbers of the
+	// allocation not replaced yet.
+	DegradedBlobbers []string `json:"degraded_blobbers,omitempty"`
+
+	// AutoRenew extends the allocation near its expiration by the renewal
+	// period of the smart contract, paying from the write pool.
+	AutoRenew bool `json:"auto_renew"`
+	// RenewalBudget is the rest of the write pool tokens the renewals can
+	// spend, the auto-renewal stops once it can't cover the next period.
+	RenewalBudget currency.Coin `json:"renewal_budget"`
+
 	WritePool currency.Coin `json:"write_pool"`
+	// WritePoolLocks are the write pool tokens of the clients that locked
+	// them, oldest first. The rest of the write pool is the owner's, see
+	// payFromWritePool.
+	WritePoolLocks []*WritePoolLock `json:"write_pool_locks,omitempty"`
 
 	// Requested ranges.
 	ReadPriceRange  PriceRange `json:"read_price_range"`
@@ -725,6 +778,9 @@ func (sa *StorageAllocation) addToWritePool(
 		} else {
 			sa.WritePool = writePool
 		}
+		if err := sa.lockWritePool(txn.ClientID, value); err != nil {
+			return err
+		}
 	} else {
 		for _, opt := range opts {
 			value, err := opt(balances)
@@ -752,29 +808,109 @@ func (sa *StorageAllocation) addToWritePool(
 	return nil
 }
 
+// WritePoolLock is the part of the write pool of an allocation locked by a
+// client.
+type WritePoolLock struct {
+	ClientID string        `json:"client_id"`
+	Amount   currency.Coin `json:"amount"`
+}
+
+// lockWritePool records the tokens the client locked in the write pool.
+func (sa *StorageAllocation) lockWritePool(clientID string, value currency.Coin) error {
+	if n := len(sa.WritePoolLocks); n > 0 && sa.WritePoolLocks[n-1].ClientID == clientID {
+		amount, err := currency.AddCoin(sa.WritePoolLocks[n-1].Amount, value)
+		if err != nil {
+			return err
+		}
+		sa.WritePoolLocks[n-1].Amount = amount
+		return nil
+	}
+	sa.WritePoolLocks = append(sa.WritePoolLocks, &WritePoolLock{ClientID: clientID, Amount: value})
+	return nil
+}
+
+// payFromWritePool removes the value from the write pool and returns the
+// parts paid by the clients. The write pool tokens not locked by a client,
+// such as the refunds and the minted tokens, are the owner's and are paid
+// first, then the locks from the oldest one.
+func (sa *StorageAllocation) payFromWritePool(value currency.Coin) ([]*WritePoolLock, error) {
+	if value > sa.WritePool {
+		return nil, &writePoolShortError{WritePool: sa.WritePool, Cost: value}
+	}
+
+	var locked currency.Coin
+	for _, l := range sa.WritePoolLocks {
+		var err error
+		if locked, err = currency.AddCoin(locked, l.Amount); err != nil {
+			return nil, err
+		}
+	}
+
+	var paid []*WritePoolLock
+	rest := value
+	if sa.WritePool > locked {
+		own := sa.WritePool - locked
+		if own > rest {
+			own = rest
+		}
+		paid = append(paid, &WritePoolLock{ClientID: sa.Owner, Amount: own})
+		rest -= own
+	}
+	var taken []*WritePoolLock
+	taken, sa.WritePoolLocks = takeWritePoolLocks(sa.WritePoolLocks, rest)
+	paid = append(paid, taken...)
+
+	sa.WritePool -= value
+	return paid, nil
+}
+
+// takeWritePoolLocks removes the value from the oldest of the locks and
+// returns the parts taken from each client and the locks left.
+func takeWritePoolLocks(locks []*WritePoolLock, value currency.Coin) (taken, rest []*WritePoolLock) {
+	for len(locks) > 0 && value > 0 {
+		l := locks[0]
+		if l.Amount > value {
+			taken = append(taken, &WritePoolLock{ClientID: l.ClientID, Amount: value})
+			locks[0] = &WritePoolLock{ClientID: l.ClientID, Amount: l.Amount - value}
+			return taken, locks
+		}
+		taken = append(taken, l)
+		value -= l.Amount
+		locks = locks[1:]
+	}
+	return taken, locks
+}
+
+// writePoolShortError is returned when the write pool of an allocation can't
+// cover the tokens to move to its challenge pool.
+type writePoolShortError struct {
+	WritePool currency.Coin
+	Cost      currency.Coin
+}
+
+func (e *writePoolShortError) Error() string {
+	return fmt.Sprintf("insufficient funds %v in write pool to pay %v", e.WritePool, e.Cost)
+}
+
+// moveToChallengePool moves the value from the write pool to the challenge
+// pool and returns the parts paid by the clients, see payFromWritePool.
 func (sa *StorageAllocation) moveToChallengePool(
 	cp *challengePool,
 	value currency.Coin,
-) error {
+) ([]*WritePoolLock, error) {
 	if cp == nil {
-		return errors.New("invalid challenge pool")
+		return nil, errors.New("invalid challenge pool")
 	}
 	if value > sa.WritePool {
-		return fmt.Errorf("insufficient funds %v in write pool to pay %v", sa.WritePool, value)
+		return nil, fmt.Errorf("insufficient funds %v in write pool to pay %v", sa.WritePool, value)
 	}
 
 	if balance, err := currency.AddCoin(cp.Balance, value); err != nil {
-		return err
+		return nil, err
 	} else {
 		cp.Balance = balance
 	}
-	if writePool, err := currency.MinusCoin(sa.WritePool, value); err != nil {
-		return err
-	} else {
-		sa.WritePool = writePool
-	}
-
-	return nil
+	return sa.payFromWritePool(value)
 }
 
 func (sa *StorageAllocation) moveFromChallengePool(
@@ -818,15 +954,20 @@ func (sa *StorageAllocation) isActive(
 		return fmt.Errorf("blobber %s is not currently available for new allocations", blobber.ID)
 	}
 
+	terms, ok := sa.blobberTerms(blobber)
+	if !ok {
+		return fmt.Errorf("blobber %s doesn't offer storage tier %s", blobber.ID, sa.Tier)
+	}
+
 	// filter by read price
-	if !sa.ReadPriceRange.isMatch(blobber.Terms.ReadPrice) {
+	if !sa.ReadPriceRange.isMatch(terms.ReadPrice) {
 		return fmt.Errorf("read price range %v does not match blobber %s read price %v",
-			sa.ReadPriceRange, blobber.ID, blobber.Terms.ReadPrice)
+			sa.ReadPriceRange, blobber.ID, terms.ReadPrice)
 	}
 	// filter by write price
-	if !sa.WritePriceRange.isMatch(blobber.Terms.WritePrice) {
+	if !sa.WritePriceRange.isMatch(terms.WritePrice) {
 		return fmt.Errorf("read price range %v does not match blobber %s write price %v",
-			sa.ReadPriceRange, blobber.ID, blobber.Terms.ReadPrice)
+			sa.ReadPriceRange, blobber.ID, terms.ReadPrice)
 	}
 
 	bSize := sa.bSize()
@@ -836,12 +977,21 @@ func (sa *StorageAllocation) isActive(
 			blobber.ID, blobber.Capacity-blobber.Allocated, bSize)
 	}
 
-	unallocCapacity, err := unallocatedCapacity(blobber.Terms.WritePrice, total, offers)
+	if blobber.reputation() < sa.MinReputation {
+		return fmt.Errorf("blobber %s reputation %v is lower than required %v",
+			blobber.ID, blobber.reputation(), sa.MinReputation)
+	}
+
+	if err := sa.Placement.isInside(blobber); err != nil {
+		return err
+	}
+
+	unallocCapacity, err := unallocatedCapacity(terms.WritePrice, total, offers)
 	if err != nil {
 		return fmt.Errorf("failed to get unallocated capacity: %v", err)
 	}
 
-	if blobber.Terms.WritePrice > 0 && unallocCapacity < bSize {
+	if terms.WritePrice > 0 && unallocCapacity < bSize {
 		return fmt.Errorf("blobber %v staked capacity %v is insufficient, wanted %v",
 			blobber.ID, unallocCapacity, bSize)
 	}
@@ -990,6 +1140,10 @@ func (sa *StorageAllocation) changeBlobbers(
 		return nil, err
 	}
 
+	if err := sa.Placement.checkAdded(blobbers, addedBlobber); err != nil {
+		return nil, err
+	}
+
 	addedBlobber.Allocated += sa.bSize() // Why increase allocation then check if the free capacity is enough?
 	balances.EmitEvent(event.TypeStats, event.TagAllocBlobberValueChange, addedBlobber.ID, event.AllocationBlobberValueChanged{
 		FieldType:    event.Allocated,
@@ -1106,18 +1260,30 @@ func (sa *StorageAllocation) filterBlobbers(list []*StorageNode,
 
 List:
 	for _, b := range list {
+		terms, ok := sa.blobberTerms(b)
+		if !ok {
+			continue
+		}
 		// filter by read price
-		if !sa.ReadPriceRange.isMatch(b.Terms.ReadPrice) {
+		if !sa.ReadPriceRange.isMatch(terms.ReadPrice) {
 			continue
 		}
 		// filter by write price
-		if !sa.WritePriceRange.isMatch(b.Terms.WritePrice) {
+		if !sa.WritePriceRange.isMatch(terms.WritePrice) {
 			continue
 		}
 		// filter by blobber's capacity left
 		if b.Capacity-b.Allocated < bsize {
 			continue
 		}
+		// filter by blobber's reputation
+		if b.Reputation < sa.MinReputation {
+			continue
+		}
+		// filter by blobber's geolocation
+		if sa.Placement.isInside(b) != nil {
+			continue
+		}
 
 		for _, filter := range filters {
 			kick, err := filter(b)
@@ -1359,7 +1525,14 @@ func (sa *StorageAllocation) removeExpiredChallenges(allocChallenges *Allocation
 			return nil, common.NewError("removeExpiredChallenges", "found duplicates expired challenge")
 		}
 
-		if !isChallengeExpired(now, oc.CreatedAt, cct) {
+		// the storage tier of the blobber can shorten the completion time
+		ba, ok := sa.BlobberAllocsMap[oc.BlobberID]
+		expireAfter := cct
+		if ok && ba.MaxChallengeCompletionTime > 0 {
+			expireAfter = ba.MaxChallengeCompletionTime
+		}
+
+		if !isChallengeExpired(now, oc.CreatedAt, expireAfter) {
 			nonExpiredChallenges = append(nonExpiredChallenges, oc)
 			continue
 		}
@@ -1367,7 +1540,6 @@ func (sa *StorageAllocation) removeExpiredChallenges(allocChallenges *Allocation
 		// expired
 		expiredChallengeBlobberMap[oc.ID] = oc.BlobberID
 
-		ba, ok := sa.BlobberAllocsMap[oc.BlobberID]
 		if ok {
 			ba.Stats.FailedChallenges++
 			ba.Stats.OpenChallenges--
@@ -139,9 +139,9 @@ func (z *Allocations) Msgsize() (s int) {
 // MarshalMsg implements msgp.Marshaler
 func (z *BlobberAllocation) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 15
+	// map header, size 16
 	// string "BlobberID"
-	o = append(o, 0x8f, 0xa9, 0x42, 0x6c, 0x6f, 0x62, 0x62, 0x65, 0x72, 0x49, 0x44)
+	o = append(o, 0xde, 0x0, 0x10, 0xa9, 0x42, 0x6c, 0x6f, 0x62, 0x62, 0x65, 0x72, 0x49, 0x44)
 	o = msgp.AppendString(o, z.BlobberID)
 	// string "AllocationID"
 	o = append(o, 0xac, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44)
@@ -254,6 +254,9 @@ func (z *BlobberAllocation) MarshalMsg(b []byte) (o []byte, err error) {
 			return
 		}
 	}
+	// string "MaxChallengeCompletionTime"
+	o = append(o, 0xba, 0x4d, 0x61, 0x78, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65)
+	o = msgp.AppendDuration(o, z.MaxChallengeCompletionTime)
 	return
 }
 
@@ -433,6 +436,12 @@ func (z *BlobberAllocation) UnmarshalMsg(bts []byte) (o []byte, err error) {
 					return
 				}
 			}
+		case "MaxChallengeCompletionTime":
+			z.MaxChallengeCompletionTime, bts, err = msgp.ReadDurationBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MaxChallengeCompletionTime")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -447,7 +456,7 @@ func (z *BlobberAllocation) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *BlobberAllocation) Msgsize() (s int) {
-	s = 1 + 10 + msgp.StringPrefixSize + len(z.BlobberID) + 13 + msgp.StringPrefixSize + len(z.AllocationID) + 5 + msgp.Int64Size + 15 + msgp.StringPrefixSize + len(z.AllocationRoot) + 16
+	s = 3 + 10 + msgp.StringPrefixSize + len(z.BlobberID) + 13 + msgp.StringPrefixSize + len(z.AllocationID) + 5 + msgp.Int64Size + 15 + msgp.StringPrefixSize + len(z.AllocationRoot) + 16
 	if z.LastWriteMarker == nil {
 		s += msgp.NilSize
 	} else {
@@ -465,6 +474,7 @@ func (z *BlobberAllocation) Msgsize() (s int) {
 	} else {
 		s += z.LatestCompletedChallenge.Msgsize()
 	}
+	s += 27 + msgp.DurationSize
 	return
 }
 
@@ -1085,9 +1095,9 @@ func (z *RewardRound) Msgsize() (s int) {
 // MarshalMsg implements msgp.Marshaler
 func (z *StorageAllocationDecode) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 24
+	// map header, size 35
 	// string "ID"
-	o = append(o, 0xde, 0x0, 0x18, 0xa2, 0x49, 0x44)
+	o = append(o, 0xde, 0x0, 0x23, 0xa2, 0x49, 0x44)
 	o = msgp.AppendString(o, z.ID)
 	// string "Tx"
 	o = append(o, 0xa2, 0x54, 0x78)
@@ -1134,6 +1144,32 @@ func (z *StorageAllocationDecode) MarshalMsg(b []byte) (o []byte, err error) {
 	for za0001 := range z.PreferredBlobbers {
 		o = msgp.AppendString(o, z.PreferredBlobbers[za0001])
 	}
+	// string "Tier"
+	o = append(o, 0xa4, 0x54, 0x69, 0x65, 0x72)
+	o = msgp.AppendString(o, z.Tier)
+	// string "MinReputation"
+	o = append(o, 0xad, 0x4d, 0x69, 0x6e, 0x52, 0x65, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e)
+	o = msgp.AppendFloat64(o, z.MinReputation)
+	// string "Placement"
+	o = append(o, 0xa9, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74)
+	o, err = z.Placement.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Placement")
+		return
+	}
+	// string "PendingOwner"
+	o = append(o, 0xac, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x4f, 0x77, 0x6e, 0x65, 0x72)
+	o = msgp.AppendString(o, z.PendingOwner)
+	// string "PendingOwnerPublicKey"
+	o = append(o, 0xb5, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x4b, 0x65, 0x79)
+	o = msgp.AppendString(o, z.PendingOwnerPublicKey)
+	// string "PendingOwnerExpiration"
+	o = append(o, 0xb6, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x45, 0x78, 0x70, 0x69, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e)
+	o, err = z.PendingOwnerExpiration.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "PendingOwnerExpiration")
+		return
+	}
 	// string "BlobberAllocs"
 	o = append(o, 0xad, 0x42, 0x6c, 0x6f, 0x62, 0x62, 0x65, 0x72, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x73)
 	o = msgp.AppendArrayHeader(o, uint32(len(z.BlobberAllocs)))
@@ -1154,6 +1190,25 @@ func (z *StorageAllocationDecode) MarshalMsg(b []byte) (o []byte, err error) {
 	// string "FileOptions"
 	o = append(o, 0xab, 0x46, 0x69, 0x6c, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73)
 	o = msgp.AppendUint16(o, z.FileOptions)
+	// string "AutoReplaceBlobbers"
+	o = append(o, 0xb3, 0x41, 0x75, 0x74, 0x6f, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x42, 0x6c, 0x6f, 0x62, 0x62, 0x65, 0x72, 0x73)
+	o = msgp.AppendBool(o, z.AutoReplaceBlobbers)
+	// string "DegradedBlobbers"
+	o = append(o, 0xb0, 0x44, 0x65, 0x67, 0x72, 0x61, 0x64, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x62, 0x62, 0x65, 0x72, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.DegradedBlobbers)))
+	for za0003 := range z.DegradedBlobbers {
+		o = msgp.AppendString(o, z.DegradedBlobbers[za0003])
+	}
+	// string "AutoRenew"
+	o = append(o, 0xa9, 0x41, 0x75, 0x74, 0x6f, 0x52, 0x65, 0x6e, 0x65, 0x77)
+	o = msgp.AppendBool(o, z.AutoRenew)
+	// string "RenewalBudget"
+	o = append(o, 0xad, 0x52, 0x65, 0x6e, 0x65, 0x77, 0x61, 0x6c, 0x42, 0x75, 0x64, 0x67, 0x65, 0x74)
+	o, err = z.RenewalBudget.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "RenewalBudget")
+		return
+	}
 	// string "WritePool"
 	o = append(o, 0xa9, 0x57, 0x72, 0x69, 0x74, 0x65, 0x50, 0x6f, 0x6f, 0x6c)
 	o, err = z.WritePool.MarshalMsg(o)
@@ -1161,6 +1216,26 @@ func (z *StorageAllocationDecode) MarshalMsg(b []byte) (o []byte, err error) {
 		err = msgp.WrapError(err, "WritePool")
 		return
 	}
+	// string "WritePoolLocks"
+	o = append(o, 0xae, 0x57, 0x72, 0x69, 0x74, 0x65, 0x50, 0x6f, 0x6f, 0x6c, 0x4c, 0x6f, 0x63, 0x6b, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.WritePoolLocks)))
+	for za0004 := range z.WritePoolLocks {
+		if z.WritePoolLocks[za0004] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			// map header, size 2
+			// string "ClientID"
+			o = append(o, 0x82, 0xa8, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x44)
+			o = msgp.AppendString(o, z.WritePoolLocks[za0004].ClientID)
+			// string "Amount"
+			o = append(o, 0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+			o, err = z.WritePoolLocks[za0004].Amount.MarshalMsg(o)
+			if err != nil {
+				err = msgp.WrapError(err, "WritePoolLocks", za0004, "Amount")
+				return
+			}
+		}
+	}
 	// string "ReadPriceRange"
 	o = append(o, 0xae, 0x52, 0x65, 0x61, 0x64, 0x50, 0x72, 0x69, 0x63, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65)
 	// map header, size 2
@@ -1343,6 +1418,42 @@ func (z *StorageAllocationDecode) UnmarshalMsg(bts []byte) (o []byte, err error)
 					return
 				}
 			}
+		case "Tier":
+			z.Tier, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Tier")
+				return
+			}
+		case "MinReputation":
+			z.MinReputation, bts, err = msgp.ReadFloat64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "MinReputation")
+				return
+			}
+		case "Placement":
+			bts, err = z.Placement.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Placement")
+				return
+			}
+		case "PendingOwner":
+			z.PendingOwner, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "PendingOwner")
+				return
+			}
+		case "PendingOwnerPublicKey":
+			z.PendingOwnerPublicKey, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "PendingOwnerPublicKey")
+				return
+			}
+		case "PendingOwnerExpiration":
+			bts, err = z.PendingOwnerExpiration.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "PendingOwnerExpiration")
+				return
+			}
 		case "BlobberAllocs":
 			var zb0003 uint32
 			zb0003, bts, err = msgp.ReadArrayHeaderBytes(bts)
@@ -1385,21 +1496,117 @@ func (z *StorageAllocationDecode) UnmarshalMsg(bts []byte) (o []byte, err error)
 				err = msgp.WrapError(err, "FileOptions")
 				return
 			}
+		case "AutoReplaceBlobbers":
+			z.AutoReplaceBlobbers, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "AutoReplaceBlobbers")
+				return
+			}
+		case "DegradedBlobbers":
+			var zb0004 uint32
+			zb0004, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "DegradedBlobbers")
+				return
+			}
+			if cap(z.DegradedBlobbers) >= int(zb0004) {
+				z.DegradedBlobbers = (z.DegradedBlobbers)[:zb0004]
+			} else {
+				z.DegradedBlobbers = make([]string, zb0004)
+			}
+			for za0003 := range z.DegradedBlobbers {
+				z.DegradedBlobbers[za0003], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "DegradedBlobbers", za0003)
+					return
+				}
+			}
+		case "AutoRenew":
+			z.AutoRenew, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "AutoRenew")
+				return
+			}
+		case "RenewalBudget":
+			bts, err = z.RenewalBudget.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "RenewalBudget")
+				return
+			}
 		case "WritePool":
 			bts, err = z.WritePool.UnmarshalMsg(bts)
 			if err != nil {
 				err = msgp.WrapError(err, "WritePool")
 				return
 			}
+		case "WritePoolLocks":
+			var zb0005 uint32
+			zb0005, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "WritePoolLocks")
+				return
+			}
+			if cap(z.WritePoolLocks) >= int(zb0005) {
+				z.WritePoolLocks = (z.WritePoolLocks)[:zb0005]
+			} else {
+				z.WritePoolLocks = make([]*WritePoolLock, zb0005)
+			}
+			for za0004 := range z.WritePoolLocks {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					z.WritePoolLocks[za0004] = nil
+				} else {
+					if z.WritePoolLocks[za0004] == nil {
+						z.WritePoolLocks[za0004] = new(WritePoolLock)
+					}
+					var zb0006 uint32
+					zb0006, bts, err = msgp.ReadMapHeaderBytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "WritePoolLocks", za0004)
+						return
+					}
+					for zb0006 > 0 {
+						zb0006--
+						field, bts, err = msgp.ReadMapKeyZC(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "WritePoolLocks", za0004)
+							return
+						}
+						switch msgp.UnsafeString(field) {
+						case "ClientID":
+							z.WritePoolLocks[za0004].ClientID, bts, err = msgp.ReadStringBytes(bts)
+							if err != nil {
+								err = msgp.WrapError(err, "WritePoolLocks", za0004, "ClientID")
+								return
+							}
+						case "Amount":
+							bts, err = z.WritePoolLocks[za0004].Amount.UnmarshalMsg(bts)
+							if err != nil {
+								err = msgp.WrapError(err, "WritePoolLocks", za0004, "Amount")
+								return
+							}
+						default:
+							bts, err = msgp.Skip(bts)
+							if err != nil {
+								err = msgp.WrapError(err, "WritePoolLocks", za0004)
+								return
+							}
+						}
+					}
+				}
+			}
 		case "ReadPriceRange":
-			var zb0004 uint32
-			zb0004, bts, err = msgp.ReadMapHeaderBytes(bts)
+			var zb0007 uint32
+			zb0007, bts, err = msgp.ReadMapHeaderBytes(bts)
 			if err != nil {
 				err = msgp.WrapError(err, "ReadPriceRange")
 				return
 			}
-			for zb0004 > 0 {
-				zb0004--
+			for zb0007 > 0 {
+				zb0007--
 				field, bts, err = msgp.ReadMapKeyZC(bts)
 				if err != nil {
 					err = msgp.WrapError(err, "ReadPriceRange")
@@ -1427,14 +1634,14 @@ func (z *StorageAllocationDecode) UnmarshalMsg(bts []byte) (o []byte, err error)
 				}
 			}
 		case "WritePriceRange":
-			var zb0005 uint32
-			zb0005, bts, err = msgp.ReadMapHeaderBytes(bts)
+			var zb0008 uint32
+			zb0008, bts, err = msgp.ReadMapHeaderBytes(bts)
 			if err != nil {
 				err = msgp.WrapError(err, "WritePriceRange")
 				return
 			}
-			for zb0005 > 0 {
-				zb0005--
+			for zb0008 > 0 {
+				zb0008--
 				field, bts, err = msgp.ReadMapKeyZC(bts)
 				if err != nil {
 					err = msgp.WrapError(err, "WritePriceRange")
@@ -1527,7 +1734,7 @@ func (z *StorageAllocationDecode) Msgsize() (s int) {
 	for za0001 := range z.PreferredBlobbers {
 		s += msgp.StringPrefixSize + len(z.PreferredBlobbers[za0001])
 	}
-	s += 14 + msgp.ArrayHeaderSize
+	s += 5 + msgp.StringPrefixSize + len(z.Tier) + 14 + msgp.Float64Size + 10 + z.Placement.Msgsize() + 13 + msgp.StringPrefixSize + len(z.PendingOwner) + 22 + msgp.StringPrefixSize + len(z.PendingOwnerPublicKey) + 23 + z.PendingOwnerExpiration.Msgsize() + 14 + msgp.ArrayHeaderSize
 	for za0002 := range z.BlobberAllocs {
 		if z.BlobberAllocs[za0002] == nil {
 			s += msgp.NilSize
@@ -1535,7 +1742,19 @@ func (z *StorageAllocationDecode) Msgsize() (s int) {
 			s += z.BlobberAllocs[za0002].Msgsize()
 		}
 	}
-	s += 21 + msgp.BoolSize + 12 + msgp.Uint16Size + 10 + z.WritePool.Msgsize() + 15 + 1 + 4 + z.ReadPriceRange.Min.Msgsize() + 4 + z.ReadPriceRange.Max.Msgsize() + 16 + 1 + 4 + z.WritePriceRange.Min.Msgsize() + 4 + z.WritePriceRange.Max.Msgsize() + 10 + z.StartTime.Msgsize() + 10 + msgp.BoolSize + 9 + msgp.BoolSize + 17 + z.MovedToChallenge.Msgsize() + 10 + z.MovedBack.Msgsize() + 18 + z.MovedToValidators.Msgsize() + 9 + msgp.DurationSize
+	s += 21 + msgp.BoolSize + 12 + msgp.Uint16Size + 20 + msgp.BoolSize + 17 + msgp.ArrayHeaderSize
+	for za0003 := range z.DegradedBlobbers {
+		s += msgp.StringPrefixSize + len(z.DegradedBlobbers[za0003])
+	}
+	s += 10 + msgp.BoolSize + 14 + z.RenewalBudget.Msgsize() + 10 + z.WritePool.Msgsize() + 15 + msgp.ArrayHeaderSize
+	for za0004 := range z.WritePoolLocks {
+		if z.WritePoolLocks[za0004] == nil {
+			s += msgp.NilSize
+		} else {
+			s += 1 + 9 + msgp.StringPrefixSize + len(z.WritePoolLocks[za0004].ClientID) + 7 + z.WritePoolLocks[za0004].Amount.Msgsize()
+		}
+	}
+	s += 15 + 1 + 4 + z.ReadPriceRange.Min.Msgsize() + 4 + z.ReadPriceRange.Max.Msgsize() + 16 + 1 + 4 + z.WritePriceRange.Min.Msgsize() + 4 + z.WritePriceRange.Max.Msgsize() + 10 + z.StartTime.Msgsize() + 10 + msgp.BoolSize + 9 + msgp.BoolSize + 17 + z.MovedToChallenge.Msgsize() + 10 + z.MovedBack.Msgsize() + 18 + z.MovedToValidators.Msgsize() + 9 + msgp.DurationSize
 	return
 }
 
@@ -1787,9 +2006,9 @@ func (z *StorageChallenge) Msgsize() (s int) {
 // MarshalMsg implements msgp.Marshaler
 func (z *StorageNode) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 13
+	// map header, size 15
 	// string "Provider"
-	o = append(o, 0x8d, 0xa8, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72)
+	o = append(o, 0x8f, 0xa8, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72)
 	o, err = z.Provider.MarshalMsg(o)
 	if err != nil {
 		err = msgp.WrapError(err, "Provider")
@@ -1868,6 +2087,19 @@ func (z *StorageNode) MarshalMsg(b []byte) (o []byte, err error) {
 	// string "IsAvailable"
 	o = append(o, 0xab, 0x49, 0x73, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65)
 	o = msgp.AppendBool(o, z.IsAvailable)
+	// string "Tiers"
+	o = append(o, 0xa5, 0x54, 0x69, 0x65, 0x72, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Tiers)))
+	for za0001 := range z.Tiers {
+		o, err = z.Tiers[za0001].MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "Tiers", za0001)
+			return
+		}
+	}
+	// string "Reputation"
+	o = append(o, 0xaa, 0x52, 0x65, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e)
+	o = msgp.AppendFloat64(o, z.Reputation)
 	return
 }
 
@@ -2060,6 +2292,31 @@ func (z *StorageNode) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "IsAvailable")
 				return
 			}
+		case "Tiers":
+			var zb0005 uint32
+			zb0005, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Tiers")
+				return
+			}
+			if cap(z.Tiers) >= int(zb0005) {
+				z.Tiers = (z.Tiers)[:zb0005]
+			} else {
+				z.Tiers = make([]StorageTier, zb0005)
+			}
+			for za0001 := range z.Tiers {
+				bts, err = z.Tiers[za0001].UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Tiers", za0001)
+					return
+				}
+			}
+		case "Reputation":
+			z.Reputation, bts, err = msgp.ReadFloat64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Reputation")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -2074,7 +2331,11 @@ func (z *StorageNode) UnmarshalMsg(bts []byte) (o []byte, err error) {
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *StorageNode) Msgsize() (s int) {
-	s = 1 + 9 + z.Provider.Msgsize() + 8 + msgp.StringPrefixSize + len(z.BaseURL) + 12 + 1 + 9 + msgp.Float64Size + 10 + msgp.Float64Size + 6 + 1 + 10 + z.Terms.ReadPrice.Msgsize() + 11 + z.Terms.WritePrice.Msgsize() + 14 + msgp.Float64Size + 9 + msgp.Int64Size + 10 + msgp.Int64Size + 10 + msgp.StringPrefixSize + len(z.PublicKey) + 10 + msgp.Int64Size + 24 + msgp.Float64Size + 24 + msgp.Int64Size + 18 + z.StakePoolSettings.Msgsize() + 12 + 1 + 11 + msgp.Int64Size + 10 + z.RewardRound.Timestamp.Msgsize() + 12 + msgp.BoolSize
+	s = 1 + 9 + z.Provider.Msgsize() + 8 + msgp.StringPrefixSize + len(z.BaseURL) + 12 + 1 + 9 + msgp.Float64Size + 10 + msgp.Float64Size + 6 + 1 + 10 + z.Terms.ReadPrice.Msgsize() + 11 + z.Terms.WritePrice.Msgsize() + 14 + msgp.Float64Size + 9 + msgp.Int64Size + 10 + msgp.Int64Size + 10 + msgp.StringPrefixSize + len(z.PublicKey) + 10 + msgp.Int64Size + 24 + msgp.Float64Size + 24 + msgp.Int64Size + 18 + z.StakePoolSettings.Msgsize() + 12 + 1 + 11 + msgp.Int64Size + 10 + z.RewardRound.Timestamp.Msgsize() + 12 + msgp.BoolSize + 6 + msgp.ArrayHeaderSize
+	for za0001 := range z.Tiers {
+		s += z.Tiers[za0001].Msgsize()
+	}
+	s += 11 + msgp.Float64Size
 	return
 }
 
@@ -2714,6 +2975,71 @@ func (z *WriteMarker) Msgsize() (s int) {
 	return
 }
 
+// MarshalMsg implements msgp.Marshaler
+func (z *WritePoolLock) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "ClientID"
+	o = append(o, 0x82, 0xa8, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x44)
+	o = msgp.AppendString(o, z.ClientID)
+	// string "Amount"
+	o = append(o, 0xa6, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74)
+	o, err = z.Amount.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Amount")
+		return
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *WritePoolLock) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "ClientID":
+			z.ClientID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ClientID")
+				return
+			}
+		case "Amount":
+			bts, err = z.Amount.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Amount")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *WritePoolLock) Msgsize() (s int) {
+	s = 1 + 9 + msgp.StringPrefixSize + len(z.ClientID) + 7 + z.Amount.Msgsize()
+	return
+}
+
 // MarshalMsg implements msgp.Marshaler
 func (z *allocationChallengesDecoder) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
@@ -2860,3 +3186,72 @@ func (z *allocationChallengesDecoder) Msgsize() (s int) {
 	}
 	return
 }
+
+// MarshalMsg implements msgp.Marshaler
+func (z *writePoolShortError) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "WritePool"
+	o = append(o, 0x82, 0xa9, 0x57, 0x72, 0x69, 0x74, 0x65, 0x50, 0x6f, 0x6f, 0x6c)
+	o, err = z.WritePool.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "WritePool")
+		return
+	}
+	// string "Cost"
+	o = append(o, 0xa4, 0x43, 0x6f, 0x73, 0x74)
+	o, err = z.Cost.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Cost")
+		return
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *writePoolShortError) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "WritePool":
+			bts, err = z.WritePool.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "WritePool")
+				return
+			}
+		case "Cost":
+			bts, err = z.Cost.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Cost")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *writePoolShortError) Msgsize() (s int) {
+	s = 1 + 10 + z.WritePool.Msgsize() + 5 + z.Cost.Msgsize()
+	return
+}
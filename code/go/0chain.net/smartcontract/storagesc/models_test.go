@@ -198,3 +198,47 @@ func TestVerifyClientID(t *testing.T) {
 //		require.NoError(t, err)
 //	})
 //}
+
+func TestStorageAllocation_payFromWritePool(t *testing.T) {
+	sa := &StorageAllocation{Owner: "owner", WritePool: 30}
+	require.NoError(t, sa.lockWritePool("alice", 40))
+	require.NoError(t, sa.lockWritePool("alice", 10))
+	require.NoError(t, sa.lockWritePool("bob", 20))
+	sa.WritePool += 70
+
+	// the owner's tokens not locked by a client are paid first
+	paid, err := sa.payFromWritePool(60)
+	require.NoError(t, err)
+	assert.Equal(t, []*WritePoolLock{
+		{ClientID: "owner", Amount: 30},
+		{ClientID: "alice", Amount: 30},
+	}, paid)
+	assert.Equal(t, []*WritePoolLock{
+		{ClientID: "alice", Amount: 20},
+		{ClientID: "bob", Amount: 20},
+	}, sa.WritePoolLocks)
+	assert.EqualValues(t, 40, sa.WritePool)
+
+	// a refund is the owner's
+	sa.WritePool += 5
+	paid, err = sa.payFromWritePool(30)
+	require.NoError(t, err)
+	assert.Equal(t, []*WritePoolLock{
+		{ClientID: "owner", Amount: 5},
+		{ClientID: "alice", Amount: 20},
+		{ClientID: "bob", Amount: 5},
+	}, paid)
+	assert.Equal(t, []*WritePoolLock{{ClientID: "bob", Amount: 15}}, sa.WritePoolLocks)
+
+	_, err = sa.payFromWritePool(16)
+	requireErrMsg(t, err, "insufficient funds 15 in write pool to pay 16")
+
+	// the locks of the previous owner move to the new one
+	require.NoError(t, sa.lockWritePool("owner", 10))
+	sa.WritePool += 10
+	sa.Owner = "new_owner"
+	owned, err := sa.moveWritePoolLocks("owner")
+	require.NoError(t, err)
+	assert.EqualValues(t, 10, owned)
+	assert.Equal(t, "new_owner", sa.WritePoolLocks[1].ClientID)
+}
@@ -37,6 +37,12 @@ type readPool struct {
 
 type readPoolLockRequest struct {
 	TargetId string `json:"target_id,omitempty"`
+	// AllocationID locks the tokens to the sponsored read pool of the
+	// allocation instead of the read pool of the target.
+	AllocationID string `json:"allocation_id,omitempty"`
+	// ReaderLimit sets the per-reader spending limit of the sponsored
+	// read pool, the allocation owner only.
+	ReaderLimit *currency.Coin `json:"reader_limit,omitempty"`
 }
 
 func (lr *readPoolLockRequest) decode(input []byte) (err error) {
@@ -46,6 +52,16 @@ func (lr *readPoolLockRequest) decode(input []byte) (err error) {
 	return // ok
 }
 
+type readPoolUnlockRequest struct {
+	// AllocationID unlocks the tokens left of the sponsored read pool of
+	// the allocation instead of the read pool of the client.
+	AllocationID string `json:"allocation_id,omitempty"`
+}
+
+func (ur *readPoolUnlockRequest) decode(input []byte) error {
+	return json.Unmarshal(input, ur)
+}
+
 // The readPoolRedeem represents part of response of read markers redeeming.
 // A Blobber uses this response for internal read pools cache.
 type readPoolRedeem struct {
@@ -162,6 +178,17 @@ func (ssc *StorageSmartContract) readPoolLock(txn *transaction.Transaction, inpu
 			"can't get configs: "+err.Error())
 	}
 
+	var req readPoolLockRequest
+	if len(input) > 0 {
+		if err = req.decode(input); err != nil {
+			return "", common.NewError("read_pool_lock_failed", err.Error())
+		}
+	}
+
+	if req.AllocationID != "" {
+		return ssc.allocationReadPoolLock(txn, &req, conf, balances)
+	}
+
 	if txn.Value < conf.MinLock {
 		return "", common.NewError("read_pool_lock_failed",
 			"insufficient amount to lock")
@@ -172,11 +199,6 @@ func (ssc *StorageSmartContract) readPoolLock(txn *transaction.Transaction, inpu
 			"invalid amount to lock [ensure token > 0].")
 	}
 
-	var req readPoolLockRequest
-	if err = req.decode(input); err != nil {
-		return "", common.NewError("read_pool_lock_failed", err.Error())
-	}
-
 	if req.TargetId == "" {
 		req.TargetId = txn.ClientID
 	}
@@ -236,7 +258,17 @@ func (ssc *StorageSmartContract) readPoolLockInternal(txn *transaction.Transacti
 }
 
 // unlock tokens if expired
-func (ssc *StorageSmartContract) readPoolUnlock(txn *transaction.Transaction, _ []byte, balances cstate.StateContextI) (string, error) {
+func (ssc *StorageSmartContract) readPoolUnlock(txn *transaction.Transaction, input []byte, balances cstate.StateContextI) (string, error) {
+	if len(input) > 0 {
+		var req readPoolUnlockRequest
+		if err := req.decode(input); err != nil {
+			return "", common.NewError("read_pool_unlock_failed", err.Error())
+		}
+		if req.AllocationID != "" {
+			return ssc.allocationReadPoolUnlock(txn, req.AllocationID, balances)
+		}
+	}
+
 	rp, err := ssc.getReadPool(txn.ClientID, balances)
 	if err != nil {
 		return "", common.NewErrorf("read_pool_unlock_failed", "no read pool found for clientID to unlock token: %v", err)
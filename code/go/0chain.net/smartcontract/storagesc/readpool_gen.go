@@ -3,6 +3,7 @@ package storagesc
 // Code generated by github.com/tinylib/msgp DO NOT EDIT.
 
 import (
+	"github.com/0chain/common/core/currency"
 	"github.com/tinylib/msgp/msgp"
 )
 
@@ -63,12 +64,26 @@ func (z *readPool) Msgsize() (s int) {
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z readPoolLockRequest) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *readPoolLockRequest) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 1
+	// map header, size 3
 	// string "TargetId"
-	o = append(o, 0x81, 0xa8, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x64)
+	o = append(o, 0x83, 0xa8, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x49, 0x64)
 	o = msgp.AppendString(o, z.TargetId)
+	// string "AllocationID"
+	o = append(o, 0xac, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44)
+	o = msgp.AppendString(o, z.AllocationID)
+	// string "ReaderLimit"
+	o = append(o, 0xab, 0x52, 0x65, 0x61, 0x64, 0x65, 0x72, 0x4c, 0x69, 0x6d, 0x69, 0x74)
+	if z.ReaderLimit == nil {
+		o = msgp.AppendNil(o)
+	} else {
+		o, err = z.ReaderLimit.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "ReaderLimit")
+			return
+		}
+	}
 	return
 }
 
@@ -96,6 +111,86 @@ func (z *readPoolLockRequest) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "TargetId")
 				return
 			}
+		case "AllocationID":
+			z.AllocationID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "AllocationID")
+				return
+			}
+		case "ReaderLimit":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.ReaderLimit = nil
+			} else {
+				if z.ReaderLimit == nil {
+					z.ReaderLimit = new(currency.Coin)
+				}
+				bts, err = z.ReaderLimit.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "ReaderLimit")
+					return
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *readPoolLockRequest) Msgsize() (s int) {
+	s = 1 + 9 + msgp.StringPrefixSize + len(z.TargetId) + 13 + msgp.StringPrefixSize + len(z.AllocationID) + 12
+	if z.ReaderLimit == nil {
+		s += msgp.NilSize
+	} else {
+		s += z.ReaderLimit.Msgsize()
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z readPoolUnlockRequest) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 1
+	// string "AllocationID"
+	o = append(o, 0x81, 0xac, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44)
+	o = msgp.AppendString(o, z.AllocationID)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *readPoolUnlockRequest) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "AllocationID":
+			z.AllocationID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "AllocationID")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -109,7 +204,7 @@ func (z *readPoolLockRequest) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z readPoolLockRequest) Msgsize() (s int) {
-	s = 1 + 9 + msgp.StringPrefixSize + len(z.TargetId)
+func (z readPoolUnlockRequest) Msgsize() (s int) {
+	s = 1 + 13 + msgp.StringPrefixSize + len(z.AllocationID)
 	return
 }
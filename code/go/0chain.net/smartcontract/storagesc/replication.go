@@ -0,0 +1,382 @@
+package storagesc
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strconv"
+
+	cstate "0chain.net/chaincore/chain/state"
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/encryption"
+	"0chain.net/smartcontract/dbs/event"
+	"0chain.net/smartcontract/partitions"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/util"
+)
+
+//
+// degraded allocations and their blobbers replacement
+//
+
+// markBlobberAllocationsDegraded marks the active allocations of the last
+// allocations partition of the killed or shut down blobber as degraded until
+// the blobber is replaced. The other partitions are left to the
+// mark_degraded_allocations calls.
+func (sc *StorageSmartContract) markBlobberAllocationsDegraded(
+	blobberID string,
+	balances cstate.StateContextI,
+) error {
+	parts, err := partitionsBlobberAllocations(blobberID, balances)
+	if err != nil {
+		return fmt.Errorf("can't get blobber allocations: %v", err)
+	}
+
+	left, err := sc.markAllocationsPartitionDegraded(blobberID, parts, parts.NumPartitions, balances)
+	if err != nil || left == 0 {
+		return err
+	}
+
+	_, err = balances.InsertTrieNode(getDegradedAllocationsCursorKey(blobberID),
+		&DegradedAllocationsCursor{PartsLeft: left})
+	return err
+}
+
+// markAllocationsPartitionDegraded marks the active allocations of the last
+// partition of the ones left as degraded and returns the number of the
+// partitions left. The allocations moved from the last partition to the
+// ones left on removal are marked again, which is a no-op.
+func (sc *StorageSmartContract) markAllocationsPartitionDegraded(
+	blobberID string,
+	parts *partitions.Partitions,
+	left int,
+	balances cstate.StateContextI,
+) (int, error) {
+	// the tail partitions are removed once empty
+	if left > parts.NumPartitions {
+		left = parts.NumPartitions
+	}
+	if left == 0 {
+		return 0, nil
+	}
+
+	ids, err := parts.PartitionIDs(balances, left-1)
+	if err != nil {
+		return 0, fmt.Errorf("can't get blobber allocations: %v", err)
+	}
+
+	for _, allocID := range ids {
+		alloc, err := sc.getAllocation(allocID, balances)
+		if err != nil {
+			return 0, fmt.Errorf("can't get allocation %s: %v", allocID, err)
+		}
+		if alloc.Finalized || alloc.Canceled || !alloc.addDegradedBlobber(blobberID) {
+			continue
+		}
+		if err := alloc.save(balances, sc.ID); err != nil {
+			return 0, fmt.Errorf("can't save allocation %s: %v", allocID, err)
+		}
+		balances.EmitEvent(event.TypeStats, event.TagUpdateAllocation, alloc.ID, alloc.buildDbUpdates())
+	}
+
+	return left - 1, nil
+}
+
+type markDegradedAllocationsRequest struct {
+	BlobberID string `json:"blobber_id"`
+}
+
+// markDegradedAllocations marks the next allocations partition of the killed
+// or shut down blobber as degraded. Anyone can call it until all the
+// allocations of the blobber are marked, the allocations not marked yet can
+// be replaced with replace_degraded_blobber as well.
+func (sc *StorageSmartContract) markDegradedAllocations(
+	_ *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	var req markDegradedAllocationsRequest
+	if err := json.Unmarshal(input, &req); err != nil {
+		return "", common.NewError("mark_degraded_allocations_failed",
+			"invalid request: "+err.Error())
+	}
+
+	var (
+		key    = getDegradedAllocationsCursorKey(req.BlobberID)
+		cursor DegradedAllocationsCursor
+	)
+	switch err := balances.GetTrieNode(key, &cursor); err {
+	case nil:
+	case util.ErrValueNotPresent:
+		return "", common.NewErrorf("mark_degraded_allocations_failed",
+			"no allocations of blobber %s left to mark degraded", req.BlobberID)
+	default:
+		return "", common.NewError("mark_degraded_allocations_failed", err.Error())
+	}
+
+	parts, err := partitionsBlobberAllocations(req.BlobberID, balances)
+	if err != nil {
+		return "", common.NewError("mark_degraded_allocations_failed",
+			"can't get blobber allocations: "+err.Error())
+	}
+
+	cursor.PartsLeft, err = sc.markAllocationsPartitionDegraded(req.BlobberID, parts, cursor.PartsLeft, balances)
+	if err != nil {
+		return "", common.NewError("mark_degraded_allocations_failed", err.Error())
+	}
+
+	if cursor.PartsLeft == 0 {
+		_, err = balances.DeleteTrieNode(key)
+	} else {
+		_, err = balances.InsertTrieNode(key, &cursor)
+	}
+	if err != nil {
+		return "", common.NewError("mark_degraded_allocations_failed",
+			"saving cursor: "+err.Error())
+	}
+
+	resp, err := json.Marshal(&cursor)
+	if err != nil {
+		return "", common.NewError("mark_degraded_allocations_failed", err.Error())
+	}
+	return string(resp), nil
+}
+
+func (sa *StorageAllocation) isDegradedBlobber(blobberID string) bool {
+	for _, id := range sa.DegradedBlobbers {
+		if id == blobberID {
+			return true
+		}
+	}
+	return false
+}
+
+// addDegradedBlobber returns false if the blobber is already degraded.
+func (sa *StorageAllocation) addDegradedBlobber(blobberID string) bool {
+	if sa.isDegradedBlobber(blobberID) {
+		return false
+	}
+	sa.DegradedBlobbers = append(sa.DegradedBlobbers, blobberID)
+	return true
+}
+
+func (sa *StorageAllocation) removeDegradedBlobber(blobberID string) {
+	for i, id := range sa.DegradedBlobbers {
+		if id == blobberID {
+			sa.DegradedBlobbers = append(sa.DegradedBlobbers[:i], sa.DegradedBlobbers[i+1:]...)
+			break
+		}
+	}
+	if len(sa.DegradedBlobbers) == 0 {
+		sa.DegradedBlobbers = nil
+	}
+}
+
+type replaceBlobberRequest struct {
+	AllocationID string `json:"allocation_id"`
+	BlobberID    string `json:"blobber_id"`
+	// Candidates are the blobbers to pick the replacement from, in order
+	// of preference. If not given the smart contract picks them, see
+	// replacementCandidates.
+	Candidates []string `json:"candidates,omitempty"`
+}
+
+func (rbr *replaceBlobberRequest) decode(p []byte) error {
+	return json.Unmarshal(p, rbr)
+}
+
+// replacementCandidates returns the blobbers of a random challenge ready
+// partition in random order, seeded by the transaction. These blobbers
+// store data and are not killed or shut down, the allocation filters them
+// by its terms then.
+func replacementCandidates(txn *transaction.Transaction, balances cstate.StateContextI) ([]string, error) {
+	parts, err := partitionsChallengeReadyBlobbers(balances)
+	if err != nil {
+		return nil, fmt.Errorf("can't get challenge ready blobbers: %v", err)
+	}
+	if parts.NumPartitions == 0 {
+		return nil, nil
+	}
+
+	seed, err := strconv.ParseInt(encryption.Hash(txn.Hash + balances.GetBlock().PrevHash)[0:15], 16, 64)
+	if err != nil {
+		return nil, fmt.Errorf("can't create seed: %v", err)
+	}
+	r := rand.New(rand.NewSource(seed))
+
+	var crbs []ChallengeReadyBlobber
+	if err := parts.GetRandomItems(balances, r, &crbs); err != nil {
+		return nil, fmt.Errorf("can't get challenge ready blobbers: %v", err)
+	}
+
+	ids := make([]string, 0, len(crbs))
+	for _, i := range r.Perm(len(crbs)) {
+		ids = append(ids, crbs[i].BlobberID)
+	}
+	return ids, nil
+}
+
+// selectReplacementBlobber returns the first candidate fitting the
+// allocation terms and placement with the remaining blobbers, not used by
+// the allocation and active.
+func (sc *StorageSmartContract) selectReplacementBlobber(
+	conf *Config,
+	alloc *StorageAllocation,
+	remaining []*StorageNode,
+	candidates []string,
+	now common.Timestamp,
+	balances cstate.StateContextI,
+) (*StorageNode, error) {
+	list := make([]*StorageNode, 0, len(candidates))
+	for _, id := range candidates {
+		if _, ok := alloc.BlobberAllocsMap[id]; ok {
+			continue
+		}
+		b, err := getBlobber(id, balances)
+		if err != nil {
+			continue
+		}
+		list = append(list, b)
+	}
+
+	list, err := alloc.filterBlobbers(list, now, alloc.bSize(),
+		sc.filterBlobbersByFreeSpace(now, alloc.bSize(), balances))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, b := range list {
+		if alloc.Placement.checkAdded(remaining, b) != nil {
+			continue
+		}
+		sp, err := sc.getStakePool(spenum.Blobber, b.ID, balances)
+		if err != nil {
+			continue
+		}
+		staked, err := sp.stake()
+		if err != nil {
+			continue
+		}
+		if alloc.isActive(b, staked, sp.TotalOffers, conf, now) == nil {
+			return b, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no candidate blobber fits the allocation")
+}
+
+// replaceDegradedBlobber replaces a killed or shut down blobber of the
+// allocation with one of the candidate blobbers, or with one the smart
+// contract picks if there are no candidates. The allocation owner can
+// always replace it, anyone else only if the allocation is opted in with
+// AutoReplaceBlobbers. The new blobber restores the data of the allocation
+// on the BlobberReplacement event.
+func (sc *StorageSmartContract) replaceDegradedBlobber(
+	txn *transaction.Transaction,
+	input []byte,
+	balances cstate.StateContextI,
+) (string, error) {
+	var req replaceBlobberRequest
+	if err := req.decode(input); err != nil {
+		return "", common.NewError("replace_blobber_failed",
+			"invalid request: "+err.Error())
+	}
+
+	conf, err := sc.getConfig(balances, false)
+	if err != nil {
+		return "", common.NewError("replace_blobber_failed",
+			"can't get SC configurations: "+err.Error())
+	}
+
+	alloc, err := sc.getAllocation(req.AllocationID, balances)
+	if err != nil {
+		return "", common.NewError("replace_blobber_failed",
+			"can't get allocation: "+err.Error())
+	}
+
+	if txn.ClientID != alloc.Owner && !alloc.AutoReplaceBlobbers {
+		return "", common.NewError("replace_blobber_failed",
+			"only owner can replace blobbers of the allocation")
+	}
+	if alloc.Finalized || alloc.Canceled || alloc.Expiration < txn.CreationDate {
+		return "", common.NewError("replace_blobber_failed",
+			"allocation is finalized, canceled or expired")
+	}
+	blobbers, err := sc.getAllocationBlobbers(alloc, balances)
+	if err != nil {
+		return "", common.NewError("replace_blobber_failed", err.Error())
+	}
+
+	// the allocations of the blobber may be not marked degraded yet
+	var (
+		remaining = make([]*StorageNode, 0, len(blobbers))
+		degraded  = alloc.isDegradedBlobber(req.BlobberID)
+	)
+	for _, b := range blobbers {
+		if b.ID != req.BlobberID {
+			remaining = append(remaining, b)
+		} else if b.IsKilled() || b.IsShutDown() {
+			degraded = true
+		}
+	}
+	if !degraded {
+		return "", common.NewErrorf("replace_blobber_failed",
+			"blobber %s of the allocation is not degraded", req.BlobberID)
+	}
+
+	candidates := req.Candidates
+	if len(candidates) == 0 {
+		if candidates, err = replacementCandidates(txn, balances); err != nil {
+			return "", common.NewError("replace_blobber_failed", err.Error())
+		}
+	}
+
+	replacement, err := sc.selectReplacementBlobber(conf, alloc, remaining, candidates,
+		txn.CreationDate, balances)
+	if err != nil {
+		return "", common.NewError("replace_blobber_failed", err.Error())
+	}
+
+	alloc.Tx = txn.Hash
+	blobbers, err = alloc.changeBlobbers(conf, blobbers, replacement.ID, req.BlobberID,
+		sc, txn.CreationDate, balances)
+	if err != nil {
+		return "", common.NewError("replace_blobber_failed", err.Error())
+	}
+
+	update := updateAllocationRequest{
+		ID:              alloc.ID,
+		AddBlobberId:    replacement.ID,
+		RemoveBlobberId: req.BlobberID,
+	}
+	if err := sc.extendAllocation(txn, conf, alloc, blobbers, &update, balances); err != nil {
+		return "", err
+	}
+	if err := alloc.checkFunding(conf.CancellationCharge); err != nil {
+		return "", common.NewError("replace_blobber_failed", err.Error())
+	}
+
+	alloc.removeDegradedBlobber(req.BlobberID)
+
+	balances.EmitEvent(event.TypeStats, event.TagDeleteAllocationBlobberTerm, txn.Hash, []event.AllocationBlobberTerm{
+		{
+			AllocationID: alloc.ID,
+			BlobberID:    req.BlobberID,
+		},
+	})
+
+	if err := alloc.saveUpdatedAllocation(blobbers, balances); err != nil {
+		return "", common.NewError("replace_blobber_failed", err.Error())
+	}
+
+	emitAddOrOverwriteAllocationBlobberTerms(alloc, balances, txn)
+
+	balances.EmitEvent(event.TypeStats, event.TagAddBlobberReplacement, alloc.ID, event.BlobberReplacement{
+		AllocationID: alloc.ID,
+		OldBlobberID: req.BlobberID,
+		NewBlobberID: replacement.ID,
+	})
+
+	return string(alloc.Encode()), nil
+}
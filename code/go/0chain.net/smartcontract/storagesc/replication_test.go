@@ -0,0 +1,216 @@
+package storagesc
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
+	"0chain.net/core/encryption"
+	"0chain.net/smartcontract/provider"
+	"0chain.net/smartcontract/stakepool"
+	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const replaceClientID, replacePubKey = "client_hex", "pub_key_hex"
+
+// newReplaceDegradedBlobberTest creates an allocation of the b1 and b2
+// blobbers, and the b3 blobber to replace one of them.
+func newReplaceDegradedBlobberTest(t *testing.T) (
+	*StorageSmartContract, *testBalances, *transaction.Transaction, *StorageAllocation,
+) {
+	const clientID, pubKey = replaceClientID, replacePubKey
+
+	var (
+		ssc      = newTestStorageSC()
+		balances = newTestBalances(t, false)
+		tx       = transaction.Transaction{
+			ClientID:     clientID,
+			ToClientID:   ADDRESS,
+			CreationDate: toSeconds(2 * time.Hour),
+		}
+	)
+	tx.Hash = encryption.Hash("new allocation")
+	balances.setTransaction(t, &tx)
+
+	conf := setConfig(t, balances)
+	conf.MinAllocSize = 10 * GB
+	conf.TimeUnit = 20 * time.Second
+	_, err := balances.InsertTrieNode(scConfigKey(ADDRESS), conf)
+	require.NoError(t, err)
+
+	// b1 and b2 of the allocation and b3 to replace one of them
+	nodes := newTestAllBlobbers().Nodes
+	nodes = append(nodes, &StorageNode{
+		Provider: provider.Provider{
+			ID:           "b3",
+			ProviderType: spenum.Blobber,
+		},
+		BaseURL:     "http://blobber3.test.ru:9100/api",
+		Terms:       Terms{ReadPrice: 30, WritePrice: 300, MinLockDemand: 0.1},
+		Capacity:    30 * GB,
+		IsAvailable: true,
+	})
+	for _, b := range nodes {
+		b.LastHealthCheck = tx.CreationDate
+		_, err = balances.InsertTrieNode(b.GetKey(), b)
+		require.NoError(t, err)
+
+		sp := newStakePool()
+		sp.Pools["hash "+b.ID] = &stakepool.DelegatePool{Balance: 20e10}
+		require.NoError(t, sp.Save(spenum.Blobber, b.ID, balances))
+	}
+
+	nar := newAllocationRequest{
+		DataShards:      1,
+		ParityShards:    1,
+		Size:            10 * GB,
+		Expiration:      tx.CreationDate + toSeconds(100*time.Second),
+		Owner:           clientID,
+		OwnerPublicKey:  pubKey,
+		Blobbers:        []string{"b1", "b2"},
+		ReadPriceRange:  PriceRange{Min: 10, Max: 40},
+		WritePriceRange: PriceRange{Min: 100, Max: 400},
+	}
+	balances.balances[clientID] = 1100 + 4500
+	tx.Value = 5000
+	resp, err := ssc.newAllocationRequest(&tx, mustEncode(t, &nar), balances, nil)
+	require.NoError(t, err)
+	var alloc StorageAllocation
+	require.NoError(t, alloc.Decode([]byte(resp)))
+	assert.False(t, alloc.AutoReplaceBlobbers)
+	return ssc, balances, &tx, &alloc
+}
+
+func replaceBlobber(t *testing.T, ssc *StorageSmartContract, balances *testBalances,
+	now common.Timestamp, clientID string, req *replaceBlobberRequest) (string, error) {
+	tx := transaction.Transaction{
+		ClientID:     clientID,
+		ToClientID:   ADDRESS,
+		CreationDate: now,
+	}
+	tx.Hash = encryption.Hash("replace " + clientID + " " + req.BlobberID)
+	balances.setTransaction(t, &tx)
+	return ssc.replaceDegradedBlobber(&tx, mustEncode(t, req), balances)
+}
+
+func TestStorageSmartContract_replaceDegradedBlobber(t *testing.T) {
+	const clientID = replaceClientID
+	ssc, balances, tx, alloc := newReplaceDegradedBlobberTest(t)
+
+	// the allocations of the killed blobber are degraded
+	require.NoError(t, ssc.markBlobberAllocationsDegraded("b1", balances))
+	require.NoError(t, ssc.markBlobberAllocationsDegraded("b1", balances))
+	degraded, err := ssc.getAllocation(alloc.ID, balances)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b1"}, degraded.DegradedBlobbers)
+
+	replace := func(clientID string, req *replaceBlobberRequest) (string, error) {
+		return replaceBlobber(t, ssc, balances, tx.CreationDate+10, clientID, req)
+	}
+
+	req := &replaceBlobberRequest{
+		AllocationID: alloc.ID,
+		BlobberID:    "b1",
+		Candidates:   []string{"b2", "b3"},
+	}
+	_, err = replace("other", req)
+	requireErrMsg(t, err, "replace_blobber_failed: only owner can replace blobbers of the allocation")
+	_, err = replace(clientID, &replaceBlobberRequest{AllocationID: alloc.ID, BlobberID: "b2"})
+	requireErrMsg(t, err, "replace_blobber_failed: blobber b2 of the allocation is not degraded")
+	_, err = replace(clientID, &replaceBlobberRequest{AllocationID: alloc.ID, BlobberID: "b1",
+		Candidates: []string{"b2"}})
+	requireErrMsg(t, err, "replace_blobber_failed: no candidate blobber fits the allocation")
+
+	// b2 is used by the allocation already, b3 replaces b1
+	_, err = replace(clientID, req)
+	require.NoError(t, err)
+
+	replaced, err := ssc.getAllocation(alloc.ID, balances)
+	require.NoError(t, err)
+	assert.Empty(t, replaced.DegradedBlobbers)
+	require.Len(t, replaced.BlobberAllocs, 2)
+	assert.Contains(t, replaced.BlobberAllocsMap, "b3")
+	assert.NotContains(t, replaced.BlobberAllocsMap, "b1")
+	assert.Equal(t, 1, replaced.ParityShards)
+}
+
+func TestStorageSmartContract_replaceDegradedBlobberPicked(t *testing.T) {
+	const clientID = replaceClientID
+	ssc, balances, tx, alloc := newReplaceDegradedBlobberTest(t)
+
+	// the killed b1 is replaced before its allocations are marked degraded
+	b1, err := getBlobber("b1", balances)
+	require.NoError(t, err)
+	b1.Kill()
+	_, err = balances.InsertTrieNode(b1.GetKey(), b1)
+	require.NoError(t, err)
+
+	req := &replaceBlobberRequest{AllocationID: alloc.ID, BlobberID: "b1"}
+	_, err = replaceBlobber(t, ssc, balances, tx.CreationDate+10, clientID, req)
+	requireErrMsg(t, err, "replace_blobber_failed: no candidate blobber fits the allocation")
+
+	// the smart contract picks b3 of the challenge ready blobbers
+	for _, id := range []string{"b2", "b3"} {
+		require.NoError(t, partitionsChallengeReadyBlobberAddOrUpdate(balances, id, 1, 1))
+	}
+	_, err = replaceBlobber(t, ssc, balances, tx.CreationDate+10, clientID, req)
+	require.NoError(t, err)
+
+	replaced, err := ssc.getAllocation(alloc.ID, balances)
+	require.NoError(t, err)
+	require.Len(t, replaced.BlobberAllocs, 2)
+	assert.Contains(t, replaced.BlobberAllocsMap, "b3")
+	assert.NotContains(t, replaced.BlobberAllocsMap, "b1")
+}
+
+func TestStorageSmartContract_markDegradedAllocations(t *testing.T) {
+	const (
+		blobberID = "b1"
+		allocsNum = 2*blobberAllocationPartitionSize + 10
+	)
+	var (
+		ssc      = newTestStorageSC()
+		balances = newTestBalances(t, false)
+		tx       = transaction.Transaction{ClientID: "anyone", ToClientID: ADDRESS}
+	)
+
+	for i := 0; i < allocsNum; i++ {
+		alloc := &StorageAllocation{ID: "alloc_" + strconv.Itoa(i)}
+		require.NoError(t, alloc.save(balances, ADDRESS))
+		_, err := partitionsBlobberAllocationsAdd(balances, blobberID, alloc.ID)
+		require.NoError(t, err)
+	}
+
+	countDegraded := func() (n int) {
+		for i := 0; i < allocsNum; i++ {
+			alloc, err := ssc.getAllocation("alloc_"+strconv.Itoa(i), balances)
+			require.NoError(t, err)
+			if alloc.isDegradedBlobber(blobberID) {
+				n++
+			}
+		}
+		return n
+	}
+
+	// only the last partition is marked on the blobber kill
+	require.NoError(t, ssc.markBlobberAllocationsDegraded(blobberID, balances))
+	assert.Equal(t, 10, countDegraded())
+
+	input := mustEncode(t, &markDegradedAllocationsRequest{BlobberID: blobberID})
+	for _, left := range []int{1, 0} {
+		resp, err := ssc.markDegradedAllocations(&tx, input, balances)
+		require.NoError(t, err)
+		var cursor DegradedAllocationsCursor
+		require.NoError(t, json.Unmarshal([]byte(resp), &cursor))
+		assert.Equal(t, left, cursor.PartsLeft)
+	}
+	assert.Equal(t, allocsNum, countDegraded())
+
+	_, err := ssc.markDegradedAllocations(&tx, input, balances)
+	requireErrMsg(t, err, "mark_degraded_allocations_failed: no allocations of blobber b1 left to mark degraded")
+}
@@ -66,6 +66,12 @@ func (ssc *StorageSmartContract) setSC(sc *sci.SmartContract, _ sci.BCContextI)
 	// allocation
 	ssc.SmartContractExecutionStats["new_allocation_request"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "new_allocation_request"), nil)
 	ssc.SmartContractExecutionStats["update_allocation_request"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "update_allocation_request"), nil)
+	ssc.SmartContractExecutionStats["replace_degraded_blobber"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "replace_degraded_blobber"), nil)
+	ssc.SmartContractExecutionStats["mark_degraded_allocations"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "mark_degraded_allocations"), nil)
+	ssc.SmartContractExecutionStats["transfer_allocation_ownership"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "transfer_allocation_ownership"), nil)
+	ssc.SmartContractExecutionStats["accept_allocation_ownership"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "accept_allocation_ownership"), nil)
+	ssc.SmartContractExecutionStats["renew_allocations"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "renew_allocations"), nil)
+	ssc.SmartContractExecutionStats["update_allocation_acl"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "update_allocation_acl"), nil)
 	ssc.SmartContractExecutionStats["finalize_allocation"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "finalize_allocation"), nil)
 	ssc.SmartContractExecutionStats["cancel_allocation"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "cancel_allocation"), nil)
 	ssc.SmartContractExecutionStats["free_allocation_request"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "free_allocation_request"), nil)
@@ -107,6 +113,9 @@ func (ssc *StorageSmartContract) setSC(sc *sci.SmartContract, _ sci.BCContextI)
 	// stake pool
 	ssc.SmartContractExecutionStats["stake_pool_lock"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "stake_pool_lock"), nil)
 	ssc.SmartContractExecutionStats["stake_pool_unlock"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "stake_pool_unlock"), nil)
+	ssc.SmartContractExecutionStats["stake_pool_auto_compound"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "stake_pool_auto_compound"), nil)
+	ssc.SmartContractExecutionStats["stake_pool_redelegate"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "stake_pool_redelegate"), nil)
+	ssc.SmartContractExecutionStats["stake_pool_withdraw_unbonded"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "stake_pool_withdraw_unbonded"), nil)
 	ssc.SmartContractExecutionStats["stake_pool_pay_interests"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "stake_pool_pay_interests"), nil)
 	ssc.SmartContractExecutionStats["pay_reward"] = metrics.GetOrRegisterTimer(fmt.Sprintf("sc:%v:func:%v", ssc.ID, "pay_reward (add/update/remove SC function)"), nil)
 }
@@ -188,6 +197,18 @@ func (sc *StorageSmartContract) Execute(t *transaction.Transaction,
 		resp, err = sc.newAllocationRequest(t, input, balances, nil)
 	case "update_allocation_request":
 		resp, err = sc.updateAllocationRequest(t, input, balances)
+	case "replace_degraded_blobber":
+		resp, err = sc.replaceDegradedBlobber(t, input, balances)
+	case "mark_degraded_allocations":
+		resp, err = sc.markDegradedAllocations(t, input, balances)
+	case "transfer_allocation_ownership":
+		resp, err = sc.transferAllocationOwnership(t, input, balances)
+	case "accept_allocation_ownership":
+		resp, err = sc.acceptAllocationOwnership(t, input, balances)
+	case "renew_allocations":
+		resp, err = sc.renewAllocations(t, input, balances)
+	case "update_allocation_acl":
+		resp, err = sc.updateAllocationACL(t, input, balances)
 	case "finalize_allocation":
 		resp, err = sc.finalizeAllocation(t, input, balances)
 	case "cancel_allocation":
@@ -250,6 +271,12 @@ func (sc *StorageSmartContract) Execute(t *transaction.Transaction,
 		resp, err = sc.stakePoolLock(t, input, balances)
 	case "stake_pool_unlock":
 		resp, err = sc.stakePoolUnlock(t, input, balances)
+	case "stake_pool_auto_compound":
+		resp, err = sc.stakePoolAutoCompound(t, input, balances)
+	case "stake_pool_redelegate":
+		resp, err = sc.stakePoolRedelegate(t, input, balances)
+	case "stake_pool_withdraw_unbonded":
+		resp, err = sc.stakePoolWithdrawUnbonded(t, input, balances)
 	case "collect_reward":
 		resp, err = sc.collectReward(t, input, balances)
 	case "generate_challenge":
@@ -12,7 +12,7 @@ import (
 // shutdownBlobber
 // shuts down the blobber: It is no longer available for new allocations
 // but its existing commitments will still be upheld.
-func (_ *StorageSmartContract) shutdownBlobber(
+func (sc *StorageSmartContract) shutdownBlobber(
 	tx *transaction.Transaction,
 	input []byte,
 	balances cstate.StateContextI,
@@ -48,6 +48,9 @@ func (_ *StorageSmartContract) shutdownBlobber(
 	if err != nil {
 		return "", common.NewError("shutdown_blobber_failed", "saving blobber: "+err.Error())
 	}
+	if err := sc.markBlobberAllocationsDegraded(blobber.ID, balances); err != nil {
+		return "", common.NewError("shutdown_blobber_failed", "marking allocations degraded: "+err.Error())
+	}
 	return "", nil
 }
 
@@ -14,6 +14,7 @@ import (
 
 	chainstate "0chain.net/chaincore/chain/state"
 	"0chain.net/chaincore/transaction"
+	"0chain.net/core/common"
 	"0chain.net/core/datastore"
 	"github.com/0chain/common/core/util"
 )
@@ -21,6 +22,18 @@ import (
 //msgp:ignore unlockResponse stakePoolStat stakePoolRequest delegatePoolStat rewardsStat
 //go:generate msgp -io=false -tests=false -unexported=true -v
 
+func init() {
+	getMaxStake := func(balances chainstate.CommonStateContextI) (currency.Coin, error) {
+		conf, err := getConfig(balances)
+		if err != nil {
+			return 0, err
+		}
+		return conf.MaxStake, nil
+	}
+	stakepool.RegisterMaxStakeGetter(spenum.Blobber, getMaxStake)
+	stakepool.RegisterMaxStakeGetter(spenum.Validator, getMaxStake)
+}
+
 func validateStakePoolSettings(
 	sps stakepool.Settings,
 	conf *Config,
@@ -127,7 +140,63 @@ func (sp *stakePool) Empty(
 		return errors.New("trying to unlock not by delegate pool owner")
 	}
 
-	requiredBalance, err := currency.AddCoin(sp.TotalOffers, dp.Balance)
+	if err := sp.checkOffersCovered(dp.Balance); err != nil {
+		return err
+	}
+
+	transfer := state.NewTransfer(sscID, clientID, dp.Balance)
+	if err := balances.AddTransfer(transfer); err != nil {
+		return err
+	}
+
+	sp.Pools[poolID].Balance = 0
+	sp.Pools[poolID].Status = spenum.Deleted
+
+	return nil
+}
+
+// move a delegate pool to the unbonding queue if possible
+func (sp *stakePool) Unbond(
+	clientID string,
+	maturesAt int64,
+	providerType spenum.Provider,
+	providerID string,
+	balances chainstate.StateContextI,
+) (currency.Coin, error) {
+	var dp, ok = sp.Pools[clientID]
+	if !ok {
+		return 0, fmt.Errorf("no such delegate pool: %q", clientID)
+	}
+
+	if err := sp.checkOffersCovered(dp.Balance); err != nil {
+		return 0, err
+	}
+
+	return sp.StakePool.Unbond(clientID, maturesAt, providerType, providerID, balances)
+}
+
+// remove a delegate pool to move its stake to another provider if possible
+func (sp *stakePool) Undelegate(
+	clientID string,
+	providerType spenum.Provider,
+	providerID string,
+	balances chainstate.StateContextI,
+) (*stakepool.DelegatePool, currency.Coin, error) {
+	var dp, ok = sp.Pools[clientID]
+	if !ok {
+		return nil, 0, fmt.Errorf("no such delegate pool: %q", clientID)
+	}
+
+	if err := sp.checkOffersCovered(dp.Balance); err != nil {
+		return nil, 0, err
+	}
+
+	return sp.StakePool.Undelegate(clientID, providerType, providerID, balances)
+}
+
+// the stake left after unlocking the balance should cover the offers
+func (sp *stakePool) checkOffersCovered(unlock currency.Coin) error {
+	requiredBalance, err := currency.AddCoin(sp.TotalOffers, unlock)
 	if err != nil {
 		return err
 	}
@@ -139,17 +208,9 @@ func (sp *stakePool) Empty(
 
 	if staked < requiredBalance {
 		return fmt.Errorf("insufficent stake to cover offers: existing stake %d, unlock balance %d, offers %d",
-			staked, dp.Balance, sp.TotalOffers)
+			staked, unlock, sp.TotalOffers)
 	}
 
-	transfer := state.NewTransfer(sscID, clientID, dp.Balance)
-	if err := balances.AddTransfer(transfer); err != nil {
-		return err
-	}
-
-	sp.Pools[poolID].Balance = 0
-	sp.Pools[poolID].Status = spenum.Deleted
-
 	return nil
 }
 
@@ -195,10 +256,21 @@ func (sp *stakePool) slash(
 		return 0, err
 	}
 
+	// the unbonding stake is slashed as well until it's withdrawn
+	unbonding, err := sp.UnbondingStake()
+	if err != nil {
+		return 0, err
+	}
+
+	total, err := currency.AddCoin(staked, unbonding)
+	if err != nil {
+		return 0, err
+	}
+
 	// offer ratio of entire stake; we are slashing only part of the offer
 	// moving the tokens to allocation user; the ratio is part of entire
 	// stake should be moved;
-	var ratio = float64(slash) / float64(staked)
+	var ratio = float64(slash) / float64(total)
 	edbSlash := stakepool.NewStakePoolReward(blobID, spenum.Blobber, spenum.ChallengeSlashPenalty)
 	orderedPoolIds := sp.OrderedPoolIds()
 	for _, id := range orderedPoolIds {
@@ -223,6 +295,15 @@ func (sp *stakePool) slash(
 		}
 		edbSlash.DelegatePenalties[id] = dpSlash
 	}
+
+	unbondingSlash, err := sp.SlashUnbonding(ratio, spenum.Blobber, blobID, balances)
+	if err != nil {
+		return 0, err
+	}
+	if move, err = currency.AddCoin(move, unbondingSlash); err != nil {
+		return 0, err
+	}
+
 	//Added New Tag for StakePoolPenalty
 	if err := edbSlash.Emit(event.TagStakePoolPenalty, balances); err != nil {
 		return 0, err
@@ -376,5 +457,45 @@ func (ssc *StorageSmartContract) stakePoolUnlock(
 	input []byte,
 	balances chainstate.StateContextI,
 ) (resp string, err error) {
-	return stakepool.StakePoolUnlock(t, input, balances, ssc.getStakePoolAdapter)
+	conf, err := getConfig(balances)
+	if err != nil {
+		return "", common.NewErrorf("stake_pool_unlock_failed",
+			"can't get SC configurations: %v", err)
+	}
+	return stakepool.StakePoolUnlock(t, input, balances, ssc.getStakePoolAdapter,
+		conf.StakePool.UnbondingRounds)
+}
+
+// withdraw matured unbonding stake of a delegate
+func (ssc *StorageSmartContract) stakePoolWithdrawUnbonded(
+	t *transaction.Transaction,
+	input []byte,
+	balances chainstate.StateContextI,
+) (resp string, err error) {
+	return stakepool.StakePoolWithdrawUnbonded(t, input, balances, ssc.getStakePoolAdapter)
+}
+
+// move stake of a delegate pool to another blobber or validator
+func (ssc *StorageSmartContract) stakePoolRedelegate(
+	t *transaction.Transaction,
+	input []byte,
+	balances chainstate.StateContextI,
+) (resp string, err error) {
+	conf, err := getConfig(balances)
+	if err != nil {
+		return "", common.NewErrorf("stake_pool_redelegate_failed",
+			"can't get SC configurations: %v", err)
+	}
+	return stakepool.StakePoolRedelegate(t, input, balances,
+		stakepool.ValidationSettings{MaxStake: conf.MaxStake, MinStake: conf.MinStake, MaxNumDelegates: conf.MaxDelegates},
+		ssc.getStakePoolAdapter)
+}
+
+// switch auto-compounding of rewards of a delegate pool
+func (ssc *StorageSmartContract) stakePoolAutoCompound(
+	t *transaction.Transaction,
+	input []byte,
+	balances chainstate.StateContextI,
+) (resp string, err error) {
+	return stakepool.StakePoolAutoCompound(t, input, balances, ssc.getStakePoolAdapter)
 }
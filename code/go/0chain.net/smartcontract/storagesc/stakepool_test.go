@@ -40,6 +40,42 @@ func Test_stakePool_Encode_Decode(t *testing.T) {
 	assert.EqualValues(t, spe, spd)
 }
 
+func Test_stakePool_Unbond(t *testing.T) {
+	var (
+		balances = newTestBalances(t, false)
+		sp       = newStakePool()
+	)
+	sp.Pools["alice"] = &stakepool.DelegatePool{DelegateID: "alice", Balance: 100}
+	sp.Pools["bob"] = &stakepool.DelegatePool{DelegateID: "bob", Balance: 100}
+	sp.TotalOffers = 150
+
+	_, err := sp.Unbond("alice", 10, spenum.Blobber, "blob_id", balances)
+	require.Error(t, err)
+	require.EqualValues(t, 100, sp.Pools["alice"].Balance)
+	require.Empty(t, sp.Unbonding)
+
+	sp.TotalOffers = 100
+	amount, err := sp.Unbond("alice", 10, spenum.Blobber, "blob_id", balances)
+	require.NoError(t, err)
+	require.EqualValues(t, 100, amount)
+	require.Len(t, sp.Unbonding, 1)
+}
+
+func Test_stakePool_slash_unbonding(t *testing.T) {
+	var (
+		balances = newTestBalances(t, false)
+		sp       = newStakePool()
+	)
+	sp.Pools["alice"] = &stakepool.DelegatePool{DelegateID: "alice", Balance: 100}
+	sp.Unbonding = []*stakepool.UnbondingEntry{{DelegateID: "bob", Amount: 100, MaturesAt: 10}}
+
+	move, err := sp.slash("blob_id", 100, 50, balances)
+	require.NoError(t, err)
+	require.EqualValues(t, 50, move)
+	require.EqualValues(t, 75, sp.Pools["alice"].Balance)
+	require.EqualValues(t, 75, sp.Unbonding[0].Amount)
+}
+
 func Test_stakePool_save(t *testing.T) {
 	const blobID = "blob_id"
 	var (
@@ -0,0 +1,129 @@
+package storagesc
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+//go:generate msgp -io=false -tests=false -unexported -v
+
+const (
+	// maxStorageTiers is the max number of storage tiers a blobber can offer.
+	maxStorageTiers = 8
+	// maxStorageTierNameLength is the max length of a storage tier name.
+	maxStorageTierNameLength = 32
+)
+
+// StorageTier is a class of storage a blobber offers in addition to its
+// default terms, e.g. hot and cold storage with different prices.
+type StorageTier struct {
+	// Name of the tier an allocation requests it by.
+	Name string `json:"name"`
+	// Terms of the tier, used instead of the blobber terms.
+	Terms Terms `json:"terms"`
+	// LatencyClass is the advertised access latency of the tier, lower
+	// is faster. It's informational for clients choosing blobbers.
+	LatencyClass int `json:"latency_class"`
+	// MaxChallengeCompletionTime is the time the blobber has to complete
+	// a challenge of the tier allocations, it can't exceed the smart
+	// contract one. Zero means the smart contract one.
+	MaxChallengeCompletionTime time.Duration `json:"max_challenge_completion_time"`
+}
+
+// validateStorageTierName checks the tier name is short and consists of
+// lower case letters, digits, '-' and '_' only.
+func validateStorageTierName(name string) error {
+	if name == "" {
+		return errors.New("missing storage tier name")
+	}
+	if len(name) > maxStorageTierNameLength {
+		return fmt.Errorf("storage tier name %s is longer than %d", name, maxStorageTierNameLength)
+	}
+	for _, c := range name {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' && c != '_' {
+			return fmt.Errorf("storage tier name %s has invalid character %q", name, c)
+		}
+	}
+	return nil
+}
+
+func (st *StorageTier) validate(conf *Config) error {
+	if err := validateStorageTierName(st.Name); err != nil {
+		return err
+	}
+	if err := st.Terms.validate(conf); err != nil {
+		return fmt.Errorf("storage tier %s: %v", st.Name, err)
+	}
+	if st.LatencyClass < 0 {
+		return fmt.Errorf("storage tier %s: negative latency class", st.Name)
+	}
+	if st.MaxChallengeCompletionTime < 0 ||
+		st.MaxChallengeCompletionTime > conf.MaxChallengeCompletionTime {
+		return fmt.Errorf("storage tier %s: max_challenge_completion_time "+
+			"should be in range [0, %v]", st.Name, conf.MaxChallengeCompletionTime)
+	}
+	return nil
+}
+
+func validateStorageTiers(tiers []StorageTier, conf *Config) error {
+	if len(tiers) > maxStorageTiers {
+		return fmt.Errorf("too many storage tiers, max %d allowed", maxStorageTiers)
+	}
+	names := make(map[string]struct{}, len(tiers))
+	for i := range tiers {
+		if err := tiers[i].validate(conf); err != nil {
+			return err
+		}
+		if _, ok := names[tiers[i].Name]; ok {
+			return fmt.Errorf("duplicate storage tier %s", tiers[i].Name)
+		}
+		names[tiers[i].Name] = struct{}{}
+	}
+	return nil
+}
+
+// getTier returns the storage tier of the blobber by name, or nil.
+func (sn *StorageNode) getTier(name string) *StorageTier {
+	for i := range sn.Tiers {
+		if sn.Tiers[i].Name == name {
+			return &sn.Tiers[i]
+		}
+	}
+	return nil
+}
+
+// blobberTerms returns the terms of the blobber for the allocation tier,
+// or false if the blobber doesn't offer the tier. The allocations with no
+// tier use the default terms of the blobber.
+func (sa *StorageAllocation) blobberTerms(b *StorageNode) (Terms, bool) {
+	if sa.Tier == "" {
+		return b.Terms, true
+	}
+	tier := b.getTier(sa.Tier)
+	if tier == nil {
+		return Terms{}, false
+	}
+	return tier.Terms, true
+}
+
+// blobberChallengeCompletionTime returns the max challenge completion time
+// of the blobber for the allocation tier, zero means the smart contract one.
+func (sa *StorageAllocation) blobberChallengeCompletionTime(b *StorageNode) time.Duration {
+	if sa.Tier == "" {
+		return 0
+	}
+	if tier := b.getTier(sa.Tier); tier != nil {
+		return tier.MaxChallengeCompletionTime
+	}
+	return 0
+}
+
+// challengeCompletionTime returns the time the blobber has to complete a
+// challenge of the allocation.
+func (d *BlobberAllocation) challengeCompletionTime() time.Duration {
+	if d.MaxChallengeCompletionTime > 0 {
+		return d.MaxChallengeCompletionTime
+	}
+	return getMaxChallengeCompletionTime()
+}
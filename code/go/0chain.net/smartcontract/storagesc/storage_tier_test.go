@@ -0,0 +1,96 @@
+package storagesc
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"0chain.net/core/common"
+	"0chain.net/smartcontract/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_validateStorageTiers(t *testing.T) {
+	conf := &Config{
+		MaxReadPrice:               100,
+		MinWritePrice:              10,
+		MaxWritePrice:              1000,
+		MaxChallengeCompletionTime: time.Minute,
+	}
+	hot := StorageTier{Name: "hot", Terms: Terms{ReadPrice: 50, WritePrice: 500}}
+	cold := StorageTier{Name: "cold", Terms: Terms{ReadPrice: 5, WritePrice: 20},
+		LatencyClass: 3, MaxChallengeCompletionTime: 30 * time.Second}
+
+	require.NoError(t, validateStorageTiers(nil, conf))
+	require.NoError(t, validateStorageTiers([]StorageTier{hot, cold}, conf))
+
+	requireErrMsg(t, validateStorageTiers([]StorageTier{hot, hot}, conf),
+		"duplicate storage tier hot")
+	requireErrMsg(t, validateStorageTiers([]StorageTier{{Terms: hot.Terms}}, conf),
+		"missing storage tier name")
+	requireErrMsg(t, validateStorageTiers([]StorageTier{{Name: "Hot SSD", Terms: hot.Terms}}, conf),
+		"storage tier name Hot SSD has invalid character 'H'")
+	requireErrMsg(t, validateStorageTiers([]StorageTier{{Name: strings.Repeat("a", 33), Terms: hot.Terms}}, conf),
+		"storage tier name "+strings.Repeat("a", 33)+" is longer than 32")
+
+	expensive := hot
+	expensive.Terms.WritePrice = 2000
+	requireErrMsg(t, validateStorageTiers([]StorageTier{expensive}, conf),
+		"storage tier hot: write_price is greater than max_write_price allowed")
+
+	slow := cold
+	slow.MaxChallengeCompletionTime = 2 * time.Minute
+	requireErrMsg(t, validateStorageTiers([]StorageTier{slow}, conf),
+		"storage tier cold: max_challenge_completion_time should be in range [0, 1m0s]")
+}
+
+func TestStorageAllocation_blobberTerms(t *testing.T) {
+	var (
+		now     = common.Timestamp(100)
+		blobber = &StorageNode{
+			Provider: provider.Provider{ID: "b1"},
+			Terms:    Terms{ReadPrice: 10, WritePrice: 100, MinLockDemand: 0.1},
+			Tiers: []StorageTier{{
+				Name:                       "cold",
+				Terms:                      Terms{ReadPrice: 2, WritePrice: 20, MinLockDemand: 0.2},
+				MaxChallengeCompletionTime: 10 * time.Second,
+			}},
+			Capacity: 10 * GB,
+		}
+		alloc = &StorageAllocation{
+			ID:              "alloc",
+			Size:            GB,
+			DataShards:      1,
+			Expiration:      now + 1000,
+			ReadPriceRange:  PriceRange{Min: 0, Max: 5},
+			WritePriceRange: PriceRange{Min: 0, Max: 50},
+			TimeUnit:        time.Minute,
+		}
+	)
+
+	// the default terms are out of the price ranges
+	filtered, err := alloc.filterBlobbers([]*StorageNode{blobber}, now, alloc.bSize())
+	require.NoError(t, err)
+	assert.Empty(t, filtered)
+
+	alloc.Tier = "cold"
+	filtered, err = alloc.filterBlobbers([]*StorageNode{blobber}, now, alloc.bSize())
+	require.NoError(t, err)
+	assert.Len(t, filtered, 1)
+
+	ba, err := newBlobberAllocation(alloc.bSize(), alloc, blobber, now, alloc.TimeUnit)
+	require.NoError(t, err)
+	assert.Equal(t, blobber.Tiers[0].Terms, ba.Terms)
+	assert.Equal(t, 10*time.Second, ba.challengeCompletionTime())
+
+	// the blobber doesn't offer the tier
+	alloc.Tier = "archive"
+	_, ok := alloc.blobberTerms(blobber)
+	assert.False(t, ok)
+	_, err = newBlobberAllocation(alloc.bSize(), alloc, blobber, now, alloc.TimeUnit)
+	requireErrMsg(t, err, "blobber b1 doesn't offer storage tier archive")
+	filtered, err = alloc.filterBlobbers([]*StorageNode{blobber}, now, alloc.bSize())
+	require.NoError(t, err)
+	assert.Empty(t, filtered)
+}
@@ -86,6 +86,9 @@ func (ssc *StorageSmartContract) writePoolLock(
 	}
 
 	allocation.WritePool, err = currency.AddCoin(allocation.WritePool, txn.Value)
+	if err == nil {
+		err = allocation.lockWritePool(txn.ClientID, txn.Value)
+	}
 	i, _ := txn.Value.Int64()
 	balances.EmitEvent(event.TypeStats, event.TagLockWritePool, allocation.ID, event.WritePoolLock{
 		Client:       txn.ClientID,
@@ -139,7 +142,9 @@ func (ssc *StorageSmartContract) writePoolUnlock(
 		return "", common.NewError("write_pool_unlock_failed", err.Error())
 	}
 	i, _ := alloc.WritePool.Int64()
+	emitAllocationCharge(alloc, txn.ClientID, "", event.ChargeUnlock, alloc.WritePool, txn.CreationDate, balances)
 	alloc.WritePool = 0
+	alloc.WritePoolLocks = nil
 	balances.EmitEvent(event.TypeStats, event.TagUnlockWritePool, alloc.ID, event.WritePoolLock{
 		Client:       txn.ClientID,
 		AllocationId: alloc.ID,
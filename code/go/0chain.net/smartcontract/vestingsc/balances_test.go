@@ -46,7 +46,10 @@ func (tb *testBalances) AddMint(*state.Mint) error                    { return n
 func (tb *testBalances) GetTransfers() []*state.Transfer              { return nil }
 func (tb *testBalances) GetChainCurrentMagicBlock() *block.MagicBlock { return nil }
 func (tb *testBalances) AddSignedTransfer(st *state.SignedTransfer)   {}
-func (tb *testBalances) GetEventDB() *event.EventDb                   { return nil }
+func (tb *testBalances) ExecuteOnBehalf(*transaction.Transaction, func(cstate.StateContextI) (string, error)) (string, error) {
+	return "", nil
+}
+func (tb *testBalances) GetEventDB() *event.EventDb { return nil }
 func (tb *testBalances) EmitEvent(event.EventType, event.EventTag, string, interface{}, ...cstate.Appender) {
 }
 func (tb *testBalances) EmitError(error)                             {}
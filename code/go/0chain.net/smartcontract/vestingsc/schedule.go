@@ -0,0 +1,279 @@
+package vestingsc
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/0chain/common/core/currency"
+
+	"0chain.net/core/common"
+)
+
+//msgp:ignore scheduleRequest trancheRequest stepInfo
+//go:generate msgp -io=false -tests=false -unexported=true -v
+
+// schedule types accepted by the addRequest
+const (
+	scheduleLinear  = "linear"  // linear vesting, optionally after a cliff
+	scheduleStepped = "stepped" // equal tranches every interval
+	scheduleCustom  = "custom"  // user defined tranches
+)
+
+// shareDenominator is the full share of a destination amount, a step share
+// is expressed in the parts of it
+const shareDenominator uint64 = 1e6
+
+// maxScheduleSteps limits number of tranches of a schedule
+const maxScheduleSteps = 1000
+
+//
+// schedule request
+//
+
+// trancheRequest is a custom schedule tranche. The Percent is the part of a
+// destination amount released at the StartTime + Offset.
+type trancheRequest struct {
+	Offset  time.Duration `json:"offset"`
+	Percent float64       `json:"percent"`
+}
+
+// scheduleRequest is optional vesting schedule of the addRequest. An empty
+// schedule means linear vesting without a cliff.
+type scheduleRequest struct {
+	Type     string            `json:"type"`               // linear, stepped, custom
+	Cliff    time.Duration     `json:"cliff,omitempty"`    // nothing vests before
+	Interval time.Duration     `json:"interval,omitempty"` // stepped tranches interval
+	Tranches []*trancheRequest `json:"tranches,omitempty"` // custom tranches
+}
+
+// validate the schedule request against the vesting duration
+func (sr *scheduleRequest) validate(duration time.Duration) (err error) {
+	switch {
+	case sr.Cliff < 0:
+		return errors.New("negative schedule cliff")
+	case sr.Cliff >= duration:
+		return errors.New("schedule cliff is not less than vesting duration")
+	}
+
+	switch sr.Type {
+	case "", scheduleLinear:
+		if sr.Interval != 0 || len(sr.Tranches) > 0 {
+			return errors.New("linear schedule can't have interval or tranches")
+		}
+	case scheduleStepped:
+		switch {
+		case len(sr.Tranches) > 0:
+			return errors.New("stepped schedule can't have tranches")
+		case toSeconds(sr.Interval) < 1:
+			return errors.New("invalid schedule interval (< 1s)")
+		case sr.Interval > duration:
+			return errors.New("schedule interval is greater than vesting duration")
+		case int64(duration/sr.Interval) > maxScheduleSteps:
+			return errors.New("too many schedule steps")
+		}
+	case scheduleCustom:
+		if sr.Interval != 0 {
+			return errors.New("custom schedule can't have interval")
+		}
+		return sr.validateTranches(duration)
+	default:
+		return fmt.Errorf("unknown schedule type %q", sr.Type)
+	}
+	return
+}
+
+func (sr *scheduleRequest) validateTranches(duration time.Duration) error {
+	switch {
+	case len(sr.Tranches) == 0:
+		return errors.New("custom schedule without tranches")
+	case len(sr.Tranches) > maxScheduleSteps:
+		return errors.New("too many schedule steps")
+	}
+	var (
+		total float64
+		prev  = time.Duration(-1)
+	)
+	for i, tr := range sr.Tranches {
+		switch {
+		case tr.Offset <= prev:
+			return fmt.Errorf("tranche %d: offsets must be strictly increasing", i)
+		case tr.Offset > duration:
+			return fmt.Errorf("tranche %d: offset is greater than vesting duration", i)
+		case tr.Percent <= 0:
+			return fmt.Errorf("tranche %d: percent must be positive", i)
+		}
+		prev, total = tr.Offset, total+tr.Percent
+	}
+	if total < 100-1e-9 || total > 100+1e-9 {
+		return fmt.Errorf("tranches percents sum is %v, should be 100", total)
+	}
+	return nil
+}
+
+// newSchedule converts validated request to the vesting pool schedule.
+// A nil result means the original linear vesting.
+func newSchedule(sr *scheduleRequest, start, end common.Timestamp) *schedule {
+	if sr == nil {
+		return nil
+	}
+
+	var sc = &schedule{Cliff: start + toSeconds(sr.Cliff)}
+	switch sr.Type {
+	case "", scheduleLinear:
+		if sr.Cliff == 0 {
+			return nil // plain linear vesting
+		}
+		sc.Linear = true
+		sc.Steps = []*scheduleStep{
+			{At: sc.Cliff, Share: linearShare(start, end, sc.Cliff)},
+			{At: end, Share: shareDenominator},
+		}
+	case scheduleStepped:
+		var interval = toSeconds(sr.Interval)
+		for at := start + interval; at < end; at += interval {
+			sc.Steps = append(sc.Steps, &scheduleStep{
+				At:    at,
+				Share: linearShare(start, end, at),
+			})
+		}
+		sc.Steps = append(sc.Steps, &scheduleStep{
+			At:    end,
+			Share: shareDenominator,
+		})
+	case scheduleCustom:
+		var total float64
+		for _, tr := range sr.Tranches {
+			total += tr.Percent
+			sc.Steps = append(sc.Steps, &scheduleStep{
+				At:    start + toSeconds(tr.Offset),
+				Share: uint64(total / 100 * float64(shareDenominator)),
+			})
+		}
+		// protect against the float rounding, the last tranche drains all
+		sc.Steps[len(sc.Steps)-1].Share = shareDenominator
+	}
+	return sc
+}
+
+// linearShare of a linear vesting between start and end at given time
+func linearShare(start, end, at common.Timestamp) uint64 {
+	return uint64(at-start) * shareDenominator / uint64(end-start)
+}
+
+//
+// vesting pool schedule
+//
+
+// scheduleStep is point of a schedule. The Share is cumulative part of a
+// destination amount vested at the time.
+type scheduleStep struct {
+	At    common.Timestamp `json:"at"`
+	Share uint64           `json:"share"`
+}
+
+// schedule of a vesting pool. Nothing vests before the Cliff. Vested share
+// jumps at every step, or, for a linear schedule, grows linearly between
+// steps starting from zero at pool start time.
+type schedule struct {
+	Cliff  common.Timestamp `json:"cliff"`
+	Linear bool             `json:"linear"`
+	Steps  []*scheduleStep  `json:"steps"`
+}
+
+// share returns cumulative vested share at given time, the now must be
+// between start and end of related vesting pool
+func (sc *schedule) share(start, now common.Timestamp) uint64 {
+	if now < sc.Cliff {
+		return 0
+	}
+	var (
+		prevAt    = start
+		prevShare uint64
+	)
+	for _, step := range sc.Steps {
+		if now < step.At {
+			if !sc.Linear {
+				return prevShare
+			}
+			return prevShare + (step.Share-prevShare)*
+				uint64(now-prevAt)/uint64(step.At-prevAt)
+		}
+		prevAt, prevShare = step.At, step.Share
+	}
+	return prevShare
+}
+
+// shareOf returns part of given amount corresponding to the share
+func shareOf(amount currency.Coin, share uint64) (currency.Coin, error) {
+	if share >= shareDenominator {
+		return amount, nil
+	}
+	return currency.MultFloat64(amount,
+		float64(share)/float64(shareDenominator))
+}
+
+// unlock returns amount of tokens to vest for a destination at given time
+// following the schedule, the dry argument leaves the destination as is
+func (sc *schedule) unlock(d *destination, start, now common.Timestamp,
+	dry bool) (amount currency.Coin, err error) {
+
+	var earned currency.Coin
+	if earned, err = shareOf(d.Amount, sc.share(start, now)); err != nil {
+		return
+	}
+	if earned > d.Vested {
+		amount = earned - d.Vested
+	}
+	if !dry {
+		err = d.move(now, amount)
+	}
+	return
+}
+
+// stepInfo represents vested and unvested parts of a schedule step
+// of all destinations of a pool
+type stepInfo struct {
+	At       common.Timestamp `json:"at"`       // step time
+	Percent  float64          `json:"percent"`  // cumulative vested percent
+	Vested   currency.Coin    `json:"vested"`   // vested part of the step
+	Unvested currency.Coin    `json:"unvested"` // not vested part yet
+}
+
+// info returns vested/unvested split of every step for the given total
+// amount of all destinations
+func (sc *schedule) info(total currency.Coin, start,
+	now common.Timestamp) (steps []*stepInfo, err error) {
+
+	var (
+		current   = sc.share(start, now)
+		prevShare uint64
+		prevAmt   currency.Coin
+	)
+	steps = make([]*stepInfo, 0, len(sc.Steps))
+	for _, step := range sc.Steps {
+		var stepAmt, vestedAmt currency.Coin
+		if stepAmt, err = shareOf(total, step.Share); err != nil {
+			return nil, err
+		}
+		var si = &stepInfo{
+			At:      step.At,
+			Percent: float64(step.Share) * 100 / float64(shareDenominator),
+		}
+		switch {
+		case current >= step.Share:
+			si.Vested = stepAmt - prevAmt
+		case current > prevShare:
+			if vestedAmt, err = shareOf(total, current); err != nil {
+				return nil, err
+			}
+			si.Vested = vestedAmt - prevAmt
+			si.Unvested = stepAmt - vestedAmt
+		default:
+			si.Unvested = stepAmt - prevAmt
+		}
+		steps = append(steps, si)
+		prevShare, prevAmt = step.Share, stepAmt
+	}
+	return
+}
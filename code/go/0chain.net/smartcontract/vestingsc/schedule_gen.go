@@ -0,0 +1,223 @@
+package vestingsc
+
+// Code generated by github.com/tinylib/msgp DO NOT EDIT.
+
+import (
+	"github.com/tinylib/msgp/msgp"
+)
+
+// MarshalMsg implements msgp.Marshaler
+func (z *schedule) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 3
+	// string "Cliff"
+	o = append(o, 0x83, 0xa5, 0x43, 0x6c, 0x69, 0x66, 0x66)
+	o, err = z.Cliff.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Cliff")
+		return
+	}
+	// string "Linear"
+	o = append(o, 0xa6, 0x4c, 0x69, 0x6e, 0x65, 0x61, 0x72)
+	o = msgp.AppendBool(o, z.Linear)
+	// string "Steps"
+	o = append(o, 0xa5, 0x53, 0x74, 0x65, 0x70, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Steps)))
+	for za0001 := range z.Steps {
+		if z.Steps[za0001] == nil {
+			o = msgp.AppendNil(o)
+		} else {
+			// map header, size 2
+			// string "At"
+			o = append(o, 0x82, 0xa2, 0x41, 0x74)
+			o, err = z.Steps[za0001].At.MarshalMsg(o)
+			if err != nil {
+				err = msgp.WrapError(err, "Steps", za0001, "At")
+				return
+			}
+			// string "Share"
+			o = append(o, 0xa5, 0x53, 0x68, 0x61, 0x72, 0x65)
+			o = msgp.AppendUint64(o, z.Steps[za0001].Share)
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *schedule) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Cliff":
+			bts, err = z.Cliff.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Cliff")
+				return
+			}
+		case "Linear":
+			z.Linear, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Linear")
+				return
+			}
+		case "Steps":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Steps")
+				return
+			}
+			if cap(z.Steps) >= int(zb0002) {
+				z.Steps = (z.Steps)[:zb0002]
+			} else {
+				z.Steps = make([]*scheduleStep, zb0002)
+			}
+			for za0001 := range z.Steps {
+				if msgp.IsNil(bts) {
+					bts, err = msgp.ReadNilBytes(bts)
+					if err != nil {
+						return
+					}
+					z.Steps[za0001] = nil
+				} else {
+					if z.Steps[za0001] == nil {
+						z.Steps[za0001] = new(scheduleStep)
+					}
+					var zb0003 uint32
+					zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Steps", za0001)
+						return
+					}
+					for zb0003 > 0 {
+						zb0003--
+						field, bts, err = msgp.ReadMapKeyZC(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Steps", za0001)
+							return
+						}
+						switch msgp.UnsafeString(field) {
+						case "At":
+							bts, err = z.Steps[za0001].At.UnmarshalMsg(bts)
+							if err != nil {
+								err = msgp.WrapError(err, "Steps", za0001, "At")
+								return
+							}
+						case "Share":
+							z.Steps[za0001].Share, bts, err = msgp.ReadUint64Bytes(bts)
+							if err != nil {
+								err = msgp.WrapError(err, "Steps", za0001, "Share")
+								return
+							}
+						default:
+							bts, err = msgp.Skip(bts)
+							if err != nil {
+								err = msgp.WrapError(err, "Steps", za0001)
+								return
+							}
+						}
+					}
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *schedule) Msgsize() (s int) {
+	s = 1 + 6 + z.Cliff.Msgsize() + 7 + msgp.BoolSize + 6 + msgp.ArrayHeaderSize
+	for za0001 := range z.Steps {
+		if z.Steps[za0001] == nil {
+			s += msgp.NilSize
+		} else {
+			s += 1 + 3 + z.Steps[za0001].At.Msgsize() + 6 + msgp.Uint64Size
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *scheduleStep) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "At"
+	o = append(o, 0x82, 0xa2, 0x41, 0x74)
+	o, err = z.At.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "At")
+		return
+	}
+	// string "Share"
+	o = append(o, 0xa5, 0x53, 0x68, 0x61, 0x72, 0x65)
+	o = msgp.AppendUint64(o, z.Share)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *scheduleStep) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "At":
+			bts, err = z.At.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "At")
+				return
+			}
+		case "Share":
+			z.Share, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Share")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *scheduleStep) Msgsize() (s int) {
+	s = 1 + 3 + z.At.Msgsize() + 6 + msgp.Uint64Size
+	return
+}
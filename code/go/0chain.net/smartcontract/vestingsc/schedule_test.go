@@ -0,0 +1,148 @@
+package vestingsc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/0chain/common/core/currency"
+
+	"0chain.net/core/common"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_scheduleRequest_validate(t *testing.T) {
+	const duration = 100 * time.Second
+
+	for _, tt := range []struct {
+		sr  scheduleRequest
+		err string
+	}{
+		{scheduleRequest{Cliff: s(-1)}, "negative schedule cliff"},
+		{scheduleRequest{Cliff: s(100)},
+			"schedule cliff is not less than vesting duration"},
+		{scheduleRequest{Cliff: s(10)}, ""},
+		{scheduleRequest{Type: scheduleLinear, Interval: s(10)},
+			"linear schedule can't have interval or tranches"},
+		{scheduleRequest{Type: "monthly"}, `unknown schedule type "monthly"`},
+		{scheduleRequest{Type: scheduleStepped},
+			"invalid schedule interval (< 1s)"},
+		{scheduleRequest{Type: scheduleStepped, Interval: s(101)},
+			"schedule interval is greater than vesting duration"},
+		{scheduleRequest{Type: scheduleStepped, Interval: s(10)}, ""},
+		{scheduleRequest{Type: scheduleCustom},
+			"custom schedule without tranches"},
+		{scheduleRequest{Type: scheduleCustom, Tranches: []*trancheRequest{
+			{Offset: s(10), Percent: 50}, {Offset: s(10), Percent: 50},
+		}}, "tranche 1: offsets must be strictly increasing"},
+		{scheduleRequest{Type: scheduleCustom, Tranches: []*trancheRequest{
+			{Offset: s(10), Percent: 50}, {Offset: s(110), Percent: 50},
+		}}, "tranche 1: offset is greater than vesting duration"},
+		{scheduleRequest{Type: scheduleCustom, Tranches: []*trancheRequest{
+			{Offset: s(10), Percent: 0}, {Offset: s(20), Percent: 100},
+		}}, "tranche 0: percent must be positive"},
+		{scheduleRequest{Type: scheduleCustom, Tranches: []*trancheRequest{
+			{Offset: s(10), Percent: 50}, {Offset: s(20), Percent: 40},
+		}}, "tranches percents sum is 90, should be 100"},
+		{scheduleRequest{Type: scheduleCustom, Tranches: []*trancheRequest{
+			{Offset: s(10), Percent: 50}, {Offset: s(20), Percent: 50},
+		}}, ""},
+	} {
+		requireErrMsg(t, tt.sr.validate(duration), tt.err)
+	}
+}
+
+func Test_schedule_linearCliff(t *testing.T) {
+	var sc = newSchedule(&scheduleRequest{Cliff: s(25)}, 100, 200)
+	require.NotNil(t, sc)
+	assert.True(t, sc.Linear)
+
+	assert.EqualValues(t, 0, sc.share(100, 100))
+	assert.EqualValues(t, 0, sc.share(100, 124))
+	assert.EqualValues(t, shareDenominator/4, sc.share(100, 125))
+	assert.EqualValues(t, shareDenominator/2, sc.share(100, 150))
+	assert.EqualValues(t, shareDenominator, sc.share(100, 200))
+
+	// linear without a cliff is the original vesting
+	assert.Nil(t, newSchedule(&scheduleRequest{Type: scheduleLinear}, 100, 200))
+}
+
+func Test_schedule_stepped(t *testing.T) {
+	var sc = newSchedule(&scheduleRequest{
+		Type:     scheduleStepped,
+		Cliff:    s(30),
+		Interval: s(25),
+	}, 100, 200)
+	require.NotNil(t, sc)
+	require.Len(t, sc.Steps, 4)
+
+	assert.EqualValues(t, 0, sc.share(100, 125)) // cliff
+	assert.EqualValues(t, 0, sc.share(100, 129)) // cliff
+	assert.EqualValues(t, shareDenominator/4, sc.share(100, 130))
+	assert.EqualValues(t, shareDenominator/2, sc.share(100, 150))
+	assert.EqualValues(t, shareDenominator/2, sc.share(100, 174))
+	assert.EqualValues(t, shareDenominator, sc.share(100, 200))
+}
+
+func Test_schedule_unlock(t *testing.T) {
+	var (
+		sc = newSchedule(&scheduleRequest{
+			Type: scheduleCustom,
+			Tranches: []*trancheRequest{
+				{Offset: s(10), Percent: 10},
+				{Offset: s(50), Percent: 30},
+				{Offset: s(100), Percent: 60},
+			},
+		}, 100, 200)
+		d = &destination{ID: "one", Amount: 100}
+
+		amount currency.Coin
+		err    error
+	)
+	d.Last, d.Move = 100, 100
+
+	amount, err = sc.unlock(d, 100, 105, false)
+	require.NoError(t, err)
+	assert.Zero(t, amount)
+
+	amount, err = sc.unlock(d, 100, 160, true) // dry
+	require.NoError(t, err)
+	assert.EqualValues(t, 40, amount)
+	assert.Zero(t, d.Vested)
+
+	amount, err = sc.unlock(d, 100, 160, false)
+	require.NoError(t, err)
+	assert.EqualValues(t, 40, amount)
+	assert.EqualValues(t, 40, d.Vested)
+	assert.EqualValues(t, 160, d.Move)
+
+	amount, err = sc.unlock(d, 100, 170, false)
+	require.NoError(t, err)
+	assert.Zero(t, amount)
+	assert.EqualValues(t, 160, d.Move)
+	assert.EqualValues(t, 170, d.Last)
+
+	amount, err = sc.unlock(d, 100, 200, false)
+	require.NoError(t, err)
+	assert.EqualValues(t, 60, amount)
+	assert.EqualValues(t, 100, d.Vested)
+}
+
+func Test_schedule_info(t *testing.T) {
+	var sc = newSchedule(&scheduleRequest{
+		Cliff: s(20),
+	}, 100, 200)
+
+	steps, err := sc.info(1000, 100, common.Timestamp(110))
+	require.NoError(t, err)
+	require.Len(t, steps, 2)
+	assert.EqualValues(t, &stepInfo{At: 120, Percent: 20, Unvested: 200}, steps[0])
+	assert.EqualValues(t, &stepInfo{At: 200, Percent: 100, Unvested: 800}, steps[1])
+
+	steps, err = sc.info(1000, 100, common.Timestamp(160))
+	require.NoError(t, err)
+	assert.EqualValues(t, &stepInfo{At: 120, Percent: 20, Vested: 200}, steps[0])
+	assert.EqualValues(t, &stepInfo{At: 200, Percent: 100, Vested: 400,
+		Unvested: 400}, steps[1])
+}
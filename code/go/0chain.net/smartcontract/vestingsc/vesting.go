@@ -166,6 +166,7 @@ type addRequest struct {
 	StartTime    common.Timestamp `json:"start_time"`            //
 	Duration     time.Duration    `json:"duration"`              //
 	Destinations destinations     `json:"destinations"`          //
+	Schedule     *scheduleRequest `json:"schedule,omitempty"`    // optional
 }
 
 func (ar *addRequest) decode(b []byte) error {
@@ -195,6 +196,11 @@ func (ar *addRequest) validate(now common.Timestamp, conf *config) (err error) {
 	case len(ar.Destinations) > conf.MaxDestinations:
 		return errors.New("too many destinations")
 	}
+	if ar.Schedule != nil {
+		if err = ar.Schedule.validate(ar.Duration); err != nil {
+			return fmt.Errorf("invalid schedule: %v", err)
+		}
+	}
 	return
 }
 
@@ -214,6 +220,8 @@ type vestingPool struct {
 	ExpireAt     common.Timestamp `json:"expire_at"`    //
 	Destinations destinations     `json:"destinations"` //
 	ClientID     string           `json:"client_id"`    // the pool owner
+	// Schedule of the vesting, nil for linear vesting without a cliff.
+	Schedule *schedule `json:"schedule,omitempty"`
 }
 
 // newVestingPool returns new empty uninitialized vesting pool.
@@ -235,6 +243,7 @@ func newVestingPoolFromReqeust(clientID datastore.Key, ar *addRequest) (
 	vp.ExpireAt = ar.StartTime + toSeconds(ar.Duration)
 	vp.Destinations = ar.Destinations
 	vp.Destinations.start(vp.StartTime)
+	vp.Schedule = newSchedule(ar.Schedule, vp.StartTime, vp.ExpireAt)
 	return
 }
 
@@ -303,6 +312,17 @@ func (vp *vestingPool) fill(t *transaction.Transaction,
 	return
 }
 
+// unlock returns amount of tokens to vest for a destination for given time
+// following the pool schedule, the now must be in the pool time range
+func (vp *vestingPool) unlock(d *destination, now common.Timestamp,
+	dry bool) (amount currency.Coin, err error) {
+
+	if vp.Schedule == nil {
+		return d.unlock(now, vp.ExpireAt, dry)
+	}
+	return vp.Schedule.unlock(d, vp.StartTime, now, dry)
+}
+
 // the tokens transfer
 func (vp *vestingPool) moveToDest(vscKey, destID datastore.Key,
 	value currency.Coin, balances chainstate.StateContextI) (
@@ -348,7 +368,7 @@ func (vp *vestingPool) trigger(t *transaction.Transaction,
 	)
 	sb.WriteByte('[')
 	for _, d := range vp.Destinations {
-		value, err := d.unlock(now, end, false)
+		value, err := vp.unlock(d, now, false)
 		if err != nil {
 			return "", err
 		}
@@ -438,7 +458,7 @@ func (vp *vestingPool) vest(vscID, destID datastore.Key, now common.Timestamp,
 		return
 	}
 
-	value, err := d.unlock(now, end, false)
+	value, err := vp.unlock(d, now, false)
 	if err != nil {
 		return "", err
 	}
@@ -513,12 +533,18 @@ func (vp *vestingPool) info(now common.Timestamp) (i *info, err error) {
 		now = end
 	}
 
-	var dinfos = make([]*destInfo, 0, len(vp.Destinations))
+	var (
+		dinfos = make([]*destInfo, 0, len(vp.Destinations))
+		total  currency.Coin
+	)
 	for _, d := range vp.Destinations {
-		value, err := d.unlock(now, end, true)
+		value, err := vp.unlock(d, now, true)
 		if err != nil {
 			return nil, err
 		}
+		if total, err = currency.AddCoin(total, d.Amount); err != nil {
+			return nil, err
+		}
 		dinfos = append(dinfos, &destInfo{
 			ID:     d.ID,
 			Wanted: d.Amount,
@@ -530,6 +556,12 @@ func (vp *vestingPool) info(now common.Timestamp) (i *info, err error) {
 
 	i.Destinations = dinfos
 	i.ClientID = vp.ClientID
+
+	if vp.Schedule != nil {
+		if i.Schedule, err = vp.Schedule.info(total, vp.StartTime, now); err != nil {
+			return nil, err
+		}
+	}
 	return
 }
 
@@ -551,6 +583,7 @@ type info struct {
 	ExpireAt     common.Timestamp `json:"expire_at"`    // until
 	Destinations []*destInfo      `json:"destinations"` // receivers
 	ClientID     datastore.Key    `json:"client_id"`    // owner
+	Schedule     []*stepInfo      `json:"schedule,omitempty"`
 }
 
 //
@@ -41,6 +41,13 @@ func BenchmarkRestTests(data benchmark.BenchData, _ benchmark.SignatureScheme) b
 				},
 				Endpoint: zrh.NotProcessedBurnTicketsHandler,
 			},
+			{
+				FuncName: "getUserUnbondingEntries",
+				Params: map[string]string{
+					"client_id": data.Clients[0],
+				},
+				Endpoint: zrh.getUserUnbondingEntries,
+			},
 		},
 		ADDRESS,
 		zrh,
@@ -108,6 +108,10 @@ func addMockStakePools(clients []string, ctx cstate.StateContextI) {
 		for j := 0; j < numDelegates; j++ {
 			sp.Pools[clients[j]] = getMockDelegatePool(clients[j])
 		}
+		sp.Unbonding = []*stakepool.UnbondingEntry{{
+			DelegateID: clients[0],
+			Amount:     51,
+		}}
 		sp.Reward = 11
 		sp.Minter = cstate.MinterZcn
 		_, err := ctx.InsertTrieNode(stakepool.StakePoolKey(spenum.Authorizer, clients[i]), sp)
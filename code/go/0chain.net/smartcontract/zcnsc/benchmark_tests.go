@@ -180,6 +180,35 @@ func BenchmarkTests(data benchmark.BenchData, scheme benchmark.SignatureScheme)
 					ProviderType: spenum.Authorizer,
 				}).Encode(),
 			},
+			{
+				name:     benchmark.ZcnSc + StakeAutoCompoundFunc,
+				endpoint: sc.StakeAutoCompound,
+				txn:      createTransaction(data.Clients[0], data.PublicKeys[0], 3000),
+				input: (&stakepool.AutoCompoundRequest{
+					ProviderID:   data.Clients[0],
+					ProviderType: spenum.Authorizer,
+					AutoCompound: true,
+				}).Encode(),
+			},
+			{
+				name:     benchmark.ZcnSc + StakeRedelegateFunc,
+				endpoint: sc.StakeRedelegate,
+				txn:      createTransaction(data.Clients[0], data.PublicKeys[0], 3000),
+				input: (&stakepool.RedelegateRequest{
+					ProviderType:   spenum.Authorizer,
+					FromProviderID: data.Clients[0],
+					ToProviderID:   data.Clients[1],
+				}).Encode(),
+			},
+			{
+				name:     benchmark.ZcnSc + StakeWithdrawUnbondedFunc,
+				endpoint: sc.StakeWithdrawUnbonded,
+				txn:      createTransaction(data.Clients[0], data.PublicKeys[0], 3000),
+				input: (&stakepool.StakePoolRequest{
+					ProviderType: spenum.Authorizer,
+					ProviderID:   data.Clients[0],
+				}).Encode(),
+			},
 		},
 	)
 }
@@ -34,6 +34,7 @@ const (
 	OwnerID            = "owner_id"
 	Cost               = "cost"
 	MaxDelegates       = "max_delegates"
+	UnbondingRounds    = "unbonding_rounds"
 )
 
 var CostFunctions = []string{
@@ -114,6 +115,7 @@ func (gn *GlobalNode) ToStringMap() smartcontract.StringMap {
 		BurnAddress:        fmt.Sprintf("%v", gn.BurnAddress),
 		OwnerID:            fmt.Sprintf("%v", gn.OwnerId),
 		MaxDelegates:       fmt.Sprintf("%v", gn.MaxDelegates),
+		UnbondingRounds:    fmt.Sprintf("%v", gn.UnbondingRounds),
 	}
 
 	for _, key := range CostFunctions {
@@ -158,6 +160,7 @@ func getConfig() (conf *ZCNSConfig, err error) {
 	conf.OwnerId = cfg.GetString(postfix(OwnerID))
 	conf.Cost = cfg.GetStringMapInt(postfix(Cost))
 	conf.MaxDelegates = cfg.GetInt(postfix(MaxDelegates))
+	conf.UnbondingRounds = cfg.GetInt64(postfix(UnbondingRounds))
 
 	return conf, nil
 }
@@ -5,7 +5,9 @@ import (
 	"sort"
 	"strconv"
 
+	common2 "0chain.net/smartcontract/common"
 	"0chain.net/smartcontract/rest"
+	"0chain.net/smartcontract/stakepool/spenum"
 
 	"github.com/0chain/common/core/currency"
 
@@ -37,6 +39,7 @@ func GetEndpoints(rh rest.RestHandlerI) []rest.Endpoint {
 		{URI: zcn + "/getAuthorizer", Handler: common.UserRateLimit(zrh.getAuthorizer)},
 		{URI: zcn + "/v1/mint_nonce", Handler: common.UserRateLimit(zrh.MintNonceHandler)},
 		{URI: zcn + "/v1/not_processed_burn_tickets", Handler: common.UserRateLimit(zrh.NotProcessedBurnTicketsHandler)},
+		{URI: zcn + "/getUserUnbondingEntries", Handler: common.UserRateLimit(zrh.getUserUnbondingEntries)},
 	}
 }
 
@@ -224,6 +227,63 @@ type authorizerNode struct {
 	URL string `json:"url"`
 }
 
+// swagger:route GET /v1/screst/6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712e0/getUserUnbondingEntries getUserUnbondingEntries
+// get user's stake unlocked from authorizers, waiting for the unbonding period to be over
+//
+// parameters:
+//
+//	+name: client_id
+//	 description: client for which to get unbonding entries
+//	 required: true
+//	 in: query
+//	 type: string
+//	+name: offset
+//	 description: offset
+//	 in: query
+//	 type: string
+//	+name: limit
+//	 description: limit
+//	 in: query
+//	 type: string
+//	+name: sort
+//	 description: desc or asc
+//	 in: query
+//	 type: string
+//
+// responses:
+//
+//	200: []UnbondingEntry
+//	400:
+//	500:
+func (zrh *ZcnRestHandler) getUserUnbondingEntries(w http.ResponseWriter, r *http.Request) {
+	clientID := r.URL.Query().Get("client_id")
+	if clientID == "" {
+		common.Respond(w, r, nil, common.NewErrBadRequest("no client_id"))
+		return
+	}
+
+	pagination, err := common2.GetOffsetLimitOrderParam(r.URL.Query())
+	if err != nil {
+		common.Respond(w, r, nil, err)
+		return
+	}
+
+	edb := zrh.GetQueryStateContext().GetEventDB()
+	if edb == nil {
+		common.Respond(w, r, nil, common.NewErrInternal("no db connection"))
+		return
+	}
+
+	entries, err := edb.GetUserUnbondingEntries(clientID,
+		[]spenum.Provider{spenum.Authorizer}, pagination)
+	if err != nil {
+		common.Respond(w, r, nil, common.NewErrInternal("getting unbonding entries: "+err.Error()))
+		return
+	}
+
+	common.Respond(w, r, entries, nil)
+}
+
 func toAuthorizerResponse(auth *event.Authorizer) *authorizerResponse {
 	resp := &authorizerResponse{
 		AuthorizerID:    auth.ID,
@@ -38,7 +38,8 @@ type ZCNSConfig struct {
 	BurnAddress        string         `json:"burn_address"`
 	OwnerId            string         `json:"owner_id"`
 	Cost               map[string]int `json:"cost"`
-	MaxDelegates       int            `json:"max_delegates"` // MaxDelegates per stake pool
+	MaxDelegates       int            `json:"max_delegates"`    // MaxDelegates per stake pool
+	UnbondingRounds    int64          `json:"unbonding_rounds"` // rounds before unlocked stake can be withdrawn
 }
 
 type GlobalNode struct {
@@ -128,6 +129,11 @@ func (gn *GlobalNode) UpdateConfig(cfg *smartcontract.StringMap) (err error) {
 			if err != nil {
 				return fmt.Errorf("key %s, unable to convert %v to int64", key, value)
 			}
+		case UnbondingRounds:
+			gn.UnbondingRounds, err = strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("key %s, unable to convert %v to int64", key, value)
+			}
 		default:
 			return fmt.Errorf("key %s, unable to convert %v to currency.Coin", key, value)
 		}
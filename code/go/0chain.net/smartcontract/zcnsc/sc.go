@@ -28,6 +28,9 @@ const (
 	DeleteFromDelegatePoolFunc    = "delete-from-delegate-pool"
 	UpdateAuthorizerStakePoolFunc = "update-authorizer-stake-pool"
 	CollectRewardsFunc            = "collect-rewards"
+	StakeAutoCompoundFunc         = "stake-auto-compound"
+	StakeRedelegateFunc           = "stake-redelegate"
+	StakeWithdrawUnbondedFunc     = "stake-withdraw-unbonded"
 )
 
 // ZCNSmartContract ...
@@ -66,6 +69,9 @@ func (zcn *ZCNSmartContract) InitSC() {
 	zcn.smartContractFunctions[CollectRewardsFunc] = zcn.CollectRewards
 	zcn.smartContractFunctions[AddToDelegatePoolFunc] = zcn.AddToDelegatePool           // stakepool lock
 	zcn.smartContractFunctions[DeleteFromDelegatePoolFunc] = zcn.DeleteFromDelegatePool // stakepool unlock
+	zcn.smartContractFunctions[StakeAutoCompoundFunc] = zcn.StakeAutoCompound
+	zcn.smartContractFunctions[StakeRedelegateFunc] = zcn.StakeRedelegate
+	zcn.smartContractFunctions[StakeWithdrawUnbondedFunc] = zcn.StakeWithdrawUnbonded
 }
 
 // SetSC ...
@@ -11,6 +11,7 @@ import (
 	"0chain.net/core/datastore"
 	"0chain.net/smartcontract/stakepool"
 	"0chain.net/smartcontract/stakepool/spenum"
+	"github.com/0chain/common/core/currency"
 	"github.com/0chain/common/core/util"
 )
 
@@ -18,6 +19,16 @@ import (
 
 //go:generate msgp -v -io=false -tests=false -unexported
 
+func init() {
+	stakepool.RegisterMaxStakeGetter(spenum.Authorizer, func(balances cstate.CommonStateContextI) (currency.Coin, error) {
+		gn, err := GetGlobalNode(balances)
+		if err != nil {
+			return 0, err
+		}
+		return gn.MaxStakeAmount, nil
+	})
+}
+
 // ----------- LockingPool pool --------------------------
 
 //type stakePool stakepool.Provider
@@ -176,6 +187,41 @@ func (zcn *ZCNSmartContract) AddToDelegatePool(t *transaction.Transaction,
 func (zcn *ZCNSmartContract) DeleteFromDelegatePool(
 	t *transaction.Transaction, inputData []byte,
 	balances cstate.StateContextI) (resp string, err error) {
+	gn, err := GetGlobalNode(balances)
+	if err != nil {
+		return "", common.NewErrorf("delete-from-delegate-pool-failed",
+			"failed to get global node error: %v", err)
+	}
+
+	return stakepool.StakePoolUnlock(t, inputData, balances, zcn.getStakePoolAdapter, gn.UnbondingRounds)
+}
+
+func (zcn *ZCNSmartContract) StakeAutoCompound(
+	t *transaction.Transaction, inputData []byte,
+	balances cstate.StateContextI) (resp string, err error) {
+
+	return stakepool.StakePoolAutoCompound(t, inputData, balances, zcn.getStakePoolAdapter)
+}
+
+func (zcn *ZCNSmartContract) StakeRedelegate(
+	t *transaction.Transaction, inputData []byte,
+	balances cstate.StateContextI) (resp string, err error) {
+	gn, err := GetGlobalNode(balances)
+	if err != nil {
+		return "", common.NewErrorf("stake-redelegate-failed",
+			"failed to get global node error: %v", err)
+	}
+
+	return stakepool.StakePoolRedelegate(t, inputData, balances, stakepool.ValidationSettings{
+		MinStake:        gn.MinStakeAmount,
+		MaxStake:        gn.MaxStakeAmount,
+		MaxNumDelegates: gn.MaxDelegates,
+	}, zcn.getStakePoolAdapter)
+}
+
+func (zcn *ZCNSmartContract) StakeWithdrawUnbonded(
+	t *transaction.Transaction, inputData []byte,
+	balances cstate.StateContextI) (resp string, err error) {
 
-	return stakepool.StakePoolUnlock(t, inputData, balances, zcn.getStakePoolAdapter)
+	return stakepool.StakePoolWithdrawUnbonded(t, inputData, balances, zcn.getStakePoolAdapter)
 }